@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CheckTokenRestrictions checks if a token with the provided restrictions can be created by the current auth session.
+// Sessions of restricted tokens can only create tokens with the same or narrower restrictions,
+// and sessions of ephemeral memberships can't create tokens at all.
+// Returns nil if the token can be created, otherwise returns an error.
+func CheckTokenRestrictions(session *auth.Session, permissions []enum.Permission, paths []string) error {
+	if session == nil {
+		return ErrNotAuthenticated
+	}
+
+	switch metadata := session.Metadata.(type) {
+	case *auth.TokenMetadata:
+		if !authz.TokenAllowsRestrictions(metadata, permissions, paths) {
+			return ErrNotAuthorized
+		}
+	case *auth.MembershipMetadata:
+		return ErrNotAuthorized
+	}
+
+	return nil
+}
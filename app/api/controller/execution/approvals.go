@@ -0,0 +1,235 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ApproveStageInput struct {
+	Decision enum.ApprovalDecision `json:"decision"`
+	Comment  string                `json:"comment"`
+}
+
+func (in *ApproveStageInput) sanitize() error {
+	decision, ok := in.Decision.Sanitize()
+	if !ok || decision == "" {
+		return usererror.BadRequest("Decision must be either approved or rejected")
+	}
+	in.Decision = decision
+
+	in.Comment = strings.TrimSpace(in.Comment)
+	if len(in.Comment) > 1024 {
+		return usererror.BadRequest("Comment can't be longer than 1024 characters")
+	}
+
+	return nil
+}
+
+// ListApprovals lists the decisions on the approval stages of an execution.
+func (c *Controller) ListApprovals(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+) ([]*types.Approval, error) {
+	execution, err := c.findExecutionCheckAccess(ctx, session, repoRef, pipelineUID, executionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, err := c.approvalStore.List(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list approvals: %w", err)
+	}
+
+	return approvals, nil
+}
+
+// ApproveStage approves or rejects an approval stage of an execution that is waiting on approval.
+// Only users that can execute the pipeline and have the required membership role
+// on the space of the repository can decide on it.
+func (c *Controller) ApproveStage(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	stageNum int64,
+	in *ApproveStageInput,
+) (*types.Approval, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	// deciding on an approval continues or stops the execution, which requires the permission to execute
+	// the pipeline. This also applies the restrictions of the token before the approval role is checked.
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineExecute)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	stage, err := c.stageStore.FindByNumber(ctx, execution.ID, int(stageNum))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage %d: %w", stageNum, err)
+	}
+
+	if !stage.IsApprovalGate() || stage.Status != enum.CIStatusWaitingOnApproval {
+		return nil, usererror.BadRequest("The stage is not waiting on approval")
+	}
+
+	role := c.approvalRole
+	if labelRole, ok := enum.MembershipRole(stage.Labels[types.StageLabelApprovalRole]).Sanitize(); ok && labelRole != "" {
+		role = labelRole
+	}
+
+	allowed, err := c.hasApprovalRole(ctx, session, repo, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check approval role: %w", err)
+	}
+	if !allowed {
+		return nil, usererror.Forbidden(fmt.Sprintf("The stage can only be approved by users with at least role %s", role))
+	}
+
+	approval := &types.Approval{
+		ExecutionID: execution.ID,
+		StageID:     stage.ID,
+		Decision:    in.Decision,
+		Comment:     in.Comment,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+		Author:      *session.Principal.ToPrincipalInfo(),
+	}
+
+	// the approval is recorded before the stage is resolved. A stage can only have a single approval,
+	// so of concurrent decisions only one can succeed.
+	err = c.approvalStore.Create(ctx, approval)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The stage was already approved or rejected")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create approval: %w", err)
+	}
+
+	err = c.executionManager.ResolveApproval(ctx, stage, approval)
+	if err != nil {
+		// the stage isn't decided, remove the approval to keep the record consistent with the stage.
+		if errDelete := c.approvalStore.Delete(ctx, approval.ID); errDelete != nil {
+			log.Ctx(ctx).Warn().Err(errDelete).
+				Int64("approval.id", approval.ID).
+				Msg("failed to delete approval of unresolved stage")
+		}
+	}
+	if errors.Is(err, manager.ErrStageNotWaitingOnApproval) {
+		return nil, usererror.BadRequest("The stage is not waiting on approval")
+	}
+	if errors.Is(err, store.ErrVersionConflict) {
+		return nil, usererror.Conflict("The stage was already approved or rejected")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve approval stage: %w", err)
+	}
+
+	return approval, nil
+}
+
+// hasApprovalRole checks whether the principal has at least the role on the space of the repository or any
+// of its ancestors - either directly or via a user group. Admins can always approve.
+func (c *Controller) hasApprovalRole(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	role enum.MembershipRole,
+) (bool, error) {
+	if session.Principal.Admin {
+		return true, nil
+	}
+
+	// roles with a higher rank include the required role, unknown roles can only be satisfied by space owners.
+	minRank := role.Rank()
+	if minRank == 0 {
+		minRank = enum.MembershipRoleSpaceOwner.Rank()
+	}
+
+	matches := func(r enum.MembershipRole) bool {
+		return r.Rank() >= minRank
+	}
+
+	spaceID := repo.ParentID
+
+	// limit the depth to be safe (e.g. root/space1/repo => maxDepth of 2)
+	maxDepth := len(paths.Segments(repo.Path)) - 1
+
+	for depth := 0; depth < maxDepth && spaceID != 0; depth++ {
+		membership, err := c.membershipStore.Find(ctx, types.MembershipKey{
+			SpaceID:     spaceID,
+			PrincipalID: session.Principal.ID,
+		})
+		if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+			return false, fmt.Errorf("failed to find membership: %w", err)
+		}
+		if membership != nil && matches(membership.Role) {
+			return true, nil
+		}
+
+		groupRoles, err := c.membershipUserGroupStore.ListRoles(ctx, spaceID, session.Principal.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to list user group roles: %w", err)
+		}
+		for _, groupRole := range groupRoles {
+			if matches(groupRole) {
+				return true, nil
+			}
+		}
+
+		space, err := c.spaceStore.Find(ctx, spaceID)
+		if err != nil {
+			return false, fmt.Errorf("failed to find space %d: %w", spaceID, err)
+		}
+		spaceID = space.ParentID
+	}
+
+	return false, nil
+}
@@ -0,0 +1,346 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testRootSpaceID  = 1
+	testChildSpaceID = 2
+	testStageID      = 7
+)
+
+type spaceStoreMock struct {
+	store.SpaceStore
+}
+
+func (spaceStoreMock) Find(_ context.Context, id int64) (*types.Space, error) {
+	switch id {
+	case testRootSpaceID:
+		return &types.Space{ID: testRootSpaceID}, nil
+	case testChildSpaceID:
+		return &types.Space{ID: testChildSpaceID, ParentID: testRootSpaceID}, nil
+	default:
+		return nil, gitness_store.ErrResourceNotFound
+	}
+}
+
+type membershipStoreMock struct {
+	store.MembershipStore
+	// roles maps space ids to the role of the principal in the space.
+	roles map[int64]enum.MembershipRole
+}
+
+func (s membershipStoreMock) Find(_ context.Context, key types.MembershipKey) (*types.Membership, error) {
+	role, ok := s.roles[key.SpaceID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Membership{MembershipKey: key, Role: role}, nil
+}
+
+type membershipUserGroupStoreMock struct {
+	store.MembershipUserGroupStore
+	// roles maps space ids to the roles the principal inherits via user groups.
+	roles map[int64][]enum.MembershipRole
+}
+
+func (s membershipUserGroupStoreMock) ListRoles(
+	_ context.Context,
+	spaceID int64,
+	_ int64,
+) ([]enum.MembershipRole, error) {
+	return s.roles[spaceID], nil
+}
+
+type authorizerMock struct {
+	authz.Authorizer
+}
+
+func (authorizerMock) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return true, nil
+}
+
+type repoStoreMock struct {
+	store.RepoStore
+}
+
+func (repoStoreMock) FindByRef(_ context.Context, repoRef string) (*types.Repository, error) {
+	return &types.Repository{ID: 3, ParentID: testChildSpaceID, Path: repoRef}, nil
+}
+
+type pipelineStoreMock struct {
+	store.PipelineStore
+}
+
+func (pipelineStoreMock) FindByUID(_ context.Context, repoID int64, uid string) (*types.Pipeline, error) {
+	return &types.Pipeline{ID: 4, RepoID: repoID, UID: uid}, nil
+}
+
+type executionStoreMock struct {
+	store.ExecutionStore
+}
+
+func (executionStoreMock) FindByNumber(_ context.Context, pipelineID int64, num int64) (*types.Execution, error) {
+	return &types.Execution{ID: 5, PipelineID: pipelineID, Number: num}, nil
+}
+
+type stageStoreMock struct {
+	store.StageStore
+}
+
+func (stageStoreMock) FindByNumber(_ context.Context, executionID int64, stageNum int) (*types.Stage, error) {
+	return &types.Stage{
+		ID:          testStageID,
+		ExecutionID: executionID,
+		Number:      int64(stageNum),
+		Type:        types.StageTypeApproval,
+		Status:      enum.CIStatusWaitingOnApproval,
+	}, nil
+}
+
+type executionManagerMock struct {
+	manager.ExecutionManager
+	err      error
+	resolved int
+}
+
+func (m *executionManagerMock) ResolveApproval(context.Context, *types.Stage, *types.Approval) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.resolved++
+	return nil
+}
+
+type approvalStoreMock struct {
+	store.ApprovalStore
+	// approvals maps stage ids to the approval of the stage.
+	approvals map[int64]*types.Approval
+}
+
+func (s approvalStoreMock) Create(_ context.Context, approval *types.Approval) error {
+	if _, ok := s.approvals[approval.StageID]; ok {
+		return gitness_store.ErrDuplicate
+	}
+	approval.ID = int64(len(s.approvals) + 1)
+	s.approvals[approval.StageID] = approval
+	return nil
+}
+
+func (s approvalStoreMock) Delete(_ context.Context, id int64) error {
+	for stageID, approval := range s.approvals {
+		if approval.ID == id {
+			delete(s.approvals, stageID)
+		}
+	}
+	return nil
+}
+
+func TestController_HasApprovalRole(t *testing.T) {
+	tests := []struct {
+		name       string
+		admin      bool
+		role       enum.MembershipRole
+		roles      map[int64]enum.MembershipRole
+		groupRoles map[int64][]enum.MembershipRole
+		want       bool
+	}{
+		{
+			name:  "exact-role",
+			role:  enum.MembershipRoleExecutor,
+			roles: map[int64]enum.MembershipRole{testChildSpaceID: enum.MembershipRoleExecutor},
+			want:  true,
+		},
+		{
+			name:  "higher-role",
+			role:  enum.MembershipRoleExecutor,
+			roles: map[int64]enum.MembershipRole{testChildSpaceID: enum.MembershipRoleContributor},
+			want:  true,
+		},
+		{
+			name:  "lower-role",
+			role:  enum.MembershipRoleExecutor,
+			roles: map[int64]enum.MembershipRole{testChildSpaceID: enum.MembershipRoleReader},
+		},
+		{
+			name:  "space-owner",
+			role:  enum.MembershipRoleContributor,
+			roles: map[int64]enum.MembershipRole{testRootSpaceID: enum.MembershipRoleSpaceOwner},
+			want:  true,
+		},
+		{
+			name:  "space-owner-required",
+			role:  enum.MembershipRoleSpaceOwner,
+			roles: map[int64]enum.MembershipRole{testChildSpaceID: enum.MembershipRoleContributor},
+		},
+		{
+			name:       "user-group-of-ancestor",
+			role:       enum.MembershipRoleExecutor,
+			groupRoles: map[int64][]enum.MembershipRole{testRootSpaceID: {enum.MembershipRoleContributor}},
+			want:       true,
+		},
+		{
+			name:  "unknown-role",
+			role:  enum.MembershipRole("unknown"),
+			roles: map[int64]enum.MembershipRole{testChildSpaceID: enum.MembershipRoleContributor},
+		},
+		{
+			name:  "unknown-role-space-owner",
+			role:  enum.MembershipRole("unknown"),
+			roles: map[int64]enum.MembershipRole{testChildSpaceID: enum.MembershipRoleSpaceOwner},
+			want:  true,
+		},
+		{
+			name:  "admin",
+			admin: true,
+			role:  enum.MembershipRoleSpaceOwner,
+			want:  true,
+		},
+		{
+			name: "no-membership",
+			role: enum.MembershipRoleReader,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{
+				spaceStore:               spaceStoreMock{},
+				membershipStore:          membershipStoreMock{roles: test.roles},
+				membershipUserGroupStore: membershipUserGroupStoreMock{roles: test.groupRoles},
+			}
+			session := &auth.Session{Principal: types.Principal{ID: 42, Admin: test.admin}}
+			repo := &types.Repository{ParentID: testChildSpaceID, Path: "root/child/repo"}
+
+			got, err := c.hasApprovalRole(context.Background(), session, repo, test.role)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
+
+func TestController_ApproveStage(t *testing.T) {
+	tests := []struct {
+		name         string
+		role         enum.MembershipRole
+		existing     bool
+		resolveErr   error
+		wantStatus   int
+		wantApproval bool
+		wantResolved int
+	}{
+		{
+			name:         "approved",
+			role:         enum.MembershipRoleContributor,
+			wantApproval: true,
+			wantResolved: 1,
+		},
+		{
+			name:       "insufficient-role",
+			role:       enum.MembershipRoleReader,
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:         "already-decided",
+			role:         enum.MembershipRoleContributor,
+			existing:     true,
+			wantStatus:   http.StatusConflict,
+			wantApproval: true,
+		},
+		{
+			name:       "stage-changed",
+			role:       enum.MembershipRoleContributor,
+			resolveErr: gitness_store.ErrVersionConflict,
+			wantStatus: http.StatusConflict,
+		},
+		{
+			name:       "stage-not-waiting",
+			role:       enum.MembershipRoleContributor,
+			resolveErr: manager.ErrStageNotWaitingOnApproval,
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			approvals := approvalStoreMock{approvals: make(map[int64]*types.Approval)}
+			if test.existing {
+				approvals.approvals[testStageID] = &types.Approval{ID: 1, StageID: testStageID}
+			}
+			executionManager := &executionManagerMock{err: test.resolveErr}
+
+			c := &Controller{
+				authorizer:               authorizerMock{},
+				repoStore:                repoStoreMock{},
+				pipelineStore:            pipelineStoreMock{},
+				executionStore:           executionStoreMock{},
+				stageStore:               stageStoreMock{},
+				approvalStore:            approvals,
+				spaceStore:               spaceStoreMock{},
+				membershipStore:          membershipStoreMock{roles: map[int64]enum.MembershipRole{testChildSpaceID: test.role}},
+				membershipUserGroupStore: membershipUserGroupStoreMock{},
+				executionManager:         executionManager,
+				approvalRole:             enum.MembershipRoleExecutor,
+			}
+			session := &auth.Session{Principal: types.Principal{ID: 42, UID: "user"}}
+
+			_, err := c.ApproveStage(context.Background(), session, "root/child/repo", "pipeline", 1, 2,
+				&ApproveStageInput{Decision: enum.ApprovalDecisionApproved})
+
+			var gotStatus int
+			var userErr *usererror.Error
+			if errors.As(err, &userErr) {
+				gotStatus = userErr.Status
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotStatus != test.wantStatus {
+				t.Errorf("status: want=%d got=%d", test.wantStatus, gotStatus)
+			}
+
+			if _, ok := approvals.approvals[testStageID]; ok != test.wantApproval {
+				t.Errorf("approval stored: want=%t got=%t", test.wantApproval, ok)
+			}
+			if executionManager.resolved != test.wantResolved {
+				t.Errorf("resolved: want=%d got=%d", test.wantResolved, executionManager.resolved)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListArtifacts lists the artifacts uploaded by the stages of an execution.
+func (c *Controller) ListArtifacts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+) ([]*types.Artifact, error) {
+	execution, err := c.findExecutionCheckAccess(ctx, session, repoRef, pipelineUID, executionNum)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := c.artifactStore.List(ctx, execution.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list artifacts: %w", err)
+	}
+
+	return artifacts, nil
+}
+
+// DownloadArtifact returns either a signed url to the artifact or a reader of its content.
+func (c *Controller) DownloadArtifact(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+	artifactID int64,
+) (*types.Artifact, string, io.ReadCloser, error) {
+	execution, err := c.findExecutionCheckAccess(ctx, session, repoRef, pipelineUID, executionNum)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	artifact, err := c.artifactStore.Find(ctx, artifactID)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to find artifact: %w", err)
+	}
+
+	// don't leak artifacts of other executions.
+	if artifact.ExecutionID != execution.ID {
+		return nil, "", nil, usererror.ErrNotFound
+	}
+
+	signedURL, file, err := c.artifacts.Download(ctx, artifact)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+
+	return artifact, signedURL, file, nil
+}
+
+func (c *Controller) findExecutionCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pipelineUID string,
+	executionNum int64,
+) (*types.Execution, error) {
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo by ref: %w", err)
+	}
+	err = apiauth.CheckPipeline(ctx, c.authorizer, session, repo.Path, pipelineUID, enum.PermissionPipelineView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	pipeline, err := c.pipelineStore.FindByUID(ctx, repo.ID, pipelineUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	execution, err := c.executionStore.FindByNumber(ctx, pipeline.ID, executionNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution %d: %w", executionNum, err)
+	}
+
+	return execution, nil
+}
@@ -16,11 +16,14 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/manager"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types/enum"
 )
 
 type Controller struct {
@@ -34,6 +37,15 @@ type Controller struct {
 	repoStore      store.RepoStore
 	stageStore     store.StageStore
 	pipelineStore  store.PipelineStore
+	artifactStore  store.ArtifactStore
+	artifacts      *artifact.Service
+
+	approvalStore            store.ApprovalStore
+	spaceStore               store.SpaceStore
+	membershipStore          store.MembershipStore
+	membershipUserGroupStore store.MembershipUserGroupStore
+	executionManager         manager.ExecutionManager
+	approvalRole             enum.MembershipRole
 }
 
 func NewController(
@@ -47,6 +59,14 @@ func NewController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	artifactStore store.ArtifactStore,
+	artifacts *artifact.Service,
+	approvalStore store.ApprovalStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	membershipUserGroupStore store.MembershipUserGroupStore,
+	executionManager manager.ExecutionManager,
+	approvalRole enum.MembershipRole,
 ) *Controller {
 	return &Controller{
 		tx:             tx,
@@ -59,5 +79,14 @@ func NewController(
 		repoStore:      repoStore,
 		stageStore:     stageStore,
 		pipelineStore:  pipelineStore,
+		artifactStore:  artifactStore,
+		artifacts:      artifacts,
+
+		approvalStore:            approvalStore,
+		spaceStore:               spaceStore,
+		membershipStore:          membershipStore,
+		membershipUserGroupStore: membershipUserGroupStore,
+		executionManager:         executionManager,
+		approvalRole:             approvalRole,
 	}
 }
@@ -27,12 +27,18 @@ import (
 	"github.com/drone/go-scm/scm"
 )
 
+// CreateInput holds the optional input parameters of a manual execution.
+type CreateInput struct {
+	Params map[string]string `json:"params"`
+}
+
 func (c *Controller) Create(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	pipelineUID string,
 	branch string,
+	in *CreateInput,
 ) (*types.Execution, error) {
 	repo, err := c.repoStore.FindByRef(ctx, repoRef)
 	if err != nil {
@@ -66,7 +72,13 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to fetch commit: %w", err)
 	}
 
+	params := map[string]string{}
+	if in != nil && in.Params != nil {
+		params = in.Params
+	}
+
 	// Create manual hook for execution.
+	// The params are validated against the inputs declared by the pipeline yaml by the triggerer.
 	hook := &triggerer.Hook{
 		Trigger:     session.Principal.UID, // who/what triggered the build, different from commit author
 		AuthorLogin: commit.Author.Identity.Name,
@@ -81,7 +93,7 @@ func (c *Controller) Create(
 		Sender:      session.Principal.UID,
 		Source:      branch,
 		Target:      branch,
-		Params:      map[string]string{},
+		Params:      params,
 		Timestamp:   commit.Author.When.UnixMilli(),
 	}
 
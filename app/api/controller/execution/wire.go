@@ -16,11 +16,14 @@ package execution
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/pipeline/artifact"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
+	"github.com/harness/gitness/app/pipeline/manager"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 
 	"github.com/google/wire"
 )
@@ -41,7 +44,16 @@ func ProvideController(
 	repoStore store.RepoStore,
 	stageStore store.StageStore,
 	pipelineStore store.PipelineStore,
+	artifactStore store.ArtifactStore,
+	artifacts *artifact.Service,
+	approvalStore store.ApprovalStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	membershipUserGroupStore store.MembershipUserGroupStore,
+	executionManager manager.ExecutionManager,
+	config *types.Config,
 ) *Controller {
 	return NewController(tx, authorizer, executionStore, checkStore,
-		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore)
+		canceler, commitService, triggerer, repoStore, stageStore, pipelineStore, artifactStore, artifacts,
+		approvalStore, spaceStore, membershipStore, membershipUserGroupStore, executionManager, config.CI.ApprovalRole)
 }
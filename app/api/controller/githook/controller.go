@@ -23,8 +23,10 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signing"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 )
@@ -60,6 +62,9 @@ type Controller struct {
 	pullreqStore      store.PullReqStore
 	urlProvider       url.Provider
 	protectionManager *protection.Manager
+	pullMirrorStore   store.PullMirrorStore
+	git               git.Interface
+	signing           *signing.Service
 }
 
 func NewController(
@@ -70,6 +75,9 @@ func NewController(
 	pullreqStore store.PullReqStore,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
+	pullMirrorStore store.PullMirrorStore,
+	git git.Interface,
+	signing *signing.Service,
 ) *Controller {
 	return &Controller{
 		authorizer:        authorizer,
@@ -79,6 +87,9 @@ func NewController(
 		pullreqStore:      pullreqStore,
 		urlProvider:       urlProvider,
 		protectionManager: protectionManager,
+		pullMirrorStore:   pullMirrorStore,
+		git:               git,
+		signing:           signing,
 	}
 }
 
@@ -16,6 +16,7 @@ package githook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -24,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/githook"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -57,6 +59,16 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	// Branches and tags of mirrors are only updated by syncs (which don't run any hooks).
+	blocked, err := c.blockMirrorRefUpdate(ctx, repo, refUpdates)
+	if err != nil {
+		return nil, err
+	}
+	if blocked {
+		output.Error = ptr.String(usererror.ErrMirrorRefsCantBeModified.Error())
+		return output, nil
+	}
+
 	if internal {
 		// It's an internal call, so no need to verify protection rules.
 		return output, nil
@@ -78,7 +90,9 @@ func (c *Controller) PreReceive(
 		Metadata:  nil,
 	}
 
-	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, output)
+	commitVerifier := c.newPushCommitVerifier(repo, in)
+
+	err = c.checkProtectionRules(ctx, dummySession, repo, refUpdates, commitVerifier, output)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check protection rules: %w", err)
 	}
@@ -96,11 +110,32 @@ func (c *Controller) blockPullReqRefUpdate(refUpdates changedRefs) bool {
 		slices.ContainsFunc(refUpdates.other.updated, fn)
 }
 
+func (c *Controller) blockMirrorRefUpdate(
+	ctx context.Context,
+	repo *types.Repository,
+	refUpdates changedRefs,
+) (bool, error) {
+	if refUpdates.branches.isEmpty() && refUpdates.tags.isEmpty() {
+		return false, nil
+	}
+
+	_, err := c.pullMirrorStore.Find(ctx, repo.ID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find pull mirror of repo: %w", err)
+	}
+
+	return true, nil
+}
+
 func (c *Controller) checkProtectionRules(
 	ctx context.Context,
 	session *auth.Session,
 	repo *types.Repository,
 	refUpdates changedRefs,
+	commitVerifier protection.CommitVerifier,
 	output *githook.Output,
 ) error {
 	isRepoOwner, err := apiauth.IsRepoOwner(ctx, c.authorizer, session, repo)
@@ -129,6 +164,8 @@ func (c *Controller) checkProtectionRules(
 			RefAction:   refAction,
 			RefType:     refType,
 			RefNames:    names,
+
+			CommitVerifier: commitVerifier,
 		})
 		if err != nil {
 			errCheckAction = fmt.Errorf("failed to verify protection rules for git push: %w", err)
@@ -169,6 +206,10 @@ type changes struct {
 	updated []string
 }
 
+func (c *changes) isEmpty() bool {
+	return len(c.created) == 0 && len(c.deleted) == 0 && len(c.updated) == 0
+}
+
 func (c *changes) groupByAction(refUpdate githook.ReferenceUpdate, name string) {
 	switch {
 	case refUpdate.Old == types.NilSHA:
@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signing"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/githook"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// pushCommitVerifier verifies signatures of the commits that are introduced to branches by a git push.
+type pushCommitVerifier struct {
+	git                 git.Interface
+	verifier            *signing.Verifier
+	readParams          git.ReadParams
+	alternateObjectDirs []string
+	defaultBranch       string
+
+	// branches maps the names of the created and updated branches to their ref updates.
+	branches map[string]githook.ReferenceUpdate
+}
+
+var _ protection.CommitVerifier = (*pushCommitVerifier)(nil)
+
+func (c *Controller) newPushCommitVerifier(
+	repo *types.Repository,
+	in githook.PreReceiveInput,
+) *pushCommitVerifier {
+	branches := make(map[string]githook.ReferenceUpdate)
+	for _, refUpdate := range in.RefUpdates {
+		branchName, ok := strings.CutPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch)
+		if !ok || refUpdate.New == types.NilSHA {
+			continue
+		}
+
+		branches[branchName] = refUpdate
+	}
+
+	return &pushCommitVerifier{
+		git:                 c.git,
+		verifier:            c.signing.NewVerifier(),
+		readParams:          git.CreateReadParams(repo),
+		alternateObjectDirs: in.Environment.AlternateObjectDirs,
+		defaultBranch:       repo.DefaultBranch,
+		branches:            branches,
+	}
+}
+
+// UnverifiedCommits returns the commits pushed to the branch which don't have a verified signature.
+// For updated branches the commits between the old and the new commit are verified,
+// for created branches the commits that aren't part of the default branch.
+func (v *pushCommitVerifier) UnverifiedCommits(
+	ctx context.Context,
+	branchName string,
+) ([]protection.UnverifiedCommit, error) {
+	refUpdate, ok := v.branches[branchName]
+	if !ok {
+		return nil, nil
+	}
+
+	excludeSHA := refUpdate.Old
+	if excludeSHA == types.NilSHA {
+		var err error
+		excludeSHA, err = v.defaultBranchSHA(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var excludeSHAs []string
+	if excludeSHA != "" {
+		excludeSHAs = []string{excludeSHA}
+	}
+
+	out, err := v.git.ListNewCommits(ctx, &git.ListNewCommitsParams{
+		ReadParams:          v.readParams,
+		SHA:                 refUpdate.New,
+		ExcludeSHAs:         excludeSHAs,
+		AlternateObjectDirs: v.alternateObjectDirs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list new commits: %w", err)
+	}
+
+	var unverified []protection.UnverifiedCommit
+
+	for _, commit := range out.Commits {
+		verification, err := v.verifier.Verify(ctx, commit.SignedData, commit.Committer.Identity.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commit %s: %w", commit.SHA, err)
+		}
+
+		if verification.Status == enum.SignatureVerificationStatusVerified {
+			continue
+		}
+
+		unverified = append(unverified, protection.UnverifiedCommit{
+			SHA:    commit.SHA,
+			Status: verification.Status,
+		})
+	}
+
+	return unverified, nil
+}
+
+// defaultBranchSHA returns the commit of the default branch of the repository,
+// or an empty string if the default branch doesn't exist yet (e.g. it's created by the push).
+func (v *pushCommitVerifier) defaultBranchSHA(ctx context.Context) (string, error) {
+	ref, err := v.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: v.readParams,
+		Name:       v.defaultBranch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get default branch: %w", err)
+	}
+
+	return ref.SHA, nil
+}
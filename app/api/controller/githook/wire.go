@@ -18,8 +18,10 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signing"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
@@ -37,6 +39,9 @@ func ProvideController(
 	pullreqStore store.PullReqStore,
 	urlProvider url.Provider,
 	protectionManager *protection.Manager,
+	pullMirrorStore store.PullMirrorStore,
+	git git.Interface,
+	signing *signing.Service,
 ) *Controller {
 	return NewController(
 		authorizer,
@@ -45,5 +50,8 @@ func ProvideController(
 		gitReporter,
 		pullreqStore,
 		urlProvider,
-		protectionManager)
+		protectionManager,
+		pullMirrorStore,
+		git,
+		signing)
 }
@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/lfs"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	OperationDownload = "download"
+	OperationUpload   = "upload"
+
+	transferBasic  = "basic"
+	hashAlgoSHA256 = "sha256"
+
+	// maxBatchObjects is the maximum number of objects of a single batch request.
+	maxBatchObjects = 1000
+)
+
+// Reference is the git reference an LFS request is made for.
+type Reference struct {
+	Name string `json:"name"`
+}
+
+// Pointer identifies an LFS object.
+type Pointer struct {
+	OID  string `json:"oid"`
+	Size int64  `json:"size"`
+}
+
+// BatchRequest is the request of the LFS batch API.
+type BatchRequest struct {
+	Operation string     `json:"operation"`
+	Transfers []string   `json:"transfers,omitempty"`
+	Ref       *Reference `json:"ref,omitempty"`
+	Objects   []Pointer  `json:"objects"`
+	HashAlgo  string     `json:"hash_algo,omitempty"`
+}
+
+// BatchResponse is the response of the LFS batch API.
+type BatchResponse struct {
+	Transfer string            `json:"transfer"`
+	Objects  []*ObjectResponse `json:"objects"`
+	HashAlgo string            `json:"hash_algo"`
+}
+
+// ObjectResponse contains the actions (or the error) for a single object of a batch request.
+type ObjectResponse struct {
+	Pointer
+	Authenticated bool              `json:"authenticated,omitempty"`
+	Actions       map[string]Action `json:"actions,omitempty"`
+	Error         *ObjectError      `json:"error,omitempty"`
+}
+
+// Action describes how the client has to transfer an object.
+type Action struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+// ObjectError is the error of a single object of a batch request.
+type ObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (in *BatchRequest) sanitize() error {
+	if in.Operation != OperationDownload && in.Operation != OperationUpload {
+		return usererror.BadRequestf("Unsupported operation %q.", in.Operation)
+	}
+
+	if len(in.Transfers) > 0 {
+		supported := false
+		for _, transfer := range in.Transfers {
+			if transfer == transferBasic {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return usererror.UnprocessableEntityf("Only the %q transfer adapter is supported.", transferBasic)
+		}
+	}
+
+	if in.HashAlgo != "" && in.HashAlgo != hashAlgoSHA256 {
+		return usererror.UnprocessableEntityf("Only the %q hash algorithm is supported.", hashAlgoSHA256)
+	}
+
+	if len(in.Objects) > maxBatchObjects {
+		return usererror.RequestTooLargef("A batch can contain at most %d objects.", maxBatchObjects)
+	}
+
+	return nil
+}
+
+// Batch returns the actions the client has to execute to upload or download the requested objects.
+// The provided authorization is passed on to the client for the actions.
+func (c *Controller) Batch(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *BatchRequest,
+	authorization string,
+) (*BatchResponse, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	isUpload := in.Operation == OperationUpload
+	permission := enum.PermissionRepoView
+	if isUpload {
+		permission = enum.PermissionRepoPush
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, permission, !isUpload)
+	if err != nil {
+		return nil, err
+	}
+
+	oids := make([]string, 0, len(in.Objects))
+	for _, obj := range in.Objects {
+		if lfs.IsValidOID(obj.OID) {
+			oids = append(oids, obj.OID)
+		}
+	}
+
+	existing, err := c.lfs.FindMany(ctx, repo.ID, oids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find LFS objects: %w", err)
+	}
+
+	var header map[string]string
+	if authorization != "" {
+		header = map[string]string{"Authorization": authorization}
+	}
+
+	objectsURL := c.urlProvider.GenerateGITCloneURL(repo.Path) + "/info/lfs/objects/"
+
+	out := &BatchResponse{
+		Transfer: transferBasic,
+		Objects:  make([]*ObjectResponse, len(in.Objects)),
+		HashAlgo: hashAlgoSHA256,
+	}
+	for i, obj := range in.Objects {
+		resp := &ObjectResponse{
+			Pointer:       obj,
+			Authenticated: true,
+		}
+		out.Objects[i] = resp
+
+		if !lfs.IsValidOID(obj.OID) || obj.Size < 0 {
+			resp.Error = &ObjectError{Code: http.StatusUnprocessableEntity, Message: "Invalid object."}
+			continue
+		}
+
+		stored, exists := existing[obj.OID]
+
+		switch {
+		case isUpload && exists:
+			// nothing to do, the object is already stored.
+		case isUpload:
+			resp.Actions = map[string]Action{
+				OperationUpload: {Href: objectsURL + obj.OID, Header: header},
+			}
+		case exists:
+			resp.Size = stored.Size
+			resp.Actions = map[string]Action{
+				OperationDownload: {Href: objectsURL + obj.OID, Header: header},
+			}
+		default:
+			resp.Error = &ObjectError{Code: http.StatusNotFound, Message: "Object does not exist."}
+		}
+	}
+
+	return out, nil
+}
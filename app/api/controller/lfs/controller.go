@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/lfs"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Controller implements the Git LFS batch, basic transfer and locking APIs.
+// See https://github.com/git-lfs/git-lfs/tree/main/docs/api.
+type Controller struct {
+	authorizer         authz.Authorizer
+	repoStore          store.RepoStore
+	lfsLockStore       store.LFSLockStore
+	principalInfoCache store.PrincipalInfoCache
+	lfs                *lfs.Service
+	urlProvider        url.Provider
+}
+
+func NewController(
+	authorizer authz.Authorizer,
+	repoStore store.RepoStore,
+	lfsLockStore store.LFSLockStore,
+	principalInfoCache store.PrincipalInfoCache,
+	lfs *lfs.Service,
+	urlProvider url.Provider,
+) *Controller {
+	return &Controller{
+		authorizer:         authorizer,
+		repoStore:          repoStore,
+		lfsLockStore:       lfsLockStore,
+		principalInfoCache: principalInfoCache,
+		lfs:                lfs,
+		urlProvider:        urlProvider,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	reqPermission enum.Permission,
+	orPublic bool,
+) (*types.Repository, error) {
+	if repoRef == "" {
+		return nil, usererror.BadRequest("A valid repository reference must be provided.")
+	}
+
+	repo, err := c.repoStore.FindByRef(ctx, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, reqPermission, orPublic); err != nil {
+		return nil, fmt.Errorf("failed to verify authorization: %w", err)
+	}
+
+	return repo, nil
+}
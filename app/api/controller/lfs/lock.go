@@ -0,0 +1,367 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	lockListLimitDefault = 100
+	lockListLimitMax     = 100
+)
+
+// Lock is the representation of an LFS lock used by the LFS locking API.
+type Lock struct {
+	ID       string     `json:"id"`
+	Path     string     `json:"path"`
+	LockedAt time.Time  `json:"locked_at"`
+	Owner    *LockOwner `json:"owner,omitempty"`
+}
+
+// LockOwner is the owner of an LFS lock.
+type LockOwner struct {
+	Name string `json:"name"`
+}
+
+// LockConflictError is returned when a lock is requested for a file that is already locked.
+type LockConflictError struct {
+	Lock    *Lock  `json:"lock"`
+	Message string `json:"message"`
+}
+
+func (e *LockConflictError) Error() string {
+	return e.Message
+}
+
+type LockCreateRequest struct {
+	Path string     `json:"path"`
+	Ref  *Reference `json:"ref,omitempty"`
+}
+
+type LockCreateResponse struct {
+	Lock *Lock `json:"lock"`
+}
+
+type LockListResponse struct {
+	Locks      []*Lock `json:"locks"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type LockVerifyRequest struct {
+	Ref    *Reference `json:"ref,omitempty"`
+	Cursor string     `json:"cursor,omitempty"`
+	Limit  int        `json:"limit,omitempty"`
+}
+
+type LockVerifyResponse struct {
+	Ours       []*Lock `json:"ours"`
+	Theirs     []*Lock `json:"theirs"`
+	NextCursor string  `json:"next_cursor,omitempty"`
+}
+
+type LockDeleteRequest struct {
+	Force bool       `json:"force,omitempty"`
+	Ref   *Reference `json:"ref,omitempty"`
+}
+
+type LockDeleteResponse struct {
+	Lock *Lock `json:"lock"`
+}
+
+// LockListOptions are the query parameters of the lock list API.
+type LockListOptions struct {
+	Path   string
+	ID     string
+	Cursor string
+	Limit  int
+}
+
+// LockCreate locks the file for the current principal.
+func (c *Controller) LockCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *LockCreateRequest,
+) (*LockCreateResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath, err := sanitizeLockPath(in.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &types.LFSLock{
+		RepoID:      repo.ID,
+		Path:        filePath,
+		PrincipalID: session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+	}
+
+	err = c.lfsLockStore.Create(ctx, lock)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		existing, errFind := c.lfsLockStore.FindByPath(ctx, repo.ID, filePath)
+		if errFind != nil {
+			return nil, fmt.Errorf("failed to find existing LFS lock: %w", errFind)
+		}
+
+		locks, errMap := c.mapLocks(ctx, existing)
+		if errMap != nil {
+			return nil, errMap
+		}
+
+		return nil, &LockConflictError{
+			Lock:    locks[0],
+			Message: "The file is already locked.",
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LFS lock: %w", err)
+	}
+
+	locks, err := c.mapLocks(ctx, lock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockCreateResponse{Lock: locks[0]}, nil
+}
+
+// LockList lists the locks of the repository.
+func (c *Controller) LockList(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	opts *LockListOptions,
+) (*LockListResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &types.LFSLockFilter{
+		Path:  opts.Path,
+		Limit: opts.Limit,
+	}
+
+	if opts.ID != "" {
+		filter.ID, err = parseLockID(opts.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	locks, nextCursor, err := c.listLocks(ctx, repo.ID, filter, opts.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := c.mapLocks(ctx, locks...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockListResponse{
+		Locks:      out,
+		NextCursor: nextCursor,
+	}, nil
+}
+
+// LockVerify lists the locks of the repository split by whether they are owned by the current principal.
+// It's used by the client before a push to check for files locked by others.
+func (c *Controller) LockVerify(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *LockVerifyRequest,
+) (*LockVerifyResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	locks, nextCursor, err := c.listLocks(ctx, repo.ID, &types.LFSLockFilter{Limit: in.Limit}, in.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	mapped, err := c.mapLocks(ctx, locks...)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &LockVerifyResponse{
+		Ours:       []*Lock{},
+		Theirs:     []*Lock{},
+		NextCursor: nextCursor,
+	}
+	for i, lock := range locks {
+		if lock.PrincipalID == session.Principal.ID {
+			out.Ours = append(out.Ours, mapped[i])
+		} else {
+			out.Theirs = append(out.Theirs, mapped[i])
+		}
+	}
+
+	return out, nil
+}
+
+// LockDelete removes the lock. Locks of other principals can only be removed with force by repo editors.
+func (c *Controller) LockDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	lockID string,
+	in *LockDeleteRequest,
+) (*LockDeleteResponse, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := parseLockID(lockID)
+	if err != nil {
+		return nil, err
+	}
+
+	lock, err := c.lfsLockStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find LFS lock: %w", err)
+	}
+	if lock.RepoID != repo.ID {
+		return nil, usererror.ErrNotFound
+	}
+
+	if lock.PrincipalID != session.Principal.ID {
+		if !in.Force {
+			return nil, usererror.Forbidden("The lock is owned by another user, use force to remove it.")
+		}
+
+		if _, err = c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = c.lfsLockStore.Delete(ctx, lock.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete LFS lock: %w", err)
+	}
+
+	locks, err := c.mapLocks(ctx, lock)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LockDeleteResponse{Lock: locks[0]}, nil
+}
+
+// listLocks lists one page of locks and returns the cursor of the next page, if there is one.
+func (c *Controller) listLocks(ctx context.Context,
+	repoID int64,
+	filter *types.LFSLockFilter,
+	cursor string,
+) ([]*types.LFSLock, string, error) {
+	var err error
+	if cursor != "" {
+		filter.Cursor, err = parseLockID(cursor)
+		if err != nil {
+			return nil, "", usererror.BadRequest("Invalid cursor.")
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = lockListLimitDefault
+	}
+	if limit > lockListLimitMax {
+		limit = lockListLimitMax
+	}
+
+	// fetch one additional lock to know whether there's another page.
+	filter.Limit = limit + 1
+
+	locks, err := c.lfsLockStore.List(ctx, repoID, filter)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to list LFS locks: %w", err)
+	}
+
+	if len(locks) <= limit {
+		return locks, "", nil
+	}
+
+	locks = locks[:limit]
+
+	return locks, strconv.FormatInt(locks[limit-1].ID, 10), nil
+}
+
+// mapLocks converts the locks to their LFS API representation.
+func (c *Controller) mapLocks(ctx context.Context, locks ...*types.LFSLock) ([]*Lock, error) {
+	principalIDs := make([]int64, 0, len(locks))
+	for _, lock := range locks {
+		principalIDs = append(principalIDs, lock.PrincipalID)
+	}
+
+	principals, err := c.principalInfoCache.Map(ctx, principalIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch lock owners: %w", err)
+	}
+
+	out := make([]*Lock, len(locks))
+	for i, lock := range locks {
+		out[i] = &Lock{
+			ID:       strconv.FormatInt(lock.ID, 10),
+			Path:     lock.Path,
+			LockedAt: time.UnixMilli(lock.Created).UTC(),
+		}
+		if principal, ok := principals[lock.PrincipalID]; ok {
+			out[i].Owner = &LockOwner{Name: principal.DisplayName}
+		}
+	}
+
+	return out, nil
+}
+
+func sanitizeLockPath(filePath string) (string, error) {
+	filePath = strings.TrimSpace(filePath)
+	if filePath == "" {
+		return "", usererror.BadRequest("A file path must be provided.")
+	}
+
+	filePath = strings.TrimPrefix(path.Clean("/"+filePath), "/")
+	if filePath == "" {
+		return "", usererror.BadRequest("A valid file path must be provided.")
+	}
+
+	return filePath, nil
+}
+
+func parseLockID(lockID string) (int64, error) {
+	id, err := strconv.ParseInt(lockID, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, usererror.BadRequest("A valid lock id must be provided.")
+	}
+
+	return id, nil
+}
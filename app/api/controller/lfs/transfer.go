@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"io"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/lfs"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+var errInvalidOID = usererror.BadRequest("A valid LFS object id must be provided.")
+
+// Download returns the LFS object and its content, which has to be closed by the caller.
+func (c *Controller) Download(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+) (*types.LFSObject, io.ReadCloser, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !lfs.IsValidOID(oid) {
+		return nil, nil, errInvalidOID
+	}
+
+	return c.lfs.Download(ctx, repo.ID, oid)
+}
+
+// Upload stores the content of the LFS object.
+func (c *Controller) Upload(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	oid string,
+	r io.Reader,
+) (*types.LFSObject, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if !lfs.IsValidOID(oid) {
+		return nil, errInvalidOID
+	}
+
+	return c.lfs.Upload(ctx, session.Principal.ID, repo.ID, oid, r)
+}
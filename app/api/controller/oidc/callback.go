@@ -0,0 +1,306 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/token"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/dchest/uniuri"
+	"github.com/rs/zerolog/log"
+)
+
+type CallbackInput struct {
+	Code  string
+	State string
+	// StoredState is the state returned by Login and stored on the client.
+	StoredState string
+	// StoredLink is the link returned by Link and stored on the client, it's empty for regular logins.
+	StoredLink string
+}
+
+// Callback completes the authorization code flow - it links the identity to the account or provisions
+// the user if required and returns the session token if successful.
+func (c *Controller) Callback(ctx context.Context, in *CallbackInput) (*types.TokenResponse, error) {
+	if !c.enabled {
+		return nil, errOIDCDisabled
+	}
+
+	state, nonce, ok := decodeState(in.StoredState)
+	if !ok || subtle.ConstantTimeCompare([]byte(state), []byte(in.State)) != 1 {
+		return nil, usererror.BadRequest("Invalid or expired OIDC login state")
+	}
+	if in.Code == "" {
+		return nil, usererror.BadRequest("Authorization code is required")
+	}
+
+	claims, err := c.provider.Exchange(ctx, in.Code, nonce)
+	if errors.Is(err, oidc.ErrInvalidIDToken) {
+		log.Ctx(ctx).Warn().Err(err).Msg("oidc provider returned an invalid id token")
+		return nil, usererror.ErrUnauthorized
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// the issuer and subject are the only claims that identify the user and can't be changed by the user.
+	issuer, subject := claims.String("iss"), claims.String("sub")
+	if issuer == "" || subject == "" {
+		return nil, usererror.BadRequest("The id token is missing the iss or sub claim")
+	}
+
+	var user *types.User
+	if in.StoredLink != "" {
+		user, err = c.linkUser(ctx, in.StoredLink, state, issuer, subject)
+	} else {
+		user, err = c.findOrProvisionUser(ctx, claims, issuer, subject)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Blocked {
+		return nil, usererror.Forbidden("User is blocked")
+	}
+
+	c.syncGroupMemberships(ctx, user, claims.Strings(c.claims.Groups))
+
+	tokenUID := fmt.Sprintf("oidc-%d-%s", time.Now().Unix(), uniuri.NewLen(4))
+	token, jwtToken, err := token.CreateUserSession(ctx, c.tokenStore, user, tokenUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TokenResponse{Token: *token, AccessToken: jwtToken}, nil
+}
+
+// linkUser links the identity to the account that started the link.
+func (c *Controller) linkUser(
+	ctx context.Context,
+	storedLink string,
+	state string,
+	issuer string,
+	subject string,
+) (*types.User, error) {
+	principalID, linkState, err := jwt.ParseOIDCLink(storedLink, c.linkSecret())
+	if err != nil || subtle.ConstantTimeCompare([]byte(linkState), []byte(state)) != 1 {
+		return nil, usererror.BadRequest("Invalid or expired OIDC link")
+	}
+
+	user, err := c.principalStore.FindUser(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	identity, err := c.identityStore.Find(ctx, issuer, subject)
+	if err == nil {
+		if identity.PrincipalID != user.ID {
+			return nil, usererror.Conflict("The identity is already linked to another account")
+		}
+		return user, nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find oidc identity: %w", err)
+	}
+
+	err = c.identityStore.Create(ctx, &types.OIDCIdentity{
+		PrincipalID: user.ID,
+		Issuer:      issuer,
+		Subject:     subject,
+		Created:     time.Now().UnixMilli(),
+	})
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, usererror.Conflict("The account is already linked to another identity")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc identity: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Str("user_uid", user.UID).Msg("linked oidc identity to user")
+
+	return user, nil
+}
+
+// findOrProvisionUser returns the user linked to the identity. Existing accounts are never matched
+// by name or email, as these claims can be chosen by the user at many identity providers.
+func (c *Controller) findOrProvisionUser(
+	ctx context.Context,
+	claims oidc.Claims,
+	issuer string,
+	subject string,
+) (*types.User, error) {
+	identity, err := c.identityStore.Find(ctx, issuer, subject)
+	if err == nil {
+		user, err := c.principalStore.FindUser(ctx, identity.PrincipalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user of oidc identity: %w", err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find oidc identity: %w", err)
+	}
+
+	if !c.autoProvision {
+		return nil, usererror.Forbidden("User isn't linked to an account and automatic provisioning is disabled")
+	}
+
+	uid := claims.String(c.claims.UID)
+	if uid == "" {
+		return nil, usererror.BadRequestf("The id token is missing the %q claim", c.claims.UID)
+	}
+	email := claims.String(c.claims.Email)
+
+	if err = c.checkAccountNotExists(ctx, uid, email); err != nil {
+		return nil, err
+	}
+
+	displayName := claims.String(c.claims.DisplayName)
+	if displayName == "" {
+		displayName = uid
+	}
+
+	var created *types.User
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		// the user authenticates via the identity provider, the random password is never used.
+		created, err = c.userCtrl.CreateNoAuth(ctx, &user.CreateInput{
+			UID:         uid,
+			Email:       email,
+			DisplayName: displayName,
+			Password:    uniuri.NewLen(64),
+		}, false)
+		if err != nil {
+			return fmt.Errorf("failed to provision user: %w", err)
+		}
+
+		err = c.identityStore.Create(ctx, &types.OIDCIdentity{
+			PrincipalID: created.ID,
+			Issuer:      issuer,
+			Subject:     subject,
+			Created:     created.Created,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create oidc identity: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Ctx(ctx).Info().Str("user_uid", created.UID).Msg("provisioned user on first oidc login")
+
+	return created, nil
+}
+
+// checkAccountNotExists returns an error in case an account with the uid or email already exists.
+// Such accounts have to be linked explicitly by signing in and starting the link.
+func (c *Controller) checkAccountNotExists(ctx context.Context, uid string, email string) error {
+	errAccountExists := usererror.Conflict(
+		"An account with the same name or email already exists, sign in to link it to the identity provider")
+
+	_, err := c.principalStore.FindUserByUID(ctx, uid)
+	if err == nil {
+		return errAccountExists
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find user by uid: %w", err)
+	}
+
+	if email == "" {
+		return nil
+	}
+
+	_, err = c.principalStore.FindUserByEmail(ctx, email)
+	if err == nil {
+		return errAccountExists
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find user by email: %w", err)
+	}
+
+	return nil
+}
+
+// syncGroupMemberships grants the space memberships mapped to the groups of the user.
+// Existing memberships are never changed or removed, and failures don't prevent the login.
+func (c *Controller) syncGroupMemberships(ctx context.Context, user *types.User, groups []string) {
+	if len(c.groupMemberships) == 0 || len(groups) == 0 {
+		return
+	}
+
+	userGroups := make(map[string]struct{}, len(groups))
+	for _, group := range groups {
+		userGroups[group] = struct{}{}
+	}
+
+	for _, mapping := range c.groupMemberships {
+		if _, ok := userGroups[mapping.Group]; !ok {
+			continue
+		}
+
+		if err := c.ensureMembership(ctx, user, mapping); err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Str("group", mapping.Group).
+				Str("space_path", mapping.SpacePath).
+				Msg("failed to grant space membership of oidc group")
+		}
+	}
+}
+
+func (c *Controller) ensureMembership(ctx context.Context, user *types.User, mapping GroupMembership) error {
+	space, err := c.spaceStore.FindByRef(ctx, mapping.SpacePath)
+	if err != nil {
+		return fmt.Errorf("failed to find space: %w", err)
+	}
+
+	key := types.MembershipKey{SpaceID: space.ID, PrincipalID: user.ID}
+
+	_, err = c.membershipStore.Find(ctx, key)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return fmt.Errorf("failed to find membership: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	err = c.membershipStore.Create(ctx, &types.Membership{
+		MembershipKey: key,
+		CreatedBy:     user.ID,
+		Created:       now,
+		Updated:       now,
+		Role:          mapping.Role,
+	})
+	if err != nil && !errors.Is(err, store.ErrDuplicate) {
+		return fmt.Errorf("failed to create membership: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// GroupMembership maps a group of the groups claim to a space membership.
+type GroupMembership struct {
+	Group     string
+	SpacePath string
+	Role      enum.MembershipRole
+}
+
+type Controller struct {
+	enabled          bool
+	autoProvision    bool
+	claims           ClaimMapping
+	groupMemberships []GroupMembership
+	provider         *oidc.Provider
+	tx               dbtx.Transactor
+	authorizer       authz.Authorizer
+	principalStore   store.PrincipalStore
+	identityStore    store.OIDCIdentityStore
+	tokenStore       store.TokenStore
+	spaceStore       store.SpaceStore
+	membershipStore  store.MembershipStore
+	userCtrl         *user.Controller
+
+	// linkSecret returns the secret used to sign the links of identities to accounts.
+	linkSecret func() string
+}
+
+// ClaimMapping defines which claims of the id token are mapped to the user.
+type ClaimMapping struct {
+	UID         string
+	Email       string
+	DisplayName string
+	Groups      string
+}
+
+func NewController(
+	config *types.Config,
+	groupMemberships []GroupMembership,
+	provider *oidc.Provider,
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
+	identityStore store.OIDCIdentityStore,
+	tokenStore store.TokenStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	userCtrl *user.Controller,
+) *Controller {
+	return &Controller{
+		enabled:       config.OIDC.Enabled,
+		autoProvision: config.OIDC.AutoProvision,
+		claims: ClaimMapping{
+			UID:         config.OIDC.Claims.UID,
+			Email:       config.OIDC.Claims.Email,
+			DisplayName: config.OIDC.Claims.DisplayName,
+			Groups:      config.OIDC.Claims.Groups,
+		},
+		groupMemberships: groupMemberships,
+		provider:         provider,
+		tx:               tx,
+		authorizer:       authorizer,
+		principalStore:   principalStore,
+		identityStore:    identityStore,
+		tokenStore:       tokenStore,
+		spaceStore:       spaceStore,
+		membershipStore:  membershipStore,
+		userCtrl:         userCtrl,
+		linkSecret: func() string {
+			return bootstrap.NewSystemServiceSession().Principal.Salt
+		},
+	}
+}
+
+// ParseGroupMemberships parses group membership mappings of the format `<group>:<space_path>:<role>`.
+func ParseGroupMemberships(entries []string) ([]GroupMembership, error) {
+	memberships := make([]GroupMembership, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid group membership %q, expected format <group>:<space_path>:<role>", entry)
+		}
+
+		role, ok := enum.MembershipRole(parts[2]).Sanitize()
+		if !ok {
+			return nil, fmt.Errorf("invalid role %q in group membership %q", parts[2], entry)
+		}
+
+		memberships = append(memberships, GroupMembership{
+			Group:     parts[0],
+			SpacePath: strings.Trim(parts[1], "/"),
+			Role:      role,
+		})
+	}
+
+	return memberships, nil
+}
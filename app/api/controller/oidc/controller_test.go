@@ -0,0 +1,250 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseGroupMemberships(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		want    []GroupMembership
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			entries: []string{"developers:acme/backend:contributor", " ops:/acme/:space_owner "},
+			want: []GroupMembership{
+				{Group: "developers", SpacePath: "acme/backend", Role: enum.MembershipRoleContributor},
+				{Group: "ops", SpacePath: "acme", Role: enum.MembershipRoleSpaceOwner},
+			},
+		},
+		{
+			name:    "empty entries are ignored",
+			entries: []string{""},
+			want:    []GroupMembership{},
+		},
+		{
+			name:    "missing role",
+			entries: []string{"developers:acme"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid role",
+			entries: []string{"developers:acme:superuser"},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseGroupMemberships(test.entries)
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeState(t *testing.T) {
+	state, nonce, ok := decodeState(encodeState("abc", "def"))
+	if !ok || state != "abc" || nonce != "def" {
+		t.Errorf("unexpected result: %q, %q, %t", state, nonce, ok)
+	}
+
+	for _, raw := range []string{"", "abc", ".def", "abc."} {
+		if _, _, ok := decodeState(raw); ok {
+			t.Errorf("expected %q to be invalid", raw)
+		}
+	}
+}
+
+type principalStoreMock struct {
+	store.PrincipalStore
+	users []*types.User
+}
+
+func (m principalStoreMock) FindUser(_ context.Context, id int64) (*types.User, error) {
+	for _, user := range m.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (m principalStoreMock) FindUserByUID(_ context.Context, uid string) (*types.User, error) {
+	for _, user := range m.users {
+		if user.UID == uid {
+			return user, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (m principalStoreMock) FindUserByEmail(_ context.Context, email string) (*types.User, error) {
+	for _, user := range m.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type identityStoreMock struct {
+	identities []*types.OIDCIdentity
+}
+
+func (m *identityStoreMock) Find(_ context.Context, issuer, subject string) (*types.OIDCIdentity, error) {
+	for _, identity := range m.identities {
+		if identity.Issuer == issuer && identity.Subject == subject {
+			return identity, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (m *identityStoreMock) Create(_ context.Context, identity *types.OIDCIdentity) error {
+	m.identities = append(m.identities, identity)
+	return nil
+}
+
+func newTestController(identityStore *identityStoreMock) *Controller {
+	return &Controller{
+		enabled:       true,
+		autoProvision: true,
+		claims:        ClaimMapping{UID: "preferred_username", Email: "email"},
+		principalStore: principalStoreMock{users: []*types.User{
+			{ID: 1, UID: "admin", Email: "admin@example.com", Admin: true},
+			{ID: 2, UID: "jane", Email: "jane@example.com"},
+		}},
+		identityStore: identityStore,
+		linkSecret:    func() string { return "secret" },
+	}
+}
+
+func TestFindOrProvisionUser(t *testing.T) {
+	identityStore := &identityStoreMock{identities: []*types.OIDCIdentity{
+		{PrincipalID: 2, Issuer: "https://idp", Subject: "sub-jane"},
+	}}
+	c := newTestController(identityStore)
+
+	tests := []struct {
+		name    string
+		subject string
+		claims  oidc.Claims
+		wantID  int64
+		wantErr int
+	}{
+		{
+			name:    "linked identity",
+			subject: "sub-jane",
+			claims:  oidc.Claims{"preferred_username": "someone-else"},
+			wantID:  2,
+		},
+		{
+			name:    "uid of existing account",
+			subject: "sub-attacker",
+			claims:  oidc.Claims{"preferred_username": "admin"},
+			wantErr: http.StatusConflict,
+		},
+		{
+			name:    "email of existing account",
+			subject: "sub-attacker",
+			claims:  oidc.Claims{"preferred_username": "attacker", "email": "admin@example.com"},
+			wantErr: http.StatusConflict,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			user, err := c.findOrProvisionUser(context.Background(), test.claims, "https://idp", test.subject)
+			if test.wantErr != 0 {
+				uErr := &usererror.Error{}
+				if !errors.As(err, &uErr) || uErr.Status != test.wantErr {
+					t.Fatalf("expected error with status %d, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if user.ID != test.wantID {
+				t.Errorf("expected user %d, got %d", test.wantID, user.ID)
+			}
+		})
+	}
+}
+
+func TestLinkUser(t *testing.T) {
+	identityStore := &identityStoreMock{}
+	c := newTestController(identityStore)
+	ctx := context.Background()
+
+	link, err := jwt.GenerateForOIDCLink(1, "state", time.Minute, "secret")
+	if err != nil {
+		t.Fatalf("failed to generate link: %s", err)
+	}
+
+	if _, err = c.linkUser(ctx, link, "other-state", "https://idp", "sub-admin"); err == nil {
+		t.Fatal("expected link with other state to fail")
+	}
+
+	forged, err := jwt.GenerateForOIDCLink(1, "state", time.Minute, "forged")
+	if err != nil {
+		t.Fatalf("failed to generate link: %s", err)
+	}
+	if _, err = c.linkUser(ctx, forged, "state", "https://idp", "sub-admin"); err == nil {
+		t.Fatal("expected forged link to fail")
+	}
+
+	user, err := c.linkUser(ctx, link, "state", "https://idp", "sub-admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if user.ID != 1 || len(identityStore.identities) != 1 || identityStore.identities[0].PrincipalID != 1 {
+		t.Fatalf("expected identity to be linked to user 1, got %+v", identityStore.identities)
+	}
+
+	user, err = c.findOrProvisionUser(ctx, oidc.Claims{}, "https://idp", "sub-admin")
+	if err != nil || user.ID != 1 {
+		t.Fatalf("expected linked identity to log in as user 1, got %v, %v", user, err)
+	}
+}
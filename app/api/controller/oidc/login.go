@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/types/enum"
+)
+
+// linkLifetime is the time the user has to complete the login at the identity provider when linking an account.
+const linkLifetime = 10 * time.Minute
+
+var errOIDCDisabled = usererror.NotFound("OIDC login is not enabled")
+
+type LoginOutput struct {
+	// RedirectURL is the URL of the identity provider the user has to be redirected to.
+	RedirectURL string
+	// State has to be stored on the client (e.g. as cookie) and provided again during the callback.
+	State string
+	// Link is only set when linking an account and has to be stored on the client like the state.
+	Link string
+}
+
+// Login starts the authorization code flow - it returns the identity provider URL the user has to be sent to.
+func (c *Controller) Login(ctx context.Context) (*LoginOutput, error) {
+	if !c.enabled {
+		return nil, errOIDCDisabled
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	redirectURL, err := c.provider.AuthCodeURL(ctx, state, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get oidc authorization url: %w", err)
+	}
+
+	return &LoginOutput{
+		RedirectURL: redirectURL,
+		State:       encodeState(state, nonce),
+	}, nil
+}
+
+// Link starts the authorization code flow to link the identity of the user at the identity provider
+// to the account of the authenticated user. Existing accounts are never linked automatically during login.
+func (c *Controller) Link(ctx context.Context, session *auth.Session) (*LoginOutput, error) {
+	if !c.enabled {
+		return nil, errOIDCDisabled
+	}
+
+	user, err := c.principalStore.FindUser(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	out, err := c.Login(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	state, _, _ := decodeState(out.State)
+	out.Link, err = jwt.GenerateForOIDCLink(user.ID, state, linkLifetime, c.linkSecret())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate oidc link: %w", err)
+	}
+
+	return out, nil
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random string: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+func encodeState(state, nonce string) string {
+	return state + "." + nonce
+}
+
+func decodeState(raw string) (string, string, bool) {
+	state, nonce, ok := strings.Cut(raw, ".")
+	if !ok || state == "" || nonce == "" {
+		return "", "", false
+	}
+
+	return state, nonce, true
+}
@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideController,
+)
+
+func ProvideController(
+	config *types.Config,
+	provider *oidc.Provider,
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	principalStore store.PrincipalStore,
+	identityStore store.OIDCIdentityStore,
+	tokenStore store.TokenStore,
+	spaceStore store.SpaceStore,
+	membershipStore store.MembershipStore,
+	userCtrl *user.Controller,
+) (*Controller, error) {
+	groupMemberships, err := ParseGroupMemberships(config.OIDC.GroupMemberships)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse oidc group memberships: %w", err)
+	}
+
+	return NewController(
+		config,
+		groupMemberships,
+		provider,
+		tx,
+		authorizer,
+		principalStore,
+		identityStore,
+		tokenStore,
+		spaceStore,
+		membershipStore,
+		userCtrl,
+	), nil
+}
@@ -42,6 +42,10 @@ type CreateInput struct {
 	Disabled      bool   `json:"disabled"`
 	DefaultBranch string `json:"default_branch"`
 	ConfigPath    string `json:"config_path"`
+	// RetentionCount and RetentionDays override the system default retention of executions.
+	// Zero uses the system default, a negative value disables the limit.
+	RetentionCount int64 `json:"retention_count"`
+	RetentionDays  int64 `json:"retention_days"`
 }
 
 func (c *Controller) Create(
@@ -67,17 +71,19 @@ func (c *Controller) Create(
 	var pipeline *types.Pipeline
 	now := time.Now().UnixMilli()
 	pipeline = &types.Pipeline{
-		Description:   in.Description,
-		RepoID:        repo.ID,
-		UID:           in.UID,
-		Disabled:      in.Disabled,
-		CreatedBy:     session.Principal.ID,
-		Seq:           0,
-		DefaultBranch: in.DefaultBranch,
-		ConfigPath:    in.ConfigPath,
-		Created:       now,
-		Updated:       now,
-		Version:       0,
+		Description:    in.Description,
+		RepoID:         repo.ID,
+		UID:            in.UID,
+		Disabled:       in.Disabled,
+		CreatedBy:      session.Principal.ID,
+		Seq:            0,
+		DefaultBranch:  in.DefaultBranch,
+		ConfigPath:     in.ConfigPath,
+		RetentionCount: in.RetentionCount,
+		RetentionDays:  in.RetentionDays,
+		Created:        now,
+		Updated:        now,
+		Version:        0,
 	}
 	err = c.pipelineStore.Create(ctx, pipeline)
 	if err != nil {
@@ -31,6 +31,10 @@ type UpdateInput struct {
 	Description *string `json:"description"`
 	Disabled    *bool   `json:"disabled"`
 	ConfigPath  *string `json:"config_path"`
+	// RetentionCount and RetentionDays override the system default retention of executions.
+	// Zero uses the system default, a negative value disables the limit.
+	RetentionCount *int64 `json:"retention_count"`
+	RetentionDays  *int64 `json:"retention_days"`
 }
 
 func (c *Controller) Update(
@@ -71,6 +75,12 @@ func (c *Controller) Update(
 		if in.Disabled != nil {
 			pipeline.Disabled = *in.Disabled
 		}
+		if in.RetentionCount != nil {
+			pipeline.RetentionCount = *in.RetentionCount
+		}
+		if in.RetentionDays != nil {
+			pipeline.RetentionDays = *in.RetentionDays
+		}
 
 		return nil
 	})
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -27,6 +28,8 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/signing"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -40,27 +43,30 @@ import (
 )
 
 type Controller struct {
-	tx                  dbtx.Transactor
-	urlProvider         url.Provider
-	authorizer          authz.Authorizer
-	pullreqStore        store.PullReqStore
-	activityStore       store.PullReqActivityStore
-	codeCommentView     store.CodeCommentView
-	reviewStore         store.PullReqReviewStore
-	reviewerStore       store.PullReqReviewerStore
-	repoStore           store.RepoStore
-	principalStore      store.PrincipalStore
-	fileViewStore       store.PullReqFileViewStore
-	membershipStore     store.MembershipStore
-	checkStore          store.CheckStore
-	git                 git.Interface
-	eventReporter       *pullreqevents.Reporter
-	mtxManager          lock.MutexManager
-	codeCommentMigrator *codecomments.Migrator
-	pullreqService      *pullreq.Service
-	protectionManager   *protection.Manager
-	sseStreamer         sse.Streamer
-	codeOwners          *codeowners.Service
+	tx                   dbtx.Transactor
+	urlProvider          url.Provider
+	authorizer           authz.Authorizer
+	pullreqStore         store.PullReqStore
+	activityStore        store.PullReqActivityStore
+	codeCommentView      store.CodeCommentView
+	reviewStore          store.PullReqReviewStore
+	reviewerStore        store.PullReqReviewerStore
+	repoStore            store.RepoStore
+	principalStore       store.PrincipalStore
+	fileViewStore        store.PullReqFileViewStore
+	membershipStore      store.MembershipStore
+	checkStore           store.CheckStore
+	git                  git.Interface
+	eventReporter        *pullreqevents.Reporter
+	mtxManager           lock.MutexManager
+	codeCommentMigrator  *codecomments.Migrator
+	pullreqService       *pullreq.Service
+	protectionManager    *protection.Manager
+	sseStreamer          sse.Streamer
+	codeOwners           *codeowners.Service
+	userGroupResolver    usergroup.Resolver
+	userGroupMemberStore store.UserGroupMemberStore
+	signing              *signing.Service
 }
 
 func NewController(
@@ -85,29 +91,35 @@ func NewController(
 	protectionManager *protection.Manager,
 	sseStreamer sse.Streamer,
 	codeowners *codeowners.Service,
+	userGroupResolver usergroup.Resolver,
+	userGroupMemberStore store.UserGroupMemberStore,
+	signing *signing.Service,
 ) *Controller {
 	return &Controller{
-		tx:                  tx,
-		urlProvider:         urlProvider,
-		authorizer:          authorizer,
-		pullreqStore:        pullreqStore,
-		activityStore:       pullreqActivityStore,
-		codeCommentView:     codeCommentView,
-		reviewStore:         pullreqReviewStore,
-		reviewerStore:       pullreqReviewerStore,
-		repoStore:           repoStore,
-		principalStore:      principalStore,
-		fileViewStore:       fileViewStore,
-		membershipStore:     membershipStore,
-		checkStore:          checkStore,
-		git:                 git,
-		codeCommentMigrator: codeCommentMigrator,
-		eventReporter:       eventReporter,
-		mtxManager:          mtxManager,
-		pullreqService:      pullreqService,
-		protectionManager:   protectionManager,
-		sseStreamer:         sseStreamer,
-		codeOwners:          codeowners,
+		tx:                   tx,
+		urlProvider:          urlProvider,
+		authorizer:           authorizer,
+		pullreqStore:         pullreqStore,
+		activityStore:        pullreqActivityStore,
+		codeCommentView:      codeCommentView,
+		reviewStore:          pullreqReviewStore,
+		reviewerStore:        pullreqReviewerStore,
+		repoStore:            repoStore,
+		principalStore:       principalStore,
+		fileViewStore:        fileViewStore,
+		membershipStore:      membershipStore,
+		checkStore:           checkStore,
+		git:                  git,
+		codeCommentMigrator:  codeCommentMigrator,
+		eventReporter:        eventReporter,
+		mtxManager:           mtxManager,
+		pullreqService:       pullreqService,
+		protectionManager:    protectionManager,
+		sseStreamer:          sseStreamer,
+		codeOwners:           codeowners,
+		userGroupResolver:    userGroupResolver,
+		userGroupMemberStore: userGroupMemberStore,
+		signing:              signing,
 	}
 }
 
@@ -137,6 +149,36 @@ func (c *Controller) verifyBranchExistence(ctx context.Context,
 	return ref.SHA, nil
 }
 
+// fetchSourceCommits makes the commits of the source repository available in the target repository,
+// which is required for pull requests from forks (diff, merge base, ...). It's a noop for pull requests
+// within the same repository.
+func (c *Controller) fetchSourceCommits(ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+	sha string,
+) error {
+	if sourceRepo.ID == targetRepo.ID {
+		return nil
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, targetRepo)
+	if err != nil {
+		return fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	err = c.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      sourceRepo.GitUID,
+		ObjectSHAs:  []string{sha},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch commits of source repository: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Controller) getRepoCheckAccess(ctx context.Context,
 	session *auth.Session, repoRef string, reqPermission enum.Permission,
 ) (*types.Repository, error) {
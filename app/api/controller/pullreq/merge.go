@@ -146,13 +146,7 @@ func (c *Controller) Merge(
 	}
 
 	sourceRepo := targetRepo
-	sourceWriteParams := targetWriteParams
 	if pr.SourceRepoID != pr.TargetRepoID {
-		sourceWriteParams, err = controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, sourceRepo)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
-		}
-
 		sourceRepo, err = c.repoStore.Find(ctx, pr.SourceRepoID)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get source repository: %w", err)
@@ -174,23 +168,24 @@ func (c *Controller) Merge(
 		return nil, nil, fmt.Errorf("failed to fetch protection rules for the repository: %w", err)
 	}
 
-	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, sourceRepo, pr, reviewers)
+	codeOwnerWithApproval, err := c.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
 	// check for error and ignore if it is codeowners file not found else throw error
 	if err != nil && errors.AsStatus(err) != errors.StatusNotFound {
 		return nil, nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
 	}
 
 	ruleOut, violations, err := protectionRules.MergeVerify(ctx, protection.MergeVerifyInput{
-		Actor:        &session.Principal,
-		AllowBypass:  in.BypassRules,
-		IsRepoOwner:  isRepoOwner,
-		TargetRepo:   targetRepo,
-		SourceRepo:   sourceRepo,
-		PullReq:      pr,
-		Reviewers:    reviewers,
-		Method:       in.Method,
-		CheckResults: checkResults,
-		CodeOwners:   codeOwnerWithApproval,
+		Actor:          &session.Principal,
+		AllowBypass:    in.BypassRules,
+		IsRepoOwner:    isRepoOwner,
+		TargetRepo:     targetRepo,
+		SourceRepo:     sourceRepo,
+		PullReq:        pr,
+		Reviewers:      reviewers,
+		Method:         in.Method,
+		CheckResults:   checkResults,
+		CodeOwners:     codeOwnerWithApproval,
+		CommitVerifier: c.newMergeCommitVerifier(targetRepo, pr),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -240,11 +235,23 @@ func (c *Controller) Merge(
 			}
 		}
 
+		allowedMethods := ruleOut.AllowedMethods
+
+		// A fast-forward merge is only possible if the target branch hasn't diverged from the source branch.
+		if allowedMethods != nil && (pr.MergeTargetSHA == nil || pr.MergeBaseSHA != *pr.MergeTargetSHA) {
+			allowedMethods = make([]enum.MergeMethod, 0, len(ruleOut.AllowedMethods))
+			for _, method := range ruleOut.AllowedMethods {
+				if method != enum.MergeMethodFastForward {
+					allowedMethods = append(allowedMethods, method)
+				}
+			}
+		}
+
 		// With in.DryRun=true this function never returns types.MergeViolations
 		out := &types.MergeResponse{
 			DryRun:         true,
 			BranchDeleted:  ruleOut.DeleteSourceBranch,
-			AllowedMethods: ruleOut.AllowedMethods,
+			AllowedMethods: allowedMethods,
 			ConflictFiles:  pr.MergeConflicts,
 			RuleViolations: violations,
 		}
@@ -305,6 +312,9 @@ func (c *Controller) Merge(
 		}, nil
 	}
 
+	// NOTE: The source branch of a fork is owned by the author of the fork, so it's never deleted automatically.
+	deleteSourceBranch := ruleOut.DeleteSourceBranch && sourceRepo.ID == targetRepo.ID
+
 	var activitySeqMerge, activitySeqBranchDeleted int64
 	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
 		pr.State = enum.PullReqStateMerged
@@ -326,7 +336,7 @@ func (c *Controller) Merge(
 		pr.ActivitySeq++
 		activitySeqMerge = pr.ActivitySeq
 
-		if ruleOut.DeleteSourceBranch {
+		if deleteSourceBranch {
 			pr.ActivitySeq++
 			activitySeqBranchDeleted = pr.ActivitySeq
 		}
@@ -358,9 +368,9 @@ func (c *Controller) Merge(
 	})
 
 	var branchDeleted bool
-	if ruleOut.DeleteSourceBranch {
+	if deleteSourceBranch {
 		errDelete := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
-			WriteParams: sourceWriteParams,
+			WriteParams: targetWriteParams,
 			BranchName:  pr.SourceBranch,
 		})
 		if errDelete != nil {
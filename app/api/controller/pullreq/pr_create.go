@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 
+	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
@@ -53,7 +54,7 @@ func (c *Controller) Create(
 		return nil, usererror.BadRequest("pull request title can't be empty")
 	}
 
-	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	targetRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
 	if err != nil {
 		return nil, fmt.Errorf("failed to acquire access access to target repo: %w", err)
 	}
@@ -66,6 +67,10 @@ func (c *Controller) Create(
 		}
 	}
 
+	if err = c.checkCreateAccess(ctx, session, sourceRepo, targetRepo); err != nil {
+		return nil, err
+	}
+
 	if sourceRepo.ID == targetRepo.ID && in.TargetBranch == in.SourceBranch {
 		return nil, usererror.BadRequest("target and source branch can't be the same")
 	}
@@ -84,9 +89,13 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	if err = c.fetchSourceCommits(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+		return nil, err
+	}
+
 	mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-		Ref1:       in.SourceBranch,
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       sourceSHA,
 		Ref2:       in.TargetBranch,
 	})
 	if err != nil {
@@ -128,6 +137,34 @@ func (c *Controller) Create(
 	return pr, nil
 }
 
+// checkCreateAccess verifies that the principal is allowed to create a pull request between the repositories.
+// Pull requests within a repository require push access, while pull requests from a fork
+// require push access to the fork only - this allows proposing changes without push access to the target.
+func (c *Controller) checkCreateAccess(
+	ctx context.Context,
+	session *auth.Session,
+	sourceRepo *types.Repository,
+	targetRepo *types.Repository,
+) error {
+	if sourceRepo.ID == targetRepo.ID {
+		if err := apiauth.CheckRepo(ctx, c.authorizer, session, targetRepo, enum.PermissionRepoPush, false); err != nil {
+			return fmt.Errorf("failed to acquire access access to target repo: %w", err)
+		}
+
+		return nil
+	}
+
+	if sourceRepo.ForkID != targetRepo.ID {
+		return usererror.BadRequest("The source repository has to be a fork of the target repository.")
+	}
+
+	if err := apiauth.CheckRepo(ctx, c.authorizer, session, sourceRepo, enum.PermissionRepoPush, false); err != nil {
+		return fmt.Errorf("failed to acquire access access to source repo: %w", err)
+	}
+
+	return nil
+}
+
 // newPullReq creates new pull request object.
 func newPullReq(
 	session *auth.Session,
@@ -120,9 +120,13 @@ func (c *Controller) State(ctx context.Context,
 			return nil, err
 		}
 
+		if err = c.fetchSourceCommits(ctx, session, sourceRepo, targetRepo, sourceSHA); err != nil {
+			return nil, err
+		}
+
 		mergeBaseResult, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-			ReadParams: git.ReadParams{RepoUID: sourceRepo.GitUID},
-			Ref1:       pr.SourceBranch,
+			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+			Ref1:       sourceSHA,
 			Ref2:       pr.TargetBranch,
 		})
 		if err != nil {
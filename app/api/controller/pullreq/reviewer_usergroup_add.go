@@ -0,0 +1,142 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type UserGroupReviewerAddInput struct {
+	UserGroupUID string `json:"usergroup_uid"`
+}
+
+// UserGroupReviewerAdd adds all members of a user group as reviewers of the pull request.
+// The user group is resolved relative to the space of the repository.
+// The pull request author and members without access to the repository are skipped.
+func (c *Controller) UserGroupReviewerAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *UserGroupReviewerAddInput,
+) ([]*types.PullReqReviewer, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if in.UserGroupUID == "" {
+		return nil, usererror.BadRequest("Must specify user group UID.")
+	}
+
+	spacePath, _, err := paths.DisectLeaf(repo.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space path of repo: %w", err)
+	}
+
+	userGroup, err := c.userGroupResolver.Resolve(ctx, paths.Concatinate(spacePath, in.UserGroupUID))
+	if errors.Is(err, usergroup.ErrNotFound) {
+		return nil, usererror.BadRequestf("User group '%s' not found.", in.UserGroupUID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user group: %w", err)
+	}
+
+	memberIDs, err := c.userGroupMemberStore.ListPrincipalIDs(ctx, userGroup.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of user group: %w", err)
+	}
+
+	addedByInfo := session.Principal.ToPrincipalInfo()
+
+	reviewers := make([]*types.PullReqReviewer, 0, len(memberIDs))
+	for _, memberID := range memberIDs {
+		if memberID == pr.CreatedBy {
+			continue
+		}
+
+		var reviewerType enum.PullReqReviewerType
+		switch session.Principal.ID {
+		case pr.CreatedBy:
+			reviewerType = enum.PullReqReviewerTypeRequested
+		case memberID:
+			reviewerType = enum.PullReqReviewerTypeSelfAssigned
+		default:
+			reviewerType = enum.PullReqReviewerTypeAssigned
+		}
+
+		reviewerPrincipal, err := c.principalStore.Find(ctx, memberID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find user group member: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+			Principal: *reviewerPrincipal,
+			Metadata:  nil,
+		}, repo, enum.PermissionRepoView, false); err != nil {
+			log.Ctx(ctx).Info().Msgf("Skipping user group member %s as reviewer: %s", reviewerPrincipal.UID, err)
+			continue
+		}
+
+		var reviewer *types.PullReqReviewer
+		created := false
+
+		err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+			reviewer, err = c.reviewerStore.Find(ctx, pr.ID, memberID)
+			if err != nil && !errors.Is(err, store.ErrResourceNotFound) {
+				return err
+			}
+
+			if reviewer != nil {
+				return nil
+			}
+
+			reviewer = newPullReqReviewer(session, pr, repo, reviewerPrincipal.ToPrincipalInfo(), addedByInfo,
+				reviewerType, &ReviewerAddInput{ReviewerID: memberID})
+			created = true
+
+			return c.reviewerStore.Create(ctx, reviewer)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pull request reviewer: %w", err)
+		}
+
+		if created {
+			c.reportReviewerAddition(ctx, session, pr, reviewer)
+		}
+
+		reviewers = append(reviewers, reviewer)
+	}
+
+	return reviewers, nil
+}
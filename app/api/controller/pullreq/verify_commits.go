@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signing"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// mergeCommitVerifier verifies signatures of the commits of a pull request,
+// which are the commits that a fast-forward merge adds to the target branch.
+type mergeCommitVerifier struct {
+	git          git.Interface
+	verifier     *signing.Verifier
+	readParams   git.ReadParams
+	sourceSHA    string
+	mergeBaseSHA string
+}
+
+var _ protection.CommitVerifier = (*mergeCommitVerifier)(nil)
+
+func (c *Controller) newMergeCommitVerifier(
+	targetRepo *types.Repository,
+	pr *types.PullReq,
+) *mergeCommitVerifier {
+	return &mergeCommitVerifier{
+		git:          c.git,
+		verifier:     c.signing.NewVerifier(),
+		readParams:   git.CreateReadParams(targetRepo),
+		sourceSHA:    pr.SourceSHA,
+		mergeBaseSHA: pr.MergeBaseSHA,
+	}
+}
+
+// UnverifiedCommits returns the commits of the pull request, from the merge base to the source commit,
+// which don't have a verified signature. The branch name is always the target branch of the pull request.
+func (v *mergeCommitVerifier) UnverifiedCommits(
+	ctx context.Context,
+	_ string,
+) ([]protection.UnverifiedCommit, error) {
+	var excludeSHAs []string
+	if v.mergeBaseSHA != "" {
+		excludeSHAs = []string{v.mergeBaseSHA}
+	}
+
+	out, err := v.git.ListNewCommits(ctx, &git.ListNewCommitsParams{
+		ReadParams:  v.readParams,
+		SHA:         v.sourceSHA,
+		ExcludeSHAs: excludeSHAs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list commits of pull request: %w", err)
+	}
+
+	var unverified []protection.UnverifiedCommit
+
+	for _, commit := range out.Commits {
+		verification, err := v.verifier.Verify(ctx, commit.SignedData, commit.Committer.Identity.Email)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify signature of commit %s: %w", commit.SHA, err)
+		}
+
+		if verification.Status == enum.SignatureVerificationStatusVerified {
+			continue
+		}
+
+		unverified = append(unverified, protection.UnverifiedCommit{
+			SHA:    commit.SHA,
+			Status: verification.Status,
+		})
+	}
+
+	return unverified, nil
+}
@@ -21,6 +21,8 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/signing"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -46,7 +48,9 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 	rpcClient git.Interface, eventReporter *pullreqevents.Reporter,
 	mtxManager lock.MutexManager, codeCommentMigrator *codecomments.Migrator,
 	pullreqService *pullreq.Service, ruleManager *protection.Manager, sseStreamer sse.Streamer,
-	codeOwners *codeowners.Service,
+	codeOwners *codeowners.Service, userGroupResolver usergroup.Resolver,
+	userGroupMemberStore store.UserGroupMemberStore,
+	signing *signing.Service,
 ) *Controller {
 	return NewController(tx, urlProvider, authorizer,
 		pullReqStore, pullReqActivityStore,
@@ -57,5 +61,6 @@ func ProvideController(tx dbtx.Transactor, urlProvider url.Provider, authorizer
 		checkStore,
 		rpcClient, eventReporter,
 		mtxManager, codeCommentMigrator,
-		pullreqService, ruleManager, sseStreamer, codeOwners)
+		pullreqService, ruleManager, sseStreamer, codeOwners,
+		userGroupResolver, userGroupMemberStore, signing)
 }
@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/blob"
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// archivesPrefix is the blob store prefix under which archives of tags are cached
+// (archives/<repoID>/<sha>-<variant>.<format>).
+const archivesPrefix = "archives/"
+
+// ArchiveOutput contains the archive of a git reference.
+type ArchiveOutput struct {
+	FileName    string
+	ContentType string
+	// Content is the (streamed) archive, it has to be closed by the caller.
+	Content io.ReadCloser
+}
+
+// Archive returns an archive of the repository content at the git reference provided as archive path
+// (e.g. "main.zip" or "v1.0.0.tar.gz"), optionally limited to a sub path of the repository.
+// Archives of tags are cached in the blob store.
+func (c *Controller) Archive(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	archivePath string,
+	subPath string,
+) (*ArchiveOutput, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	gitRef, format, err := parseArchivePath(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	subPath = strings.Trim(subPath, "/")
+
+	readParams := git.CreateReadParams(repo)
+
+	// verify the reference and the path upfront - errors can't be reported once the archive is streamed.
+	_, err = c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       subPath,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tree node: %w", err)
+	}
+
+	name := repo.UID + "-" + strings.ReplaceAll(gitRef, "/", "-")
+	params := &git.ArchiveParams{
+		ReadParams: readParams,
+		Format:     format,
+		Treeish:    gitRef,
+		Prefix:     name + "/",
+	}
+	if subPath != "" {
+		params.Paths = []string{subPath}
+	}
+
+	out := &ArchiveOutput{
+		FileName:    name + "." + string(format),
+		ContentType: format.ContentType(),
+	}
+
+	tag, err := c.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: readParams,
+		Name:       gitRef,
+		Type:       gitenum.RefTypeTag,
+	})
+	if gitness_errors.IsNotFound(err) {
+		// branches and commits aren't cached, branches move and commits are rarely downloaded twice.
+		out.Content = c.streamArchive(ctx, params, "")
+		return out, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	// archive the tag object itself to be safe in case a branch with the same name exists.
+	params.Treeish = tag.SHA
+	cachePath := archiveCachePath(repo, tag.SHA, params)
+
+	out.Content, err = c.blobStore.Download(ctx, cachePath)
+	if errors.Is(err, blob.ErrNotFound) {
+		out.Content = c.streamArchive(ctx, params, cachePath)
+		return out, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download cached archive: %w", err)
+	}
+
+	return out, nil
+}
+
+// streamArchive creates the archive in the background and returns a reader of it.
+// If a cache path is provided, the archive is stored in the blob store before the reader is closed.
+func (c *Controller) streamArchive(ctx context.Context, params *git.ArchiveParams, cachePath string) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		var (
+			w    io.Writer = pw
+			file *os.File
+			err  error
+		)
+
+		if cachePath != "" {
+			file, err = os.CreateTemp("", "gitness-archive-*")
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to create temporary file for caching the archive")
+			} else {
+				defer func() {
+					_ = file.Close()
+					_ = os.Remove(file.Name())
+				}()
+				w = io.MultiWriter(pw, file)
+			}
+		}
+
+		err = c.git.Archive(ctx, params, w)
+		if err == nil && file != nil {
+			// the upload happens before the reader is closed to keep the request (and its context) alive.
+			c.cacheArchive(ctx, file, cachePath)
+		}
+
+		pw.CloseWithError(err)
+	}()
+
+	return pr
+}
+
+func (c *Controller) cacheArchive(ctx context.Context, file *os.File, cachePath string) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to rewind the archive for caching")
+		return
+	}
+
+	if err := c.blobStore.Upload(ctx, file, cachePath); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("path", cachePath).Msg("failed to cache archive")
+	}
+}
+
+// archiveCachePath returns the blob store path of a cached archive. The variant distinguishes archives
+// of the same tag with a different prefix (repo got renamed) or sub path.
+func archiveCachePath(repo *types.Repository, sha string, params *git.ArchiveParams) string {
+	h := sha256.New()
+	h.Write([]byte(params.Prefix))
+	for _, p := range params.Paths {
+		h.Write([]byte{0})
+		h.Write([]byte(p))
+	}
+	variant := hex.EncodeToString(h.Sum(nil))[:16]
+
+	return fmt.Sprintf("%s%d/%s-%s.%s", archivesPrefix, repo.ID, sha, variant, params.Format)
+}
+
+// parseArchivePath splits an archive path of the form "<git_ref>.<format>" into the git reference and the format.
+func parseArchivePath(archivePath string) (string, git.ArchiveFormat, error) {
+	for _, format := range git.ArchiveFormats {
+		gitRef, ok := strings.CutSuffix(archivePath, "."+string(format))
+		if !ok {
+			continue
+		}
+
+		if gitRef == "" {
+			return "", "", usererror.BadRequest("A git reference has to be provided.")
+		}
+
+		return gitRef, format, nil
+	}
+
+	return "", "", usererror.BadRequestf("Unsupported archive format, supported formats are %v.", git.ArchiveFormats)
+}
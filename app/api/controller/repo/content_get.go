@@ -22,10 +22,13 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/lfs"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
 )
 
 const (
@@ -66,6 +69,9 @@ type FileContent struct {
 	Data     string                   `json:"data"`
 	Size     int64                    `json:"size"`
 	DataSize int64                    `json:"data_size"`
+
+	// LFSObjectID is set in case the file is a Git LFS pointer - the data and size are the ones of the LFS object.
+	LFSObjectID string `json:"lfs_object_id,omitempty"`
 }
 
 func (c *FileContent) isContent() {}
@@ -132,7 +138,7 @@ func (c *Controller) GetContent(ctx context.Context,
 	case ContentTypeDir:
 		content, err = c.getDirContent(ctx, readParams, gitRef, repoPath, includeLatestCommit)
 	case ContentTypeFile:
-		content, err = c.getFileContent(ctx, readParams, info.SHA)
+		content, err = c.getFileContent(ctx, readParams, repo.ID, info.SHA)
 	case ContentTypeSymlink:
 		content, err = c.getSymlinkContent(ctx, readParams, info.SHA)
 	case ContentTypeSubmodule:
@@ -175,6 +181,7 @@ func (c *Controller) getSubmoduleContent(ctx context.Context,
 
 func (c *Controller) getFileContent(ctx context.Context,
 	readParams git.ReadParams,
+	repoID int64,
 	blobSHA string,
 ) (*FileContent, error) {
 	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
@@ -191,6 +198,16 @@ func (c *Controller) getFileContent(ctx context.Context,
 		return nil, fmt.Errorf("failed to read blob content: %w", err)
 	}
 
+	if output.Size <= lfs.MaxPointerSize {
+		lfsContent, ok, err := c.getLFSFileContent(ctx, repoID, content)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return lfsContent, nil
+		}
+	}
+
 	return &FileContent{
 		Size:     output.Size,
 		DataSize: output.ContentSize,
@@ -199,6 +216,43 @@ func (c *Controller) getFileContent(ctx context.Context,
 	}, nil
 }
 
+// getLFSFileContent returns the (partial) content of the LFS object the pointer is referencing.
+func (c *Controller) getLFSFileContent(ctx context.Context,
+	repoID int64,
+	pointer []byte,
+) (*FileContent, bool, error) {
+	obj, ok, err := c.lfs.Resolve(ctx, repoID, pointer)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to resolve LFS pointer: %w", err)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	_, objReader, err := c.lfs.Download(ctx, repoID, obj.OID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to download LFS object: %w", err)
+	}
+	defer func() {
+		if errClose := objReader.Close(); errClose != nil {
+			log.Ctx(ctx).Warn().Err(errClose).Msg("failed to close LFS object reader")
+		}
+	}()
+
+	content, err := io.ReadAll(io.LimitReader(objReader, maxGetContentFileSize))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read LFS object content: %w", err)
+	}
+
+	return &FileContent{
+		Size:        obj.Size,
+		DataSize:    int64(len(content)),
+		Encoding:    enum.ContentEncodingTypeBase64,
+		Data:        base64.StdEncoding.EncodeToString(content),
+		LFSObjectID: obj.OID,
+	}, true, nil
+}
+
 func (c *Controller) getSymlinkContent(ctx context.Context,
 	readParams git.ReadParams,
 	blobSHA string,
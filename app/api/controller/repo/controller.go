@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/usererror"
@@ -28,9 +29,14 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/lfs"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signing"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -45,6 +51,7 @@ var (
 type Controller struct {
 	defaultBranch                 string
 	publicResourceCreationEnabled bool
+	mirrorMinInterval             time.Duration
 
 	tx                dbtx.Transactor
 	urlProvider       url.Provider
@@ -61,6 +68,13 @@ type Controller struct {
 	codeOwners        *codeowners.Service
 	eventReporter     *repoevents.Reporter
 	indexer           keywordsearch.Indexer
+	pullMirrorStore   store.PullMirrorStore
+	pushMirrorStore   store.PushMirrorStore
+	encrypter         encrypt.Encrypter
+	mirror            *mirror.Service
+	blobStore         blob.Store
+	lfs               *lfs.Service
+	signing           *signing.Service
 }
 
 func NewController(
@@ -80,10 +94,18 @@ func NewController(
 	codeOwners *codeowners.Service,
 	eventReporter *repoevents.Reporter,
 	indexer keywordsearch.Indexer,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	encrypter encrypt.Encrypter,
+	mirror *mirror.Service,
+	blobStore blob.Store,
+	lfs *lfs.Service,
+	signing *signing.Service,
 ) *Controller {
 	return &Controller{
 		defaultBranch:                 config.Git.DefaultBranch,
 		publicResourceCreationEnabled: config.PublicResourceCreationEnabled,
+		mirrorMinInterval:             config.Mirror.MinInterval,
 		tx:                            tx,
 		urlProvider:                   urlProvider,
 		uidCheck:                      uidCheck,
@@ -99,6 +121,13 @@ func NewController(
 		codeOwners:                    codeOwners,
 		eventReporter:                 eventReporter,
 		indexer:                       indexer,
+		pullMirrorStore:               pullMirrorStore,
+		pushMirrorStore:               pushMirrorStore,
+		encrypter:                     encrypter,
+		mirror:                        mirror,
+		blobStore:                     blobStore,
+		lfs:                           lfs,
+		signing:                       signing,
 	}
 }
 
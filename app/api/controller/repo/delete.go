@@ -24,6 +24,7 @@ import (
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -60,6 +61,11 @@ func (c *Controller) Delete(ctx context.Context, session *auth.Session, repoRef
 }
 
 func (c *Controller) DeleteNoAuth(ctx context.Context, session *auth.Session, repo *types.Repository) error {
+	// forks share the git objects of the repo, so they have to be made independent before deleting the repo.
+	if err := c.dissociateForks(ctx, session, repo); err != nil {
+		return fmt.Errorf("failed to dissociate forks: %w", err)
+	}
+
 	if err := c.deleteGitRepository(ctx, session, repo); err != nil {
 		return fmt.Errorf("failed to delete git repository: %w", err)
 	}
@@ -68,6 +74,10 @@ func (c *Controller) DeleteNoAuth(ctx context.Context, session *auth.Session, re
 		return fmt.Errorf("failed to delete repo from db: %w", err)
 	}
 
+	if repo.ForkID != 0 {
+		c.decrementNumForks(ctx, repo.ForkID)
+	}
+
 	c.eventReporter.Deleted(
 		ctx,
 		&repoevents.DeletedPayload{
@@ -107,3 +117,64 @@ func (c *Controller) deleteGitRepository(
 	}
 	return nil
 }
+
+func (c *Controller) dissociateForks(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+) error {
+	if repo.NumForks == 0 || repo.Importing {
+		return nil
+	}
+
+	forks, err := c.repoStore.ListForks(ctx, repo.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list forks: %w", err)
+	}
+
+	for _, fork := range forks {
+		writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, fork)
+		if err != nil {
+			return fmt.Errorf("failed to create RPC write params: %w", err)
+		}
+
+		err = c.git.DissociateRepository(ctx, &git.DissociateRepositoryParams{
+			WriteParams: writeParams,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to dissociate fork %d: %w", fork.ID, err)
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, fork, func(r *types.Repository) error {
+			r.ForkID = 0
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to reset fork id of fork %d: %w", fork.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// decrementNumForks decrements the fork count of the source repo of a deleted fork.
+func (c *Controller) decrementNumForks(ctx context.Context, sourceRepoID int64) {
+	sourceRepo, err := c.repoStore.Find(ctx, sourceRepoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return
+	}
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to find source repository of deleted fork")
+		return
+	}
+
+	_, err = c.repoStore.UpdateOptLock(ctx, sourceRepo, func(r *types.Repository) error {
+		if r.NumForks > 0 {
+			r.NumForks--
+		}
+		return nil
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to update number of forks of source repository")
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+var errTestDissociate = errors.New("dissociate failure")
+
+type urlProviderMock struct {
+	url.Provider
+}
+
+func (urlProviderMock) GetInternalAPIURL() string {
+	return "http://localhost:3000"
+}
+
+type gitMock struct {
+	git.Interface
+	// failUID is the git uid of the repository for which the dissociation fails.
+	failUID     string
+	dissociated []string
+}
+
+func (g *gitMock) DissociateRepository(_ context.Context, params *git.DissociateRepositoryParams) error {
+	if params.RepoUID == g.failUID {
+		return errTestDissociate
+	}
+	g.dissociated = append(g.dissociated, params.RepoUID)
+	return nil
+}
+
+type repoStoreMock struct {
+	store.RepoStore
+	repos []*types.Repository
+}
+
+func (s *repoStoreMock) Find(_ context.Context, id int64) (*types.Repository, error) {
+	for _, repo := range s.repos {
+		if repo.ID == id {
+			return repo, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *repoStoreMock) ListForks(_ context.Context, forkID int64) ([]*types.Repository, error) {
+	var forks []*types.Repository
+	for _, repo := range s.repos {
+		if repo.ForkID == forkID {
+			forks = append(forks, repo)
+		}
+	}
+	return forks, nil
+}
+
+func (s *repoStoreMock) UpdateOptLock(
+	_ context.Context,
+	repo *types.Repository,
+	mutateFn func(repository *types.Repository) error,
+) (*types.Repository, error) {
+	if err := mutateFn(repo); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+func TestController_DissociateForks(t *testing.T) {
+	tests := []struct {
+		name            string
+		repo            *types.Repository
+		failUID         string
+		wantErr         error
+		wantDissociated []string
+		wantForkIDs     []int64
+	}{
+		{
+			name:            "forks",
+			repo:            &types.Repository{ID: 1, GitUID: "source", NumForks: 2},
+			wantDissociated: []string{"fork-a", "fork-b"},
+			wantForkIDs:     []int64{0, 0, 3},
+		},
+		{
+			name:        "no-forks",
+			repo:        &types.Repository{ID: 1, GitUID: "source"},
+			wantForkIDs: []int64{1, 1, 3},
+		},
+		{
+			name:        "importing",
+			repo:        &types.Repository{ID: 1, GitUID: "source", NumForks: 2, Importing: true},
+			wantForkIDs: []int64{1, 1, 3},
+		},
+		{
+			name:            "dissociate-failure",
+			repo:            &types.Repository{ID: 1, GitUID: "source", NumForks: 2},
+			failUID:         "fork-b",
+			wantErr:         errTestDissociate,
+			wantDissociated: []string{"fork-a"},
+			wantForkIDs:     []int64{0, 1, 3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			forks := []*types.Repository{
+				{ID: 2, GitUID: "fork-a", ForkID: 1},
+				{ID: 4, GitUID: "fork-b", ForkID: 1},
+				{ID: 5, GitUID: "other-fork", ForkID: 3},
+			}
+			gitMock := &gitMock{failUID: test.failUID}
+			c := &Controller{
+				urlProvider: urlProviderMock{},
+				repoStore:   &repoStoreMock{repos: forks},
+				git:         gitMock,
+			}
+			session := &auth.Session{Principal: types.Principal{ID: 42}}
+
+			err := c.dissociateForks(context.Background(), session, test.repo)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("want error %v, got %v", test.wantErr, err)
+			}
+
+			if !reflect.DeepEqual(gitMock.dissociated, test.wantDissociated) {
+				t.Errorf("dissociated: want=%v got=%v", test.wantDissociated, gitMock.dissociated)
+			}
+
+			gotForkIDs := make([]int64, len(forks))
+			for i, fork := range forks {
+				gotForkIDs[i] = fork.ForkID
+			}
+			if !reflect.DeepEqual(gotForkIDs, test.wantForkIDs) {
+				t.Errorf("fork ids: want=%v got=%v", test.wantForkIDs, gotForkIDs)
+			}
+		})
+	}
+}
+
+func TestController_DecrementNumForks(t *testing.T) {
+	tests := []struct {
+		name         string
+		numForks     int
+		sourceRepoID int64
+		wantNumForks int
+	}{
+		{
+			name:         "decrement",
+			numForks:     2,
+			sourceRepoID: 1,
+			wantNumForks: 1,
+		},
+		{
+			name:         "no-negative-count",
+			sourceRepoID: 1,
+		},
+		{
+			name:         "source-deleted",
+			numForks:     2,
+			sourceRepoID: 9,
+			wantNumForks: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := &types.Repository{ID: 1, NumForks: test.numForks}
+			c := &Controller{repoStore: &repoStoreMock{repos: []*types.Repository{source}}}
+
+			c.decrementNumForks(context.Background(), test.sourceRepoID)
+
+			if source.NumForks != test.wantNumForks {
+				t.Errorf("want=%d got=%d", test.wantNumForks, source.NumForks)
+			}
+		})
+	}
+}
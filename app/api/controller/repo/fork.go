@@ -0,0 +1,163 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type ForkInput struct {
+	ParentRef   string `json:"parent_ref"`
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+	IsPublic    bool   `json:"is_public"`
+}
+
+// Fork creates a new repository as a fork of an existing repository.
+// The fork shares the git objects with the source repository, and pull requests can be created
+// from the fork into the source repository without having push access to the source repository.
+func (c *Controller) Fork(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *ForkInput,
+) (*types.Repository, error) {
+	sourceRepo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	parentSpace, err := c.getSpaceCheckAuthRepoCreation(ctx, session, in.ParentRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.sanitizeForkInput(in, sourceRepo); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	// generate envars (add everything githook CLI needs for execution)
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		c.urlProvider.GetInternalAPIURL(),
+		0,
+		session.Principal.ID,
+		true,
+		true,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	gitResp, err := c.git.ForkRepository(ctx, &git.ForkRepositoryParams{
+		Actor:         *identityFromPrincipal(session.Principal),
+		EnvVars:       envVars,
+		SourceRepoUID: sourceRepo.GitUID,
+		DefaultBranch: sourceRepo.DefaultBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error forking repository on git: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	repo := &types.Repository{
+		Version:       0,
+		ParentID:      parentSpace.ID,
+		UID:           in.UID,
+		GitUID:        gitResp.UID,
+		Description:   in.Description,
+		IsPublic:      in.IsPublic,
+		CreatedBy:     session.Principal.ID,
+		Created:       now,
+		Updated:       now,
+		ForkID:        sourceRepo.ID,
+		DefaultBranch: sourceRepo.DefaultBranch,
+	}
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		err = c.repoStore.Create(ctx, repo)
+		if err != nil {
+			return fmt.Errorf("failed to create repository in storage: %w", err)
+		}
+
+		_, err = c.repoStore.UpdateOptLock(ctx, sourceRepo, func(r *types.Repository) error {
+			r.NumForks++
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update number of forks of source repository: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		if dErr := c.deleteGitRepository(ctx, session, repo); dErr != nil {
+			log.Ctx(ctx).Warn().Err(dErr).Msg("failed to delete forked repo for cleanup")
+		}
+		return nil, err
+	}
+
+	// backfil GitURL
+	repo.GitURL = c.urlProvider.GenerateGITCloneURL(repo.Path)
+
+	err = c.indexer.Index(ctx, repo)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index forked repo")
+	}
+
+	return repo, nil
+}
+
+func (c *Controller) sanitizeForkInput(in *ForkInput, sourceRepo *types.Repository) error {
+	if in.IsPublic && !c.publicResourceCreationEnabled {
+		return errPublicRepoCreationDisabled
+	}
+
+	if err := c.validateParentRef(in.ParentRef); err != nil {
+		return err
+	}
+
+	// by default the fork has the same name as the source repository.
+	if in.UID == "" {
+		in.UID = sourceRepo.UID
+	}
+
+	if err := c.uidCheck(in.UID, false); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	if in.Description == "" {
+		in.Description = sourceRepo.Description
+	}
+
+	if err := check.Description(in.Description); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -50,5 +50,11 @@ func (c *Controller) GetCommit(ctx context.Context,
 		return nil, fmt.Errorf("failed to map commit: %w", err)
 	}
 
+	commit.Verification, err = c.signing.NewVerifier().Verify(ctx,
+		rpcCommit.SignedData, rpcCommit.Committer.Identity.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify commit signature: %w", err)
+	}
+
 	return commit, nil
 }
@@ -25,13 +25,15 @@ import (
 	"github.com/harness/gitness/types/enum"
 )
 
-// GitServicePack executes the service pack part of git's smart http protocol (receive-/upload-pack).
+// GitServicePack executes the service pack part of git's smart protocol (receive-/upload-pack).
+// statelessRPC has to be set for transports that don't keep a bidirectional connection (smart http).
 func (c *Controller) GitServicePack(
 	ctx context.Context,
 	session *auth.Session,
 	repoRef string,
 	service enum.GitServiceType,
 	gitProtocol string,
+	statelessRPC bool,
 	r io.Reader,
 	w io.Writer,
 ) error {
@@ -50,10 +52,11 @@ func (c *Controller) GitServicePack(
 
 	params := &git.ServicePackParams{
 		// TODO: git shouldn't take a random string here, but instead have accepted enum values.
-		Service:     string(service),
-		Data:        r,
-		Options:     nil,
-		GitProtocol: gitProtocol,
+		Service:      string(service),
+		Data:         r,
+		Options:      nil,
+		GitProtocol:  gitProtocol,
+		StatelessRPC: statelessRPC,
 	}
 
 	// setup read/writeparams depending on whether it's a write operation
@@ -32,6 +32,7 @@ type ImportInput struct {
 	ProviderRepo string            `json:"provider_repo"`
 
 	Pipelines importer.PipelineOption `json:"pipelines"`
+	PullReqs  importer.PullReqOption  `json:"pull_requests"`
 }
 
 // Import creates a new empty repository and starts git import to it from a remote repository.
@@ -66,7 +67,8 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 			return fmt.Errorf("failed to create repository in storage: %w", err)
 		}
 
-		err = c.importer.Run(ctx, provider, repo, remoteRepository.CloneURL, in.Pipelines)
+		err = c.importer.Run(ctx, provider, repo, remoteRepository.CloneURL, in.ProviderRepo,
+			in.Pipelines, in.PullReqs)
 		if err != nil {
 			return fmt.Errorf("failed to start import repository job: %w", err)
 		}
@@ -95,5 +97,9 @@ func (c *Controller) sanitizeImportInput(in *ImportInput) error {
 		in.Pipelines = importer.PipelineOptionConvert
 	}
 
+	if in.PullReqs == "" {
+		in.PullReqs = importer.PullReqOptionIgnore
+	}
+
 	return nil
 }
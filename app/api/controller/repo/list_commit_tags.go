@@ -20,6 +20,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/signing"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
@@ -33,6 +34,8 @@ type CommitTag struct {
 	Message     string           `json:"message,omitempty"`
 	Tagger      *types.Signature `json:"tagger,omitempty"`
 	Commit      *types.Commit    `json:"commit,omitempty"`
+	// Verification is the result of the verification of the tag signature, set for annotated tags only.
+	Verification *types.SignatureVerification `json:"verification,omitempty"`
 }
 
 // ListCommitTags lists the commit tags of a repo.
@@ -60,12 +63,19 @@ func (c *Controller) ListCommitTags(ctx context.Context,
 		return nil, err
 	}
 
+	verifier := c.signing.NewVerifier()
+
 	tags := make([]CommitTag, len(rpcOut.Tags))
 	for i := range rpcOut.Tags {
 		tags[i], err = mapCommitTag(rpcOut.Tags[i])
 		if err != nil {
 			return nil, fmt.Errorf("failed to map CommitTag: %w", err)
 		}
+
+		err = verifyCommitTag(ctx, verifier, rpcOut.Tags[i], &tags[i])
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return tags, nil
@@ -85,6 +95,32 @@ func mapToRPCTagSortOption(o enum.TagSortOption) git.TagSortOption {
 	}
 }
 
+// verifyCommitTag sets the signature verification of the annotated tag and of the tag's commit (if included).
+func verifyCommitTag(ctx context.Context, verifier *signing.Verifier, t git.CommitTag, tag *CommitTag) error {
+	var err error
+
+	if t.IsAnnotated {
+		var email string
+		if t.Tagger != nil {
+			email = t.Tagger.Identity.Email
+		}
+
+		tag.Verification, err = verifier.Verify(ctx, t.SignedData, email)
+		if err != nil {
+			return fmt.Errorf("failed to verify tag signature: %w", err)
+		}
+	}
+
+	if t.Commit != nil && tag.Commit != nil {
+		tag.Commit.Verification, err = verifier.Verify(ctx, t.Commit.SignedData, t.Commit.Committer.Identity.Email)
+		if err != nil {
+			return fmt.Errorf("failed to verify commit signature: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func mapCommitTag(t git.CommitTag) (CommitTag, error) {
 	var commit *types.Commit
 	if t.Commit != nil {
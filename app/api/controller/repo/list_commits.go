@@ -57,6 +57,8 @@ func (c *Controller) ListCommits(ctx context.Context,
 		return types.ListCommitResponse{}, err
 	}
 
+	verifier := c.signing.NewVerifier()
+
 	commits := make([]types.Commit, len(rpcOut.Commits))
 	for i := range rpcOut.Commits {
 		var commit *types.Commit
@@ -64,6 +66,13 @@ func (c *Controller) ListCommits(ctx context.Context,
 		if err != nil {
 			return types.ListCommitResponse{}, fmt.Errorf("failed to map commit: %w", err)
 		}
+
+		commit.Verification, err = verifier.Verify(ctx,
+			rpcOut.Commits[i].SignedData, rpcOut.Commits[i].Committer.Identity.Email)
+		if err != nil {
+			return types.ListCommitResponse{}, fmt.Errorf("failed to verify commit signature: %w", err)
+		}
+
 		commits[i] = *commit
 	}
 
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mirror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MirrorSetInput is used to configure a repository as pull mirror of an upstream repository.
+type MirrorSetInput struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	// Password is the password or access token used to fetch from the upstream repository.
+	// If not provided, the password of an existing configuration is kept.
+	Password *string `json:"password"`
+	// Interval is the time between two syncs in seconds (the server default if not provided).
+	Interval int64 `json:"interval"`
+}
+
+func (in *MirrorSetInput) sanitize(minInterval time.Duration) error {
+	in.URL = strings.TrimSpace(in.URL)
+	in.Username = strings.TrimSpace(in.Username)
+
+	if err := validateMirrorURL(in.URL); err != nil {
+		return err
+	}
+
+	if in.Interval < 0 || (in.Interval > 0 && time.Duration(in.Interval)*time.Second < minInterval) {
+		return usererror.BadRequestf("The sync interval has to be at least %d seconds.",
+			int64(minInterval/time.Second))
+	}
+
+	return nil
+}
+
+// validateMirrorURL checks the url of a remote repository used by a pull or push mirror.
+func validateMirrorURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return usererror.BadRequest("A valid url of the remote repository must be provided.")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return usererror.BadRequest("Only http and https urls are supported for mirrors.")
+	}
+	if u.User != nil {
+		return usererror.BadRequest("Credentials have to be provided as username and password, not as part of the url.")
+	}
+
+	return nil
+}
+
+// MirrorSet configures the repository as pull mirror of an upstream repository and starts a sync right away.
+// All branches and tags of the repository are replaced with the ones of the upstream repository.
+func (c *Controller) MirrorSet(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *MirrorSetInput,
+) (*types.PullMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(c.mirrorMinInterval); err != nil {
+		return nil, err
+	}
+
+	var password []byte
+	if in.Password != nil && *in.Password != "" {
+		password, err = c.encrypter.Encrypt(*in.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt mirror password: %w", err)
+		}
+	}
+
+	pullMirror, err := c.pullMirrorStore.Find(ctx, repo.ID)
+	if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find pull mirror: %w", err)
+	}
+
+	if pullMirror == nil {
+		now := time.Now().UnixMilli()
+		pullMirror = &types.PullMirror{
+			RepoID:    repo.ID,
+			URL:       in.URL,
+			Username:  in.Username,
+			Password:  password,
+			Interval:  in.Interval,
+			State:     enum.MirrorSyncStatePending,
+			NextSync:  now,
+			CreatedBy: session.Principal.ID,
+			Created:   now,
+			Updated:   now,
+		}
+
+		err = c.pullMirrorStore.Create(ctx, pullMirror)
+		if errors.Is(err, gitness_store.ErrDuplicate) {
+			return nil, usererror.Conflict("The repository got configured as mirror in the meantime.")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create pull mirror: %w", err)
+		}
+	} else {
+		pullMirror, err = c.pullMirrorStore.UpdateOptLock(ctx, pullMirror, func(pullMirror *types.PullMirror) error {
+			pullMirror.URL = in.URL
+			pullMirror.Username = in.Username
+			if in.Password != nil {
+				pullMirror.Password = password
+			}
+			pullMirror.Interval = in.Interval
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to update pull mirror: %w", err)
+		}
+	}
+
+	synced, err := c.mirror.SyncNow(ctx, pullMirror)
+	if errors.Is(err, mirror.ErrSyncInProgress) {
+		// the new configuration will be used by the next sync.
+		return pullMirror, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to start sync of pull mirror: %w", err)
+	}
+
+	return synced, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/mirror"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// maxPushMirrorBranchFilters is the maximum number of patterns of the branch filter of a push mirror.
+const maxPushMirrorBranchFilters = 50
+
+// PushMirrorCreateInput is used to add a push mirror to a repository.
+type PushMirrorCreateInput struct {
+	URL      string `json:"url"`
+	Username string `json:"username"`
+	// Password is the password or access token used to push to the remote repository.
+	Password string `json:"password"`
+	// BranchFilter restricts the pushed branches (all branches are pushed if empty).
+	BranchFilter []string `json:"branch_filter"`
+	// Enabled is true by default.
+	Enabled *bool `json:"enabled"`
+}
+
+func (in *PushMirrorCreateInput) sanitize() error {
+	in.URL = strings.TrimSpace(in.URL)
+	in.Username = strings.TrimSpace(in.Username)
+
+	if err := validateMirrorURL(in.URL); err != nil {
+		return err
+	}
+
+	var err error
+	in.BranchFilter, err = sanitizeBranchFilter(in.BranchFilter)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// PushMirrorCreate adds a push mirror to the repository and pushes the repository to it right away.
+// All branches (matching the branch filter) and tags of the remote repository are replaced
+// with the ones of the repository.
+func (c *Controller) PushMirrorCreate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *PushMirrorCreateInput,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	var password []byte
+	if in.Password != "" {
+		password, err = c.encrypter.Encrypt(in.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt mirror password: %w", err)
+		}
+	}
+
+	enabled := true
+	if in.Enabled != nil {
+		enabled = *in.Enabled
+	}
+
+	now := time.Now().UnixMilli()
+	pushMirror := &types.PushMirror{
+		RepoID:       repo.ID,
+		URL:          in.URL,
+		Username:     in.Username,
+		Password:     password,
+		Enabled:      enabled,
+		BranchFilter: in.BranchFilter,
+		State:        enum.MirrorSyncStatePending,
+		CreatedBy:    session.Principal.ID,
+		Created:      now,
+		Updated:      now,
+	}
+
+	err = c.pushMirrorStore.Create(ctx, pushMirror)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("A push mirror with the same url already exists.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create push mirror: %w", err)
+	}
+
+	if pushMirror.Enabled {
+		if err = c.mirror.PushNow(ctx, pushMirror); err != nil {
+			return nil, fmt.Errorf("failed to start push to push mirror: %w", err)
+		}
+	}
+
+	return pushMirror, nil
+}
+
+func sanitizeBranchFilter(branchFilter []string) ([]string, error) {
+	if len(branchFilter) > maxPushMirrorBranchFilters {
+		return nil, usererror.BadRequestf("The branch filter can contain at most %d patterns.",
+			maxPushMirrorBranchFilters)
+	}
+
+	res := make([]string, 0, len(branchFilter))
+	for _, pattern := range branchFilter {
+		pattern = strings.TrimSpace(pattern)
+		if err := mirror.ValidateBranchPattern(pattern); err != nil {
+			return nil, usererror.BadRequest(err.Error())
+		}
+		res = append(res, pattern)
+	}
+
+	return res, nil
+}
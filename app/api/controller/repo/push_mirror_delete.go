@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PushMirrorDelete deletes a push mirror of the repository. The remote repository is kept as is.
+func (c *Controller) PushMirrorDelete(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pushMirrorID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return err
+	}
+
+	if _, err = c.getPushMirror(ctx, repo.ID, pushMirrorID); err != nil {
+		return err
+	}
+
+	if err = c.pushMirrorStore.Delete(ctx, pushMirrorID); err != nil {
+		return fmt.Errorf("failed to delete push mirror: %w", err)
+	}
+
+	return nil
+}
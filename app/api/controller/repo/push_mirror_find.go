@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PushMirrorFind returns a push mirror of the repository including its push status.
+func (c *Controller) PushMirrorFind(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pushMirrorID int64,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.getPushMirror(ctx, repo.ID, pushMirrorID)
+}
+
+// getPushMirror returns the push mirror if it belongs to the repository.
+func (c *Controller) getPushMirror(ctx context.Context, repoID int64, pushMirrorID int64) (*types.PushMirror, error) {
+	pushMirror, err := c.pushMirrorStore.Find(ctx, pushMirrorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	if pushMirror.RepoID != repoID {
+		return nil, usererror.ErrNotFound
+	}
+
+	return pushMirror, nil
+}
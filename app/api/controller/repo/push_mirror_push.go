@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PushMirrorPush starts a push of the repository to the push mirror right away.
+func (c *Controller) PushMirrorPush(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pushMirrorID int64,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pushMirror, err := c.getPushMirror(ctx, repo.ID, pushMirrorID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !pushMirror.Enabled {
+		return nil, usererror.BadRequest("The push mirror is disabled.")
+	}
+
+	if err = c.mirror.PushNow(ctx, pushMirror); err != nil {
+		return nil, fmt.Errorf("failed to start push to push mirror: %w", err)
+	}
+
+	return pushMirror, nil
+}
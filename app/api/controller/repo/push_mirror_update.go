@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// PushMirrorUpdateInput is used to update a push mirror of a repository.
+type PushMirrorUpdateInput struct {
+	URL      *string `json:"url"`
+	Username *string `json:"username"`
+	// Password is the password or access token used to push to the remote repository.
+	// An empty password removes the stored one.
+	Password     *string   `json:"password"`
+	BranchFilter *[]string `json:"branch_filter"`
+	Enabled      *bool     `json:"enabled"`
+}
+
+func (in *PushMirrorUpdateInput) sanitize() error {
+	if in.URL != nil {
+		*in.URL = strings.TrimSpace(*in.URL)
+		if err := validateMirrorURL(*in.URL); err != nil {
+			return err
+		}
+	}
+
+	if in.Username != nil {
+		*in.Username = strings.TrimSpace(*in.Username)
+	}
+
+	if in.BranchFilter != nil {
+		branchFilter, err := sanitizeBranchFilter(*in.BranchFilter)
+		if err != nil {
+			return err
+		}
+		in.BranchFilter = &branchFilter
+	}
+
+	return nil
+}
+
+// PushMirrorUpdate updates a push mirror of the repository.
+func (c *Controller) PushMirrorUpdate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pushMirrorID int64,
+	in *PushMirrorUpdateInput,
+) (*types.PushMirror, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	pushMirror, err := c.getPushMirror(ctx, repo.ID, pushMirrorID)
+	if err != nil {
+		return nil, err
+	}
+
+	var password []byte
+	if in.Password != nil && *in.Password != "" {
+		password, err = c.encrypter.Encrypt(*in.Password)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt mirror password: %w", err)
+		}
+	}
+
+	pushMirror, err = c.pushMirrorStore.UpdateOptLock(ctx, pushMirror, func(pushMirror *types.PushMirror) error {
+		if in.URL != nil {
+			pushMirror.URL = *in.URL
+		}
+		if in.Username != nil {
+			pushMirror.Username = *in.Username
+		}
+		if in.Password != nil {
+			pushMirror.Password = password
+		}
+		if in.BranchFilter != nil {
+			pushMirror.BranchFilter = *in.BranchFilter
+		}
+		if in.Enabled != nil {
+			pushMirror.Enabled = *in.Enabled
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update push mirror: %w", err)
+	}
+
+	return pushMirror, nil
+}
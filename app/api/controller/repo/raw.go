@@ -15,18 +15,22 @@
 package repo
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/lfs"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types/enum"
 )
 
 // Raw finds the file of the repo at the given path and returns its raw content.
 // If no gitRef is provided, the content is retrieved from the default branch.
+// In case the file is a Git LFS pointer to an uploaded object, the content of the object is returned.
+// If the returned reader implements io.Closer, it has to be closed by the caller.
 func (c *Controller) Raw(ctx context.Context,
 	session *auth.Session,
 	repoRef string,
@@ -71,5 +75,27 @@ func (c *Controller) Raw(ctx context.Context,
 		return nil, 0, fmt.Errorf("failed to read blob: %w", err)
 	}
 
-	return blobReader.Content, blobReader.ContentSize, nil
+	if blobReader.ContentSize > lfs.MaxPointerSize {
+		return blobReader.Content, blobReader.ContentSize, nil
+	}
+
+	content, err := io.ReadAll(blobReader.Content)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	obj, ok, err := c.lfs.Resolve(ctx, repo.ID, content)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to resolve LFS pointer: %w", err)
+	}
+	if !ok {
+		return bytes.NewReader(content), int64(len(content)), nil
+	}
+
+	_, objReader, err := c.lfs.Download(ctx, repo.ID, obj.OID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download LFS object: %w", err)
+	}
+
+	return objReader, obj.Size, nil
 }
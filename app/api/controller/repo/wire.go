@@ -20,9 +20,14 @@ import (
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/lfs"
+	"github.com/harness/gitness/app/services/mirror"
 	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/signing"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -53,10 +58,18 @@ func ProvideController(
 	codeOwners *codeowners.Service,
 	reporeporter *repoevents.Reporter,
 	indexer keywordsearch.Indexer,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	encrypter encrypt.Encrypter,
+	mirror *mirror.Service,
+	blobStore blob.Store,
+	lfs *lfs.Service,
+	signing *signing.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		uidCheck, authorizer, repoStore,
 		spaceStore, pipelineStore,
 		principalStore, ruleStore, protectionManager,
-		rpcClient, importer, codeOwners, reporeporter, indexer)
+		rpcClient, importer, codeOwners, reporeporter, indexer,
+		pullMirrorStore, pushMirrorStore, encrypter, mirror, blobStore, lfs, signing)
 }
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/pipeline/rpc"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateInput struct {
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+}
+
+// CreateOutput contains the created runner and its registration token.
+// The token is only returned once and can't be retrieved later on.
+type CreateOutput struct {
+	*types.Runner
+	Token string `json:"token"`
+}
+
+// Create registers a new runner and generates its registration token.
+func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*CreateOutput, error) {
+	if err := c.sanitizeCreateInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	if err := apiauth.CheckRunner(ctx, c.authorizer, session, in.UID, enum.PermissionRunnerCreate); err != nil {
+		return nil, err
+	}
+
+	token, err := rpc.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate runner token: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	runner := &types.Runner{
+		UID:         in.UID,
+		Description: in.Description,
+		TokenHash:   rpc.HashToken(token),
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.runnerStore.Create(ctx, runner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create runner: %w", err)
+	}
+
+	return &CreateOutput{
+		Runner: runner,
+		Token:  token,
+	}, nil
+}
+
+func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
+	if err := c.uidCheck(in.UID, false); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	return check.Description(in.Description)
+}
@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes a runner, which revokes its registration token.
+func (c *Controller) Delete(ctx context.Context, session *auth.Session, uid string) error {
+	if err := apiauth.CheckRunner(ctx, c.authorizer, session, uid, enum.PermissionRunnerDelete); err != nil {
+		return err
+	}
+
+	runner, err := c.runnerStore.FindByUID(ctx, uid)
+	if err != nil {
+		return fmt.Errorf("failed to find runner: %w", err)
+	}
+
+	err = c.runnerStore.Delete(ctx, runner.ID)
+	if err != nil {
+		return fmt.Errorf("failed to delete runner: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists all registered runners.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.ListQueryFilter,
+) ([]*types.Runner, int64, error) {
+	if err := apiauth.CheckRunner(ctx, c.authorizer, session, "", enum.PermissionRunnerView); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.runnerStore.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count runners: %w", err)
+	}
+
+	runners, err := c.runnerStore.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list runners: %w", err)
+	}
+
+	return runners, count, nil
+}
@@ -29,6 +29,10 @@ import (
 type CreateTokenInput struct {
 	UID      string         `json:"uid"`
 	Lifetime *time.Duration `json:"lifetime"`
+	// Permissions optionally restricts the token to the provided permissions.
+	Permissions []enum.Permission `json:"permissions"`
+	// Paths optionally restricts the token to the provided spaces and repositories.
+	Paths []string `json:"paths"`
 }
 
 // CreateToken creates a new service account access token.
@@ -49,6 +53,12 @@ func (c *Controller) CreateToken(
 	if err = check.TokenLifetime(in.Lifetime, true); err != nil {
 		return nil, err
 	}
+	if in.Permissions, in.Paths, err = check.TokenRestrictions(in.Permissions, in.Paths); err != nil {
+		return nil, err
+	}
+	if err = apiauth.CheckTokenRestrictions(session, in.Permissions, in.Paths); err != nil {
+		return nil, err
+	}
 
 	// Ensure principal has required permissions on parent (ensures that parent exists)
 	if err = apiauth.CheckServiceAccount(ctx, c.authorizer, session, c.spaceStore, c.repoStore,
@@ -62,6 +72,8 @@ func (c *Controller) CreateToken(
 		sa,
 		in.UID,
 		in.Lifetime,
+		in.Permissions,
+		in.Paths,
 	)
 	if err != nil {
 		return nil, err
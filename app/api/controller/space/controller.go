@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -55,6 +56,10 @@ type Controller struct {
 	membershipStore store.MembershipStore
 	importer        *importer.Repository
 	exporter        *exporter.Repository
+
+	userGroupStore           store.UserGroupStore
+	membershipUserGroupStore store.MembershipUserGroupStore
+	userGroupResolver        usergroup.Resolver
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -63,6 +68,8 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	connectorStore store.ConnectorStore, templateStore store.TemplateStore, spaceStore store.SpaceStore,
 	repoStore store.RepoStore, principalStore store.PrincipalStore, repoCtrl *repo.Controller,
 	membershipStore store.MembershipStore, importer *importer.Repository, exporter *exporter.Repository,
+	userGroupStore store.UserGroupStore, membershipUserGroupStore store.MembershipUserGroupStore,
+	userGroupResolver usergroup.Resolver,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled:           config.NestedSpacesEnabled,
@@ -84,5 +91,8 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		membershipStore:               membershipStore,
 		importer:                      importer,
 		exporter:                      exporter,
+		userGroupStore:                userGroupStore,
+		membershipUserGroupStore:      membershipUserGroupStore,
+		userGroupResolver:             userGroupResolver,
 	}
 }
@@ -28,6 +28,7 @@ type ProviderInput struct {
 	Provider      importer.Provider       `json:"provider"`
 	ProviderSpace string                  `json:"provider_space"`
 	Pipelines     importer.PipelineOption `json:"pipelines"`
+	PullReqs      importer.PullReqOption  `json:"pull_requests"`
 }
 
 type ImportInput struct {
@@ -63,6 +64,7 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 
 	repoIDs := make([]int64, len(remoteRepositories))
 	cloneURLs := make([]string, len(remoteRepositories))
+	repoSlugs := make([]string, len(remoteRepositories))
 
 	var space *types.Space
 	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
@@ -87,10 +89,12 @@ func (c *Controller) Import(ctx context.Context, session *auth.Session, in *Impo
 
 			repoIDs[i] = repo.ID
 			cloneURLs[i] = remoteRepository.CloneURL
+			repoSlugs[i] = remoteRepository.Slug()
 		}
 
 		jobGroupID := fmt.Sprintf("space-import-%d", space.ID)
-		err = c.importer.RunMany(ctx, jobGroupID, provider, repoIDs, cloneURLs, in.Pipelines)
+		err = c.importer.RunMany(ctx, jobGroupID, provider, repoIDs, cloneURLs, repoSlugs,
+			in.Pipelines, in.PullReqs)
 		if err != nil {
 			return fmt.Errorf("failed to start import repository jobs: %w", err)
 		}
@@ -113,5 +117,9 @@ func (c *Controller) sanitizeImportInput(in *ImportInput) error {
 		in.Pipelines = importer.PipelineOptionConvert
 	}
 
+	if in.PullReqs == "" {
+		in.PullReqs = importer.PullReqOptionIgnore
+	}
+
 	return nil
 }
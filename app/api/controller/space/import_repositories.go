@@ -91,6 +91,7 @@ func (c *Controller) ImportRepositories(
 
 	repoIDs := make([]int64, 0, len(remoteRepositories))
 	cloneURLs := make([]string, 0, len(remoteRepositories))
+	repoSlugs := make([]string, 0, len(remoteRepositories))
 	repos := make([]*types.Repository, 0, len(remoteRepositories))
 	duplicateRepos := make([]*types.Repository, 0, len(remoteRepositories))
 
@@ -115,13 +116,15 @@ func (c *Controller) ImportRepositories(
 			repos = append(repos, repo)
 			repoIDs = append(repoIDs, repo.ID)
 			cloneURLs = append(cloneURLs, remoteRepository.CloneURL)
+			repoSlugs = append(repoSlugs, remoteRepository.Slug())
 		}
 		if len(repoIDs) == 0 {
 			return nil
 		}
 
 		jobGroupID := fmt.Sprintf("space-import-%d", space.ID)
-		err = c.importer.RunMany(ctx, jobGroupID, provider, repoIDs, cloneURLs, in.Pipelines)
+		err = c.importer.RunMany(ctx, jobGroupID, provider, repoIDs, cloneURLs, repoSlugs,
+			in.Pipelines, in.PullReqs)
 		if err != nil {
 			return fmt.Errorf("failed to start import repository jobs: %w", err)
 		}
@@ -17,6 +17,7 @@ package space
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
@@ -26,11 +27,13 @@ import (
 )
 
 // ListSecrets lists the secrets in a space.
+// In case inherited is true, the secrets of all ancestor spaces are included as well (the nearest space wins).
 func (c *Controller) ListSecrets(
 	ctx context.Context,
 	session *auth.Session,
 	spaceRef string,
 	filter types.ListQueryFilter,
+	inherited bool,
 ) ([]*types.Secret, int64, error) {
 	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
 	if err != nil {
@@ -42,6 +45,10 @@ func (c *Controller) ListSecrets(
 		return nil, 0, fmt.Errorf("could not authorize: %w", err)
 	}
 
+	if inherited {
+		return c.listInheritedSecrets(ctx, space.ID, filter)
+	}
+
 	var count int64
 	var secrets []*types.Secret
 
@@ -63,3 +70,39 @@ func (c *Controller) ListSecrets(
 
 	return secrets, count, nil
 }
+
+// listInheritedSecrets lists the secrets visible in the space including the ones of its ancestors.
+// Filtering and pagination is done in memory as the number of secrets is expected to be small.
+func (c *Controller) listInheritedSecrets(
+	ctx context.Context,
+	spaceID int64,
+	filter types.ListQueryFilter,
+) ([]*types.Secret, int64, error) {
+	all, err := c.secretStore.ListAllInherited(ctx, spaceID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list inherited secrets: %w", err)
+	}
+
+	query := strings.ToLower(filter.Query)
+	secrets := make([]*types.Secret, 0, len(all))
+	for _, secret := range all {
+		if query != "" && !strings.Contains(strings.ToLower(secret.UID), query) {
+			continue
+		}
+		secrets = append(secrets, secret)
+	}
+
+	count := int64(len(secrets))
+
+	// page and size are sanitized by the request parsing (page >= 1, size > 0).
+	start := (filter.Page - 1) * filter.Size
+	if start < 0 || start >= len(secrets) {
+		return []*types.Secret{}, count, nil
+	}
+	end := start + filter.Size
+	if end > len(secrets) {
+		end = len(secrets)
+	}
+
+	return secrets[start:end], count, nil
+}
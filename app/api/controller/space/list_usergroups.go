@@ -0,0 +1,65 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListUserGroups lists the user groups defined in a space.
+func (c *Controller) ListUserGroups(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	filter types.ListQueryFilter,
+) ([]*types.UserGroup, int64, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find parent space: %w", err)
+	}
+
+	err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceView, false)
+	if err != nil {
+		return nil, 0, fmt.Errorf("could not authorize: %w", err)
+	}
+
+	var count int64
+	var userGroups []*types.UserGroup
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		count, err = c.userGroupStore.Count(ctx, space.ID, &filter)
+		if err != nil {
+			return fmt.Errorf("failed to count user groups: %w", err)
+		}
+
+		userGroups, err = c.userGroupStore.List(ctx, space.ID, &filter)
+		if err != nil {
+			return fmt.Errorf("failed to list user groups: %w", err)
+		}
+		return
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return userGroups, count, fmt.Errorf("failed to list user groups: %w", err)
+	}
+
+	return userGroups, count, nil
+}
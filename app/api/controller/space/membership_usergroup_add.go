@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type MembershipUserGroupAddInput struct {
+	UserGroupUID string              `json:"usergroup_uid"`
+	Role         enum.MembershipRole `json:"role"`
+}
+
+func (in *MembershipUserGroupAddInput) Validate() error {
+	if in.UserGroupUID == "" {
+		return usererror.BadRequest("UserGroupUID must be provided")
+	}
+
+	if in.Role == "" {
+		return usererror.BadRequest("Role must be provided")
+	}
+
+	role, ok := in.Role.Sanitize()
+	if !ok {
+		msg := fmt.Sprintf("Provided role '%s' is not suppored. Valid values are: %v",
+			in.Role, enum.MembershipRoles)
+		return usererror.BadRequest(msg)
+	}
+
+	in.Role = role
+
+	return nil
+}
+
+// MembershipUserGroupAdd grants all members of a user group a role in the space.
+// The user group has to be defined in the space itself or in one of its ancestors.
+func (c *Controller) MembershipUserGroupAdd(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *MembershipUserGroupAddInput,
+) (*types.MembershipUserGroup, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	userGroup, err := c.resolveUserGroup(ctx, space, in.UserGroupUID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	membership := &types.MembershipUserGroup{
+		MembershipUserGroupKey: types.MembershipUserGroupKey{
+			SpaceID:     space.ID,
+			UserGroupID: userGroup.ID,
+		},
+		CreatedBy: session.Principal.ID,
+		Created:   now,
+		Updated:   now,
+		Role:      in.Role,
+		UserGroup: *userGroup,
+		AddedBy:   *session.Principal.ToPrincipalInfo(),
+	}
+
+	err = c.membershipUserGroupStore.Create(ctx, membership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new user group membership: %w", err)
+	}
+
+	return membership, nil
+}
+
+// resolveUserGroup resolves the user group with the provided uid as seen from the space.
+func (c *Controller) resolveUserGroup(
+	ctx context.Context,
+	space *types.Space,
+	userGroupUID string,
+) (*types.UserGroup, error) {
+	userGroup, err := c.userGroupResolver.Resolve(ctx, paths.Concatinate(space.Path, userGroupUID))
+	if errors.Is(err, usergroup.ErrNotFound) {
+		return nil, usererror.BadRequestf("User group '%s' not found", userGroupUID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve user group: %w", err)
+	}
+
+	return userGroup, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MembershipUserGroupDelete removes a user group membership from a space.
+func (c *Controller) MembershipUserGroupDelete(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userGroupUID string,
+) error {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return err
+	}
+
+	userGroup, err := c.resolveUserGroup(ctx, space, userGroupUID)
+	if err != nil {
+		return err
+	}
+
+	err = c.membershipUserGroupStore.Delete(ctx, types.MembershipUserGroupKey{
+		SpaceID:     space.ID,
+		UserGroupID: userGroup.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete user group membership: %w", err)
+	}
+
+	return nil
+}
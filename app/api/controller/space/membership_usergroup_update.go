@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// MembershipUserGroupUpdate changes the role of an existing user group membership.
+func (c *Controller) MembershipUserGroupUpdate(ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	userGroupUID string,
+	in *MembershipUpdateInput,
+) (*types.MembershipUserGroup, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, enum.PermissionSpaceEdit, false); err != nil {
+		return nil, err
+	}
+
+	err = in.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	userGroup, err := c.resolveUserGroup(ctx, space, userGroupUID)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := c.membershipUserGroupStore.Find(ctx, types.MembershipUserGroupKey{
+		SpaceID:     space.ID,
+		UserGroupID: userGroup.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group membership for update: %w", err)
+	}
+
+	if membership.Role == in.Role {
+		return membership, nil
+	}
+
+	membership.Role = in.Role
+	membership.Updated = time.Now().UnixMilli()
+
+	err = c.membershipUserGroupStore.Update(ctx, membership)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user group membership: %w", err)
+	}
+
+	return membership, nil
+}
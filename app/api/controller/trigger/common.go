@@ -15,6 +15,11 @@
 package trigger
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 	"github.com/harness/gitness/types/enum"
 )
@@ -36,8 +41,58 @@ func checkSecret(secret string) error {
 	return nil
 }
 
+// checkType validates the trigger type.
+func checkType(triggerType string) error {
+	if triggerType != enum.TriggerHook && triggerType != enum.TriggerCron {
+		return check.NewValidationErrorf("The provided trigger type '%s' is invalid.", triggerType)
+	}
+
+	return nil
+}
+
+func checkCron(triggerType string, cron string, timezone string) error {
+	if triggerType != enum.TriggerCron {
+		if cron != "" || timezone != "" {
+			return check.NewValidationError("Cron and timezone can only be provided for cron triggers.")
+		}
+		return nil
+	}
+
+	if cron == "" {
+		return check.NewValidationError("Cron triggers require a cron expression.")
+	}
+
+	if _, err := triggerer.NextCronRun(cron, timezone, time.Now()); err != nil {
+		return check.NewValidationErrorf("The provided cron schedule is invalid: %s", err)
+	}
+
+	return nil
+}
+
+// updateNextRun sets the next run of the trigger in case it's a cron trigger.
+func updateNextRun(trigger *types.Trigger, now time.Time) error {
+	if trigger.Type != enum.TriggerCron {
+		trigger.NextRun = 0
+		return nil
+	}
+
+	next, err := triggerer.NextCronRun(trigger.Cron, trigger.Timezone, now)
+	if err != nil {
+		return fmt.Errorf("failed to calculate next run of trigger: %w", err)
+	}
+
+	trigger.NextRun = next.UnixMilli()
+
+	return nil
+}
+
 // checkActions validates the trigger actions.
-func checkActions(actions []enum.TriggerAction) error {
+func checkActions(triggerType string, actions []enum.TriggerAction) error {
+	// cron triggers are executed on schedule only, they don't react to any repository events.
+	if triggerType == enum.TriggerCron && len(actions) > 0 {
+		return check.NewValidationError("Cron triggers don't support any actions.")
+	}
+
 	// ignore duplicates here, should be deduplicated later
 	for _, action := range actions {
 		if _, ok := action.Sanitize(); !ok {
@@ -32,7 +32,10 @@ type CreateInput struct {
 	UID         string               `json:"uid"`
 	Secret      string               `json:"secret"`
 	Disabled    bool                 `json:"disabled"`
+	Type        string               `json:"trigger_type"`
 	Actions     []enum.TriggerAction `json:"actions"`
+	Cron        string               `json:"cron"`
+	Timezone    string               `json:"timezone"`
 }
 
 func (c *Controller) Create(
@@ -63,20 +66,28 @@ func (c *Controller) Create(
 		return nil, fmt.Errorf("failed to find pipeline: %w", err)
 	}
 
-	now := time.Now().UnixMilli()
+	now := time.Now()
 	trigger := &types.Trigger{
 		Description: in.Description,
+		Type:        in.Type,
 		Disabled:    in.Disabled,
 		Secret:      in.Secret,
 		CreatedBy:   session.Principal.ID,
 		RepoID:      repo.ID,
 		Actions:     deduplicateActions(in.Actions),
+		Cron:        in.Cron,
+		Timezone:    in.Timezone,
 		UID:         in.UID,
 		PipelineID:  pipeline.ID,
-		Created:     now,
-		Updated:     now,
+		Created:     now.UnixMilli(),
+		Updated:     now.UnixMilli(),
 		Version:     0,
 	}
+
+	err = updateNextRun(trigger, now)
+	if err != nil {
+		return nil, err
+	}
 	err = c.triggerStore.Create(ctx, trigger)
 	if err != nil {
 		return nil, fmt.Errorf("trigger creation failed: %w", err)
@@ -92,7 +103,16 @@ func (c *Controller) checkCreateInput(in *CreateInput) error {
 	if err := checkSecret(in.Secret); err != nil {
 		return err
 	}
-	if err := checkActions(in.Actions); err != nil {
+	if in.Type == "" {
+		in.Type = enum.TriggerHook
+	}
+	if err := checkType(in.Type); err != nil {
+		return err
+	}
+	if err := checkActions(in.Type, in.Actions); err != nil {
+		return err
+	}
+	if err := checkCron(in.Type, in.Cron, in.Timezone); err != nil {
 		return err
 	}
 	if err := c.uidCheck(in.UID, false); err != nil { //nolint:revive
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/auth"
@@ -31,6 +32,8 @@ type UpdateInput struct {
 	Description *string              `json:"description"`
 	UID         *string              `json:"uid"`
 	Actions     []enum.TriggerAction `json:"actions"`
+	Cron        *string              `json:"cron"`
+	Timezone    *string              `json:"timezone"`
 	Secret      *string              `json:"secret"`
 	Disabled    *bool                `json:"disabled"` // can be nil, so keeping it a pointer
 }
@@ -85,8 +88,22 @@ func (c *Controller) Update(
 			if in.Disabled != nil {
 				original.Disabled = *in.Disabled
 			}
+			if in.Cron != nil {
+				original.Cron = *in.Cron
+			}
+			if in.Timezone != nil {
+				original.Timezone = *in.Timezone
+			}
+
+			if err := checkActions(original.Type, original.Actions); err != nil {
+				return err
+			}
+			if err := checkCron(original.Type, original.Cron, original.Timezone); err != nil {
+				return err
+			}
 
-			return nil
+			// always recalculate the next run to not fire missed runs of a disabled or changed trigger.
+			return updateNextRun(original, time.Now())
 		})
 }
 
@@ -110,11 +127,5 @@ func (c *Controller) checkUpdateInput(in *UpdateInput) error {
 		}
 	}
 
-	if in.Actions != nil {
-		if err := checkActions(in.Actions); err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
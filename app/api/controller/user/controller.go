@@ -18,6 +18,7 @@ import (
 	"context"
 
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/mailer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
@@ -34,6 +35,11 @@ type Controller struct {
 	principalStore    store.PrincipalStore
 	tokenStore        store.TokenStore
 	membershipStore   store.MembershipStore
+	publicKeyStore    store.PublicKeyStore
+	signingKeyStore   store.SigningKeyStore
+	notificationStore store.NotificationSettingsStore
+	// mailer is nil if SMTP isn't configured.
+	mailer *mailer.Service
 }
 
 func NewController(
@@ -43,6 +49,10 @@ func NewController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	publicKeyStore store.PublicKeyStore,
+	signingKeyStore store.SigningKeyStore,
+	notificationStore store.NotificationSettingsStore,
+	mailer *mailer.Service,
 ) *Controller {
 	return &Controller{
 		tx:                tx,
@@ -51,6 +61,10 @@ func NewController(
 		principalStore:    principalStore,
 		tokenStore:        tokenStore,
 		membershipStore:   membershipStore,
+		publicKeyStore:    publicKeyStore,
+		signingKeyStore:   signingKeyStore,
+		notificationStore: notificationStore,
+		mailer:            mailer,
 	}
 }
 
@@ -29,6 +29,10 @@ import (
 type CreateTokenInput struct {
 	UID      string         `json:"uid"`
 	Lifetime *time.Duration `json:"lifetime"`
+	// Permissions optionally restricts the token to the provided permissions.
+	Permissions []enum.Permission `json:"permissions"`
+	// Paths optionally restricts the token to the provided spaces and repositories.
+	Paths []string `json:"paths"`
 }
 
 /*
@@ -56,6 +60,12 @@ func (c *Controller) CreateAccessToken(
 	if err = check.TokenLifetime(in.Lifetime, true); err != nil {
 		return nil, err
 	}
+	if in.Permissions, in.Paths, err = check.TokenRestrictions(in.Permissions, in.Paths); err != nil {
+		return nil, err
+	}
+	if err = apiauth.CheckTokenRestrictions(session, in.Permissions, in.Paths); err != nil {
+		return nil, err
+	}
 
 	token, jwtToken, err := token.CreatePAT(
 		ctx,
@@ -64,6 +74,8 @@ func (c *Controller) CreateAccessToken(
 		user,
 		in.UID,
 		in.Lifetime,
+		in.Permissions,
+		in.Paths,
 	)
 	if err != nil {
 		return nil, err
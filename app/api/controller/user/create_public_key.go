@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/crypto/ssh"
+)
+
+type CreatePublicKeyInput struct {
+	UID     string `json:"uid"`
+	Content string `json:"content"`
+}
+
+// CreatePublicKey adds a new public key to the user which can be used to authenticate via SSH.
+func (c *Controller) CreatePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *CreatePublicKeyInput,
+) (*types.PublicKey, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = check.UID(in.UID); err != nil {
+		return nil, err
+	}
+
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(in.Content))
+	if err != nil {
+		return nil, usererror.BadRequestf("Invalid public key: %s", err)
+	}
+
+	publicKey := &types.PublicKey{
+		PrincipalID: user.ID,
+		Created:     time.Now().UnixMilli(),
+		UID:         in.UID,
+		Fingerprint: ssh.FingerprintSHA256(key),
+		Type:        key.Type(),
+		Content:     strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+	}
+
+	err = c.publicKeyStore.Create(ctx, publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create public key: %w", err)
+	}
+
+	return publicKey, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/signing"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+type CreateSigningKeyInput struct {
+	UID     string `json:"uid"`
+	Content string `json:"content"`
+}
+
+// CreateSigningKey adds a new GPG or SSH key to the user which is used to verify signatures of commits and tags.
+func (c *Controller) CreateSigningKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *CreateSigningKeyInput,
+) (*types.SigningKey, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	if err = check.UID(in.UID); err != nil {
+		return nil, err
+	}
+
+	signingKey, err := signing.ParseKey(in.Content)
+	if errors.Is(err, signing.ErrInvalidKey) {
+		return nil, usererror.BadRequestf("Invalid signing key: %s", err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+
+	signingKey.PrincipalID = user.ID
+	signingKey.Created = time.Now().UnixMilli()
+	signingKey.UID = in.UID
+
+	err = c.tx.WithTx(ctx, func(ctx context.Context) error {
+		return c.signingKeyStore.Create(ctx, signingKey)
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		return nil, usererror.Conflict("A signing key with the same identifier or fingerprint already exists")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	return signingKey, nil
+}
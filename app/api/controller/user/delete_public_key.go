@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DeletePublicKey deletes a public key of a user.
+func (c *Controller) DeletePublicKey(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	uid string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	// ensure the key exists, otherwise the delete would silently succeed.
+	if _, err = c.publicKeyStore.FindByUID(ctx, user.ID, uid); err != nil {
+		return fmt.Errorf("failed to find public key by uid: %w", err)
+	}
+
+	err = c.publicKeyStore.DeleteByUID(ctx, user.ID, uid)
+	if err != nil {
+		return fmt.Errorf("failed to delete public key: %w", err)
+	}
+
+	return nil
+}
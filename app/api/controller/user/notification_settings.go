@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateNotificationSettingsInput holds the notification settings to change.
+type UpdateNotificationSettingsInput struct {
+	PullReqCreated *bool `json:"pullreq_created"`
+	ReviewerAdded  *bool `json:"reviewer_added"`
+	CommentCreated *bool `json:"comment_created"`
+	PullReqMerged  *bool `json:"pullreq_merged"`
+	PullReqClosed  *bool `json:"pullreq_closed"`
+	BranchUpdated  *bool `json:"branch_updated"`
+}
+
+// FindNotificationSettings returns the email notification settings of a user.
+func (c *Controller) FindNotificationSettings(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) (*types.NotificationSettings, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserView); err != nil {
+		return nil, err
+	}
+
+	return c.findNotificationSettings(ctx, user.ID)
+}
+
+// UpdateNotificationSettings updates the email notification settings of a user.
+func (c *Controller) UpdateNotificationSettings(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *UpdateNotificationSettingsInput,
+) (*types.NotificationSettings, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent.
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	settings, err := c.findNotificationSettings(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	setIfProvided(&settings.PullReqCreated, in.PullReqCreated)
+	setIfProvided(&settings.ReviewerAdded, in.ReviewerAdded)
+	setIfProvided(&settings.CommentCreated, in.CommentCreated)
+	setIfProvided(&settings.PullReqMerged, in.PullReqMerged)
+	setIfProvided(&settings.PullReqClosed, in.PullReqClosed)
+	setIfProvided(&settings.BranchUpdated, in.BranchUpdated)
+
+	now := time.Now().UnixMilli()
+	if settings.Created == 0 {
+		settings.Created = now
+	}
+	settings.Updated = now
+
+	err = c.notificationStore.Upsert(ctx, settings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to store notification settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// findNotificationSettings returns the stored settings or the defaults in case the user never changed them.
+func (c *Controller) findNotificationSettings(
+	ctx context.Context,
+	principalID int64,
+) (*types.NotificationSettings, error) {
+	settings, err := c.notificationStore.Find(ctx, principalID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return types.DefaultNotificationSettings(principalID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+func setIfProvided(dst *bool, value *bool) {
+	if value != nil {
+		*dst = *value
+	}
+}
@@ -34,6 +34,8 @@ type UpdateInput struct {
 	Email       *string `json:"email"`
 	Password    *string `json:"password"`
 	DisplayName *string `json:"display_name"`
+	// EmailVerified can only be set by admins, changing the email of a user resets it.
+	EmailVerified *bool `json:"email_verified"`
 }
 
 // Update updates the provided user.
@@ -49,6 +51,12 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 		return nil, err
 	}
 
+	if in.EmailVerified != nil {
+		if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEditAdmin); err != nil {
+			return nil, err
+		}
+	}
+
 	if err = c.sanitizeUpdateInput(in); err != nil {
 		return nil, fmt.Errorf("invalid input: %w", err)
 	}
@@ -56,9 +64,16 @@ func (c *Controller) Update(ctx context.Context, session *auth.Session,
 	if in.DisplayName != nil {
 		user.DisplayName = *in.DisplayName
 	}
+	if in.Email != nil && !strings.EqualFold(user.Email, *in.Email) {
+		// the new email has to be verified again.
+		user.EmailVerified = false
+	}
 	if in.Email != nil {
 		user.Email = *in.Email
 	}
+	if in.EmailVerified != nil {
+		user.EmailVerified = *in.EmailVerified
+	}
 	if in.Password != nil {
 		var hash []byte
 		hash, err = hashPassword([]byte(*in.Password), bcrypt.DefaultCost)
@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/services/mailer"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// emailVerificationLifetime is the time after which a verification token sent to a user expires.
+const emailVerificationLifetime = 24 * time.Hour
+
+var errEmailVerificationInvalid = usererror.BadRequest("The verification token is invalid or expired.")
+
+type VerifyEmailInput struct {
+	Token string `json:"token"`
+}
+
+// SendEmailVerification sends a mail with a verification token to the email of the user.
+func (c *Controller) SendEmailVerification(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+) error {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return err
+	}
+
+	// Ensure principal has required permissions on parent
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return err
+	}
+
+	if c.mailer == nil {
+		return usererror.BadRequest("Emails can't be verified as sending mails isn't configured.")
+	}
+
+	if user.Email == "" {
+		return usererror.BadRequest("The user doesn't have an email.")
+	}
+
+	if user.EmailVerified {
+		return nil
+	}
+
+	// the salt of the user is the secret, changing it invalidates all verification tokens.
+	token, err := jwt.GenerateForEmailVerification(user.ID, user.Email, emailVerificationLifetime, user.Salt)
+	if err != nil {
+		return fmt.Errorf("failed to generate email verification token: %w", err)
+	}
+
+	err = c.mailer.SendMail(&mailer.MailRequest{
+		ToRecipients: []string{user.Email},
+		Subject:      "Verify your email",
+		Body: fmt.Sprintf("Use the following token to verify the email of your account %q:\n\n%s\n\n"+
+			"The token expires in %s.", user.UID, token, emailVerificationLifetime),
+		ContentType: "text/plain",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send email verification mail: %w", err)
+	}
+
+	log.Ctx(ctx).Info().Str("user_uid", user.UID).Msg("sent email verification mail")
+
+	return nil
+}
+
+// VerifyEmail marks the email of the user as verified if the token was sent to the current email of the user.
+func (c *Controller) VerifyEmail(
+	ctx context.Context,
+	session *auth.Session,
+	userUID string,
+	in *VerifyEmailInput,
+) (*types.User, error) {
+	user, err := findUserFromUID(ctx, c.principalStore, userUID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure principal has required permissions on parent
+	if err = apiauth.CheckUser(ctx, c.authorizer, session, user, enum.PermissionUserEdit); err != nil {
+		return nil, err
+	}
+
+	principalID, email, err := jwt.ParseEmailVerification(strings.TrimSpace(in.Token), user.Salt)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to parse email verification token")
+		return nil, errEmailVerificationInvalid
+	}
+
+	// the email could have been changed after the token was sent.
+	if principalID != user.ID || !strings.EqualFold(email, user.Email) {
+		return nil, errEmailVerificationInvalid
+	}
+
+	if user.EmailVerified {
+		return user, nil
+	}
+
+	user.EmailVerified = true
+	user.Updated = time.Now().UnixMilli()
+
+	err = c.principalStore.UpdateUser(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
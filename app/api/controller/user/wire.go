@@ -16,8 +16,10 @@ package user
 
 import (
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/mailer"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/check"
 
 	"github.com/google/wire"
@@ -35,12 +37,26 @@ func ProvideController(
 	principalStore store.PrincipalStore,
 	tokenStore store.TokenStore,
 	membershipStore store.MembershipStore,
+	publicKeyStore store.PublicKeyStore,
+	signingKeyStore store.SigningKeyStore,
+	notificationStore store.NotificationSettingsStore,
+	config *types.Config,
+	mailService *mailer.Service,
 ) *Controller {
+	if config.SMTP.Host == "" {
+		// without SMTP no verification mails can be sent.
+		mailService = nil
+	}
+
 	return NewController(
 		tx,
 		principalUIDCheck,
 		authorizer,
 		principalStore,
 		tokenStore,
-		membershipStore)
+		membershipStore,
+		publicKeyStore,
+		signingKeyStore,
+		notificationStore,
+		mailService)
 }
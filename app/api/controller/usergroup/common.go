@@ -0,0 +1,68 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// getSpaceCheckAuth fetches the space of the user group and checks that the
+// principal has the required permission on it.
+func (c *Controller) getSpaceCheckAuth(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	reqPermission enum.Permission,
+) (*types.Space, error) {
+	space, err := c.spaceStore.FindByRef(ctx, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space: %w", err)
+	}
+
+	err = apiauth.CheckSpace(ctx, c.authorizer, session, space, reqPermission, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authorize: %w", err)
+	}
+
+	return space, nil
+}
+
+// getUserGroupCheckAuth fetches the user group and checks that the principal
+// has the required permission on the space of the user group.
+func (c *Controller) getUserGroupCheckAuth(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	uid string,
+	reqPermission enum.Permission,
+) (*types.UserGroup, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, reqPermission)
+	if err != nil {
+		return nil, err
+	}
+
+	userGroup, err := c.userGroupStore.Find(ctx, space.ID, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user group: %w", err)
+	}
+
+	return userGroup, nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types/check"
+)
+
+type Controller struct {
+	tx                   dbtx.Transactor
+	uidCheck             check.PathUID
+	authorizer           authz.Authorizer
+	spaceStore           store.SpaceStore
+	principalStore       store.PrincipalStore
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	uidCheck check.PathUID,
+	authorizer authz.Authorizer,
+	spaceStore store.SpaceStore,
+	principalStore store.PrincipalStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+) *Controller {
+	return &Controller{
+		tx:                   tx,
+		uidCheck:             uidCheck,
+		authorizer:           authorizer,
+		spaceStore:           spaceStore,
+		principalStore:       principalStore,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+	}
+}
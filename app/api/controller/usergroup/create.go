@@ -0,0 +1,94 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+var (
+	// errUserGroupRequiresParent if the user tries to create a user group without a parent space.
+	errUserGroupRequiresParent = usererror.BadRequest(
+		"Parent space required - standalone user groups are not supported.")
+)
+
+type CreateInput struct {
+	SpaceRef    string `json:"space_ref"` // Ref of the parent space
+	UID         string `json:"uid"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Create creates a new user group in a space.
+func (c *Controller) Create(ctx context.Context, session *auth.Session, in *CreateInput) (*types.UserGroup, error) {
+	if err := c.sanitizeCreateInput(in); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	space, err := c.getSpaceCheckAuth(ctx, session, in.SpaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	userGroup := &types.UserGroup{
+		SpaceID:     space.ID,
+		UID:         in.UID,
+		Name:        in.Name,
+		Description: in.Description,
+		CreatedBy:   session.Principal.ID,
+		Created:     now,
+		Updated:     now,
+	}
+
+	err = c.userGroupStore.Create(ctx, userGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user group: %w", err)
+	}
+
+	return userGroup, nil
+}
+
+func (c *Controller) sanitizeCreateInput(in *CreateInput) error {
+	parentRefAsID, err := strconv.ParseInt(in.SpaceRef, 10, 64)
+	if (err == nil && parentRefAsID <= 0) || (len(strings.TrimSpace(in.SpaceRef)) == 0) {
+		return errUserGroupRequiresParent
+	}
+
+	if err := c.uidCheck(in.UID, false); err != nil {
+		return err
+	}
+
+	in.Name = strings.TrimSpace(in.Name)
+	if in.Name == "" {
+		in.Name = in.UID
+	}
+	if err := check.DisplayName(in.Name); err != nil {
+		return err
+	}
+
+	in.Description = strings.TrimSpace(in.Description)
+	return check.Description(in.Description)
+}
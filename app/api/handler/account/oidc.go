@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package account
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/oidc"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+const (
+	oidcStateCookieName = "gitness_oidc_state"
+	oidcLinkCookieName  = "gitness_oidc_link"
+	oidcStateExpiry     = 10 * time.Minute
+)
+
+// HandleOIDCLogin returns an http.HandlerFunc that redirects the user
+// to the OIDC identity provider to start the login.
+func HandleOIDCLogin(oidcCtrl *oidc.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		out, err := oidcCtrl.Login(ctx)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		setOIDCCookies(w, r, out)
+
+		http.Redirect(w, r, out.RedirectURL, http.StatusFound)
+	}
+}
+
+// HandleOIDCLink returns an http.HandlerFunc that redirects the authenticated user
+// to the OIDC identity provider to link the identity to the account of the user.
+func HandleOIDCLink(oidcCtrl *oidc.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		out, err := oidcCtrl.Link(ctx, session)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		setOIDCCookies(w, r, out)
+
+		http.Redirect(w, r, out.RedirectURL, http.StatusFound)
+	}
+}
+
+// HandleOIDCCallback returns an http.HandlerFunc that completes the OIDC login
+// and redirects the user to the UI with the session token set as cookie.
+func HandleOIDCCallback(oidcCtrl *oidc.Controller, cookieName string, uiURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		if errCode, ok := request.QueryParam(r, "error"); ok {
+			render.TranslatedUserError(w, usererror.BadRequestf("OIDC login failed: %s %s",
+				errCode, request.QueryParamOrDefault(r, "error_description", "")))
+			return
+		}
+
+		storedState, _ := request.GetCookie(r, oidcStateCookieName)
+		storedLink, _ := request.GetCookie(r, oidcLinkCookieName)
+
+		// the state and link are single use, always remove them.
+		setOIDCCookies(w, r, &oidc.LoginOutput{})
+
+		tokenResponse, err := oidcCtrl.Callback(ctx, &oidc.CallbackInput{
+			Code:        request.QueryParamOrDefault(r, "code", ""),
+			State:       request.QueryParamOrDefault(r, "state", ""),
+			StoredState: storedState,
+			StoredLink:  storedLink,
+		})
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if cookieName != "" {
+			includeTokenCookie(r, w, tokenResponse, cookieName)
+		}
+
+		http.Redirect(w, r, uiURL, http.StatusFound)
+	}
+}
+
+// setOIDCCookies stores the state and link of the login on the client. Empty values remove the cookies.
+func setOIDCCookies(w http.ResponseWriter, r *http.Request, out *oidc.LoginOutput) {
+	for name, value := range map[string]string{oidcStateCookieName: out.State, oidcLinkCookieName: out.Link} {
+		cookie := newOIDCCookie(r, name)
+		cookie.Value = value
+		cookie.Expires = time.Now().Add(oidcStateExpiry)
+		if value == "" {
+			cookie.Expires = time.UnixMilli(0)
+		}
+		http.SetCookie(w, cookie)
+	}
+}
+
+func newOIDCCookie(r *http.Request, name string) *http.Cookie {
+	// lax is required as the callback is a cross-site navigation from the identity provider.
+	return &http.Cookie{
+		Name:     name,
+		SameSite: http.SameSiteLaxMode,
+		HttpOnly: true,
+		Path:     "/",
+		Domain:   r.URL.Hostname(),
+		Secure:   r.URL.Scheme == "https",
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/url"
+)
+
+// HandleBatch handles the LFS batch API request.
+func HandleBatch(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		in := new(lfs.BatchRequest)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			renderError(w, urlProvider, usererror.BadRequestf("Invalid Request Body: %s.", err))
+			return
+		}
+
+		out, err := lfsCtrl.Batch(ctx, session, repoRef, in, r.Header.Get("Authorization"))
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		renderJSON(w, http.StatusOK, out)
+	}
+}
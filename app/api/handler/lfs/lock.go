@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/url"
+)
+
+// HandleLockCreate locks a file of the repository.
+func HandleLockCreate(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		in := new(lfs.LockCreateRequest)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			renderError(w, urlProvider, usererror.BadRequestf("Invalid Request Body: %s.", err))
+			return
+		}
+
+		out, err := lfsCtrl.LockCreate(ctx, session, repoRef, in)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		renderJSON(w, http.StatusCreated, out)
+	}
+}
+
+// HandleLockList lists the locks of the repository.
+func HandleLockList(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		opts := &lfs.LockListOptions{
+			Path:   request.QueryParamOrDefault(r, request.QueryParamPath, ""),
+			ID:     request.QueryParamOrDefault(r, request.QueryParamLFSLockID, ""),
+			Cursor: request.QueryParamOrDefault(r, request.QueryParamLFSLockCursor, ""),
+		}
+		if limit, ok := request.QueryParam(r, request.QueryParamLimit); ok {
+			opts.Limit, err = strconv.Atoi(limit)
+			if err != nil {
+				renderError(w, urlProvider, usererror.BadRequest("Parameter 'limit' must be an integer."))
+				return
+			}
+		}
+
+		out, err := lfsCtrl.LockList(ctx, session, repoRef, opts)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		renderJSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleLockVerify lists the locks of the repository split by ownership.
+func HandleLockVerify(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		in := new(lfs.LockVerifyRequest)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			renderError(w, urlProvider, usererror.BadRequestf("Invalid Request Body: %s.", err))
+			return
+		}
+
+		out, err := lfsCtrl.LockVerify(ctx, session, repoRef, in)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		renderJSON(w, http.StatusOK, out)
+	}
+}
+
+// HandleLockDelete removes a lock of the repository.
+func HandleLockDelete(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		lockID, err := request.GetLFSLockIDFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		in := new(lfs.LockDeleteRequest)
+		// the body is optional.
+		if r.ContentLength != 0 {
+			err = json.NewDecoder(r.Body).Decode(in)
+			if err != nil {
+				renderError(w, urlProvider, usererror.BadRequestf("Invalid Request Body: %s.", err))
+				return
+			}
+		}
+
+		out, err := lfsCtrl.LockDelete(ctx, session, repoRef, lockID, in)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		renderJSON(w, http.StatusOK, out)
+	}
+}
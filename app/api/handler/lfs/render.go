@@ -0,0 +1,62 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/url"
+
+	"github.com/rs/zerolog/log"
+)
+
+// contentType is the media type of all requests and responses of the LFS API.
+const contentType = "application/vnd.git-lfs+json"
+
+// renderJSON writes the json-encoded value with the LFS media type to the response.
+func renderJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Err(err).Msg("failed to write LFS json response")
+	}
+}
+
+// renderError writes the error in the format expected by LFS clients.
+func renderError(w http.ResponseWriter, urlProvider url.Provider, err error) {
+	if errors.Is(err, apiauth.ErrNotAuthenticated) {
+		// tell the client to query the user credentials.
+		w.Header().Add("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s"`, urlProvider.GetAPIHostname()))
+		renderJSON(w, http.StatusUnauthorized, usererror.ErrUnauthorized)
+		return
+	}
+
+	var conflictErr *lfs.LockConflictError
+	if errors.As(err, &conflictErr) {
+		renderJSON(w, http.StatusConflict, conflictErr)
+		return
+	}
+
+	log.Warn().Msgf("LFS operation resulted in user facing error. Internal details: %s", err)
+	userErr := usererror.Translate(err)
+	renderJSON(w, userErr.Status, userErr)
+}
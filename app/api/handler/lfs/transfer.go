@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/controller/lfs"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/url"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleDownload streams the content of an LFS object.
+func HandleDownload(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		oid, err := request.GetLFSObjectIDFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		obj, content, err := lfsCtrl.Download(ctx, session, repoRef, oid)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+		defer func() {
+			if errClose := content.Close(); errClose != nil {
+				log.Ctx(ctx).Warn().Err(errClose).Msg("failed to close LFS object reader")
+			}
+		}()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.FormatInt(obj.Size, 10))
+
+		render.Reader(ctx, w, http.StatusOK, content)
+	}
+}
+
+// HandleUpload stores the content of an LFS object.
+func HandleUpload(lfsCtrl *lfs.Controller, urlProvider url.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		oid, err := request.GetLFSObjectIDFromPath(r)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		_, err = lfsCtrl.Upload(ctx, session, repoRef, oid, r.Body)
+		if err != nil {
+			renderError(w, urlProvider, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
@@ -70,7 +70,7 @@ func HandleGitServicePack(
 		render.NoCache(w)
 		w.Header().Set("Content-Type", fmt.Sprintf("application/x-git-%s-result", service))
 
-		err = repoCtrl.GitServicePack(ctx, session, repoRef, service, gitProtocol, dataReader, w)
+		err = repoCtrl.GitServicePack(ctx, session, repoRef, service, gitProtocol, true, dataReader, w)
 		if errors.Is(err, apiauth.ErrNotAuthenticated) {
 			renderBasicAuth(w, urlProvider)
 			return
@@ -16,11 +16,14 @@ package repo
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/render"
 	"github.com/harness/gitness/app/api/request"
+
+	"github.com/rs/zerolog/log"
 )
 
 // HandleRaw returns the raw content of a file.
@@ -45,6 +48,14 @@ func HandleRaw(repoCtrl *repo.Controller) http.HandlerFunc {
 			return
 		}
 
+		if closer, ok := dataReader.(io.Closer); ok {
+			defer func() {
+				if errClose := closer.Close(); errClose != nil {
+					log.Ctx(ctx).Warn().Err(errClose).Msg("failed to close raw content reader")
+				}
+			}()
+		}
+
 		w.Header().Add("Content-Length", fmt.Sprint(dataLength))
 
 		render.Reader(ctx, w, http.StatusOK, dataReader)
@@ -33,8 +33,14 @@ func HandleListSecrets(spaceCtrl *space.Controller) http.HandlerFunc {
 			return
 		}
 
+		inherited, err := request.ParseInheritedFromQuery(r)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
 		filter := request.ParseListQueryFilterFromRequest(r)
-		ret, totalCount, err := spaceCtrl.ListSecrets(ctx, session, spaceRef, filter)
+		ret, totalCount, err := spaceCtrl.ListSecrets(ctx, session, spaceRef, filter, inherited)
 		if err != nil {
 			render.TranslatedUserError(w, err)
 			return
@@ -51,6 +51,12 @@ type registerRequest struct {
 	user.RegisterInput
 }
 
+// callback of the oidc identity provider.
+type oidcCallbackRequest struct {
+	Code  string `query:"code"`
+	State string `query:"state"`
+}
+
 // helper function that constructs the openapi specification
 // for the account registration and login endpoints.
 func buildAccount(reflector *openapi3.Reflector) {
@@ -84,4 +90,37 @@ func buildAccount(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&onRegister, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/register", onRegister)
+
+	onOIDCLogin := openapi3.Operation{}
+	onOIDCLogin.WithTags("account")
+	onOIDCLogin.WithMapOfAnything(map[string]interface{}{"operationId": "onOIDCLogin"})
+	_ = reflector.SetRequest(&onOIDCLogin, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onOIDCLogin, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/login", onOIDCLogin)
+
+	onOIDCLink := openapi3.Operation{}
+	onOIDCLink.WithTags("account")
+	onOIDCLink.WithMapOfAnything(map[string]interface{}{"operationId": "onOIDCLink"})
+	_ = reflector.SetRequest(&onOIDCLink, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&onOIDCLink, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onOIDCLink, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onOIDCLink, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onOIDCLink, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&onOIDCLink, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/link", onOIDCLink)
+
+	onOIDCCallback := openapi3.Operation{}
+	onOIDCCallback.WithTags("account")
+	onOIDCCallback.WithMapOfAnything(map[string]interface{}{"operationId": "onOIDCCallback"})
+	_ = reflector.SetRequest(&onOIDCCallback, new(oidcCallbackRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, nil, http.StatusFound)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&onOIDCCallback, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/oidc/callback", onOIDCCallback)
 }
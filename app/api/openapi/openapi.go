@@ -57,11 +57,13 @@ func Generate() *openapi3.Spec {
 	connectorOperations(&reflector)
 	templateOperations(&reflector)
 	secretOperations(&reflector)
+	userGroupOperations(&reflector)
 	resourceOperations(&reflector)
 	pullReqOperations(&reflector)
 	webhookOperations(&reflector)
 	checkOperations(&reflector)
 	uploadOperations(&reflector)
+	runnerOperations(&reflector)
 
 	//
 	// define security scheme
@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/request"
@@ -49,8 +50,20 @@ type logRequest struct {
 	StepNum  string `path:"step_number"`
 }
 
+type artifactRequest struct {
+	executionRequest
+	ID int64 `path:"artifact_id"`
+}
+
+type approveStageRequest struct {
+	executionRequest
+	StageNum string `path:"stage_number"`
+	execution.ApproveStageInput
+}
+
 type createExecutionRequest struct {
 	pipelineRequest
+	execution.CreateInput
 }
 
 type createTriggerRequest struct {
@@ -234,6 +247,59 @@ func pipelineOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions", executionList)
 
+	artifactList := openapi3.Operation{}
+	artifactList.WithTags("pipeline")
+	artifactList.WithMapOfAnything(map[string]interface{}{"operationId": "listArtifacts"})
+	_ = reflector.SetRequest(&artifactList, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&artifactList, []types.Artifact{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/artifacts", artifactList)
+
+	artifactDownload := openapi3.Operation{}
+	artifactDownload.WithTags("pipeline")
+	artifactDownload.WithMapOfAnything(map[string]interface{}{"operationId": "downloadArtifact"})
+	_ = reflector.SetRequest(&artifactDownload, new(artifactRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&artifactDownload, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&artifactDownload, nil, http.StatusTemporaryRedirect)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&artifactDownload, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/artifacts/{artifact_id}/download",
+		artifactDownload)
+
+	approvalList := openapi3.Operation{}
+	approvalList.WithTags("pipeline")
+	approvalList.WithMapOfAnything(map[string]interface{}{"operationId": "listApprovals"})
+	_ = reflector.SetRequest(&approvalList, new(getExecutionRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&approvalList, []types.Approval{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&approvalList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&approvalList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&approvalList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&approvalList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/approvals", approvalList)
+
+	stageApprove := openapi3.Operation{}
+	stageApprove.WithTags("pipeline")
+	stageApprove.WithMapOfAnything(map[string]interface{}{"operationId": "approveStage"})
+	_ = reflector.SetRequest(&stageApprove, new(approveStageRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&stageApprove, new(types.Approval), http.StatusOK)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&stageApprove, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pipelines/{pipeline_uid}/executions/{execution_number}/stages/{stage_number}/approval",
+		stageApprove)
+
 	triggerCreate := openapi3.Operation{}
 	triggerCreate.WithTags("pipeline")
 	triggerCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createTrigger"})
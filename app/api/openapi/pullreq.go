@@ -103,6 +103,11 @@ type reviewerAddPullReqRequest struct {
 	pullreq.ReviewerAddInput
 }
 
+type userGroupReviewerAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.UserGroupReviewerAddInput
+}
+
 type reviewSubmitPullReqRequest struct {
 	pullreq.ReviewSubmitInput
 	pullReqRequest
@@ -422,6 +427,18 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers", reviewerAdd)
 
+	userGroupReviewerAdd := openapi3.Operation{}
+	userGroupReviewerAdd.WithTags("pullreq")
+	userGroupReviewerAdd.WithMapOfAnything(map[string]interface{}{"operationId": "userGroupReviewerAddPullReq"})
+	_ = reflector.SetRequest(&userGroupReviewerAdd, new(userGroupReviewerAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, []types.PullReqReviewer{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&userGroupReviewerAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reviewers/usergroups", userGroupReviewerAdd)
+
 	reviewerList := openapi3.Operation{}
 	reviewerList.WithTags("pullreq")
 	reviewerList.WithMapOfAnything(map[string]interface{}{"operationId": "reviewerListPullReq"})
@@ -43,6 +43,11 @@ type repoRequest struct {
 	Ref string `path:"repo_ref"`
 }
 
+type pushMirrorRequest struct {
+	repoRequest
+	ID int64 `path:"push_mirror_id"`
+}
+
 type updateRepoRequest struct {
 	repoRequest
 	repo.UpdateInput
@@ -53,11 +58,22 @@ type moveRepoRequest struct {
 	repo.MoveInput
 }
 
+type forkRepoRequest struct {
+	repoRequest
+	repo.ForkInput
+}
+
 type getContentRequest struct {
 	repoRequest
 	Path string `path:"path"`
 }
 
+type archiveRequest struct {
+	repoRequest
+	ArchivePath string `path:"archive_path" example:"v1.0.0.tar.gz"`
+	Path        string `query:"path" description:"Limits the archive to the provided path of the repository."`
+}
+
 type pathsDetailsRequest struct {
 	repoRequest
 	repo.PathsDetailsInput
@@ -516,6 +532,18 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMove, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/move", opMove)
 
+	opFork := openapi3.Operation{}
+	opFork.WithTags("repository")
+	opFork.WithMapOfAnything(map[string]interface{}{"operationId": "forkRepository"})
+	_ = reflector.SetRequest(&opFork, new(forkRepoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opFork, new(types.Repository), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFork, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork", opFork)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("repository")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listRepositoryServiceAccounts"})
@@ -564,6 +592,29 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetRaw, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/raw/{path}", opGetRaw)
 
+	opArchive := openapi3.Operation{}
+	opArchive.WithTags("repository")
+	opArchive.WithMapOfAnything(map[string]interface{}{"operationId": "archive"})
+	_ = reflector.SetRequest(&opArchive, new(archiveRequest), http.MethodGet)
+	_ = reflector.SetStringResponse(&opArchive, http.StatusOK, "application/octet-stream")
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opArchive, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/archive/{archive_path}", opArchive)
+
+	opLFSUsage := openapi3.Operation{}
+	opLFSUsage.WithTags("repository")
+	opLFSUsage.WithMapOfAnything(map[string]interface{}{"operationId": "getLFSUsage"})
+	_ = reflector.SetRequest(&opLFSUsage, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opLFSUsage, new(types.LFSUsage), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opLFSUsage, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opLFSUsage, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opLFSUsage, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opLFSUsage, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/lfs/usage", opLFSUsage)
+
 	opGetBlame := openapi3.Operation{}
 	opGetBlame.WithTags("repository")
 	opGetBlame.WithMapOfAnything(map[string]interface{}{"operationId": "getBlame"})
@@ -853,4 +904,129 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opCodeOwnerValidate, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/codeowners/validate", opCodeOwnerValidate)
+
+	opMirrorFind := openapi3.Operation{}
+	opMirrorFind.WithTags("repository")
+	opMirrorFind.WithMapOfAnything(map[string]interface{}{"operationId": "findMirror"})
+	_ = reflector.SetRequest(&opMirrorFind, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/mirror", opMirrorFind)
+
+	opMirrorSet := openapi3.Operation{}
+	opMirrorSet.WithTags("repository")
+	opMirrorSet.WithMapOfAnything(map[string]interface{}{"operationId": "setMirror"})
+	_ = reflector.SetRequest(&opMirrorSet, &struct {
+		repoRequest
+		repo.MirrorSetInput
+	}{}, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opMirrorSet, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorSet, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMirrorSet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorSet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorSet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorSet, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPut, "/repos/{repo_ref}/mirror", opMirrorSet)
+
+	opMirrorDelete := openapi3.Operation{}
+	opMirrorDelete.WithTags("repository")
+	opMirrorDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteMirror"})
+	_ = reflector.SetRequest(&opMirrorDelete, new(repoRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/mirror", opMirrorDelete)
+
+	opMirrorSync := openapi3.Operation{}
+	opMirrorSync.WithTags("repository")
+	opMirrorSync.WithMapOfAnything(map[string]interface{}{"operationId": "syncMirror"})
+	_ = reflector.SetRequest(&opMirrorSync, new(repoRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(types.PullMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opMirrorSync, new(usererror.Error), http.StatusConflict)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/mirror/sync", opMirrorSync)
+
+	opPushMirrorList := openapi3.Operation{}
+	opPushMirrorList.WithTags("repository")
+	opPushMirrorList.WithMapOfAnything(map[string]interface{}{"operationId": "listPushMirrors"})
+	_ = reflector.SetRequest(&opPushMirrorList, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, []types.PushMirror{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/push-mirrors", opPushMirrorList)
+
+	opPushMirrorCreate := openapi3.Operation{}
+	opPushMirrorCreate.WithTags("repository")
+	opPushMirrorCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorCreate, &struct {
+		repoRequest
+		repo.PushMirrorCreateInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(types.PushMirror), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorCreate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/push-mirrors", opPushMirrorCreate)
+
+	opPushMirrorFind := openapi3.Operation{}
+	opPushMirrorFind.WithTags("repository")
+	opPushMirrorFind.WithMapOfAnything(map[string]interface{}{"operationId": "findPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorFind, new(pushMirrorRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/push-mirrors/{push_mirror_id}", opPushMirrorFind)
+
+	opPushMirrorUpdate := openapi3.Operation{}
+	opPushMirrorUpdate.WithTags("repository")
+	opPushMirrorUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updatePushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorUpdate, &struct {
+		pushMirrorRequest
+		repo.PushMirrorUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/repos/{repo_ref}/push-mirrors/{push_mirror_id}", opPushMirrorUpdate)
+
+	opPushMirrorDelete := openapi3.Operation{}
+	opPushMirrorDelete.WithTags("repository")
+	opPushMirrorDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deletePushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorDelete, new(pushMirrorRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/repos/{repo_ref}/push-mirrors/{push_mirror_id}", opPushMirrorDelete)
+
+	opPushMirrorPush := openapi3.Operation{}
+	opPushMirrorPush.WithTags("repository")
+	opPushMirrorPush.WithMapOfAnything(map[string]interface{}{"operationId": "pushPushMirror"})
+	_ = reflector.SetRequest(&opPushMirrorPush, new(pushMirrorRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPushMirrorPush, new(types.PushMirror), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPushMirrorPush, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPushMirrorPush, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opPushMirrorPush, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPushMirrorPush, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPushMirrorPush, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/push-mirrors/{push_mirror_id}/push", opPushMirrorPush)
 }
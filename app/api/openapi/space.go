@@ -148,6 +148,21 @@ var queryParameterSortMembershipUsers = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterInheritedSecrets = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamInherited,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Indicates whether the secrets inherited from the parent spaces should be included."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
 //nolint:funlen // api spec generation no need for checking func complexity
 func spaceOperations(reflector *openapi3.Reflector) {
 	opCreate := openapi3.Operation{}
@@ -304,7 +319,8 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	opSecrets := openapi3.Operation{}
 	opSecrets.WithTags("space")
 	opSecrets.WithMapOfAnything(map[string]interface{}{"operationId": "listSecrets"})
-	opSecrets.WithParameters(queryParameterQueryRepo, queryParameterPage, queryParameterLimit)
+	opSecrets.WithParameters(queryParameterQueryRepo, queryParameterPage, queryParameterLimit,
+		queryParameterInheritedSecrets)
 	_ = reflector.SetRequest(&opSecrets, new(spaceRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&opSecrets, []types.Secret{}, http.StatusOK)
 	_ = reflector.SetJSONResponse(&opSecrets, new(usererror.Error), http.StatusInternalServerError)
@@ -313,6 +329,18 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opSecrets, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/secrets", opSecrets)
 
+	opUserGroups := openapi3.Operation{}
+	opUserGroups.WithTags("space")
+	opUserGroups.WithMapOfAnything(map[string]interface{}{"operationId": "listUserGroups"})
+	opUserGroups.WithParameters(queryParameterQueryRepo, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opUserGroups, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opUserGroups, []types.UserGroup{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUserGroups, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUserGroups, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUserGroups, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUserGroups, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups", opUserGroups)
+
 	opServiceAccounts := openapi3.Operation{}
 	opServiceAccounts.WithTags("space")
 	opServiceAccounts.WithMapOfAnything(map[string]interface{}{"operationId": "listServiceAccounts"})
@@ -383,4 +411,66 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opMembershipList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/members", opMembershipList)
+
+	opMembershipUserGroupAdd := openapi3.Operation{}
+	opMembershipUserGroupAdd.WithTags("space")
+	opMembershipUserGroupAdd.WithMapOfAnything(map[string]interface{}{"operationId": "membershipUserGroupAdd"})
+	_ = reflector.SetRequest(&opMembershipUserGroupAdd, struct {
+		spaceRequest
+		space.MembershipUserGroupAddInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupAdd, &types.MembershipUserGroup{}, http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/usergroup-members", opMembershipUserGroupAdd)
+
+	opMembershipUserGroupDelete := openapi3.Operation{}
+	opMembershipUserGroupDelete.WithTags("space")
+	opMembershipUserGroupDelete.WithMapOfAnything(
+		map[string]interface{}{"operationId": "membershipUserGroupDelete"})
+	_ = reflector.SetRequest(&opMembershipUserGroupDelete, struct {
+		spaceRequest
+		UserGroupUID string `path:"usergroup_uid"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/usergroup-members/{usergroup_uid}", opMembershipUserGroupDelete)
+
+	opMembershipUserGroupUpdate := openapi3.Operation{}
+	opMembershipUserGroupUpdate.WithTags("space")
+	opMembershipUserGroupUpdate.WithMapOfAnything(
+		map[string]interface{}{"operationId": "membershipUserGroupUpdate"})
+	_ = reflector.SetRequest(&opMembershipUserGroupUpdate, &struct {
+		spaceRequest
+		UserGroupUID string `path:"usergroup_uid"`
+		space.MembershipUpdateInput
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupUpdate, &types.MembershipUserGroup{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/usergroup-members/{usergroup_uid}", opMembershipUserGroupUpdate)
+
+	opMembershipUserGroupList := openapi3.Operation{}
+	opMembershipUserGroupList.WithTags("space")
+	opMembershipUserGroupList.WithMapOfAnything(map[string]interface{}{"operationId": "membershipUserGroupList"})
+	opMembershipUserGroupList.WithParameters(queryParameterQueryRepo, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opMembershipUserGroupList, &struct {
+		spaceRequest
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupList, []types.MembershipUserGroup{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMembershipUserGroupList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroup-members", opMembershipUserGroupList)
 }
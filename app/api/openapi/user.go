@@ -31,6 +31,26 @@ type createTokenRequest struct {
 	user.CreateTokenInput
 }
 
+type createPublicKeyRequest struct {
+	user.CreatePublicKeyInput
+}
+
+type deletePublicKeyRequest struct {
+	UID string `path:"public_key_uid"`
+}
+
+type createSigningKeyRequest struct {
+	user.CreateSigningKeyInput
+}
+
+type deleteSigningKeyRequest struct {
+	UID string `path:"signing_key_uid"`
+}
+
+type verifyEmailRequest struct {
+	user.VerifyEmailInput
+}
+
 var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamQuery,
@@ -45,6 +65,36 @@ var queryParameterMembershipSpaces = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQueryPublicKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the public keys by their uid."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterSortPublicKey = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The field by which the public keys are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.PublicKeySortCreated),
+				Enum:    enum.PublicKeySort("").Enum(),
+			},
+		},
+	},
+}
+
 var queryParameterSortMembershipSpaces = openapi3.ParameterOrRef{
 	Parameter: &openapi3.Parameter{
 		Name:        request.QueryParamSort,
@@ -99,4 +149,97 @@ func buildUser(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new([]types.MembershipSpace), http.StatusOK)
 	_ = reflector.SetJSONResponse(&opMemberSpaces, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/memberships", opMemberSpaces)
+
+	opFindNotificationSettings := openapi3.Operation{}
+	opFindNotificationSettings.WithTags("user")
+	opFindNotificationSettings.WithMapOfAnything(map[string]interface{}{"operationId": "findNotificationSettings"})
+	_ = reflector.SetRequest(&opFindNotificationSettings, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindNotificationSettings, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindNotificationSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-settings", opFindNotificationSettings)
+
+	opUpdateNotificationSettings := openapi3.Operation{}
+	opUpdateNotificationSettings.WithTags("user")
+	opUpdateNotificationSettings.WithMapOfAnything(
+		map[string]interface{}{"operationId": "updateNotificationSettings"})
+	_ = reflector.SetRequest(&opUpdateNotificationSettings, new(user.UpdateNotificationSettingsInput),
+		http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationSettings, new(types.NotificationSettings), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateNotificationSettings, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notification-settings", opUpdateNotificationSettings)
+
+	opListPublicKeys := openapi3.Operation{}
+	opListPublicKeys.WithTags("user")
+	opListPublicKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listPublicKey"})
+	opListPublicKeys.WithParameters(
+		queryParameterQueryPublicKey,
+		queryParameterOrder, queryParameterSortPublicKey,
+		queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opListPublicKeys, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new([]types.PublicKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPublicKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/keys", opListPublicKeys)
+
+	opCreatePublicKey := openapi3.Operation{}
+	opCreatePublicKey.WithTags("user")
+	opCreatePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "createPublicKey"})
+	_ = reflector.SetRequest(&opCreatePublicKey, new(createPublicKeyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(types.PublicKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreatePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/keys", opCreatePublicKey)
+
+	opDeletePublicKey := openapi3.Operation{}
+	opDeletePublicKey.WithTags("user")
+	opDeletePublicKey.WithMapOfAnything(map[string]interface{}{"operationId": "deletePublicKey"})
+	_ = reflector.SetRequest(&opDeletePublicKey, new(deletePublicKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeletePublicKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/keys/{public_key_uid}", opDeletePublicKey)
+
+	opListSigningKeys := openapi3.Operation{}
+	opListSigningKeys.WithTags("user")
+	opListSigningKeys.WithMapOfAnything(map[string]interface{}{"operationId": "listSigningKeys"})
+	_ = reflector.SetRequest(&opListSigningKeys, struct{}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSigningKeys, new([]types.SigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSigningKeys, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/signing-keys", opListSigningKeys)
+
+	opCreateSigningKey := openapi3.Operation{}
+	opCreateSigningKey.WithTags("user")
+	opCreateSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "createSigningKey"})
+	_ = reflector.SetRequest(&opCreateSigningKey, new(createSigningKeyRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateSigningKey, new(types.SigningKey), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateSigningKey, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateSigningKey, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreateSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/signing-keys", opCreateSigningKey)
+
+	opDeleteSigningKey := openapi3.Operation{}
+	opDeleteSigningKey.WithTags("user")
+	opDeleteSigningKey.WithMapOfAnything(map[string]interface{}{"operationId": "deleteSigningKey"})
+	_ = reflector.SetRequest(&opDeleteSigningKey, new(deleteSigningKeyRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDeleteSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/user/signing-keys/{signing_key_uid}", opDeleteSigningKey)
+
+	opSendEmailVerification := openapi3.Operation{}
+	opSendEmailVerification.WithTags("user")
+	opSendEmailVerification.WithMapOfAnything(map[string]interface{}{"operationId": "sendEmailVerification"})
+	_ = reflector.SetRequest(&opSendEmailVerification, struct{}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opSendEmailVerification, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opSendEmailVerification, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSendEmailVerification, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/email/verification", opSendEmailVerification)
+
+	opVerifyEmail := openapi3.Operation{}
+	opVerifyEmail.WithTags("user")
+	opVerifyEmail.WithMapOfAnything(map[string]interface{}{"operationId": "verifyEmail"})
+	_ = reflector.SetRequest(&opVerifyEmail, new(verifyEmailRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(types.User), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opVerifyEmail, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/email/verify", opVerifyEmail)
 }
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/usergroup"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type createUserGroupRequest struct {
+	usergroup.CreateInput
+}
+
+type userGroupRequest struct {
+	Ref string `path:"usergroup_ref"`
+}
+
+type getUserGroupRequest struct {
+	userGroupRequest
+}
+
+type updateUserGroupRequest struct {
+	userGroupRequest
+	usergroup.UpdateInput
+}
+
+type addUserGroupMemberRequest struct {
+	userGroupRequest
+	usergroup.MemberAddInput
+}
+
+type deleteUserGroupMemberRequest struct {
+	userGroupRequest
+	UserUID string `path:"user_uid"`
+}
+
+func userGroupOperations(reflector *openapi3.Reflector) {
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("usergroup")
+	opCreate.WithMapOfAnything(map[string]interface{}{"operationId": "createUserGroup"})
+	_ = reflector.SetRequest(&opCreate, new(createUserGroupRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.UserGroup), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/usergroups", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("usergroup")
+	opFind.WithMapOfAnything(map[string]interface{}{"operationId": "findUserGroup"})
+	_ = reflector.SetRequest(&opFind, new(getUserGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.UserGroup), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/usergroups/{usergroup_ref}", opFind)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("usergroup")
+	opDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserGroup"})
+	_ = reflector.SetRequest(&opDelete, new(getUserGroupRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, "/usergroups/{usergroup_ref}", opDelete)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("usergroup")
+	opUpdate.WithMapOfAnything(map[string]interface{}{"operationId": "updateUserGroup"})
+	_ = reflector.SetRequest(&opUpdate, new(updateUserGroupRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.UserGroup), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/usergroups/{usergroup_ref}", opUpdate)
+
+	opMemberList := openapi3.Operation{}
+	opMemberList.WithTags("usergroup")
+	opMemberList.WithMapOfAnything(map[string]interface{}{"operationId": "listUserGroupMembers"})
+	opMemberList.WithParameters(queryParameterQueryPrincipals, queryParameterPage, queryParameterLimit)
+	_ = reflector.SetRequest(&opMemberList, new(getUserGroupRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opMemberList, []types.UserGroupMember{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberList, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/usergroups/{usergroup_ref}/members", opMemberList)
+
+	opMemberAdd := openapi3.Operation{}
+	opMemberAdd.WithTags("usergroup")
+	opMemberAdd.WithMapOfAnything(map[string]interface{}{"operationId": "addUserGroupMember"})
+	_ = reflector.SetRequest(&opMemberAdd, new(addUserGroupMemberRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(types.UserGroupMember), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberAdd, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/usergroups/{usergroup_ref}/members", opMemberAdd)
+
+	opMemberDelete := openapi3.Operation{}
+	opMemberDelete.WithTags("usergroup")
+	opMemberDelete.WithMapOfAnything(map[string]interface{}{"operationId": "deleteUserGroupMember"})
+	_ = reflector.SetRequest(&opMemberDelete, new(deleteUserGroupMemberRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opMemberDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opMemberDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/usergroups/{usergroup_ref}/members/{user_uid}", opMemberDelete)
+}
@@ -25,6 +25,7 @@ const (
 	PathParamStageNumber     = "stage_number"
 	PathParamStepNumber      = "step_number"
 	PathParamTriggerUID      = "trigger_uid"
+	PathParamArtifactID      = "artifact_id"
 	QueryParamLatest         = "latest"
 	QueryParamBranch         = "branch"
 )
@@ -55,6 +56,10 @@ func GetStepNumberFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamStepNumber)
 }
 
+func GetArtifactIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamArtifactID)
+}
+
 func GetLatestFromPath(r *http.Request) bool {
 	v, _ := QueryParam(r, QueryParamLatest)
 	return v == "true"
@@ -21,6 +21,8 @@ import (
 
 const (
 	PathParamSecretRef = "secret_ref"
+
+	QueryParamInherited = "inherited"
 )
 
 func GetSecretRefFromPath(r *http.Request) (string, error) {
@@ -32,3 +34,8 @@ func GetSecretRefFromPath(r *http.Request) (string, error) {
 	// paths are unescaped
 	return url.PathUnescape(rawRef)
 }
+
+// ParseInheritedFromQuery extracts the inherited parameter from the URL query.
+func ParseInheritedFromQuery(r *http.Request) (bool, error) {
+	return QueryParamAsBoolOrDefault(r, QueryParamInherited, false)
+}
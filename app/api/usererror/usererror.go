@@ -70,6 +70,10 @@ var (
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrMirrorRefsCantBeModified is returned if a user tries to push branches or tags to a mirror repository.
+	ErrMirrorRefsCantBeModified = New(http.StatusBadRequest,
+		"Branches and tags of a mirror repository can't be modified, they are synced from the upstream repository")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
@@ -118,8 +118,10 @@ func (a *JWTAuthenticator) metadataFromTokenClaims(
 	}
 
 	return &auth.TokenMetadata{
-		TokenType: tkn.Type,
-		TokenID:   tkn.ID,
+		TokenType:   tkn.Type,
+		TokenID:     tkn.ID,
+		Permissions: tkn.Permissions,
+		Paths:       tkn.Paths,
 	}, nil
 }
 
@@ -72,6 +72,17 @@ func (a *MembershipAuthorizer) Check(
 		session.Metadata,
 	)
 
+	// restricted tokens can't exceed their restrictions - not even for system admins.
+	tokenMetadata, isTokenMetadata := session.Metadata.(*auth.TokenMetadata)
+	if isTokenMetadata && !tokenAllows(tokenMetadata, scope, resource, permission) {
+		log.Ctx(ctx).Debug().Msgf(
+			"[MembershipAuthorizer] %s is outside of the restrictions of token %d",
+			permission,
+			tokenMetadata.TokenID,
+		)
+		return false, nil
+	}
+
 	if session.Principal.Admin {
 		return true, nil // system admin can call any API
 	}
@@ -115,6 +126,10 @@ func (a *MembershipAuthorizer) Check(
 	case enum.ResourceTypeService:
 		return false, nil
 
+	// Runners are managed by admins only
+	case enum.ResourceTypeRunner:
+		return false, nil
+
 	default:
 		return false, nil
 	}
@@ -125,7 +140,8 @@ func (a *MembershipAuthorizer) Check(
 	}
 
 	// ensure we aren't bypassing unknown metadata with impact on authorization
-	if session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
+	// (token restrictions were already verified above).
+	if !isTokenMetadata && session.Metadata != nil && session.Metadata.ImpactsAuthorization() {
 		return false, fmt.Errorf("session contains unknown metadata that impacts authorization: %T", session.Metadata)
 	}
 
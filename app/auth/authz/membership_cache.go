@@ -40,17 +40,20 @@ type PermissionCache cache.Cache[PermissionCacheKey, bool]
 func NewPermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	membershipUserGroupStore store.MembershipUserGroupStore,
 	cacheDuration time.Duration,
 ) PermissionCache {
 	return cache.New[PermissionCacheKey, bool](permissionCacheGetter{
-		spaceStore:      spaceStore,
-		membershipStore: membershipStore,
+		spaceStore:               spaceStore,
+		membershipStore:          membershipStore,
+		membershipUserGroupStore: membershipUserGroupStore,
 	}, cacheDuration)
 }
 
 type permissionCacheGetter struct {
-	spaceStore      store.SpaceStore
-	membershipStore store.MembershipStore
+	spaceStore               store.SpaceStore
+	membershipStore          store.MembershipStore
+	membershipUserGroupStore store.MembershipUserGroupStore
 }
 
 func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey) (bool, error) {
@@ -82,6 +85,18 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 			return true, nil
 		}
 
+		// The principal inherits the roles of all user groups it's a member of.
+		groupRoles, err := g.membershipUserGroupStore.ListRoles(ctx, space.ID, principalID)
+		if err != nil {
+			return false, fmt.Errorf("failed to list user group roles: %w", err)
+		}
+
+		for _, role := range groupRoles {
+			if roleHasPermission(role, key.Permission) {
+				return true, nil
+			}
+		}
+
 		// If membership with the requested permission has not been found in the current space,
 		// move to the parent space, if any.
 
@@ -89,9 +104,10 @@ func (g permissionCacheGetter) Find(ctx context.Context, key PermissionCacheKey)
 			return false, nil
 		}
 
-		space, err = g.spaceStore.Find(ctx, space.ParentID)
+		parentID := space.ParentID
+		space, err = g.spaceStore.Find(ctx, parentID)
 		if err != nil {
-			return false, fmt.Errorf("failed to find parent space with id %d: %w", space.ParentID, err)
+			return false, fmt.Errorf("failed to find parent space with id %d: %w", parentID, err)
 		}
 	}
 
@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type spaceStoreMock struct {
+	store.SpaceStore
+	spaces []*types.Space
+}
+
+func (s spaceStoreMock) Find(_ context.Context, id int64) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.ID == id {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s spaceStoreMock) FindByRef(_ context.Context, spaceRef string) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.Path == spaceRef {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type membershipStoreMock struct {
+	store.MembershipStore
+	memberships map[types.MembershipKey]enum.MembershipRole
+}
+
+func (s membershipStoreMock) Find(_ context.Context, key types.MembershipKey) (*types.Membership, error) {
+	role, ok := s.memberships[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Membership{MembershipKey: key, Role: role}, nil
+}
+
+type membershipUserGroupStoreMock struct {
+	store.MembershipUserGroupStore
+	// roles maps the space id to the roles of the groups of the principal in the space.
+	roles map[int64][]enum.MembershipRole
+}
+
+func (s membershipUserGroupStoreMock) ListRoles(
+	_ context.Context,
+	spaceID int64,
+	_ int64,
+) ([]enum.MembershipRole, error) {
+	return s.roles[spaceID], nil
+}
+
+func TestPermissionCacheGetter_UserGroupRoles(t *testing.T) {
+	const principalID = 42
+
+	spaces := []*types.Space{
+		{ID: 1, Path: "root"},
+		{ID: 2, ParentID: 1, Path: "root/team"},
+	}
+
+	tests := []struct {
+		name        string
+		memberships map[types.MembershipKey]enum.MembershipRole
+		groupRoles  map[int64][]enum.MembershipRole
+		spaceRef    string
+		want        bool
+	}{
+		{
+			name:     "no-membership",
+			spaceRef: "root/team",
+			want:     false,
+		},
+		{
+			name:       "group-role-in-space",
+			groupRoles: map[int64][]enum.MembershipRole{2: {enum.MembershipRoleContributor}},
+			spaceRef:   "root/team",
+			want:       true,
+		},
+		{
+			name:       "group-role-in-ancestor",
+			groupRoles: map[int64][]enum.MembershipRole{1: {enum.MembershipRoleContributor}},
+			spaceRef:   "root/team",
+			want:       true,
+		},
+		{
+			name:       "group-role-without-permission",
+			groupRoles: map[int64][]enum.MembershipRole{2: {enum.MembershipRoleReader}},
+			spaceRef:   "root/team",
+			want:       false,
+		},
+		{
+			name: "direct-reader-and-group-contributor",
+			memberships: map[types.MembershipKey]enum.MembershipRole{
+				{SpaceID: 2, PrincipalID: principalID}: enum.MembershipRoleReader,
+			},
+			groupRoles: map[int64][]enum.MembershipRole{2: {enum.MembershipRoleReader, enum.MembershipRoleContributor}},
+			spaceRef:   "root/team",
+			want:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			getter := permissionCacheGetter{
+				spaceStore:               spaceStoreMock{spaces: spaces},
+				membershipStore:          membershipStoreMock{memberships: test.memberships},
+				membershipUserGroupStore: membershipUserGroupStoreMock{roles: test.groupRoles},
+			}
+
+			got, err := getter.Find(context.Background(), PermissionCacheKey{
+				PrincipalID: principalID,
+				SpaceRef:    test.spaceRef,
+				Permission:  enum.PermissionRepoPush,
+			})
+			if err != nil {
+				t.Fatalf("failed to check permission: %v", err)
+			}
+
+			if got != test.want {
+				t.Errorf("want=%t got=%t", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"golang.org/x/exp/slices"
+)
+
+// tokenAllows returns true if the requested permission is within the restrictions of the token.
+// The restrictions only ever reduce access - the principal still requires the permission itself.
+func tokenAllows(
+	tokenMetadata *auth.TokenMetadata,
+	scope *types.Scope,
+	resource *types.Resource,
+	permission enum.Permission,
+) bool {
+	permissionListed := slices.Contains(tokenMetadata.Permissions, permission)
+	if len(tokenMetadata.Permissions) > 0 && !permissionListed {
+		return false
+	}
+
+	if len(tokenMetadata.Paths) == 0 {
+		return true
+	}
+
+	resourcePath, ok := resourcePathOf(scope, resource)
+	if !ok {
+		// resources outside of the space hierarchy (e.g. the user itself) are only accessible
+		// by a path restricted token if the permission is explicitly granted.
+		return permissionListed
+	}
+
+	for _, allowedPath := range tokenMetadata.Paths {
+		if isPathOrChildOf(resourcePath, allowedPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TokenAllowsRestrictions returns true if a token with the provided restrictions can't be used for anything
+// the restricted token can't be used for. Restrictions of the token can only ever be narrowed.
+func TokenAllowsRestrictions(tokenMetadata *auth.TokenMetadata, permissions []enum.Permission, paths []string) bool {
+	if len(tokenMetadata.Permissions) > 0 {
+		if len(permissions) == 0 {
+			return false
+		}
+		for _, permission := range permissions {
+			if !slices.Contains(tokenMetadata.Permissions, permission) {
+				return false
+			}
+		}
+	}
+
+	if len(tokenMetadata.Paths) > 0 {
+		if len(paths) == 0 {
+			return false
+		}
+		for _, path := range paths {
+			if !slices.ContainsFunc(tokenMetadata.Paths, func(allowedPath string) bool {
+				return isPathOrChildOf(path, allowedPath)
+			}) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// resourcePathOf returns the path of the resource within the space hierarchy.
+// For resources that are children of a space or repo the path of the parent is returned.
+func resourcePathOf(scope *types.Scope, resource *types.Resource) (string, bool) {
+	//nolint:exhaustive // resources not listed aren't part of the space hierarchy
+	switch resource.Type {
+	case enum.ResourceTypeSpace, enum.ResourceTypeRepo:
+		return paths.Concatinate(scope.SpacePath, resource.Name), true
+
+	case enum.ResourceTypeServiceAccount,
+		enum.ResourceTypePipeline,
+		enum.ResourceTypeSecret,
+		enum.ResourceTypeConnector,
+		enum.ResourceTypeTemplate:
+		if scope.Repo != "" {
+			return paths.Concatinate(scope.SpacePath, scope.Repo), true
+		}
+		return scope.SpacePath, true
+
+	default:
+		return "", false
+	}
+}
+
+// isPathOrChildOf returns true if the path is equal to the parent path or located below it (case insensitive).
+func isPathOrChildOf(path string, parent string) bool {
+	path = strings.ToLower(strings.Trim(path, types.PathSeparator))
+	parent = strings.ToLower(strings.Trim(parent, types.PathSeparator))
+
+	return path == parent || strings.HasPrefix(path, parent+types.PathSeparator)
+}
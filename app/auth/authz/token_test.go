@@ -0,0 +1,204 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authz
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestTokenAllows(t *testing.T) {
+	repo := &types.Resource{Type: enum.ResourceTypeRepo, Name: "api"}
+	repoScope := &types.Scope{SpacePath: "acme/backend"}
+
+	tests := []struct {
+		name       string
+		metadata   auth.TokenMetadata
+		scope      *types.Scope
+		resource   *types.Resource
+		permission enum.Permission
+		want       bool
+	}{
+		{
+			name:       "unrestricted",
+			scope:      repoScope,
+			resource:   repo,
+			permission: enum.PermissionRepoPush,
+			want:       true,
+		},
+		{
+			name:       "listed permission",
+			metadata:   auth.TokenMetadata{Permissions: []enum.Permission{enum.PermissionRepoView}},
+			scope:      repoScope,
+			resource:   repo,
+			permission: enum.PermissionRepoView,
+			want:       true,
+		},
+		{
+			name:       "permission not listed",
+			metadata:   auth.TokenMetadata{Permissions: []enum.Permission{enum.PermissionRepoView}},
+			scope:      repoScope,
+			resource:   repo,
+			permission: enum.PermissionRepoPush,
+			want:       false,
+		},
+		{
+			name:       "repo path",
+			metadata:   auth.TokenMetadata{Paths: []string{"acme/backend/api"}},
+			scope:      repoScope,
+			resource:   repo,
+			permission: enum.PermissionRepoPush,
+			want:       true,
+		},
+		{
+			name:       "parent space path case insensitive",
+			metadata:   auth.TokenMetadata{Paths: []string{"ACME"}},
+			scope:      repoScope,
+			resource:   repo,
+			permission: enum.PermissionRepoView,
+			want:       true,
+		},
+		{
+			name:       "sibling repo with same prefix",
+			metadata:   auth.TokenMetadata{Paths: []string{"acme/backend/ap"}},
+			scope:      repoScope,
+			resource:   repo,
+			permission: enum.PermissionRepoView,
+			want:       false,
+		},
+		{
+			name:       "pipeline of allowed repo",
+			metadata:   auth.TokenMetadata{Paths: []string{"acme/backend/api"}},
+			scope:      &types.Scope{SpacePath: "acme/backend", Repo: "api"},
+			resource:   &types.Resource{Type: enum.ResourceTypePipeline, Name: "build"},
+			permission: enum.PermissionPipelineExecute,
+			want:       true,
+		},
+		{
+			name:       "secret of parent space",
+			metadata:   auth.TokenMetadata{Paths: []string{"acme/backend/api"}},
+			scope:      &types.Scope{SpacePath: "acme/backend"},
+			resource:   &types.Resource{Type: enum.ResourceTypeSecret, Name: "key"},
+			permission: enum.PermissionSecretView,
+			want:       false,
+		},
+		{
+			name:       "user resource with path restriction",
+			metadata:   auth.TokenMetadata{Paths: []string{"acme"}},
+			scope:      &types.Scope{},
+			resource:   &types.Resource{Type: enum.ResourceTypeUser, Name: "jane"},
+			permission: enum.PermissionUserEdit,
+			want:       false,
+		},
+		{
+			name: "user resource with explicit permission",
+			metadata: auth.TokenMetadata{
+				Paths:       []string{"acme"},
+				Permissions: []enum.Permission{enum.PermissionUserView},
+			},
+			scope:      &types.Scope{},
+			resource:   &types.Resource{Type: enum.ResourceTypeUser, Name: "jane"},
+			permission: enum.PermissionUserView,
+			want:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := test.metadata
+			if got := tokenAllows(&metadata, test.scope, test.resource, test.permission); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTokenAllowsRestrictions(t *testing.T) {
+	restricted := auth.TokenMetadata{
+		Permissions: []enum.Permission{enum.PermissionRepoView, enum.PermissionUserEdit},
+		Paths:       []string{"acme/backend"},
+	}
+
+	tests := []struct {
+		name        string
+		metadata    auth.TokenMetadata
+		permissions []enum.Permission
+		paths       []string
+		want        bool
+	}{
+		{
+			name: "unrestricted token allows unrestricted token",
+			want: true,
+		},
+		{
+			name:     "restricted token doesn't allow unrestricted token",
+			metadata: restricted,
+			want:     false,
+		},
+		{
+			name:        "same restrictions",
+			metadata:    restricted,
+			permissions: []enum.Permission{enum.PermissionRepoView, enum.PermissionUserEdit},
+			paths:       []string{"acme/backend"},
+			want:        true,
+		},
+		{
+			name:        "narrower restrictions",
+			metadata:    restricted,
+			permissions: []enum.Permission{enum.PermissionRepoView},
+			paths:       []string{"ACME/backend/api"},
+			want:        true,
+		},
+		{
+			name:        "additional permission",
+			metadata:    restricted,
+			permissions: []enum.Permission{enum.PermissionRepoView, enum.PermissionRepoPush},
+			paths:       []string{"acme/backend"},
+			want:        false,
+		},
+		{
+			name:        "missing paths",
+			metadata:    restricted,
+			permissions: []enum.Permission{enum.PermissionRepoView},
+			want:        false,
+		},
+		{
+			name:        "parent path",
+			metadata:    restricted,
+			permissions: []enum.Permission{enum.PermissionRepoView},
+			paths:       []string{"acme"},
+			want:        false,
+		},
+		{
+			name:        "only permissions restricted",
+			metadata:    auth.TokenMetadata{Permissions: []enum.Permission{enum.PermissionRepoView}},
+			permissions: []enum.Permission{enum.PermissionRepoView},
+			paths:       []string{"acme"},
+			want:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			metadata := test.metadata
+			if got := TokenAllowsRestrictions(&metadata, test.permissions, test.paths); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
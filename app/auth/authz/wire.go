@@ -35,7 +35,8 @@ func ProvideAuthorizer(pCache PermissionCache, spaceStore store.SpaceStore) Auth
 func ProvidePermissionCache(
 	spaceStore store.SpaceStore,
 	membershipStore store.MembershipStore,
+	membershipUserGroupStore store.MembershipUserGroupStore,
 ) PermissionCache {
 	const permissionCacheTimeout = time.Second * 15
-	return NewPermissionCache(spaceStore, membershipStore, permissionCacheTimeout)
+	return NewPermissionCache(spaceStore, membershipStore, membershipUserGroupStore, permissionCacheTimeout)
 }
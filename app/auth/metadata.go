@@ -31,9 +31,22 @@ func (m *EmptyMetadata) ImpactsAuthorization() bool {
 type TokenMetadata struct {
 	TokenType enum.TokenType
 	TokenID   int64
+
+	// Permissions and Paths optionally restrict what the token can be used for.
+	Permissions []enum.Permission
+	Paths       []string
 }
 
 func (m *TokenMetadata) ImpactsAuthorization() bool {
+	return len(m.Permissions) > 0 || len(m.Paths) > 0
+}
+
+// PublicKeyMetadata contains information about the ssh public key that was used during auth.
+type PublicKeyMetadata struct {
+	PublicKeyID int64
+}
+
+func (m *PublicKeyMetadata) ImpactsAuthorization() bool {
 	return false
 }
 
@@ -0,0 +1,243 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	testClientID = "gitness"
+	testCode     = "auth-code"
+	testKeyID    = "key-1"
+)
+
+// mockIdP is a minimal OpenID Connect identity provider serving discovery, keys and token endpoints.
+type mockIdP struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	idp := &mockIdP{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(discoveryDocument{
+			Issuer:                idp.server.URL,
+			AuthorizationEndpoint: idp.server.URL + "/authorize",
+			TokenEndpoint:         idp.server.URL + "/token",
+			JWKSURI:               idp.server.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jsonWebKeySet{Keys: []jsonWebKey{{
+			Kty: "RSA",
+			Kid: testKeyID,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("code") != testCode {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims)
+		token.Header["kid"] = testKeyID
+		idToken, err := token.SignedString(idp.key)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     idToken,
+		})
+	})
+
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *mockIdP) provider() *Provider {
+	return NewProvider(Config{
+		Issuer:      idp.server.URL,
+		ClientID:    testClientID,
+		RedirectURL: "http://localhost:3000/api/v1/oidc/callback",
+		Scopes:      []string{"openid", "email"},
+	}, idp.server.Client())
+}
+
+func (idp *mockIdP) validClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":                idp.server.URL,
+		"aud":                testClientID,
+		"sub":                "1234",
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"nonce":              nonce,
+		"preferred_username": "jane",
+		"email":              "jane@example.com",
+		"groups":             []string{"developers", "ops"},
+	}
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	idp := newMockIdP(t)
+
+	raw, err := idp.provider().AuthCodeURL(context.Background(), "state", "nonce")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("failed to parse url: %s", err)
+	}
+
+	if got, want := u.Path, "/authorize"; got != want {
+		t.Errorf("path: got %q, want %q", got, want)
+	}
+	for name, want := range map[string]string{
+		"client_id":     testClientID,
+		"state":         "state",
+		"nonce":         "nonce",
+		"response_type": "code",
+		"scope":         "openid email",
+	} {
+		if got := u.Query().Get(name); got != want {
+			t.Errorf("query param %s: got %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	idp := newMockIdP(t)
+
+	tests := []struct {
+		name    string
+		code    string
+		modify  func(claims jwt.MapClaims)
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			code: testCode,
+		},
+		{
+			name:    "invalid code",
+			code:    "unknown",
+			wantErr: true,
+		},
+		{
+			name:    "wrong audience",
+			code:    testCode,
+			modify:  func(claims jwt.MapClaims) { claims["aud"] = "other" },
+			wantErr: true,
+		},
+		{
+			name:    "wrong issuer",
+			code:    testCode,
+			modify:  func(claims jwt.MapClaims) { claims["iss"] = "https://evil.example.com" },
+			wantErr: true,
+		},
+		{
+			name:    "wrong nonce",
+			code:    testCode,
+			modify:  func(claims jwt.MapClaims) { claims["nonce"] = "other" },
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			code:    testCode,
+			modify:  func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			idp.claims = idp.validClaims("nonce")
+			if test.modify != nil {
+				test.modify(idp.claims)
+			}
+
+			claims, err := idp.provider().Exchange(context.Background(), test.code, "nonce")
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, want := claims.String("preferred_username"), "jane"; got != want {
+				t.Errorf("uid: got %q, want %q", got, want)
+			}
+			if got := claims.Strings("groups"); len(got) != 2 || got[0] != "developers" || got[1] != "ops" {
+				t.Errorf("unexpected groups: %v", got)
+			}
+		})
+	}
+}
+
+func TestProvider_ExchangeInvalidSignature(t *testing.T) {
+	idp := newMockIdP(t)
+	idp.claims = idp.validClaims("nonce")
+
+	// sign with a key unknown to the key set.
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+	idp.key = other
+
+	_, err = idp.provider().Exchange(context.Background(), testCode, "nonce")
+	if !errors.Is(err, ErrInvalidIDToken) {
+		t.Fatalf("expected invalid id token error, got: %v", err)
+	}
+}
@@ -45,3 +45,29 @@ func (r *Reader) RegisterRepoDeleted(fn events.HandlerFunc[*DeletedPayload],
 	opts ...events.HandlerOption) error {
 	return events.ReaderRegisterEvent(r.innerReader, DeletedEvent, fn, opts...)
 }
+
+const DefaultBranchUpdatedEvent events.EventType = "default-branch-updated"
+
+type DefaultBranchUpdatedPayload struct {
+	RepoID    int64  `json:"repo_id"`
+	OldBranch string `json:"old_branch"`
+	NewBranch string `json:"new_branch"`
+}
+
+func (r *Reporter) DefaultBranchUpdated(ctx context.Context, payload *DefaultBranchUpdatedPayload) {
+	if payload == nil {
+		return
+	}
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, DefaultBranchUpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo default branch updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo default branch updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterDefaultBranchUpdated(fn events.HandlerFunc[*DefaultBranchUpdatedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, DefaultBranchUpdatedEvent, fn, opts...)
+}
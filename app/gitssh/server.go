@@ -0,0 +1,261 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gitssh implements an ssh server that serves git's transport protocol.
+package gitssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// permissionsExtensionPublicKeyID is used to pass the id of the public key
+	// from the authentication callback to the session.
+	permissionsExtensionPublicKeyID = "gitness-public-key-id"
+
+	// permissionsExtensionPrincipalID is used to pass the id of the authenticated principal
+	// from the authentication callback to the session.
+	permissionsExtensionPrincipalID = "gitness-principal-id"
+
+	// handshakeTimeout is the maximum time a client has to complete the ssh handshake.
+	handshakeTimeout = 30 * time.Second
+)
+
+type Config struct {
+	Port int
+	// HostKeyPath is the path of the PEM encoded private host key of the server.
+	// In case the file doesn't exist, a new ed25519 key is generated and stored at the path.
+	HostKeyPath string
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.Port <= 0 {
+		return errors.New("config.Port has to be a positive number")
+	}
+	if c.HostKeyPath == "" {
+		return errors.New("config.HostKeyPath is required")
+	}
+	return nil
+}
+
+// ShutdownFunction defines a function that is called to shutdown the server.
+type ShutdownFunction func(context.Context) error
+
+// Server is an ssh server that serves git-upload-pack and git-receive-pack for gitness repositories.
+// Clients are authenticated via the fingerprint of the public keys registered by the users.
+type Server struct {
+	config         Config
+	sshConfig      *ssh.ServerConfig
+	principalStore store.PrincipalStore
+	publicKeyStore store.PublicKeyStore
+	repoCtrl       *repo.Controller
+}
+
+func NewServer(
+	config Config,
+	principalStore store.PrincipalStore,
+	publicKeyStore store.PublicKeyStore,
+	repoCtrl *repo.Controller,
+) (*Server, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided git ssh config is invalid: %w", err)
+	}
+
+	s := &Server{
+		config:         config,
+		principalStore: principalStore,
+		publicKeyStore: publicKeyStore,
+		repoCtrl:       repoCtrl,
+	}
+
+	s.sshConfig = &ssh.ServerConfig{
+		PublicKeyCallback: s.authenticate,
+	}
+
+	return s, nil
+}
+
+// ListenAndServe starts accepting ssh connections on the configured port.
+// NOTE: The host key is loaded (or generated) lazily to not create it in case the server isn't started.
+func (s *Server) ListenAndServe() (*errgroup.Group, ShutdownFunction) {
+	var (
+		g       errgroup.Group
+		conns   sync.WaitGroup
+		closeMx sync.Mutex
+		closed  bool
+		lis     net.Listener
+	)
+
+	// ctx is canceled in case the shutdown didn't complete in time to abort all running git operations.
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = log.Logger.With().Str("server", "ssh").Logger().WithContext(ctx)
+
+	g.Go(func() error {
+		hostKey, err := loadOrGenerateHostKey(s.config.HostKeyPath)
+		if err != nil {
+			return fmt.Errorf("failed to load host key: %w", err)
+		}
+		s.sshConfig.AddHostKey(hostKey)
+
+		l, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.Port))
+		if err != nil {
+			return fmt.Errorf("failed to listen on port %d: %w", s.config.Port, err)
+		}
+
+		closeMx.Lock()
+		if closed {
+			closeMx.Unlock()
+			_ = l.Close()
+			return nil
+		}
+		lis = l
+		closeMx.Unlock()
+
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				closeMx.Lock()
+				isClosed := closed
+				closeMx.Unlock()
+				if isClosed {
+					return nil
+				}
+
+				return fmt.Errorf("failed to accept connection: %w", err)
+			}
+
+			conns.Add(1)
+			go func() {
+				defer conns.Done()
+				s.handleConn(ctx, conn)
+			}()
+		}
+	})
+
+	shutdown := func(shutdownCtx context.Context) error {
+		closeMx.Lock()
+		if !closed {
+			closed = true
+			if lis != nil {
+				_ = lis.Close()
+			}
+		}
+		closeMx.Unlock()
+
+		done := make(chan struct{})
+		go func() {
+			conns.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			cancel()
+			return nil
+		case <-shutdownCtx.Done():
+			cancel()
+			return shutdownCtx.Err()
+		}
+	}
+
+	return &g, shutdown
+}
+
+// authenticate authenticates the client via the fingerprint of the provided public key.
+func (s *Server) authenticate(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	ctx := context.Background()
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	publicKey, err := s.publicKeyStore.FindByFingerprint(ctx, fingerprint)
+	if err != nil {
+		log.Debug().Err(err).
+			Str("remote_addr", conn.RemoteAddr().String()).
+			Str("fingerprint", fingerprint).
+			Msg("ssh authentication failed")
+		return nil, errors.New("unknown public key")
+	}
+
+	principal, err := s.principalStore.Find(ctx, publicKey.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal of public key: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, errors.New("principal is blocked")
+	}
+
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			permissionsExtensionPublicKeyID: strconv.FormatInt(publicKey.ID, 10),
+			permissionsExtensionPrincipalID: strconv.FormatInt(principal.ID, 10),
+		},
+	}, nil
+}
+
+func (s *Server) handleConn(ctx context.Context, netConn net.Conn) {
+	_ = netConn.SetDeadline(time.Now().Add(handshakeTimeout))
+
+	conn, chans, reqs, err := ssh.NewServerConn(netConn, s.sshConfig)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Str("remote_addr", netConn.RemoteAddr().String()).
+			Msg("ssh handshake failed")
+		_ = netConn.Close()
+		return
+	}
+	defer conn.Close()
+
+	// remove the handshake deadline - git operations can take arbitrarily long.
+	_ = netConn.SetDeadline(time.Time{})
+
+	go ssh.DiscardRequests(reqs)
+
+	var wg sync.WaitGroup
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to accept ssh channel")
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.handleSession(ctx, conn.Permissions, channel, requests)
+		}()
+	}
+
+	wg.Wait()
+}
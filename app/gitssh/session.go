@@ -0,0 +1,203 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitssh
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+const envGitProtocol = "GIT_PROTOCOL"
+
+// handleSession handles a single ssh session. Only "env" and "exec" requests are supported,
+// the session is closed after the first executed command.
+func (s *Server) handleSession(
+	ctx context.Context,
+	permissions *ssh.Permissions,
+	channel ssh.Channel,
+	requests <-chan *ssh.Request,
+) {
+	defer channel.Close()
+
+	gitProtocol := ""
+	for req := range requests {
+		switch req.Type {
+		case "env":
+			name, value, ok := parseEnvPayload(req.Payload)
+			if ok && name == envGitProtocol {
+				gitProtocol = value
+			}
+			_ = req.Reply(ok && name == envGitProtocol, nil)
+
+		case "exec":
+			command, ok := parseStringPayload(req.Payload)
+			if !ok {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			_ = req.Reply(true, nil)
+
+			status := uint32(0)
+			err := s.exec(ctx, permissions, command, gitProtocol, channel)
+			if err != nil {
+				log.Ctx(ctx).Debug().Err(err).Str("command", command).Msg("failed to execute ssh command")
+				_, _ = fmt.Fprintf(channel.Stderr(), "error: %s\n", err)
+				status = 1
+			}
+
+			_ = channel.CloseWrite()
+			_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+			return
+
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+// exec executes the git command requested by the client.
+func (s *Server) exec(
+	ctx context.Context,
+	permissions *ssh.Permissions,
+	command string,
+	gitProtocol string,
+	channel ssh.Channel,
+) error {
+	service, repoRef, err := parseGitCommand(command)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.createSession(ctx, permissions)
+	if err != nil {
+		return err
+	}
+
+	// ssh keeps a bidirectional connection, so the pack is served without stateless-rpc.
+	return s.repoCtrl.GitServicePack(ctx, session, repoRef, service, gitProtocol, false, channel, channel)
+}
+
+// createSession creates the auth session of the principal that was authenticated during the ssh handshake.
+func (s *Server) createSession(ctx context.Context, permissions *ssh.Permissions) (*auth.Session, error) {
+	if permissions == nil {
+		return nil, errors.New("connection isn't authenticated")
+	}
+
+	principalID, err := strconv.ParseInt(permissions.Extensions[permissionsExtensionPrincipalID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid principal id: %w", err)
+	}
+
+	publicKeyID, err := strconv.ParseInt(permissions.Extensions[permissionsExtensionPublicKeyID], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key id: %w", err)
+	}
+
+	// load the principal again to get the latest state (the connection might be long-lived).
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	if principal.Blocked {
+		return nil, errors.New("principal is blocked")
+	}
+
+	return &auth.Session{
+		Principal: *principal,
+		Metadata: &auth.PublicKeyMetadata{
+			PublicKeyID: publicKeyID,
+		},
+	}, nil
+}
+
+// parseGitCommand parses the command sent by git (e.g. "git-upload-pack '/space/repo.git'")
+// and returns the requested service and the path of the repository.
+func parseGitCommand(command string) (enum.GitServiceType, string, error) {
+	verb, arg, ok := strings.Cut(strings.TrimSpace(command), " ")
+	if !ok {
+		return "", "", fmt.Errorf("unsupported command %q", command)
+	}
+
+	var service enum.GitServiceType
+	switch verb {
+	case "git-upload-pack":
+		service = enum.GitServiceTypeUploadPack
+	case "git-receive-pack":
+		service = enum.GitServiceTypeReceivePack
+	default:
+		return "", "", fmt.Errorf("unsupported command %q", verb)
+	}
+
+	repoRef := strings.Trim(strings.TrimSpace(arg), "'\"")
+	repoRef = strings.Trim(repoRef, "/")
+	repoRef = strings.TrimSuffix(repoRef, url.GITSuffix)
+	if repoRef == "" {
+		return "", "", errors.New("repository path is missing")
+	}
+
+	return service, repoRef, nil
+}
+
+// parseStringPayload parses a payload consisting of a single ssh string.
+func parseStringPayload(payload []byte) (string, bool) {
+	value, rest, ok := readString(payload)
+	if !ok || len(rest) != 0 {
+		return "", false
+	}
+
+	return value, true
+}
+
+// parseEnvPayload parses the payload of an "env" request consisting of name and value.
+func parseEnvPayload(payload []byte) (string, string, bool) {
+	name, rest, ok := readString(payload)
+	if !ok {
+		return "", "", false
+	}
+
+	value, rest, ok := readString(rest)
+	if !ok || len(rest) != 0 {
+		return "", "", false
+	}
+
+	return name, value, true
+}
+
+// readString reads a length prefixed string as defined by the ssh wire format.
+func readString(data []byte) (string, []byte, bool) {
+	if len(data) < 4 {
+		return "", nil, false
+	}
+
+	length := binary.BigEndian.Uint32(data)
+	data = data[4:]
+	if uint32(len(data)) < length {
+		return "", nil, false
+	}
+
+	return string(data[:length]), data[length:], true
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitssh
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestParseGitCommand(t *testing.T) {
+	tests := []struct {
+		name            string
+		command         string
+		expectedService enum.GitServiceType
+		expectedRepoRef string
+		expectErr       bool
+	}{
+		{
+			name:            "upload-pack",
+			command:         "git-upload-pack '/space/repo.git'",
+			expectedService: enum.GitServiceTypeUploadPack,
+			expectedRepoRef: "space/repo",
+		},
+		{
+			name:            "receive-pack without suffix",
+			command:         "git-receive-pack 'space/sub/repo'",
+			expectedService: enum.GitServiceTypeReceivePack,
+			expectedRepoRef: "space/sub/repo",
+		},
+		{
+			name:      "unsupported command",
+			command:   "ls -la",
+			expectErr: true,
+		},
+		{
+			name:      "missing path",
+			command:   "git-upload-pack ''",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, repoRef, err := parseGitCommand(test.command)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got service %q and repo %q", service, repoRef)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if service != test.expectedService || repoRef != test.expectedRepoRef {
+				t.Errorf("expected (%q, %q), got (%q, %q)",
+					test.expectedService, test.expectedRepoRef, service, repoRef)
+			}
+		})
+	}
+}
@@ -36,6 +36,9 @@ type Claims struct {
 
 	Token      *SubClaimsToken      `json:"tkn,omitempty"`
 	Membership *SubClaimsMembership `json:"ms,omitempty"`
+	Stage      *SubClaimsStage      `json:"stg,omitempty"`
+	OIDCLink   *SubClaimsOIDCLink   `json:"oidc,omitempty"`
+	Email      *SubClaimsEmail      `json:"eml,omitempty"`
 }
 
 // SubClaimsToken contains information about the token the JWT was created for.
@@ -50,6 +53,21 @@ type SubClaimsMembership struct {
 	SpaceID int64               `json:"sid,omitempty"`
 }
 
+// SubClaimsStage contains the pipeline stage the JWT was created for.
+type SubClaimsStage struct {
+	ID int64 `json:"id,omitempty"`
+}
+
+// SubClaimsOIDCLink contains the OIDC login state the JWT was created for.
+type SubClaimsOIDCLink struct {
+	State string `json:"st,omitempty"`
+}
+
+// SubClaimsEmail contains the email the JWT was created to verify.
+type SubClaimsEmail struct {
+	Address string `json:"addr,omitempty"`
+}
+
 // GenerateForToken generates a jwt for a given token.
 func GenerateForToken(token *types.Token, secret string) (string, error) {
 	var expiresAt int64
@@ -111,3 +129,161 @@ func GenerateWithMembership(
 
 	return res, nil
 }
+
+// GenerateForStage generates a jwt that's restricted to the given pipeline stage.
+// It can't be used to authenticate against the API, as it doesn't contain a token or membership.
+func GenerateForStage(
+	principalID int64,
+	stageID int64,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		Stage: &SubClaimsStage{
+			ID: stageID,
+		},
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// ParseStage validates a jwt generated by GenerateForStage and returns the id of the stage.
+func ParseStage(token string, secret string) (int64, error) {
+	claims := &Claims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to parse token")
+	}
+
+	if !parsed.Valid || claims.Stage == nil || claims.Stage.ID <= 0 {
+		return 0, errors.New("token isn't valid for a stage")
+	}
+
+	return claims.Stage.ID, nil
+}
+
+// GenerateForOIDCLink generates a jwt that links the identity of the OIDC login with the given state
+// to the principal. It can't be used to authenticate against the API, as it doesn't contain a token or membership.
+func GenerateForOIDCLink(
+	principalID int64,
+	state string,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		OIDCLink: &SubClaimsOIDCLink{
+			State: state,
+		},
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// ParseOIDCLink validates a jwt generated by GenerateForOIDCLink
+// and returns the id of the principal and the state of the OIDC login.
+func ParseOIDCLink(token string, secret string) (int64, string, error) {
+	claims := &Claims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return 0, "", errors.Wrap(err, "Failed to parse token")
+	}
+
+	if !parsed.Valid || claims.OIDCLink == nil || claims.OIDCLink.State == "" || claims.PrincipalID <= 0 {
+		return 0, "", errors.New("token isn't valid for an oidc link")
+	}
+
+	return claims.PrincipalID, claims.OIDCLink.State, nil
+}
+
+// GenerateForEmailVerification generates a jwt that proves that the principal received a mail at the email address.
+// It can't be used to authenticate against the API, as it doesn't contain a token or membership.
+func GenerateForEmailVerification(
+	principalID int64,
+	email string,
+	lifetime time.Duration,
+	secret string,
+) (string, error) {
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(lifetime)
+
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, Claims{
+		StandardClaims: jwt.StandardClaims{
+			Issuer: issuer,
+			// times required to be in sec
+			IssuedAt:  issuedAt.Unix(),
+			ExpiresAt: expiresAt.Unix(),
+		},
+		PrincipalID: principalID,
+		Email: &SubClaimsEmail{
+			Address: email,
+		},
+	})
+
+	res, err := jwtToken.SignedString([]byte(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "Failed to sign token")
+	}
+
+	return res, nil
+}
+
+// ParseEmailVerification validates a jwt generated by GenerateForEmailVerification
+// and returns the id of the principal and the verified email.
+func ParseEmailVerification(token string, secret string) (int64, string, error) {
+	claims := &Claims{}
+	parsed, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errors.New("invalid signing method")
+		}
+		return []byte(secret), nil
+	})
+	if err != nil {
+		return 0, "", errors.Wrap(err, "Failed to parse token")
+	}
+
+	if !parsed.Valid || claims.Email == nil || claims.Email.Address == "" || claims.PrincipalID <= 0 {
+		return 0, "", errors.New("token isn't valid for an email verification")
+	}
+
+	return claims.PrincipalID, claims.Email.Address, nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// BlobPrefix is the blob store prefix under which artifacts are stored (artifacts/<executionID>/<stageID>/<name>).
+	BlobPrefix = "artifacts/"
+
+	maxNameLength = 1024
+)
+
+var (
+	errInvalidName    = usererror.BadRequest("Artifact name has to be a relative path without '..' elements.")
+	errStageCompleted = usererror.Conflict("Artifacts can only be uploaded while the stage is running.")
+)
+
+type Config struct {
+	// Retention is the duration artifacts are kept after their upload.
+	Retention time.Duration
+	// MaxSize is the maximum size of a single artifact in bytes.
+	MaxSize int64
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.Retention <= 0 {
+		return errors.New("config.Retention has to be provided")
+	}
+	if c.MaxSize <= 0 {
+		return errors.New("config.MaxSize has to be provided")
+	}
+	return nil
+}
+
+// Service stores the artifacts of pipeline stages in the blob store.
+type Service struct {
+	config        Config
+	artifactStore store.ArtifactStore
+	blobStore     blob.Store
+}
+
+func NewService(
+	config Config,
+	artifactStore store.ArtifactStore,
+	blobStore blob.Store,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided artifact config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:        config,
+		artifactStore: artifactStore,
+		blobStore:     blobStore,
+	}, nil
+}
+
+// Upload stores the content as artifact of the stage, replacing an existing artifact with the same name.
+func (s *Service) Upload(
+	ctx context.Context,
+	stage *types.Stage,
+	name string,
+	r io.Reader,
+) (*types.Artifact, error) {
+	if stage.Status.IsDone() {
+		return nil, errStageCompleted
+	}
+
+	name, err := sanitizeName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	blobPath := BlobPath(stage.ExecutionID, stage.ID, name)
+	content := &limitReader{r: r, limit: s.config.MaxSize}
+
+	if err = s.blobStore.Upload(ctx, content, blobPath); err != nil {
+		// don't leave partially uploaded content behind.
+		if errDelete := s.blobStore.Delete(ctx, blobPath); errDelete != nil {
+			log.Ctx(ctx).Warn().Err(errDelete).Msgf("failed to delete partial artifact %q", blobPath)
+		}
+		if errors.Is(content.err, errTooLarge) {
+			return nil, usererror.RequestTooLargef("Artifact exceeds the maximum size of %d bytes.",
+				s.config.MaxSize)
+		}
+		return nil, fmt.Errorf("failed to upload artifact to blob store: %w", err)
+	}
+
+	now := time.Now()
+	artifact := &types.Artifact{
+		ExecutionID: stage.ExecutionID,
+		StageID:     stage.ID,
+		Name:        name,
+		Size:        content.n,
+		Created:     now.UnixMilli(),
+		Expires:     now.Add(s.config.Retention).UnixMilli(),
+	}
+
+	if err = s.artifactStore.Upsert(ctx, artifact); err != nil {
+		return nil, fmt.Errorf("failed to store artifact: %w", err)
+	}
+
+	return artifact, nil
+}
+
+// UploadArchive stores every regular file of the tar archive as artifact of the stage.
+// The names of the artifacts are the paths of the files in the archive prefixed with dir.
+func (s *Service) UploadArchive(
+	ctx context.Context,
+	stage *types.Stage,
+	dir string,
+	r io.Reader,
+) ([]*types.Artifact, error) {
+	artifacts := []*types.Artifact{}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return artifacts, nil
+		}
+		if err != nil {
+			return nil, usererror.BadRequestf("Invalid tar archive: %s", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		artifact, err := s.Upload(ctx, stage, path.Join(dir, header.Name), tr)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+}
+
+// Download returns either a signed url to the artifact (if supported by the blob store)
+// or a reader of the artifact content.
+func (s *Service) Download(ctx context.Context, artifact *types.Artifact) (string, io.ReadCloser, error) {
+	blobPath := BlobPath(artifact.ExecutionID, artifact.StageID, artifact.Name)
+
+	signedURL, err := s.blobStore.GetSignedURL(ctx, blobPath)
+	if err != nil && !errors.Is(err, blob.ErrNotSupported) {
+		return "", nil, fmt.Errorf("failed to get signed URL: %w", err)
+	}
+
+	if signedURL != "" {
+		return signedURL, nil, nil
+	}
+
+	file, err := s.blobStore.Download(ctx, blobPath)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to download artifact from blob store: %w", err)
+	}
+
+	return "", file, nil
+}
+
+// Delete deletes the artifact and its content.
+func (s *Service) Delete(ctx context.Context, artifact *types.Artifact) error {
+	blobPath := BlobPath(artifact.ExecutionID, artifact.StageID, artifact.Name)
+	if err := s.blobStore.Delete(ctx, blobPath); err != nil {
+		return fmt.Errorf("failed to delete artifact from blob store: %w", err)
+	}
+
+	if err := s.artifactStore.Delete(ctx, artifact.ID); err != nil {
+		return fmt.Errorf("failed to delete artifact: %w", err)
+	}
+
+	return nil
+}
+
+// BlobPath returns the path of the artifact content in the blob store.
+func BlobPath(executionID, stageID int64, name string) string {
+	return fmt.Sprintf("%s%d/%d/%s", BlobPrefix, executionID, stageID, name)
+}
+
+// ParseBlobPath returns the id of the execution the artifact blob belongs to.
+func ParseBlobPath(blobPath string) (int64, bool) {
+	rest, ok := strings.CutPrefix(blobPath, BlobPrefix)
+	if !ok {
+		return 0, false
+	}
+
+	executionIDStr, _, ok := strings.Cut(rest, "/")
+	if !ok {
+		return 0, false
+	}
+
+	executionID, err := strconv.ParseInt(executionIDStr, 10, 64)
+	if err != nil || executionID <= 0 {
+		return 0, false
+	}
+
+	return executionID, true
+}
+
+// sanitizeName cleans the name of the artifact and ensures it's a relative path that stays within the stage.
+func sanitizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxNameLength || strings.HasPrefix(name, "/") {
+		return "", errInvalidName
+	}
+
+	name = path.Clean(name)
+	if name == "." || name == ".." || strings.HasPrefix(name, "../") {
+		return "", errInvalidName
+	}
+
+	return name, nil
+}
+
+var errTooLarge = errors.New("artifact too large")
+
+// limitReader fails with errTooLarge once more than limit bytes were read.
+type limitReader struct {
+	r     io.Reader
+	limit int64
+	n     int64
+	err   error
+}
+
+func (l *limitReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n += int64(n)
+	if l.n > l.limit {
+		l.err = errTooLarge
+		return n, errTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package artifact
+
+import (
+	"testing"
+)
+
+func TestSanitizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		valid    bool
+	}{
+		{name: "app.zip", expected: "app.zip", valid: true},
+		{name: " dist/./bin//app ", expected: "dist/bin/app", valid: true},
+		{name: "dist/../app.zip", expected: "app.zip", valid: true},
+		{name: "", valid: false},
+		{name: ".", valid: false},
+		{name: "/etc/passwd", valid: false},
+		{name: "../app.zip", valid: false},
+		{name: "dist/../../app.zip", valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := sanitizeName(test.name)
+			if test.valid != (err == nil) {
+				t.Fatalf("expected valid=%t, got error %v", test.valid, err)
+			}
+			if got != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParseBlobPath(t *testing.T) {
+	executionID, ok := ParseBlobPath(BlobPath(12, 34, "dist/app.zip"))
+	if !ok || executionID != 12 {
+		t.Errorf("expected execution 12, got %d (ok=%t)", executionID, ok)
+	}
+
+	if _, ok = ParseBlobPath("uploads/12/file"); ok {
+		t.Error("expected path outside of the artifacts prefix to be rejected")
+	}
+}
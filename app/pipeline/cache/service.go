@@ -0,0 +1,272 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// BlobPrefix is the blob store prefix under which caches are stored (caches/<repoID>/<hash of branch and key>).
+	BlobPrefix = "caches/"
+)
+
+var (
+	keyRegex = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,128}$`)
+
+	errInvalidKey     = usererror.BadRequest("Cache key has to consist of 1 to 128 alphanumeric, '.', '_' or '-' characters.")
+	errStageCompleted = usererror.Conflict("Caches can only be saved while the stage is running.")
+	errCacheNotFound  = usererror.NotFound("Cache not found")
+)
+
+type Config struct {
+	// Retention is the duration a cache entry is kept after it was last used.
+	Retention time.Duration
+	// MaxSize is the maximum size of a single cache entry in bytes.
+	MaxSize int64
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.Retention <= 0 {
+		return errors.New("config.Retention has to be provided")
+	}
+	if c.MaxSize <= 0 {
+		return errors.New("config.MaxSize has to be provided")
+	}
+	return nil
+}
+
+// Service stores the caches saved by pipeline steps in the blob store. Cache entries are scoped to the
+// branch the execution runs for, restoring falls back to the entries of the default branch of the repository.
+type Service struct {
+	config         Config
+	cacheStore     store.PipelineCacheStore
+	executionStore store.ExecutionStore
+	repoStore      store.RepoStore
+	blobStore      blob.Store
+}
+
+func NewService(
+	config Config,
+	cacheStore store.PipelineCacheStore,
+	executionStore store.ExecutionStore,
+	repoStore store.RepoStore,
+	blobStore blob.Store,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided cache config is invalid: %w", err)
+	}
+
+	return &Service{
+		config:         config,
+		cacheStore:     cacheStore,
+		executionStore: executionStore,
+		repoStore:      repoStore,
+		blobStore:      blobStore,
+	}, nil
+}
+
+// Restore returns a reader of the cache entry with the key for the branch of the stage's execution.
+// In case the branch doesn't have the entry (yet), the entry of the default branch is returned.
+func (s *Service) Restore(ctx context.Context, stage *types.Stage, key string) (io.ReadCloser, error) {
+	if !keyRegex.MatchString(key) {
+		return nil, errInvalidKey
+	}
+
+	execution, err := s.executionStore.Find(ctx, stage.ExecutionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, execution.RepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	for _, branch := range []string{executionBranch(execution), repo.DefaultBranch} {
+		entry, err := s.cacheStore.Find(ctx, repo.ID, branch, key)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find cache entry: %w", err)
+		}
+
+		file, err := s.blobStore.Download(ctx, BlobPath(entry.RepoID, entry.Branch, entry.Key))
+		if errors.Is(err, blob.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to download cache from blob store: %w", err)
+		}
+
+		if err = s.cacheStore.Touch(ctx, entry.ID, time.Now().UnixMilli()); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msgf("failed to update usage of cache entry %d", entry.ID)
+		}
+
+		return file, nil
+	}
+
+	return nil, errCacheNotFound
+}
+
+// Save stores the content as cache entry with the key for the branch of the stage's execution,
+// replacing an existing entry with the same key.
+func (s *Service) Save(
+	ctx context.Context,
+	stage *types.Stage,
+	key string,
+	r io.Reader,
+) (*types.PipelineCache, error) {
+	if stage.Status.IsDone() {
+		return nil, errStageCompleted
+	}
+
+	if !keyRegex.MatchString(key) {
+		return nil, errInvalidKey
+	}
+
+	execution, err := s.executionStore.Find(ctx, stage.ExecutionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find execution: %w", err)
+	}
+
+	branch := executionBranch(execution)
+	blobPath := BlobPath(execution.RepoID, branch, key)
+
+	// read one byte more than allowed to detect content exceeding the maximum size.
+	content := &countingReader{r: io.LimitReader(r, s.config.MaxSize+1)}
+
+	err = s.blobStore.Upload(ctx, content, blobPath)
+	if err == nil && content.n > s.config.MaxSize {
+		err = usererror.RequestTooLargef("Cache exceeds the maximum size of %d bytes.", s.config.MaxSize)
+	}
+	if err != nil {
+		// don't leave partially uploaded content behind.
+		if errDelete := s.blobStore.Delete(ctx, blobPath); errDelete != nil {
+			log.Ctx(ctx).Warn().Err(errDelete).Msgf("failed to delete partial cache %q", blobPath)
+		}
+		return nil, fmt.Errorf("failed to upload cache to blob store: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	entry := &types.PipelineCache{
+		RepoID:   execution.RepoID,
+		Branch:   branch,
+		Key:      key,
+		Size:     content.n,
+		Created:  now,
+		Updated:  now,
+		LastUsed: now,
+	}
+
+	if err = s.cacheStore.Upsert(ctx, entry); err != nil {
+		return nil, fmt.Errorf("failed to store cache entry: %w", err)
+	}
+
+	return entry, nil
+}
+
+// Delete deletes the cache entry and its content.
+func (s *Service) Delete(ctx context.Context, entry *types.PipelineCache) error {
+	if err := s.blobStore.Delete(ctx, BlobPath(entry.RepoID, entry.Branch, entry.Key)); err != nil {
+		return fmt.Errorf("failed to delete cache from blob store: %w", err)
+	}
+
+	if err := s.cacheStore.Delete(ctx, entry.ID); err != nil {
+		return fmt.Errorf("failed to delete cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// ListStale returns up to limit cache entries that weren't used within the retention duration.
+func (s *Service) ListStale(ctx context.Context, limit int) ([]*types.PipelineCache, error) {
+	usedBefore := time.Now().Add(-s.config.Retention).UnixMilli()
+
+	entries, err := s.cacheStore.ListUnused(ctx, usedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unused cache entries: %w", err)
+	}
+
+	return entries, nil
+}
+
+// BlobPath returns the path of the cache content in the blob store.
+// Branch and key are hashed, as branch names can contain characters that aren't valid in blob paths.
+func BlobPath(repoID int64, branch string, key string) string {
+	hash := sha256.Sum256([]byte(branch + "\x00" + key))
+	return fmt.Sprintf("%s%d/%s", BlobPrefix, repoID, hex.EncodeToString(hash[:]))
+}
+
+// ParseBlobPath returns the id of the repository the cache blob belongs to.
+func ParseBlobPath(blobPath string) (int64, bool) {
+	rest, ok := strings.CutPrefix(blobPath, BlobPrefix)
+	if !ok {
+		return 0, false
+	}
+
+	repoIDStr, _, ok := strings.Cut(rest, "/")
+	if !ok {
+		return 0, false
+	}
+
+	repoID, err := strconv.ParseInt(repoIDStr, 10, 64)
+	if err != nil || repoID <= 0 {
+		return 0, false
+	}
+
+	return repoID, true
+}
+
+// executionBranch returns the branch the cache entries of the execution are scoped to.
+func executionBranch(execution *types.Execution) string {
+	if execution.Source != "" {
+		return execution.Source
+	}
+	return execution.Target
+}
+
+// countingReader counts the number of bytes read.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -73,9 +73,10 @@ func (s *service) Cancel(ctx context.Context, repo *types.Repository, execution
 
 	// do not cancel the build if the build status is
 	// complete. only cancel the build if the status is
-	// running or pending.
+	// running, pending or waiting on an approval.
 	if execution.Status != enum.CIStatusPending &&
-		execution.Status != enum.CIStatusRunning {
+		execution.Status != enum.CIStatusRunning &&
+		execution.Status != enum.CIStatusWaitingOnApproval {
 		return nil
 	}
 
@@ -86,7 +86,7 @@ func (e *embedded) Request(ctx context.Context, args *client.Filter) (*drone.Sta
 
 // Accept accepts the build stage for execution.
 func (e *embedded) Accept(ctx context.Context, s *drone.Stage) error {
-	stage, err := e.manager.Accept(ctx, s.ID, s.Machine)
+	stage, err := e.manager.Accept(ctx, s.ID, s.Machine, nil)
 	if err != nil {
 		return err
 	}
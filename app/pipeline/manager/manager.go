@@ -24,7 +24,10 @@ import (
 
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/pipeline/artifact"
+	"github.com/harness/gitness/app/pipeline/cache"
 	"github.com/harness/gitness/app/pipeline/file"
+	"github.com/harness/gitness/app/pipeline/matrix"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
@@ -44,8 +47,35 @@ const (
 	pipelineJWTRole = enum.MembershipRoleContributor
 )
 
+const (
+	// ArtifactsURLParam is the name of the environment variable with the artifact upload url of the stage.
+	ArtifactsURLParam = "GITNESS_ARTIFACTS_URL"
+	// ArtifactsTokenParam is the name of the environment variable with the artifact upload token of the stage.
+	ArtifactsTokenParam = "GITNESS_ARTIFACTS_TOKEN"
+	// CacheURLParam is the name of the environment variable with the cache url of the stage.
+	CacheURLParam = "GITNESS_CACHE_URL"
+	// CacheTokenParam is the name of the environment variable with the cache token of the stage.
+	CacheTokenParam = "GITNESS_CACHE_TOKEN"
+)
+
 var noContext = context.Background()
 
+// ArtifactsURL returns the artifact upload url of the stage for the provided rpc base url.
+func ArtifactsURL(rpcURL string, stageID int64) string {
+	return fmt.Sprintf("%s/v2/stage/%d/artifacts", rpcURL, stageID)
+}
+
+// CacheURL returns the cache url of the stage for the provided rpc base url.
+func CacheURL(rpcURL string, stageID int64) string {
+	return fmt.Sprintf("%s/v2/stage/%d/cache", rpcURL, stageID)
+}
+
+// ErrStageAlreadyAssigned is returned in case a stage is accepted that's already assigned to another machine.
+var ErrStageAlreadyAssigned = errors.New("stage already assigned, abort")
+
+// ErrStageNotWaitingOnApproval is returned in case an approval is resolved for a stage that doesn't wait on one.
+var ErrStageNotWaitingOnApproval = errors.New("stage is not waiting on approval")
+
 var _ ExecutionManager = (*Manager)(nil)
 
 type (
@@ -97,7 +127,8 @@ type (
 		Watch(ctx context.Context, executionID int64) (bool, error)
 
 		// Accept accepts the build stage for execution.
-		Accept(ctx context.Context, stage int64, machine string) (*types.Stage, error)
+		// The runner ID is the ID of the external runner accepting the stage, nil for the embedded runner.
+		Accept(ctx context.Context, stage int64, machine string, runnerID *int64) (*types.Stage, error)
 
 		// Write writes a line to the build logs.
 		Write(ctx context.Context, step int64, line *livelog.Line) error
@@ -108,6 +139,18 @@ type (
 		// UploadLogs uploads the full logs.
 		UploadLogs(ctx context.Context, step int64, r io.Reader) error
 
+		// UploadArtifact uploads a file as artifact of the stage.
+		UploadArtifact(ctx context.Context, stageID int64, name string, r io.Reader) (*types.Artifact, error)
+
+		// UploadArtifactArchive uploads all files of a tar archive as artifacts of the stage.
+		UploadArtifactArchive(ctx context.Context, stageID int64, dir string, r io.Reader) ([]*types.Artifact, error)
+
+		// RestoreCache returns a reader of the cache entry with the key for the stage.
+		RestoreCache(ctx context.Context, stageID int64, key string) (io.ReadCloser, error)
+
+		// SaveCache stores the content as cache entry with the key for the stage.
+		SaveCache(ctx context.Context, stageID int64, key string, r io.Reader) (*types.PipelineCache, error)
+
 		// BeforeStep signals the build step is about to start.
 		BeforeStep(ctx context.Context, step *types.Step) error
 
@@ -119,6 +162,9 @@ type (
 
 		// AfterStage signals the build stage is complete.
 		AfterStage(ctx context.Context, stage *types.Stage) error
+
+		// ResolveApproval completes the approval stage with the decision of a user.
+		ResolveApproval(ctx context.Context, stage *types.Stage, approval *types.Approval) error
 	}
 )
 
@@ -144,7 +190,9 @@ type Manager struct {
 	Stages store.StageStore
 	Steps  store.StepStore
 	// System  *store.System
-	Users store.PrincipalStore
+	Users     store.PrincipalStore
+	Artifacts *artifact.Service
+	Caches    *cache.Service
 	// Webhook store.WebhookSender
 }
 
@@ -164,6 +212,8 @@ func New(
 	stageStore store.StageStore,
 	stepStore store.StepStore,
 	userStore store.PrincipalStore,
+	artifactService *artifact.Service,
+	cacheService *cache.Service,
 ) *Manager {
 	return &Manager{
 		Config:      config,
@@ -181,6 +231,8 @@ func New(
 		Stages:      stageStore,
 		Steps:       stepStore,
 		Users:       userStore,
+		Artifacts:   artifactService,
+		Caches:      cacheService,
 	}
 }
 
@@ -218,7 +270,7 @@ func (m *Manager) Request(ctx context.Context, args *Request) (*types.Stage, err
 
 // Accept accepts the build stage for execution. It is possible for multiple
 // agents to pull the same stage from the queue.
-func (m *Manager) Accept(_ context.Context, id int64, machine string) (*types.Stage, error) {
+func (m *Manager) Accept(_ context.Context, id int64, machine string, runnerID *int64) (*types.Stage, error) {
 	log := log.With().
 		Int64("stage-id", id).
 		Str("machine", machine).
@@ -232,10 +284,11 @@ func (m *Manager) Accept(_ context.Context, id int64, machine string) (*types.St
 	}
 	if stage.Machine != "" {
 		log.Debug().Msg("manager: stage already assigned. abort.")
-		return nil, fmt.Errorf("stage already assigned, abort")
+		return nil, ErrStageAlreadyAssigned
 	}
 
 	stage.Machine = machine
+	stage.RunnerID = runnerID
 	stage.Status = enum.CIStatusPending
 	err = m.Stages.Update(noContext, stage)
 	switch {
@@ -269,6 +322,61 @@ func (m *Manager) UploadLogs(ctx context.Context, step int64, r io.Reader) error
 	return nil
 }
 
+// UploadArtifact uploads a file as artifact of the stage.
+func (m *Manager) UploadArtifact(
+	ctx context.Context,
+	stageID int64,
+	name string,
+	r io.Reader,
+) (*types.Artifact, error) {
+	stage, err := m.Stages.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	return m.Artifacts.Upload(ctx, stage, name, r)
+}
+
+// UploadArtifactArchive uploads all files of a tar archive as artifacts of the stage.
+func (m *Manager) UploadArtifactArchive(
+	ctx context.Context,
+	stageID int64,
+	dir string,
+	r io.Reader,
+) ([]*types.Artifact, error) {
+	stage, err := m.Stages.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	return m.Artifacts.UploadArchive(ctx, stage, dir, r)
+}
+
+// RestoreCache returns a reader of the cache entry with the key for the stage.
+func (m *Manager) RestoreCache(ctx context.Context, stageID int64, key string) (io.ReadCloser, error) {
+	stage, err := m.Stages.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	return m.Caches.Restore(ctx, stage, key)
+}
+
+// SaveCache stores the content as cache entry with the key for the stage.
+func (m *Manager) SaveCache(
+	ctx context.Context,
+	stageID int64,
+	key string,
+	r io.Reader,
+) (*types.PipelineCache, error) {
+	stage, err := m.Stages.Find(ctx, stageID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find stage: %w", err)
+	}
+
+	return m.Caches.Save(ctx, stage, key, r)
+}
+
 // Details provides details about the stage.
 func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext, error) {
 	log := log.With().
@@ -310,27 +418,42 @@ func (m *Manager) Details(_ context.Context, stageID int64) (*ExecutionContext,
 		Str("repo", repo.GetGitUID()).
 		Logger()
 
-	// TODO: Currently we fetch all the secrets from the same space.
-	// This logic can be updated when needed.
-	secrets, err := m.Secrets.ListAll(noContext, repo.ParentID)
+	// Fetch contents of YAML from the execution ref at the pipeline config path.
+	file, err := m.FileService.Get(noContext, repo, pipeline.ConfigPath, execution.After)
 	if err != nil {
-		log.Warn().Err(err).Msg("manager: cannot list secrets")
+		log.Warn().Err(err).Msg("manager: cannot fetch file")
 		return nil, err
 	}
 
-	// Fetch contents of YAML from the execution ref at the pipeline config path.
-	file, err := m.FileService.Get(noContext, repo, pipeline.ConfigPath, execution.After)
+	// The stages of pipelines with a matrix were created from the expanded yaml, so the runner needs it as well.
+	file.Data, err = matrix.Expand(file.Data)
 	if err != nil {
-		log.Warn().Err(err).Msg("manager: cannot fetch file")
+		log.Warn().Err(err).Msg("manager: cannot expand matrix")
 		return nil, err
 	}
 
+	// Secrets are inherited from all ancestor spaces (the nearest space wins),
+	// but only the ones referenced by the pipeline are passed on.
+	secrets, err := m.Secrets.ListAllInherited(noContext, repo.ParentID)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: cannot list secrets")
+		return nil, err
+	}
+	secrets = filterReferencedSecrets(file.Data, secrets)
+
 	netrc, err := m.createNetrc(repo)
 	if err != nil {
 		log.Warn().Err(err).Msg("manager: failed to create netrc")
 		return nil, err
 	}
 
+	// Steps upload artifacts and caches via the rpc endpoint, authenticated by a token restricted to the stage.
+	execution.Params, err = m.withStageParams(execution.Params, stage.ID)
+	if err != nil {
+		log.Warn().Err(err).Msg("manager: failed to create stage params")
+		return nil, err
+	}
+
 	return &ExecutionContext{
 		Repo:      repo,
 		Execution: execution,
@@ -366,6 +489,31 @@ func (m *Manager) createNetrc(repo *types.Repository) (*Netrc, error) {
 	}, nil
 }
 
+// withStageParams returns a copy of the params extended with the artifact and cache urls and tokens of the stage.
+func (m *Manager) withStageParams(params map[string]string, stageID int64) (map[string]string, error) {
+	pipelinePrincipal := bootstrap.NewPipelineServiceSession().Principal
+	token, err := jwt.GenerateForStage(
+		pipelinePrincipal.ID,
+		stageID,
+		pipelineJWTLifetime,
+		pipelinePrincipal.Salt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stage jwt: %w", err)
+	}
+
+	res := make(map[string]string, len(params)+4)
+	for k, v := range params {
+		res[k] = v
+	}
+	res[ArtifactsURLParam] = ArtifactsURL(m.urlProvider.GetContainerRPCURL(), stageID)
+	res[ArtifactsTokenParam] = token
+	res[CacheURLParam] = CacheURL(m.urlProvider.GetContainerRPCURL(), stageID)
+	res[CacheTokenParam] = token
+
+	return res, nil
+}
+
 // Before signals the build step is about to start.
 func (m *Manager) BeforeStep(_ context.Context, step *types.Step) error {
 	log := log.With().
@@ -452,6 +600,26 @@ func (m *Manager) AfterStage(_ context.Context, stage *types.Stage) error {
 	return t.do(noContext, stage)
 }
 
+// ResolveApproval completes the approval stage with the decision of a user
+// and continues the execution with the downstream stages.
+func (m *Manager) ResolveApproval(_ context.Context, stage *types.Stage, approval *types.Approval) error {
+	if !stage.IsApprovalGate() || stage.Status != enum.CIStatusWaitingOnApproval {
+		return ErrStageNotWaitingOnApproval
+	}
+
+	now := time.Now().UnixMilli()
+	stage.Started = now
+	stage.Stopped = now
+	stage.Status = enum.CIStatusSuccess
+	if approval.Decision == enum.ApprovalDecisionRejected {
+		stage.Status = enum.CIStatusFailure
+		stage.Error = "rejected by " + approval.Author.UID
+		stage.ExitCode = 1
+	}
+
+	return m.AfterStage(noContext, stage)
+}
+
 // Watch watches for build cancellation requests.
 func (m *Manager) Watch(ctx context.Context, executionID int64) (bool, error) {
 	ok, err := m.Scheduler.Cancelled(ctx, executionID)
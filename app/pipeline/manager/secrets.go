@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"regexp"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-yaml/yaml"
+)
+
+var (
+	// v1SecretReferenceRegex matches secret expressions of v1 yaml, e.g. `${{ secrets.get("docker_password") }}`.
+	v1SecretReferenceRegex = regexp.MustCompile(`secrets\.get\(\s*["']([^"']+)["']\s*\)`)
+
+	// droneSecretReferenceRegex matches secret references of drone yaml, e.g. `from_secret: docker_password`.
+	// It's only used in case the yaml can't be parsed (e.g. in case it's templated).
+	droneSecretReferenceRegex = regexp.MustCompile(`from_secret:\s*["']?([\w.-]+)`)
+
+	// v1YamlRegex is used to detect v1 yaml, analogous to the triggerer.
+	v1YamlRegex = regexp.MustCompilePOSIX(`^spec:`)
+)
+
+// filterReferencedSecrets returns only the secrets that are referenced in the provided pipeline yaml.
+func filterReferencedSecrets(data []byte, secrets []*types.Secret) []*types.Secret {
+	references := secretReferences(data)
+
+	filtered := make([]*types.Secret, 0, len(references))
+	for _, secret := range secrets {
+		if _, ok := references[secret.UID]; ok {
+			filtered = append(filtered, secret)
+		}
+	}
+
+	return filtered
+}
+
+// secretReferences returns the names of all secrets that are referenced in the provided pipeline yaml.
+func secretReferences(data []byte) map[string]struct{} {
+	references := make(map[string]struct{})
+
+	if v1YamlRegex.Match(data) {
+		addRegexMatches(references, v1SecretReferenceRegex, data)
+		return references
+	}
+
+	manifest, err := yaml.ParseBytes(data)
+	if err != nil {
+		addRegexMatches(references, droneSecretReferenceRegex, data)
+		return references
+	}
+
+	for _, resource := range manifest.Resources {
+		pipeline, ok := resource.(*yaml.Pipeline)
+		if !ok {
+			continue
+		}
+
+		for _, name := range pipeline.PullSecrets {
+			references[name] = struct{}{}
+		}
+
+		for _, container := range append(pipeline.Steps, pipeline.Services...) {
+			if container == nil {
+				continue
+			}
+			for _, variable := range container.Environment {
+				if variable != nil && variable.Secret != "" {
+					references[variable.Secret] = struct{}{}
+				}
+			}
+			for _, parameter := range container.Settings {
+				if parameter != nil && parameter.Secret != "" {
+					references[parameter.Secret] = struct{}{}
+				}
+			}
+		}
+	}
+
+	return references
+}
+
+func addRegexMatches(references map[string]struct{}, regex *regexp.Regexp, data []byte) {
+	for _, match := range regex.FindAllSubmatch(data, -1) {
+		references[string(match[1])] = struct{}{}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSecretReferences(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected map[string]struct{}
+	}{
+		{
+			name: "drone yaml",
+			data: `kind: pipeline
+type: docker
+name: default
+image_pull_secrets:
+- dockerconfig
+steps:
+- name: build
+  image: golang
+  environment:
+    TOKEN:
+      from_secret: token
+    PLAIN: value
+- name: publish
+  image: plugins/docker
+  settings:
+    password:
+      from_secret: docker_password
+`,
+			expected: map[string]struct{}{"dockerconfig": {}, "token": {}, "docker_password": {}},
+		},
+		{
+			name: "v1 yaml",
+			data: `spec:
+  stages:
+  - type: ci
+    spec:
+      steps:
+      - type: run
+        spec:
+          script: echo ${{ secrets.get("token") }} ${{secrets.get('other')}}
+`,
+			expected: map[string]struct{}{"token": {}, "other": {}},
+		},
+		{
+			name:     "unparsable drone yaml",
+			data:     "kind: pipeline\n\tsteps: [\nfrom_secret: token\n",
+			expected: map[string]struct{}{"token": {}},
+		},
+		{
+			name:     "no references",
+			data:     "kind: pipeline\nname: default\nsteps:\n- name: test\n  image: golang\n",
+			expected: map[string]struct{}{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := secretReferences([]byte(test.data))
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected: %v, got: %v", test.expected, got)
+			}
+		})
+	}
+}
@@ -130,7 +130,7 @@ func (t *teardown) do(ctx context.Context, stage *types.Stage) error {
 	if !isexecutionComplete(stages) {
 		log.Warn().Err(err).
 			Msg("manager: execution pending completion of additional stages")
-		return nil
+		return t.updateApprovalStatus(ctx, repo, execution, stages)
 	}
 
 	log.Info().Msg("manager: execution is finished, teardown")
@@ -261,6 +261,7 @@ func isexecutionComplete(stages []*types.Stage) bool {
 		if stage.Status == enum.CIStatusPending ||
 			stage.Status == enum.CIStatusRunning ||
 			stage.Status == enum.CIStatusWaitingOnDeps ||
+			stage.Status == enum.CIStatusWaitingOnApproval ||
 			stage.Status == enum.CIStatusDeclined ||
 			stage.Status == enum.CIStatusBlocked {
 			return false
@@ -322,7 +323,12 @@ func (t *teardown) scheduleDownstream(
 
 		log.Debug().Msg("manager: schedule next stage")
 
+		// approval stages aren't executed by a runner, they wait for a user decision instead.
 		sibling.Status = enum.CIStatusPending
+		if sibling.IsApprovalGate() {
+			sibling.Status = enum.CIStatusWaitingOnApproval
+		}
+
 		err := t.Stages.Update(noContext, sibling)
 		if errors.Is(err, gitness_store.ErrVersionConflict) {
 			rErr := t.resync(ctx, sibling)
@@ -337,6 +343,10 @@ func (t *teardown) scheduleDownstream(
 			errs = multierror.Append(errs, err)
 		}
 
+		if sibling.IsApprovalGate() {
+			continue
+		}
+
 		err = t.Scheduler.Schedule(noContext, sibling)
 		if err != nil {
 			log.Error().Err(err).
@@ -347,6 +357,51 @@ func (t *teardown) scheduleDownstream(
 	return errs
 }
 
+// updateApprovalStatus updates the status of an unfinished execution to reflect
+// whether it is waiting on the approval of any of its stages.
+func (t *teardown) updateApprovalStatus(
+	ctx context.Context,
+	repo *types.Repository,
+	execution *types.Execution,
+	stages []*types.Stage,
+) error {
+	status := enum.CIStatusRunning
+	for _, s := range stages {
+		if s.Status == enum.CIStatusWaitingOnApproval {
+			status = enum.CIStatusWaitingOnApproval
+			break
+		}
+	}
+
+	// only toggle between running and waiting - a pending execution is set to running by the runner.
+	if execution.Status == status ||
+		(status == enum.CIStatusRunning && execution.Status != enum.CIStatusWaitingOnApproval) {
+		return nil
+	}
+
+	execution.Status = status
+	err := t.Executions.Update(ctx, execution)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		log.Warn().Err(err).
+			Msg("manager: execution updated by another goroutine")
+		return nil
+	}
+	if err != nil {
+		log.Error().Err(err).
+			Msg("manager: cannot update the execution approval status")
+		return err
+	}
+
+	execution.Stages = stages
+	err = t.SSEStreamer.Publish(ctx, repo.ParentID, enum.SSETypeExecutionUpdated, execution)
+	if err != nil {
+		log.Warn().Err(err).
+			Msg("manager: could not publish execution updated event")
+	}
+
+	return nil
+}
+
 // resync updates the stage from the database. Note that it does
 // not update the Version field. This is by design. It prevents
 // the current go routine from updating a stage that has been
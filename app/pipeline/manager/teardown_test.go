@@ -0,0 +1,131 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/pipeline/scheduler"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type fakeStageStore struct {
+	store.StageStore
+	updated []*types.Stage
+}
+
+func (s *fakeStageStore) Update(_ context.Context, stage *types.Stage) error {
+	s.updated = append(s.updated, stage)
+	return nil
+}
+
+type fakeExecutionStore struct {
+	store.ExecutionStore
+	updated []*types.Execution
+}
+
+func (s *fakeExecutionStore) Update(_ context.Context, execution *types.Execution) error {
+	s.updated = append(s.updated, execution)
+	return nil
+}
+
+type fakeScheduler struct {
+	scheduler.Scheduler
+	scheduled []*types.Stage
+}
+
+func (s *fakeScheduler) Schedule(_ context.Context, stage *types.Stage) error {
+	s.scheduled = append(s.scheduled, stage)
+	return nil
+}
+
+type fakeStreamer struct {
+	sse.Streamer
+	events []enum.SSEType
+}
+
+func (s *fakeStreamer) Publish(_ context.Context, _ int64, eventType enum.SSEType, _ any) error {
+	s.events = append(s.events, eventType)
+	return nil
+}
+
+func TestTeardownApprovalGate(t *testing.T) {
+	stages := []*types.Stage{
+		{Name: "build", Type: "docker", Status: enum.CIStatusSuccess},
+		{Name: "gate", Type: types.StageTypeApproval, Status: enum.CIStatusWaitingOnDeps,
+			DependsOn: []string{"build"}},
+		{Name: "deploy", Type: "docker", Status: enum.CIStatusWaitingOnDeps,
+			DependsOn: []string{"gate"}},
+	}
+
+	stageStore := &fakeStageStore{}
+	executionStore := &fakeExecutionStore{}
+	sched := &fakeScheduler{}
+	streamer := &fakeStreamer{}
+	td := &teardown{
+		Executions:  executionStore,
+		SSEStreamer: streamer,
+		Scheduler:   sched,
+		Stages:      stageStore,
+	}
+
+	if err := td.scheduleDownstream(context.Background(), stages); err != nil {
+		t.Fatalf("failed to schedule downstream stages: %s", err)
+	}
+
+	if got := stages[1].Status; got != enum.CIStatusWaitingOnApproval {
+		t.Errorf("expected the approval stage to be %s, got %s", enum.CIStatusWaitingOnApproval, got)
+	}
+	if got := stages[2].Status; got != enum.CIStatusWaitingOnDeps {
+		t.Errorf("expected the stage after the approval to be %s, got %s", enum.CIStatusWaitingOnDeps, got)
+	}
+	if len(sched.scheduled) != 0 {
+		t.Errorf("expected no stage to be scheduled, got %d", len(sched.scheduled))
+	}
+	if isexecutionComplete(stages) {
+		t.Error("expected execution waiting on approval to be incomplete")
+	}
+
+	execution := &types.Execution{Status: enum.CIStatusRunning}
+	repo := &types.Repository{}
+	if err := td.updateApprovalStatus(context.Background(), repo, execution, stages); err != nil {
+		t.Fatalf("failed to update approval status: %s", err)
+	}
+	if execution.Status != enum.CIStatusWaitingOnApproval {
+		t.Errorf("expected execution to be %s, got %s", enum.CIStatusWaitingOnApproval, execution.Status)
+	}
+
+	// once approved, the downstream stages are scheduled and the execution continues.
+	stages[1].Status = enum.CIStatusSuccess
+	if err := td.scheduleDownstream(context.Background(), stages); err != nil {
+		t.Fatalf("failed to schedule downstream stages: %s", err)
+	}
+	if len(sched.scheduled) != 1 || sched.scheduled[0].Name != "deploy" {
+		t.Errorf("expected the deploy stage to be scheduled, got %v", sched.scheduled)
+	}
+	if err := td.updateApprovalStatus(context.Background(), repo, execution, stages); err != nil {
+		t.Fatalf("failed to update approval status: %s", err)
+	}
+	if execution.Status != enum.CIStatusRunning {
+		t.Errorf("expected execution to be %s, got %s", enum.CIStatusRunning, execution.Status)
+	}
+	if len(streamer.events) != 2 {
+		t.Errorf("expected two execution updated events, got %d", len(streamer.events))
+	}
+}
@@ -15,6 +15,8 @@
 package manager
 
 import (
+	"github.com/harness/gitness/app/pipeline/artifact"
+	"github.com/harness/gitness/app/pipeline/cache"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/sse"
@@ -49,9 +51,12 @@ func ProvideExecutionManager(
 	secretStore store.SecretStore,
 	stageStore store.StageStore,
 	stepStore store.StepStore,
-	userStore store.PrincipalStore) ExecutionManager {
+	userStore store.PrincipalStore,
+	artifactService *artifact.Service,
+	cacheService *cache.Service) ExecutionManager {
 	return New(config, executionStore, pipelineStore, urlProvider, sseStreamer, fileService, logStore,
-		logStream, checkStore, repoStore, scheduler, secretStore, stageStore, stepStore, userStore)
+		logStream, checkStore, repoStore, scheduler, secretStore, stageStore, stepStore, userStore, artifactService,
+		cacheService)
 }
 
 // ProvideExecutionClient provides a client implementation to interact with the execution manager.
@@ -0,0 +1,338 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package matrix expands drone yaml pipelines with a matrix into one pipeline per combination:
+//
+//	kind: pipeline
+//	name: test
+//	matrix:
+//	  GO_VERSION: [ "1.20", "1.21" ]
+//	  GOOS: [ linux, darwin ]
+//
+// Every combination becomes a sibling pipeline named after the combination (e.g. "test (GOOS=linux, GO_VERSION=1.20)")
+// with the matrix values added to its environment. Pipelines that depend on the original pipeline depend on all of
+// its combinations instead.
+package matrix
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	droneyaml "github.com/drone/drone-yaml/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// MaxCombinations is the maximum number of combinations of a single matrix.
+	MaxCombinations = 64
+
+	keyMatrix      = "matrix"
+	keyName        = "name"
+	keyDependsOn   = "depends_on"
+	keyEnvironment = "environment"
+
+	defaultName = "default"
+)
+
+type axis struct {
+	name   string
+	values []string
+}
+
+type document struct {
+	raw  *droneyaml.RawResource
+	node *yaml.Node
+	name string
+	axes []axis
+}
+
+// Expand expands the pipelines with a matrix. The data is returned as is in case no pipeline declares a matrix.
+func Expand(data []byte) ([]byte, error) {
+	// only parse the yaml if it can contain a matrix at all.
+	if !bytes.Contains(data, []byte(keyMatrix+":")) {
+		return data, nil
+	}
+
+	raws, err := droneyaml.ParseRawBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make([]*document, 0, len(raws))
+	expanded := false
+	for _, raw := range raws {
+		doc := &document{raw: raw}
+		docs = append(docs, doc)
+
+		if !isPipeline(raw) {
+			continue
+		}
+
+		doc.node = &yaml.Node{}
+		if err = yaml.Unmarshal(raw.Data, doc.node); err != nil {
+			return nil, fmt.Errorf("failed to parse pipeline: %w", err)
+		}
+
+		doc.name = pipelineName(root(doc.node))
+		doc.axes, err = parseAxes(root(doc.node))
+		if err != nil {
+			return nil, fmt.Errorf("invalid matrix of pipeline %q: %w", doc.name, err)
+		}
+		if len(doc.axes) > 0 {
+			expanded = true
+		}
+	}
+
+	if !expanded {
+		return data, nil
+	}
+
+	// collect the names of all combinations to rewrite the dependencies.
+	names := map[string][]string{}
+	for _, doc := range docs {
+		if len(doc.axes) == 0 {
+			continue
+		}
+		for _, combination := range combinations(doc.axes) {
+			names[doc.name] = append(names[doc.name], CombinationName(doc.name, combination))
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	for _, doc := range docs {
+		if doc.node == nil {
+			writeDocument(buf, doc.raw.Data)
+			continue
+		}
+
+		rewriteDependencies(root(doc.node), names)
+
+		if len(doc.axes) == 0 {
+			if err = marshalDocument(buf, doc.node); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, combination := range combinations(doc.axes) {
+			clone := cloneNode(doc.node)
+			applyCombination(root(clone), CombinationName(doc.name, combination), combination)
+			if err = marshalDocument(buf, clone); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// CombinationName returns the name of the pipeline of a matrix combination.
+func CombinationName(name string, combination map[string]string) string {
+	keys := make([]string, 0, len(combination))
+	for k := range combination {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		pairs[i] = k + "=" + combination[k]
+	}
+
+	return fmt.Sprintf("%s (%s)", name, strings.Join(pairs, ", "))
+}
+
+func isPipeline(raw *droneyaml.RawResource) bool {
+	return raw.Kind == "" || raw.Kind == "pipeline"
+}
+
+func pipelineName(mapping *yaml.Node) string {
+	idx := lookup(mapping, keyName)
+	if idx < 0 || mapping.Content[idx].Value == "" {
+		return defaultName
+	}
+	return mapping.Content[idx].Value
+}
+
+// root returns the mapping node of the document.
+func root(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		return node.Content[0]
+	}
+	return node
+}
+
+// lookup returns the index of the value node of the key in the mapping node, or -1.
+func lookup(mapping *yaml.Node, key string) int {
+	if mapping.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+func parseAxes(mapping *yaml.Node) ([]axis, error) {
+	idx := lookup(mapping, keyMatrix)
+	if idx < 0 {
+		return nil, nil
+	}
+
+	node := mapping.Content[idx]
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("matrix has to be a map of variables to lists of values")
+	}
+
+	axes := make([]axis, 0, len(node.Content)/2)
+	total := 1
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name, valuesNode := node.Content[i].Value, node.Content[i+1]
+		if valuesNode.Kind != yaml.SequenceNode || len(valuesNode.Content) == 0 {
+			return nil, fmt.Errorf("matrix variable %q has to be a non-empty list of values", name)
+		}
+
+		values := make([]string, len(valuesNode.Content))
+		for j, v := range valuesNode.Content {
+			if v.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("values of matrix variable %q have to be scalars", name)
+			}
+			values[j] = v.Value
+		}
+
+		total *= len(values)
+		if total > MaxCombinations {
+			return nil, fmt.Errorf("matrix exceeds the maximum of %d combinations", MaxCombinations)
+		}
+
+		axes = append(axes, axis{name: name, values: values})
+	}
+
+	return axes, nil
+}
+
+// combinations returns the cartesian product of the axes, varying the last axis fastest.
+func combinations(axes []axis) []map[string]string {
+	res := []map[string]string{{}}
+	for _, a := range axes {
+		next := make([]map[string]string, 0, len(res)*len(a.values))
+		for _, partial := range res {
+			for _, value := range a.values {
+				combination := make(map[string]string, len(partial)+1)
+				for k, v := range partial {
+					combination[k] = v
+				}
+				combination[a.name] = value
+				next = append(next, combination)
+			}
+		}
+		res = next
+	}
+	return res
+}
+
+// rewriteDependencies replaces dependencies on pipelines with a matrix with all of their combinations.
+func rewriteDependencies(mapping *yaml.Node, names map[string][]string) {
+	idx := lookup(mapping, keyDependsOn)
+	if idx < 0 || mapping.Content[idx].Kind != yaml.SequenceNode {
+		return
+	}
+
+	deps := mapping.Content[idx]
+	content := make([]*yaml.Node, 0, len(deps.Content))
+	for _, dep := range deps.Content {
+		expandedNames, ok := names[dep.Value]
+		if !ok {
+			content = append(content, dep)
+			continue
+		}
+		for _, name := range expandedNames {
+			content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name})
+		}
+	}
+	deps.Content = content
+}
+
+// applyCombination renames the pipeline, adds the combination to its environment and removes the matrix.
+func applyCombination(mapping *yaml.Node, name string, combination map[string]string) {
+	setScalar(mapping, keyName, name)
+
+	idx := lookup(mapping, keyEnvironment)
+	if idx < 0 || mapping.Content[idx].Kind != yaml.MappingNode {
+		if idx >= 0 {
+			mapping.Content = append(mapping.Content[:idx-1], mapping.Content[idx+1:]...)
+		}
+		mapping.Content = append(mapping.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: keyEnvironment},
+			&yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"},
+		)
+		idx = len(mapping.Content) - 1
+	}
+
+	env := mapping.Content[idx]
+	keys := make([]string, 0, len(combination))
+	for k := range combination {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		setScalar(env, k, combination[k])
+	}
+
+	if idx := lookup(mapping, keyMatrix); idx >= 0 {
+		mapping.Content = append(mapping.Content[:idx-1], mapping.Content[idx+1:]...)
+	}
+}
+
+func setScalar(mapping *yaml.Node, key string, value string) {
+	valueNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	if idx := lookup(mapping, key); idx >= 0 {
+		mapping.Content[idx] = valueNode
+		return
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		valueNode,
+	)
+}
+
+func cloneNode(node *yaml.Node) *yaml.Node {
+	clone := *node
+	clone.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		clone.Content[i] = cloneNode(child)
+	}
+	return &clone
+}
+
+func marshalDocument(buf *bytes.Buffer, node *yaml.Node) error {
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pipeline: %w", err)
+	}
+	writeDocument(buf, data)
+	return nil
+}
+
+func writeDocument(buf *bytes.Buffer, data []byte) {
+	buf.WriteString("---\n")
+	buf.Write(data)
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		buf.WriteByte('\n')
+	}
+}
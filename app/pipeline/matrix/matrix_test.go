@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package matrix
+
+import (
+	"bytes"
+	"testing"
+
+	droneyaml "github.com/drone/drone-yaml/yaml"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExpand(t *testing.T) {
+	data := []byte(`kind: pipeline
+name: test
+matrix:
+  GO_VERSION: [ "1.20", "1.21" ]
+  GOOS: [ linux, darwin ]
+environment:
+  CGO_ENABLED: "0"
+steps:
+- name: test
+  image: golang:${GO_VERSION}
+  commands: [ go test ./... ]
+---
+kind: pipeline
+name: publish
+depends_on: [ test ]
+steps:
+- name: publish
+  image: alpine
+`)
+
+	out, err := Expand(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	manifest, err := droneyaml.ParseString(string(out))
+	if err != nil {
+		t.Fatalf("failed to parse expanded yaml: %v", err)
+	}
+
+	var pipelines []*droneyaml.Pipeline
+	for _, r := range manifest.Resources {
+		if p, ok := r.(*droneyaml.Pipeline); ok {
+			pipelines = append(pipelines, p)
+		}
+	}
+
+	if len(pipelines) != 5 {
+		t.Fatalf("expected 5 pipelines, got %d", len(pipelines))
+	}
+
+	want := []string{
+		"test (GOOS=linux, GO_VERSION=1.20)",
+		"test (GOOS=darwin, GO_VERSION=1.20)",
+		"test (GOOS=linux, GO_VERSION=1.21)",
+		"test (GOOS=darwin, GO_VERSION=1.21)",
+	}
+	for i, name := range want {
+		if pipelines[i].Name != name {
+			t.Errorf("pipeline %d: expected name %q, got %q", i, name, pipelines[i].Name)
+		}
+	}
+
+	// the environment of the pipeline is only known to the runner, so decode the document directly.
+	var first struct {
+		Environment map[string]string `yaml:"environment"`
+		Matrix      interface{}       `yaml:"matrix"`
+	}
+	if err = yaml.NewDecoder(bytes.NewReader(out)).Decode(&first); err != nil {
+		t.Fatalf("failed to decode first pipeline: %v", err)
+	}
+	if first.Environment["GO_VERSION"] != "1.20" || first.Environment["GOOS"] != "linux" ||
+		first.Environment["CGO_ENABLED"] != "0" {
+		t.Errorf("unexpected environment: %v", first.Environment)
+	}
+	if first.Matrix != nil {
+		t.Errorf("expected matrix to be removed, got %v", first.Matrix)
+	}
+
+	publish := pipelines[4]
+	if len(publish.DependsOn) != len(want) {
+		t.Fatalf("expected publish to depend on %d pipelines, got %v", len(want), publish.DependsOn)
+	}
+	for i, name := range want {
+		if publish.DependsOn[i] != name {
+			t.Errorf("dependency %d: expected %q, got %q", i, name, publish.DependsOn[i])
+		}
+	}
+}
+
+func TestExpandWithoutMatrix(t *testing.T) {
+	data := []byte("kind: pipeline\nname: default\nsteps: []\n")
+
+	out, err := Expand(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(out) != string(data) {
+		t.Errorf("expected data to be unchanged, got %q", out)
+	}
+}
+
+func TestExpandTooManyCombinations(t *testing.T) {
+	data := []byte(`kind: pipeline
+name: default
+matrix:
+  A: [ 1, 2, 3, 4, 5, 6, 7, 8, 9 ]
+  B: [ 1, 2, 3, 4, 5, 6, 7, 8, 9 ]
+`)
+
+	if _, err := Expand(data); err == nil {
+		t.Errorf("expected an error for a matrix exceeding %d combinations", MaxCombinations)
+	}
+}
@@ -0,0 +1,632 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rpc serves the runner rpc protocol (as used by drone runners) to allow external build agents
+// to poll and execute pipeline stages.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/store"
+	urlprovider "github.com/harness/gitness/app/url"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/drone/drone-go/drone"
+	"github.com/drone/runner-go/client"
+	"github.com/go-chi/chi"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// headerToken is the header used by runners to provide their registration token.
+	headerToken = "X-Drone-Token"
+
+	// pollTimeout is the maximum duration of a long polling request.
+	// The runner automatically reconnects in case no content is returned.
+	pollTimeout = 30 * time.Second
+
+	// heartbeatInterval is the minimum duration between two heartbeat updates of a runner in the database.
+	heartbeatInterval = 30 * time.Second
+
+	paramStageID     = "stage_id"
+	paramStepID      = "step_id"
+	paramExecutionID = "execution_id"
+	paramCacheKey    = "cache_key"
+)
+
+type runnerKey struct{}
+
+// Server serves the runner rpc endpoints. Requests are authenticated via the registration token of the runner.
+type Server struct {
+	client      client.Client
+	manager     manager.ExecutionManager
+	urlProvider urlprovider.Provider
+	runnerStore store.RunnerStore
+	stageStore  store.StageStore
+	stepStore   store.StepStore
+	router      chi.Router
+
+	// stageTokenSecret returns the secret used to sign the artifact tokens of stages.
+	stageTokenSecret func() string
+}
+
+func NewServer(
+	client client.Client,
+	executionManager manager.ExecutionManager,
+	urlProvider urlprovider.Provider,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+) *Server {
+	s := &Server{
+		client:      client,
+		manager:     executionManager,
+		urlProvider: urlProvider,
+		runnerStore: runnerStore,
+		stageStore:  stageStore,
+		stepStore:   stepStore,
+		stageTokenSecret: func() string {
+			return bootstrap.NewPipelineServiceSession().Principal.Salt
+		},
+	}
+
+	r := chi.NewRouter()
+
+	// artifacts and caches are uploaded by steps, authenticated either by the runner or by the token of the stage.
+	r.With(s.authenticateStage).
+		Put(fmt.Sprintf("/v2/stage/{%s}/artifacts/*", paramStageID), s.handleArtifactUpload)
+	r.With(s.authenticateStage).Route(fmt.Sprintf("/v2/stage/{%s}/cache/{%s}", paramStageID, paramCacheKey),
+		func(r chi.Router) {
+			r.Get("/", s.handleCacheRestore)
+			// the built-in cache step uses busybox wget, which only supports uploads via POST.
+			r.Post("/", s.handleCacheSave)
+			r.Put("/", s.handleCacheSave)
+		})
+
+	r.With(s.authenticate).Route("/v2", func(r chi.Router) {
+		r.Post("/ping", s.handlePing)
+		r.Post("/stage", s.handleRequest)
+		r.Route(fmt.Sprintf("/stage/{%s}", paramStageID), func(r chi.Router) {
+			r.Post("/", s.handleAccept)
+			r.With(s.authorizeStage).Get("/", s.handleDetail)
+			r.With(s.authorizeStage).Put("/", s.handleUpdate)
+		})
+		r.With(s.authorizeStep).Route(fmt.Sprintf("/step/{%s}", paramStepID), func(r chi.Router) {
+			r.Put("/", s.handleUpdateStep)
+			r.Post("/logs/batch", s.handleBatch)
+			r.Post("/logs/upload", s.handleUpload)
+			r.Post("/card", s.handleCard)
+		})
+		r.With(s.authorizeExecution).Post(fmt.Sprintf("/build/{%s}/watch", paramExecutionID), s.handleWatch)
+	})
+	s.router = r
+
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.router.ServeHTTP(w, r)
+}
+
+// authenticate is a middleware that authenticates the runner via its registration token
+// and records the heartbeat of the runner.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get(headerToken)
+		if token == "" {
+			render.Unauthorized(w)
+			return
+		}
+
+		runner, err := s.runnerStore.FindByTokenHash(ctx, HashToken(token))
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			render.Unauthorized(w)
+			return
+		}
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to find runner by token")
+			render.InternalError(w)
+			return
+		}
+
+		s.heartbeat(ctx, runner, types.RunnerHeartbeat{Machine: r.URL.Query().Get("machine")})
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, runnerKey{}, runner)))
+	})
+}
+
+// authenticateStage is a middleware that authenticates requests for a specific stage.
+// It accepts the registration token of a runner as well as the token of the stage.
+func (s *Server) authenticateStage(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(headerToken) != "" {
+			s.authenticate(s.authorizeStage(next)).ServeHTTP(w, r)
+			return
+		}
+
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			render.Unauthorized(w)
+			return
+		}
+
+		stageID, err := request.PathParamAsPositiveInt64(r, paramStageID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		tokenStageID, err := jwt.ParseStage(token, s.stageTokenSecret())
+		if err != nil {
+			log.Ctx(r.Context()).Debug().Err(err).Msg("failed to parse stage token")
+			render.Unauthorized(w)
+			return
+		}
+
+		if tokenStageID != stageID {
+			render.Forbidden(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorizeStage is a middleware that rejects requests of the authenticated runner
+// for stages that haven't been accepted by the runner.
+func (s *Server) authorizeStage(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		stageID, err := request.PathParamAsPositiveInt64(r, paramStageID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		stage, err := s.stageStore.Find(ctx, stageID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if !isAssignedToRunner(ctx, stage) {
+			render.Forbidden(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorizeStep is a middleware that rejects requests of the authenticated runner
+// for steps of stages that haven't been accepted by the runner.
+func (s *Server) authorizeStep(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		stepID, err := request.PathParamAsPositiveInt64(r, paramStepID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		step, err := s.stepStore.Find(ctx, stepID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		stage, err := s.stageStore.Find(ctx, step.StageID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		if !isAssignedToRunner(ctx, stage) {
+			render.Forbidden(w)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authorizeExecution is a middleware that rejects requests of the authenticated runner
+// for executions without any stage that has been accepted by the runner.
+func (s *Server) authorizeExecution(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		executionID, err := request.PathParamAsPositiveInt64(r, paramExecutionID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		stages, err := s.stageStore.List(ctx, executionID)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		for _, stage := range stages {
+			if isAssignedToRunner(ctx, stage) {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		render.Forbidden(w)
+	})
+}
+
+// isAssignedToRunner returns true in case the stage has been accepted by the authenticated runner.
+func isAssignedToRunner(ctx context.Context, stage *types.Stage) bool {
+	runner, ok := ctx.Value(runnerKey{}).(*types.Runner)
+	return ok && stage.RunnerID != nil && *stage.RunnerID == runner.ID
+}
+
+// heartbeat updates the heartbeat of the runner. To reduce the load on the database,
+// the heartbeat is only stored in case it's outdated or the reported information changed.
+func (s *Server) heartbeat(ctx context.Context, runner *types.Runner, heartbeat types.RunnerHeartbeat) {
+	now := time.Now()
+	changed := (heartbeat.Machine != "" && heartbeat.Machine != runner.Machine) ||
+		(heartbeat.OS != "" && heartbeat.OS != runner.OS) ||
+		(heartbeat.Arch != "" && heartbeat.Arch != runner.Arch)
+	if !changed && now.Sub(time.UnixMilli(runner.LastSeen)) < heartbeatInterval {
+		return
+	}
+
+	heartbeat.LastSeen = now.UnixMilli()
+	err := s.runnerStore.UpdateHeartbeat(ctx, runner.ID, heartbeat)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("runner", runner.UID).Msg("failed to update runner heartbeat")
+		return
+	}
+
+	runner.LastSeen = heartbeat.LastSeen
+}
+
+func (s *Server) handlePing(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleRequest long polls for the next stage matching the filter of the runner.
+func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	filter := &client.Filter{}
+	if err := json.NewDecoder(r.Body).Decode(filter); err != nil {
+		render.BadRequestf(w, "Invalid request body: %s.", err)
+		return
+	}
+
+	if runner, ok := ctx.Value(runnerKey{}).(*types.Runner); ok {
+		s.heartbeat(ctx, runner, types.RunnerHeartbeat{OS: filter.OS, Arch: filter.Arch})
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, pollTimeout)
+	defer cancel()
+
+	stage, err := s.client.Request(ctx, filter)
+	if ctx.Err() != nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, stage)
+}
+
+func (s *Server) handleAccept(w http.ResponseWriter, r *http.Request) {
+	stageID, err := request.PathParamAsPositiveInt64(r, paramStageID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	runner, ok := r.Context().Value(runnerKey{}).(*types.Runner)
+	if !ok {
+		render.Unauthorized(w)
+		return
+	}
+
+	// the stage is assigned to the runner, all later requests for the stage are restricted to it.
+	stage, err := s.manager.Accept(r.Context(), stageID, r.URL.Query().Get("machine"), &runner.ID)
+	if errors.Is(err, manager.ErrStageAlreadyAssigned) || errors.Is(err, gitness_store.ErrVersionConflict) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, manager.ConvertToDroneStage(stage))
+}
+
+func (s *Server) handleDetail(w http.ResponseWriter, r *http.Request) {
+	stageID, err := request.PathParamAsPositiveInt64(r, paramStageID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	details, err := s.client.Detail(r.Context(), &drone.Stage{ID: stageID})
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	// the embedded client uses the container clone url - external runners have to use the public one.
+	if details.Repo != nil {
+		cloneURL := s.urlProvider.GenerateGITCloneURL(details.Repo.Namespace)
+		details.Repo.HTTPURL = cloneURL
+		details.Repo.Link = cloneURL
+
+		if u, err := url.Parse(cloneURL); err == nil && details.Netrc != nil {
+			details.Netrc.Machine = u.Hostname()
+		}
+	}
+
+	// same for the artifact and cache urls - use the rpc endpoint the runner is connected to.
+	if details.Build != nil {
+		if _, ok := details.Build.Params[manager.ArtifactsURLParam]; ok {
+			details.Build.Params[manager.ArtifactsURLParam] = manager.ArtifactsURL(publicRPCURL(r), stageID)
+		}
+		if _, ok := details.Build.Params[manager.CacheURLParam]; ok {
+			details.Build.Params[manager.CacheURLParam] = manager.CacheURL(publicRPCURL(r), stageID)
+		}
+	}
+
+	render.JSON(w, http.StatusOK, details)
+}
+
+func (s *Server) handleUpdate(w http.ResponseWriter, r *http.Request) {
+	stageID, err := request.PathParamAsPositiveInt64(r, paramStageID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	stage := &drone.Stage{}
+	if err = json.NewDecoder(r.Body).Decode(stage); err != nil {
+		render.BadRequestf(w, "Invalid request body: %s.", err)
+		return
+	}
+	stage.ID = stageID
+
+	err = s.client.Update(r.Context(), stage)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, stage)
+}
+
+func (s *Server) handleUpdateStep(w http.ResponseWriter, r *http.Request) {
+	stepID, err := request.PathParamAsPositiveInt64(r, paramStepID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	step := &drone.Step{}
+	if err = json.NewDecoder(r.Body).Decode(step); err != nil {
+		render.BadRequestf(w, "Invalid request body: %s.", err)
+		return
+	}
+	step.ID = stepID
+
+	err = s.client.UpdateStep(r.Context(), step)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, step)
+}
+
+// handleWatch long polls for the cancellation of the execution.
+// Returns 200 in case the execution got canceled (or completed), 204 otherwise.
+func (s *Server) handleWatch(w http.ResponseWriter, r *http.Request) {
+	executionID, err := request.PathParamAsPositiveInt64(r, paramExecutionID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), pollTimeout)
+	defer cancel()
+
+	done, err := s.client.Watch(ctx, executionID)
+	if ctx.Err() != nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	if !done {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	s.handleLines(w, r, s.client.Batch)
+}
+
+func (s *Server) handleUpload(w http.ResponseWriter, r *http.Request) {
+	s.handleLines(w, r, s.client.Upload)
+}
+
+func (s *Server) handleLines(
+	w http.ResponseWriter,
+	r *http.Request,
+	fn func(ctx context.Context, step int64, lines []*drone.Line) error,
+) {
+	stepID, err := request.PathParamAsPositiveInt64(r, paramStepID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	var lines []*drone.Line
+	if err = json.NewDecoder(r.Body).Decode(&lines); err != nil {
+		render.BadRequestf(w, "Invalid request body: %s.", err)
+		return
+	}
+
+	err = fn(r.Context(), stepID, lines)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleArtifactUpload stores the request body as artifact of the stage.
+// Tar archives (content type application/x-tar) are extracted, with the artifact name used as directory.
+func (s *Server) handleArtifactUpload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stageID, err := request.PathParamAsPositiveInt64(r, paramStageID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	name, err := url.PathUnescape(chi.URLParam(r, "*"))
+	if err != nil {
+		render.BadRequestf(w, "Invalid artifact name: %s.", err)
+		return
+	}
+
+	if r.Header.Get("Content-Type") == "application/x-tar" {
+		artifacts, err := s.manager.UploadArtifactArchive(ctx, stageID, name, r.Body)
+		if err != nil {
+			render.TranslatedUserError(w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, artifacts)
+		return
+	}
+
+	artifact, err := s.manager.UploadArtifact(ctx, stageID, name, r.Body)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, artifact)
+}
+
+// handleCacheRestore returns the content of the cache entry with the key.
+func (s *Server) handleCacheRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stageID, err := request.PathParamAsPositiveInt64(r, paramStageID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	file, err := s.manager.RestoreCache(ctx, stageID, chi.URLParam(r, paramCacheKey))
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close cache reader")
+		}
+	}()
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.WriteHeader(http.StatusOK)
+	if _, err = io.Copy(w, file); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to stream cache")
+	}
+}
+
+// handleCacheSave stores the request body as cache entry with the key.
+func (s *Server) handleCacheSave(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stageID, err := request.PathParamAsPositiveInt64(r, paramStageID)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	entry, err := s.manager.SaveCache(ctx, stageID, chi.URLParam(r, paramCacheKey), r.Body)
+	if err != nil {
+		render.TranslatedUserError(w, err)
+		return
+	}
+
+	render.JSON(w, http.StatusOK, entry)
+}
+
+// publicRPCURL returns the url of the rpc endpoint as used by the caller of the request.
+func publicRPCURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	return (&url.URL{Scheme: scheme, Host: r.Host, Path: "/" + urlprovider.RPCMount}).String()
+}
+
+// handleCard accepts step cards, which aren't supported (yet) and are discarded.
+func (s *Server) handleCard(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
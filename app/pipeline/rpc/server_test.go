@@ -0,0 +1,271 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/jwt"
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakeRunnerStore struct {
+	store.RunnerStore
+	runner     *types.Runner
+	heartbeats []types.RunnerHeartbeat
+}
+
+func (s *fakeRunnerStore) FindByTokenHash(_ context.Context, tokenHash string) (*types.Runner, error) {
+	if s.runner == nil || s.runner.TokenHash != tokenHash {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	r := *s.runner
+	return &r, nil
+}
+
+func (s *fakeRunnerStore) UpdateHeartbeat(_ context.Context, _ int64, heartbeat types.RunnerHeartbeat) error {
+	s.heartbeats = append(s.heartbeats, heartbeat)
+	s.runner.LastSeen = heartbeat.LastSeen
+	return nil
+}
+
+type fakeStageStore struct {
+	store.StageStore
+	stages map[int64]*types.Stage
+}
+
+func (s *fakeStageStore) Find(_ context.Context, stageID int64) (*types.Stage, error) {
+	stage, ok := s.stages[stageID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return stage, nil
+}
+
+func (s *fakeStageStore) List(_ context.Context, executionID int64) ([]*types.Stage, error) {
+	var stages []*types.Stage
+	for _, stage := range s.stages {
+		if stage.ExecutionID == executionID {
+			stages = append(stages, stage)
+		}
+	}
+	return stages, nil
+}
+
+type fakeStepStore struct {
+	store.StepStore
+	steps map[int64]*types.Step
+}
+
+func (s *fakeStepStore) Find(_ context.Context, stepID int64) (*types.Step, error) {
+	step, ok := s.steps[stepID]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return step, nil
+}
+
+type fakeExecutionManager struct {
+	manager.ExecutionManager
+}
+
+func (m *fakeExecutionManager) UploadArtifact(
+	_ context.Context,
+	stageID int64,
+	name string,
+	r io.Reader,
+) (*types.Artifact, error) {
+	n, err := io.Copy(io.Discard, r)
+	if err != nil {
+		return nil, err
+	}
+	return &types.Artifact{StageID: stageID, Name: name, Size: n}, nil
+}
+
+func TestServerArtifactAuthentication(t *testing.T) {
+	runnerToken, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("failed to generate token: %s", err)
+	}
+
+	const secret = "secret"
+	stageToken := func(stageID int64) string {
+		token, err := jwt.GenerateForStage(1, stageID, time.Minute, secret)
+		if err != nil {
+			t.Fatalf("failed to generate stage token: %s", err)
+		}
+		return token
+	}
+
+	runnerStore := &fakeRunnerStore{
+		runner: &types.Runner{ID: 1, UID: "runner", TokenHash: HashToken(runnerToken)},
+	}
+	stageStore := &fakeStageStore{
+		stages: map[int64]*types.Stage{1: {ID: 1, RunnerID: &runnerStore.runner.ID}},
+	}
+	server := NewServer(nil, &fakeExecutionManager{}, nil, runnerStore, stageStore, nil)
+	server.stageTokenSecret = func() string { return secret }
+
+	tests := []struct {
+		name     string
+		header   string
+		value    string
+		expected int
+	}{
+		{name: "no token", expected: http.StatusUnauthorized},
+		{name: "invalid token", header: "Authorization", value: "Bearer invalid", expected: http.StatusUnauthorized},
+		{name: "token of other stage", header: "Authorization", value: "Bearer " + stageToken(2),
+			expected: http.StatusForbidden},
+		{name: "token of stage", header: "Authorization", value: "Bearer " + stageToken(1), expected: http.StatusOK},
+		{name: "runner token", header: headerToken, value: runnerToken, expected: http.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/v2/stage/1/artifacts/dist/app.zip", strings.NewReader("data"))
+			if test.header != "" {
+				req.Header.Set(test.header, test.value)
+			}
+			rec := httptest.NewRecorder()
+
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != test.expected {
+				t.Errorf("expected status %d, got %d", test.expected, rec.Code)
+			}
+		})
+	}
+}
+
+func TestServerAuthentication(t *testing.T) {
+	token, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("failed to generate token: %s", err)
+	}
+
+	runnerStore := &fakeRunnerStore{
+		runner: &types.Runner{ID: 1, UID: "runner", TokenHash: HashToken(token)},
+	}
+	server := NewServer(nil, nil, nil, runnerStore, nil, nil)
+
+	tests := []struct {
+		name       string
+		token      string
+		expected   int
+		heartbeats int
+	}{
+		{name: "no token", token: "", expected: http.StatusUnauthorized, heartbeats: 0},
+		{name: "invalid token", token: "invalid", expected: http.StatusUnauthorized, heartbeats: 0},
+		{name: "valid token", token: token, expected: http.StatusOK, heartbeats: 1},
+		{name: "heartbeat is throttled", token: token, expected: http.StatusOK, heartbeats: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/v2/ping", nil)
+			if test.token != "" {
+				req.Header.Set(headerToken, test.token)
+			}
+			rec := httptest.NewRecorder()
+
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != test.expected {
+				t.Errorf("expected status %d, got %d", test.expected, rec.Code)
+			}
+			if len(runnerStore.heartbeats) != test.heartbeats {
+				t.Errorf("expected %d heartbeats, got %d", test.heartbeats, len(runnerStore.heartbeats))
+			}
+		})
+	}
+}
+
+func TestServerStageAuthorization(t *testing.T) {
+	token, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("failed to generate token: %s", err)
+	}
+
+	runnerID, otherRunnerID := int64(1), int64(2)
+	runnerStore := &fakeRunnerStore{
+		runner: &types.Runner{ID: runnerID, UID: "runner", TokenHash: HashToken(token)},
+	}
+	stageStore := &fakeStageStore{
+		stages: map[int64]*types.Stage{
+			1: {ID: 1, ExecutionID: 1, RunnerID: &runnerID},
+			2: {ID: 2, ExecutionID: 2, RunnerID: &otherRunnerID},
+			3: {ID: 3, ExecutionID: 3},
+		},
+	}
+	stepStore := &fakeStepStore{
+		steps: map[int64]*types.Step{
+			1: {ID: 1, StageID: 1},
+			2: {ID: 2, StageID: 2},
+			3: {ID: 3, StageID: 3},
+		},
+	}
+	server := NewServer(nil, &fakeExecutionManager{}, nil, runnerStore, stageStore, stepStore)
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		expected int
+	}{
+		{name: "artifact of assigned stage", method: http.MethodPut, path: "/v2/stage/1/artifacts/app.zip",
+			expected: http.StatusOK},
+		{name: "detail of stage of other runner", method: http.MethodGet, path: "/v2/stage/2",
+			expected: http.StatusForbidden},
+		{name: "detail of unassigned stage", method: http.MethodGet, path: "/v2/stage/3",
+			expected: http.StatusForbidden},
+		{name: "detail of unknown stage", method: http.MethodGet, path: "/v2/stage/4",
+			expected: http.StatusNotFound},
+		{name: "update of stage of other runner", method: http.MethodPut, path: "/v2/stage/2",
+			expected: http.StatusForbidden},
+		{name: "artifact of stage of other runner", method: http.MethodPut, path: "/v2/stage/2/artifacts/app.zip",
+			expected: http.StatusForbidden},
+		{name: "card of assigned step", method: http.MethodPost, path: "/v2/step/1/card",
+			expected: http.StatusOK},
+		{name: "step of other runner", method: http.MethodPut, path: "/v2/step/2",
+			expected: http.StatusForbidden},
+		{name: "logs of unassigned step", method: http.MethodPost, path: "/v2/step/3/logs/batch",
+			expected: http.StatusForbidden},
+		{name: "watch of execution of other runner", method: http.MethodPost, path: "/v2/build/2/watch",
+			expected: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(test.method, test.path, strings.NewReader("[]"))
+			req.Header.Set(headerToken, token)
+			rec := httptest.NewRecorder()
+
+			server.ServeHTTP(rec, req)
+
+			if rec.Code != test.expected {
+				t.Errorf("expected status %d, got %d", test.expected, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rpc
+
+import (
+	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+
+	"github.com/drone/runner-go/client"
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideServer,
+)
+
+// ProvideServer provides the runner rpc server.
+// It reuses the embedded client to translate between the runner protocol and the execution manager.
+func ProvideServer(
+	client client.Client,
+	executionManager manager.ExecutionManager,
+	urlProvider url.Provider,
+	runnerStore store.RunnerStore,
+	stageStore store.StageStore,
+	stepStore store.StepStore,
+) *Server {
+	return NewServer(client, executionManager, urlProvider, runnerStore, stageStore, stepStore)
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/harness/gitness/app/pipeline/manager"
+
+	"github.com/drone-runners/drone-runner-docker/engine/resource"
+	"github.com/drone/runner-go/manifest"
+	"github.com/drone/runner-go/pipeline/runtime"
+)
+
+// CacheStepImage is the reserved image of the built-in cache step. Steps using it aren't executed
+// as plugin, but replaced with a script that restores or saves the cache via the rpc endpoint:
+//
+//	steps:
+//	- name: restore
+//	  image: gitness/cache
+//	  settings:
+//	    restore: true # or save: true
+//	    key: go-mod
+//	    key_files: [ go.sum ]
+//	    mount: [ .cache/go-mod ]
+const CacheStepImage = "gitness/cache"
+
+const cacheArchivePath = "/tmp/gitness-cache.tgz"
+
+var (
+	cacheKeyRegex  = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+	cachePathRegex = regexp.MustCompile(`^[a-zA-Z0-9._/-]+$`)
+)
+
+// cacheCompiler is a compiler that replaces the built-in cache steps before compiling the pipeline.
+type cacheCompiler struct {
+	runtime.Compiler
+	image string
+}
+
+func (c *cacheCompiler) Compile(ctx context.Context, args runtime.CompilerArgs) runtime.Spec {
+	pipeline, ok := args.Pipeline.(*resource.Pipeline)
+	if !ok {
+		return c.Compiler.Compile(ctx, args)
+	}
+
+	// don't modify the parsed pipeline, it's owned by the caller.
+	clone := *pipeline
+	clone.Steps = make([]*resource.Step, len(pipeline.Steps))
+	for i, step := range pipeline.Steps {
+		if strings.TrimSuffix(step.Image, ":latest") != CacheStepImage {
+			clone.Steps[i] = step
+			continue
+		}
+
+		cacheStep := *step
+		cacheStep.Image = c.image
+		cacheStep.Entrypoint = nil
+		cacheStep.Command = nil
+		cacheStep.Settings = nil
+		cacheStep.Commands = cacheCommands(step.Settings)
+		clone.Steps[i] = &cacheStep
+	}
+
+	args.Pipeline = &clone
+
+	return c.Compiler.Compile(ctx, args)
+}
+
+// cacheCommands returns the shell commands of a cache step with the provided settings.
+// Invalid settings result in commands that fail the step with the error.
+func cacheCommands(settings map[string]*manifest.Parameter) []string {
+	commands, err := buildCacheCommands(settings)
+	if err != nil {
+		return []string{
+			// the error can contain user input, strip quotes to keep it within the single quoted string.
+			fmt.Sprintf("echo 'invalid cache step: %s'", strings.ReplaceAll(err.Error(), "'", "")),
+			"exit 1",
+		}
+	}
+	return commands
+}
+
+//nolint:gocognit // the settings are validated inline on purpose.
+func buildCacheCommands(settings map[string]*manifest.Parameter) ([]string, error) {
+	restore := settingString(settings, "restore") == "true"
+	save := settingString(settings, "save") == "true"
+	if restore == save {
+		return nil, fmt.Errorf("exactly one of restore or save has to be enabled")
+	}
+
+	key := settingString(settings, "key")
+	if key == "" {
+		key = "default"
+	}
+	if !cacheKeyRegex.MatchString(key) {
+		return nil, fmt.Errorf("key has to consist of up to 64 alphanumeric, '.', '_' or '-' characters")
+	}
+
+	keyFiles := settingList(settings, "key_files")
+	for _, file := range keyFiles {
+		if !isCachePath(file) {
+			return nil, fmt.Errorf("key file %q has to be a relative path within the workspace", file)
+		}
+	}
+
+	mounts := settingList(settings, "mount")
+	if len(mounts) == 0 {
+		return nil, fmt.Errorf("at least one mount has to be provided")
+	}
+	for _, mount := range mounts {
+		if !isCachePath(mount) {
+			return nil, fmt.Errorf("mount %q has to be a relative path within the workspace", mount)
+		}
+	}
+
+	commands := []string{fmt.Sprintf("KEY=%s", key)}
+	if len(keyFiles) > 0 {
+		// the key changes whenever any of the key files changes (e.g. a lock file).
+		commands = append(commands,
+			fmt.Sprintf(`KEY="$KEY-$(cat %s | sha256sum | cut -c1-32)"`, strings.Join(keyFiles, " ")))
+	}
+
+	auth := fmt.Sprintf(`--header "Authorization: Bearer $%s"`, manager.CacheTokenParam)
+	url := fmt.Sprintf(`"$%s/$KEY"`, manager.CacheURLParam)
+
+	if restore {
+		commands = append(commands,
+			fmt.Sprintf(`if wget -q -O %s %s %s; then tar -xzf %s && echo "restored cache $KEY"; `+
+				`else echo "no cache found for $KEY"; fi`,
+				cacheArchivePath, auth, url, cacheArchivePath),
+			fmt.Sprintf("rm -f %s", cacheArchivePath),
+		)
+		return commands, nil
+	}
+
+	// mounts that don't exist are skipped, failing to upload the cache doesn't fail the step.
+	commands = append(commands,
+		fmt.Sprintf(`MOUNTS=""; for p in %s; do if [ -e "$p" ]; then MOUNTS="$MOUNTS $p"; fi; done`,
+			strings.Join(mounts, " ")),
+		fmt.Sprintf(`if [ -z "$MOUNTS" ]; then echo "nothing to cache"; `+
+			`else tar -czf %s $MOUNTS && (wget -q -O /dev/null %s --post-file %s %s `+
+			`&& echo "saved cache $KEY" || echo "failed to save cache $KEY"); fi`,
+			cacheArchivePath, auth, cacheArchivePath, url),
+		fmt.Sprintf("rm -f %s", cacheArchivePath),
+	)
+
+	return commands, nil
+}
+
+// isCachePath returns true if the path is a relative path within the workspace without special characters.
+func isCachePath(p string) bool {
+	if !cachePathRegex.MatchString(p) || strings.HasPrefix(p, "/") {
+		return false
+	}
+	for _, segment := range strings.Split(p, "/") {
+		if segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+func settingString(settings map[string]*manifest.Parameter, name string) string {
+	param, ok := settings[name]
+	if !ok || param == nil || param.Value == nil {
+		return ""
+	}
+	return strings.TrimSpace(fmt.Sprint(param.Value))
+}
+
+// settingList returns the values of a list setting, which can also be provided as comma separated string.
+func settingList(settings map[string]*manifest.Parameter, name string) []string {
+	param, ok := settings[name]
+	if !ok || param == nil || param.Value == nil {
+		return nil
+	}
+
+	var values []string
+	switch v := param.Value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+	default:
+		values = strings.Split(fmt.Sprint(v), ",")
+	}
+
+	res := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			res = append(res, value)
+		}
+	}
+	return res
+}
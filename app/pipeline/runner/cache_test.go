@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/drone-runners/drone-runner-docker/engine/resource"
+	"github.com/drone/runner-go/manifest"
+	"github.com/drone/runner-go/pipeline/runtime"
+)
+
+type fakeCompiler struct {
+	pipeline *resource.Pipeline
+}
+
+func (c *fakeCompiler) Compile(_ context.Context, args runtime.CompilerArgs) runtime.Spec {
+	c.pipeline, _ = args.Pipeline.(*resource.Pipeline)
+	return nil
+}
+
+func params(values map[string]interface{}) map[string]*manifest.Parameter {
+	res := make(map[string]*manifest.Parameter, len(values))
+	for k, v := range values {
+		res[k] = &manifest.Parameter{Value: v}
+	}
+	return res
+}
+
+func TestCacheCompiler(t *testing.T) {
+	build := &resource.Step{Name: "build", Image: "golang", Commands: []string{"go build"}}
+	restore := &resource.Step{Name: "restore", Image: "gitness/cache", Settings: params(map[string]interface{}{
+		"restore":   true,
+		"key":       "go",
+		"key_files": []interface{}{"go.sum"},
+		"mount":     []interface{}{".cache/go-mod"},
+	})}
+	pipeline := &resource.Pipeline{Steps: []*resource.Step{restore, build}}
+
+	inner := &fakeCompiler{}
+	c := &cacheCompiler{Compiler: inner, image: "alpine:3"}
+	c.Compile(context.Background(), runtime.CompilerArgs{Pipeline: pipeline})
+
+	if pipeline.Steps[0] != restore || restore.Image != "gitness/cache" {
+		t.Fatal("expected the original pipeline to be unchanged")
+	}
+
+	compiled := inner.pipeline.Steps[0]
+	if compiled.Image != "alpine:3" || compiled.Settings != nil {
+		t.Errorf("expected the cache step to be replaced, got image %q", compiled.Image)
+	}
+	script := strings.Join(compiled.Commands, "\n")
+	for _, expected := range []string{"KEY=go", "cat go.sum | sha256sum", "wget -q -O /tmp/gitness-cache.tgz"} {
+		if !strings.Contains(script, expected) {
+			t.Errorf("expected the restore script to contain %q, got:\n%s", expected, script)
+		}
+	}
+	if inner.pipeline.Steps[1] != build {
+		t.Error("expected other steps to be unchanged")
+	}
+}
+
+func TestBuildCacheCommandsValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		valid    bool
+	}{
+		{
+			name:     "save",
+			settings: map[string]interface{}{"save": true, "mount": "node_modules, .cache/go-mod"},
+			valid:    true,
+		},
+		{
+			name:     "neither restore nor save",
+			settings: map[string]interface{}{"mount": "node_modules"},
+		},
+		{
+			name:     "restore and save",
+			settings: map[string]interface{}{"restore": true, "save": true, "mount": "node_modules"},
+		},
+		{
+			name:     "no mount",
+			settings: map[string]interface{}{"save": true},
+		},
+		{
+			name:     "absolute mount",
+			settings: map[string]interface{}{"save": true, "mount": "/root/.cache"},
+		},
+		{
+			name:     "mount outside workspace",
+			settings: map[string]interface{}{"save": true, "mount": "../other"},
+		},
+		{
+			name:     "mount with shell characters",
+			settings: map[string]interface{}{"save": true, "mount": "$(reboot)"},
+		},
+		{
+			name:     "invalid key",
+			settings: map[string]interface{}{"save": true, "key": "a b", "mount": "node_modules"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := buildCacheCommands(params(test.settings))
+			if test.valid && err != nil {
+				t.Errorf("expected settings to be valid, got %s", err)
+			}
+			if !test.valid && err == nil {
+				t.Error("expected settings to be invalid")
+			}
+		})
+	}
+}
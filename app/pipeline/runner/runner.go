@@ -80,7 +80,7 @@ func NewExecutionRunner(
 		Reporter: tracer,
 		Lookup:   resource.Lookup,
 		Lint:     linter.New().Lint,
-		Compiler: compiler,
+		Compiler: &cacheCompiler{Compiler: compiler, image: config.CI.Cache.Image},
 		Exec:     exec.Exec,
 	}
 
@@ -0,0 +1,46 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gorhill/cronexpr"
+)
+
+// NextCronRun returns the next time after the provided time at which the cron expression is due.
+// The expression is evaluated in the provided timezone (IANA name), UTC is used in case it's empty.
+func NextCronRun(expression string, timezone string, after time.Time) (time.Time, error) {
+	cronExp, err := cronexpr.Parse(expression)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid cron expression: %w", err)
+	}
+
+	loc := time.UTC
+	if timezone != "" {
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid timezone: %w", err)
+		}
+	}
+
+	next := cronExp.Next(after.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("cron expression %q is never due", expression)
+	}
+
+	return next, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextCronRun(t *testing.T) {
+	after := time.Date(2023, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		expression string
+		timezone   string
+		expected   time.Time
+		expectErr  bool
+	}{
+		{
+			name:       "utc by default",
+			expression: "0 2 * * *",
+			expected:   time.Date(2023, 10, 2, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:       "with timezone",
+			expression: "0 2 * * *",
+			timezone:   "Asia/Kolkata",
+			expected:   time.Date(2023, 10, 1, 20, 30, 0, 0, time.UTC),
+		},
+		{
+			name:       "invalid expression",
+			expression: "not a cron",
+			expectErr:  true,
+		},
+		{
+			name:       "invalid timezone",
+			expression: "0 2 * * *",
+			timezone:   "Mars/Olympus",
+			expectErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := NextCronRun(test.expression, test.timezone, after)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !got.Equal(test.expected) {
+				t.Errorf("expected %s, got %s", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/harness/gitness/app/api/usererror"
+
+	"github.com/drone/drone-yaml/yaml"
+	v1yaml "github.com/drone/spec/dist/go"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+const (
+	inputTypeString  = "string"
+	inputTypeNumber  = "number"
+	inputTypeBoolean = "boolean"
+)
+
+var inputNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// declaredInputs returns the input parameters declared by the pipeline yaml.
+// For drone yaml, inputs are declared per pipeline document and the first declaration of an input wins.
+func declaredInputs(data []byte) (map[string]*v1yaml.Input, error) {
+	if isV1Yaml(data) {
+		config, err := v1yaml.ParseBytes(data)
+		if err != nil {
+			// parsing errors are reported when the stages are created.
+			return nil, nil //nolint:nilerr // on purpose
+		}
+		if pipeline, ok := config.Spec.(*v1yaml.Pipeline); ok {
+			return pipeline.Inputs, nil
+		}
+		return nil, nil
+	}
+
+	raws, err := yaml.ParseRawBytes(data)
+	if err != nil {
+		// parsing errors are reported when the stages are created.
+		return nil, nil //nolint:nilerr // on purpose
+	}
+
+	declared := map[string]*v1yaml.Input{}
+	for _, raw := range raws {
+		if raw.Kind != "" && raw.Kind != "pipeline" {
+			continue
+		}
+
+		doc := struct {
+			Inputs map[string]*v1yaml.Input `yaml:"inputs"`
+		}{}
+		if err = yamlv3.Unmarshal(raw.Data, &doc); err != nil {
+			return nil, usererror.BadRequestf("Invalid inputs in pipeline yaml: %s", err)
+		}
+
+		for name, input := range doc.Inputs {
+			if _, ok := declared[name]; !ok {
+				declared[name] = input
+			}
+		}
+	}
+
+	return declared, nil
+}
+
+// resolveInputs validates the provided parameters against the declared inputs
+// and returns the parameters of the execution, with defaults applied.
+func resolveInputs(
+	declared map[string]*v1yaml.Input,
+	provided map[string]string,
+) (map[string]string, error) {
+	for name := range provided {
+		if _, ok := declared[name]; !ok {
+			return nil, usererror.BadRequestf("Pipeline doesn't declare an input %q.", name)
+		}
+	}
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make(map[string]string, len(declared))
+	for _, name := range names {
+		input := declared[name]
+		if input == nil {
+			input = &v1yaml.Input{}
+		}
+
+		if !inputNameRegex.MatchString(name) {
+			return nil, usererror.BadRequestf(
+				"Input name %q is invalid, it has to be a valid environment variable name.", name)
+		}
+
+		value, ok := provided[name]
+		if !ok {
+			if input.Default == nil {
+				if input.Required {
+					return nil, usererror.BadRequestf("Input %q is required.", name)
+				}
+				continue
+			}
+			value = fmt.Sprint(input.Default)
+		}
+
+		value, err := sanitizeInput(name, input, value)
+		if err != nil {
+			return nil, err
+		}
+
+		params[name] = value
+	}
+
+	return params, nil
+}
+
+func sanitizeInput(name string, input *v1yaml.Input, value string) (string, error) {
+	switch input.Type {
+	case "", inputTypeString:
+	case inputTypeNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", usererror.BadRequestf("Input %q has to be a number.", name)
+		}
+	case inputTypeBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", usererror.BadRequestf("Input %q has to be a boolean.", name)
+		}
+		value = strconv.FormatBool(b)
+	default:
+		// other types are passed on as is.
+	}
+
+	if len(input.Enum) == 0 {
+		return value, nil
+	}
+
+	for _, allowed := range input.Enum {
+		if value == allowed {
+			return value, nil
+		}
+	}
+
+	return "", usererror.BadRequestf("Input %q has to be one of %v.", name, input.Enum)
+}
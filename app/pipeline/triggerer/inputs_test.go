@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggerer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveInputs(t *testing.T) {
+	data := []byte(`kind: pipeline
+name: default
+inputs:
+  TARGET:
+    type: string
+    enum: [ staging, production ]
+    required: true
+  DRY_RUN:
+    type: boolean
+    default: true
+  REPLICAS:
+    type: number
+    default: 2
+  NOTE:
+    type: string
+steps: []
+`)
+
+	declared, err := declaredInputs(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		provided  map[string]string
+		expected  map[string]string
+		expectErr bool
+	}{
+		{
+			name:     "defaults",
+			provided: map[string]string{"TARGET": "staging"},
+			expected: map[string]string{"TARGET": "staging", "DRY_RUN": "true", "REPLICAS": "2"},
+		},
+		{
+			name:     "provided",
+			provided: map[string]string{"TARGET": "production", "DRY_RUN": "0", "REPLICAS": "3.5", "NOTE": "hi"},
+			expected: map[string]string{"TARGET": "production", "DRY_RUN": "false", "REPLICAS": "3.5", "NOTE": "hi"},
+		},
+		{
+			name:      "missing required",
+			provided:  map[string]string{},
+			expectErr: true,
+		},
+		{
+			name:      "not in enum",
+			provided:  map[string]string{"TARGET": "qa"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid number",
+			provided:  map[string]string{"TARGET": "staging", "REPLICAS": "many"},
+			expectErr: true,
+		},
+		{
+			name:      "invalid boolean",
+			provided:  map[string]string{"TARGET": "staging", "DRY_RUN": "maybe"},
+			expectErr: true,
+		},
+		{
+			name:      "undeclared",
+			provided:  map[string]string{"TARGET": "staging", "OTHER": "x"},
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := resolveInputs(declared, test.provided)
+			if test.expectErr {
+				if err == nil {
+					t.Errorf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+}
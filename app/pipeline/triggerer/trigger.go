@@ -24,6 +24,7 @@ import (
 	"github.com/harness/gitness/app/pipeline/checks"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
+	"github.com/harness/gitness/app/pipeline/matrix"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer/dag"
 	"github.com/harness/gitness/app/store"
@@ -148,6 +149,29 @@ func (t *triggerer) Trigger(
 		return nil, err
 	}
 
+	// pipelines with a matrix are expanded into one pipeline per combination.
+	data, err := matrix.Expand(file.Data)
+	if err != nil {
+		log.Warn().Err(err).Msg("trigger: cannot expand matrix")
+		return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+	}
+
+	declared, err := declaredInputs(data)
+	if err != nil {
+		log.Warn().Err(err).Msg("trigger: cannot parse inputs")
+		return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+	}
+
+	params, err := resolveInputs(declared, base.Params)
+	if err != nil {
+		// invalid inputs of manual executions are reported back to the user right away.
+		if base.Action.GetTriggerEvent() == enum.TriggerEventManual {
+			return nil, err
+		}
+		log.Warn().Err(err).Msg("trigger: invalid inputs")
+		return t.createExecutionWithError(ctx, pipeline, base, err.Error())
+	}
+
 	now := time.Now().UnixMilli()
 	execution := &types.Execution{
 		RepoID:     repo.ID,
@@ -172,7 +196,7 @@ func (t *triggerer) Trigger(
 		AuthorName:   base.AuthorName,
 		AuthorEmail:  base.AuthorEmail,
 		AuthorAvatar: base.AuthorAvatar,
-		Params:       base.Params,
+		Params:       params,
 		Debug:        base.Debug,
 		Sender:       base.Sender,
 		Cron:         base.Cron,
@@ -185,8 +209,8 @@ func (t *triggerer) Trigger(
 	// and create them sequentially.
 	stages := []*types.Stage{}
 	//nolint:nestif // refactor if needed
-	if !isV1Yaml(file.Data) {
-		manifest, err := yaml.ParseString(string(file.Data))
+	if !isV1Yaml(data) {
+		manifest, err := yaml.ParseString(string(data))
 		if err != nil {
 			log.Warn().Err(err).Msg("trigger: cannot parse yaml")
 			return t.createExecutionWithError(ctx, pipeline, base, err.Error())
@@ -305,12 +329,20 @@ func (t *triggerer) Trigger(
 			}
 		}
 	} else {
-		stages, err = parseV1Stages(file.Data, repo, execution)
+		stages, err = parseV1Stages(data, repo, execution)
 		if err != nil {
 			return nil, fmt.Errorf("could not parse v1 YAML into stages: %w", err)
 		}
 	}
 
+	// approval stages that are ready right away pause the execution until a user decides on them.
+	for _, stage := range stages {
+		if stage.Status == enum.CIStatusPending && stage.IsApprovalGate() {
+			stage.Status = enum.CIStatusWaitingOnApproval
+			execution.Status = enum.CIStatusWaitingOnApproval
+		}
+	}
+
 	// Increment pipeline number using optimistic locking.
 	pipeline, err = t.pipelineStore.IncrementSeqNum(ctx, pipeline)
 	if err != nil {
@@ -384,6 +416,13 @@ func parseV1Stages(data []byte, repo *types.Repository, execution *types.Executi
 	inputParams["repo"] = inputs.Repo(manager.ConvertToDroneRepo(repo))
 	inputParams["build"] = inputs.Build(manager.ConvertToDroneBuild(execution))
 
+	// at this point the params of the execution only contain the resolved inputs.
+	pipelineInputs := make(map[string]interface{}, len(execution.Params))
+	for k, v := range execution.Params {
+		pipelineInputs[k] = v
+	}
+	inputParams["inputs"] = pipelineInputs
+
 	var prevStage string
 
 	switch v := config.Spec.(type) {
@@ -25,11 +25,13 @@ import (
 	controllergithook "github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/oidc"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -38,6 +40,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/api/handler/account"
 	handlercheck "github.com/harness/gitness/app/api/handler/check"
@@ -52,6 +55,7 @@ import (
 	handlerpullreq "github.com/harness/gitness/app/api/handler/pullreq"
 	handlerrepo "github.com/harness/gitness/app/api/handler/repo"
 	"github.com/harness/gitness/app/api/handler/resource"
+	handlerrunner "github.com/harness/gitness/app/api/handler/runner"
 	handlersecret "github.com/harness/gitness/app/api/handler/secret"
 	handlerserviceaccount "github.com/harness/gitness/app/api/handler/serviceaccount"
 	handlerspace "github.com/harness/gitness/app/api/handler/space"
@@ -60,6 +64,7 @@ import (
 	handlertrigger "github.com/harness/gitness/app/api/handler/trigger"
 	handlerupload "github.com/harness/gitness/app/api/handler/upload"
 	handleruser "github.com/harness/gitness/app/api/handler/user"
+	handlerusergroup "github.com/harness/gitness/app/api/handler/usergroup"
 	"github.com/harness/gitness/app/api/handler/users"
 	handlerwebhook "github.com/harness/gitness/app/api/handler/webhook"
 	"github.com/harness/gitness/app/api/middleware/address"
@@ -87,7 +92,7 @@ type APIHandler interface {
 var (
 	// terminatedPathPrefixesAPI is the list of prefixes that will require resolving terminated paths.
 	terminatedPathPrefixesAPI = []string{"/v1/spaces/", "/v1/repos/",
-		"/v1/secrets/", "/v1/connectors", "/v1/templates"}
+		"/v1/secrets/", "/v1/connectors", "/v1/templates", "/v1/usergroups/"}
 )
 
 // NewAPIHandler returns a new APIHandler.
@@ -115,6 +120,9 @@ func NewAPIHandler(
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	userGroupCtrl *usergroup.Controller,
+	runnerCtrl *runner.Controller,
+	oidcCtrl *oidc.Controller,
 ) APIHandler {
 	// Use go-chi router for inner routing.
 	r := chi.NewRouter()
@@ -140,7 +148,7 @@ func NewAPIHandler(
 		setupRoutesV1(r, appCtx, config, repoCtrl, executionCtrl, triggerCtrl, logCtrl, pipelineCtrl,
 			connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 			webhookCtrl, githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, uploadCtrl,
-			searchCtrl)
+			searchCtrl, userGroupCtrl, runnerCtrl, oidcCtrl)
 	})
 
 	// wrap router in terminatedPath encoder.
@@ -184,6 +192,9 @@ func setupRoutesV1(r chi.Router,
 	sysCtrl *system.Controller,
 	uploadCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	userGroupCtrl *usergroup.Controller,
+	runnerCtrl *runner.Controller,
+	oidcCtrl *oidc.Controller,
 ) {
 	setupSpaces(r, appCtx, spaceCtrl)
 	setupRepos(r, repoCtrl, pipelineCtrl, executionCtrl, triggerCtrl, logCtrl, pullreqCtrl, webhookCtrl, checkCtrl,
@@ -191,12 +202,13 @@ func setupRoutesV1(r chi.Router,
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
+	setupUserGroups(r, userGroupCtrl)
 	setupUser(r, userCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl)
-	setupAdmin(r, userCtrl)
-	setupAccount(r, userCtrl, sysCtrl, config)
+	setupAdmin(r, userCtrl, runnerCtrl)
+	setupAccount(r, userCtrl, sysCtrl, oidcCtrl, config)
 	setupSystem(r, config, sysCtrl)
 	setupResources(r)
 	setupPlugins(r, pluginCtrl)
@@ -226,6 +238,7 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 			r.Get("/secrets", handlerspace.HandleListSecrets(spaceCtrl))
 			r.Get("/connectors", handlerspace.HandleListConnectors(spaceCtrl))
 			r.Get("/templates", handlerspace.HandleListTemplates(spaceCtrl))
+			r.Get("/usergroups", handlerspace.HandleListUserGroups(spaceCtrl))
 			r.Post("/export", handlerspace.HandleExport(spaceCtrl))
 			r.Get("/export-progress", handlerspace.HandleExportProgress(spaceCtrl))
 
@@ -237,6 +250,15 @@ func setupSpaces(r chi.Router, appCtx context.Context, spaceCtrl *space.Controll
 					r.Patch("/", handlerspace.HandleMembershipUpdate(spaceCtrl))
 				})
 			})
+
+			r.Route("/usergroup-members", func(r chi.Router) {
+				r.Get("/", handlerspace.HandleMembershipUserGroupList(spaceCtrl))
+				r.Post("/", handlerspace.HandleMembershipUserGroupAdd(spaceCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupUID), func(r chi.Router) {
+					r.Delete("/", handlerspace.HandleMembershipUserGroupDelete(spaceCtrl))
+					r.Patch("/", handlerspace.HandleMembershipUserGroupUpdate(spaceCtrl))
+				})
+			})
 		})
 	})
 }
@@ -263,10 +285,29 @@ func setupRepos(r chi.Router,
 			r.Delete("/", handlerrepo.HandleDelete(repoCtrl))
 
 			r.Post("/move", handlerrepo.HandleMove(repoCtrl))
+			r.Post("/fork", handlerrepo.HandleFork(repoCtrl))
 			r.Get("/service-accounts", handlerrepo.HandleListServiceAccounts(repoCtrl))
 
 			r.Get("/import-progress", handlerrepo.HandleImportProgress(repoCtrl))
 
+			r.Route("/mirror", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandleMirrorFind(repoCtrl))
+				r.Put("/", handlerrepo.HandleMirrorSet(repoCtrl))
+				r.Delete("/", handlerrepo.HandleMirrorDelete(repoCtrl))
+				r.Post("/sync", handlerrepo.HandleMirrorSync(repoCtrl))
+			})
+
+			r.Route("/push-mirrors", func(r chi.Router) {
+				r.Get("/", handlerrepo.HandlePushMirrorList(repoCtrl))
+				r.Post("/", handlerrepo.HandlePushMirrorCreate(repoCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamPushMirrorID), func(r chi.Router) {
+					r.Get("/", handlerrepo.HandlePushMirrorFind(repoCtrl))
+					r.Patch("/", handlerrepo.HandlePushMirrorUpdate(repoCtrl))
+					r.Delete("/", handlerrepo.HandlePushMirrorDelete(repoCtrl))
+					r.Post("/push", handlerrepo.HandlePushMirrorPush(repoCtrl))
+				})
+			})
+
 			// content operations
 			// NOTE: this allows /content and /content/ to both be valid (without any other tricks.)
 			// We don't expect there to be any other operations in that route (as that could overlap with file names)
@@ -284,6 +325,12 @@ func setupRepos(r chi.Router,
 				r.Get("/*", handlerrepo.HandleRaw(repoCtrl))
 			})
 
+			r.Route("/archive", func(r chi.Router) {
+				r.Get("/*", handlerrepo.HandleArchive(repoCtrl))
+			})
+
+			r.Get("/lfs/usage", handlerrepo.HandleLFSUsage(repoCtrl))
+
 			// commit operations
 			r.Route("/commits", func(r chi.Router) {
 				r.Get("/", handlerrepo.HandleListCommits(repoCtrl))
@@ -414,6 +461,26 @@ func setupSecrets(r chi.Router, secretCtrl *secret.Controller) {
 	})
 }
 
+func setupUserGroups(r chi.Router, userGroupCtrl *usergroup.Controller) {
+	r.Route("/usergroups", func(r chi.Router) {
+		// Create takes the space ref via body, not uri
+		r.Post("/", handlerusergroup.HandleCreate(userGroupCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamUserGroupRef), func(r chi.Router) {
+			r.Get("/", handlerusergroup.HandleFind(userGroupCtrl))
+			r.Patch("/", handlerusergroup.HandleUpdate(userGroupCtrl))
+			r.Delete("/", handlerusergroup.HandleDelete(userGroupCtrl))
+
+			r.Route("/members", func(r chi.Router) {
+				r.Get("/", handlerusergroup.HandleMemberList(userGroupCtrl))
+				r.Post("/", handlerusergroup.HandleMemberAdd(userGroupCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamUserUID), func(r chi.Router) {
+					r.Delete("/", handlerusergroup.HandleMemberDelete(userGroupCtrl))
+				})
+			})
+		})
+	})
+}
+
 func setupPlugins(r chi.Router, pluginCtrl *plugin.Controller) {
 	r.Route("/plugins", func(r chi.Router) {
 		r.Get("/", handlerplugin.HandleList(pluginCtrl))
@@ -443,6 +510,14 @@ func setupExecutions(
 					request.PathParamStageNumber,
 					request.PathParamStepNumber,
 				), handlerlogs.HandleTail(logCtrl))
+			r.Route("/artifacts", func(r chi.Router) {
+				r.Get("/", handlerexecution.HandleListArtifacts(executionCtrl))
+				r.Get(fmt.Sprintf("/{%s}/download", request.PathParamArtifactID),
+					handlerexecution.HandleDownloadArtifact(executionCtrl))
+			})
+			r.Get("/approvals", handlerexecution.HandleListApprovals(executionCtrl))
+			r.Post(fmt.Sprintf("/stages/{%s}/approval", request.PathParamStageNumber),
+				handlerexecution.HandleApproveStage(executionCtrl))
 		})
 	})
 }
@@ -498,6 +573,7 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			r.Route("/reviewers", func(r chi.Router) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
+				r.Put("/usergroups", handlerpullreq.HandleUserGroupReviewerAdd(pullreqCtrl))
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamReviewerID), func(r chi.Router) {
 					r.Delete("/", handlerpullreq.HandleReviewerDelete(pullreqCtrl))
 				})
@@ -571,6 +647,14 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 		r.Get("/", handleruser.HandleFind(userCtrl))
 		r.Patch("/", handleruser.HandleUpdate(userCtrl))
 		r.Get("/memberships", handleruser.HandleMembershipSpaces(userCtrl))
+		r.Get("/notification-settings", handleruser.HandleFindNotificationSettings(userCtrl))
+		r.Patch("/notification-settings", handleruser.HandleUpdateNotificationSettings(userCtrl))
+
+		// EMAIL VERIFICATION
+		r.Route("/email", func(r chi.Router) {
+			r.Post("/verification", handleruser.HandleSendEmailVerification(userCtrl))
+			r.Post("/verify", handleruser.HandleVerifyEmail(userCtrl))
+		})
 
 		// PAT
 		r.Route("/tokens", func(r chi.Router) {
@@ -592,6 +676,28 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 				r.Delete("/", handleruser.HandleDeleteToken(userCtrl, enum.TokenTypeSession))
 			})
 		})
+
+		// SSH PUBLIC KEYS
+		r.Route("/keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListPublicKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreatePublicKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamPublicKeyUID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeletePublicKey(userCtrl))
+			})
+		})
+
+		// GPG AND SSH SIGNING KEYS
+		r.Route("/signing-keys", func(r chi.Router) {
+			r.Get("/", handleruser.HandleListSigningKeys(userCtrl))
+			r.Post("/", handleruser.HandleCreateSigningKey(userCtrl))
+
+			// per key operations
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamSigningKeyUID), func(r chi.Router) {
+				r.Delete("/", handleruser.HandleDeleteSigningKey(userCtrl))
+			})
+		})
 	})
 }
 
@@ -643,7 +749,7 @@ func setupKeywordSearch(r chi.Router, searchCtrl *keywordsearch.Controller) {
 	r.Post("/search", handlerkeywordsearch.HandleSearch(searchCtrl))
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, userCtrl *user.Controller, runnerCtrl *runner.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -657,12 +763,33 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+		r.Route("/runners", func(r chi.Router) {
+			r.Get("/", handlerrunner.HandleList(runnerCtrl))
+			r.Post("/", handlerrunner.HandleCreate(runnerCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamRunnerUID), func(r chi.Router) {
+				r.Get("/", handlerrunner.HandleFind(runnerCtrl))
+				r.Delete("/", handlerrunner.HandleDelete(runnerCtrl))
+			})
+		})
 	})
 }
 
-func setupAccount(r chi.Router, userCtrl *user.Controller, sysCtrl *system.Controller, config *types.Config) {
+func setupAccount(
+	r chi.Router,
+	userCtrl *user.Controller,
+	sysCtrl *system.Controller,
+	oidcCtrl *oidc.Controller,
+	config *types.Config,
+) {
 	cookieName := config.Token.CookieName
 	r.Post("/login", account.HandleLogin(userCtrl, cookieName))
 	r.Post("/register", account.HandleRegister(userCtrl, sysCtrl, cookieName))
 	r.Post("/logout", account.HandleLogout(userCtrl, cookieName))
+
+	r.Route("/oidc", func(r chi.Router) {
+		r.Get("/login", account.HandleOIDCLogin(oidcCtrl))
+		r.With(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser)).Get("/link", account.HandleOIDCLink(oidcCtrl))
+		r.Get("/callback", account.HandleOIDCCallback(oidcCtrl, cookieName, config.URL.UI))
+	})
 }
@@ -31,11 +31,13 @@ import (
 const (
 	APIMount = "/api"
 	GitMount = "/git"
+	RPCMount = "/rpc"
 )
 
 type Router struct {
 	api APIHandler
 	git GitHandler
+	rpc RPCHandler
 	web WebHandler
 
 	// gitHost describes the optional host via which git traffic is identified.
@@ -48,12 +50,14 @@ type Router struct {
 func NewRouter(
 	api APIHandler,
 	git GitHandler,
+	rpc RPCHandler,
 	web WebHandler,
 	gitHost string,
 ) *Router {
 	return &Router{
 		api: api,
 		git: git,
+		rpc: rpc,
 		web: web,
 
 		gitHost: strings.ToLower(gitHost),
@@ -116,7 +120,28 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	}
 
 	/*
-	 * 3. WEB
+	 * 3. RPC
+	 *
+	 * All calls of external runners start with "/rpc/", and thus can be uniquely identified.
+	 */
+	if r.isRPCTraffic(req) {
+		log.UpdateContext(func(c zerolog.Context) zerolog.Context {
+			return c.Str("http.handler", "rpc")
+		})
+
+		// remove matched prefix to simplify rpc handlers
+		if err = stripPrefix(RPCMount, req); err != nil {
+			hlog.FromRequest(req).Err(err).Msgf("Failed striping of prefix for rpc request.")
+			render.InternalError(w)
+			return
+		}
+
+		r.rpc.ServeHTTP(w, req)
+		return
+	}
+
+	/*
+	 * 4. WEB
 	 *
 	 * Everything else will be routed to web (or return 404)
 	 */
@@ -164,3 +189,9 @@ func (r *Router) isAPITraffic(req *http.Request) bool {
 	p := req.URL.Path
 	return strings.HasPrefix(p, APIMount)
 }
+
+// isRPCTraffic returns true iff the request is identified as part of the runner rpc protocol.
+func (r *Router) isRPCTraffic(req *http.Request) bool {
+	p := req.URL.Path
+	return strings.HasPrefix(p, RPCMount+"/")
+}
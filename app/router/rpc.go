@@ -0,0 +1,52 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/middleware/logging"
+	"github.com/harness/gitness/app/pipeline/rpc"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/rs/zerolog/hlog"
+)
+
+// RPCHandler is an abstraction of an http handler that handles the rpc calls of external runners.
+type RPCHandler interface {
+	http.Handler
+}
+
+// NewRPCHandler returns a new RPCHandler.
+func NewRPCHandler(rpcServer *rpc.Server) RPCHandler {
+	// Use go-chi router for inner routing.
+	r := chi.NewRouter()
+
+	// Apply common api middleware.
+	r.Use(middleware.NoCache)
+	r.Use(middleware.Recoverer)
+
+	// configure logging middleware.
+	r.Use(hlog.URLHandler("http.url"))
+	r.Use(hlog.MethodHandler("http.method"))
+	r.Use(logging.HLogRequestIDHandler())
+	r.Use(logging.HLogAccessLogHandler())
+
+	// runners authenticate via their registration token - enforced by the rpc server.
+	r.Mount("/", rpcServer)
+
+	return r
+}
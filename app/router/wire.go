@@ -23,12 +23,15 @@ import (
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
+	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/logs"
+	"github.com/harness/gitness/app/api/controller/oidc"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
 	"github.com/harness/gitness/app/api/controller/space"
@@ -37,8 +40,10 @@ import (
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/app/api/controller/usergroup"
 	"github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/auth/authn"
+	"github.com/harness/gitness/app/pipeline/rpc"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/types"
 
@@ -50,12 +55,14 @@ var WireSet = wire.NewSet(
 	ProvideRouter,
 	ProvideGitHandler,
 	ProvideAPIHandler,
+	ProvideRPCHandler,
 	ProvideWebHandler,
 )
 
 func ProvideRouter(
 	api APIHandler,
 	git GitHandler,
+	rpc RPCHandler,
 	web WebHandler,
 	urlProvider url.Provider,
 ) *Router {
@@ -70,18 +77,20 @@ func ProvideRouter(
 		gitRoutingHost = gitHostname
 	}
 
-	return NewRouter(api, git, web, gitRoutingHost)
+	return NewRouter(api, git, rpc, web, gitRoutingHost)
 }
 
 func ProvideGitHandler(
 	urlProvider url.Provider,
 	authenticator authn.Authenticator,
 	repoCtrl *repo.Controller,
+	lfsCtrl *lfs.Controller,
 ) GitHandler {
 	return NewGitHandler(
 		urlProvider,
 		authenticator,
 		repoCtrl,
+		lfsCtrl,
 	)
 }
 
@@ -109,11 +118,19 @@ func ProvideAPIHandler(
 	sysCtrl *system.Controller,
 	blobCtrl *upload.Controller,
 	searchCtrl *keywordsearch.Controller,
+	userGroupCtrl *usergroup.Controller,
+	runnerCtrl *runner.Controller,
+	oidcCtrl *oidc.Controller,
 ) APIHandler {
 	return NewAPIHandler(appCtx, config,
 		authenticator, repoCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
-		githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl)
+		githookCtrl, saCtrl, userCtrl, principalCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
+		userGroupCtrl, runnerCtrl, oidcCtrl)
+}
+
+func ProvideRPCHandler(rpcServer *rpc.Server) RPCHandler {
+	return NewRPCHandler(rpcServer)
 }
 
 func ProvideWebHandler(config *types.Config) WebHandler {
@@ -0,0 +1,141 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/pipeline/artifact"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeArtifacts        = "gitness:cleanup:artifacts"
+	jobCronArtifacts        = "27 * * * *" // At minute 27 past every hour.
+	jobMaxDurationArtifacts = 15 * time.Minute
+
+	artifactsCleanupBatchSize = 100
+)
+
+type artifactsCleanupJob struct {
+	artifacts      *artifact.Service
+	artifactStore  store.ArtifactStore
+	executionStore store.ExecutionStore
+	blobStore      blob.Store
+}
+
+func newArtifactsCleanupJob(
+	artifacts *artifact.Service,
+	artifactStore store.ArtifactStore,
+	executionStore store.ExecutionStore,
+	blobStore blob.Store,
+) *artifactsCleanupJob {
+	return &artifactsCleanupJob{
+		artifacts:      artifacts,
+		artifactStore:  artifactStore,
+		executionStore: executionStore,
+		blobStore:      blobStore,
+	}
+}
+
+// Handle purges expired pipeline artifacts, as well as the content of artifacts
+// that belong to executions that don't exist anymore.
+func (j *artifactsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+
+	log.Ctx(ctx).Info().Msgf(
+		"start purging pipeline artifacts expired before %s",
+		now.Format(time.RFC3339Nano),
+	)
+
+	nExpired := 0
+	for {
+		expired, err := j.artifactStore.ListExpired(ctx, now.UnixMilli(), artifactsCleanupBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list expired artifacts: %w", err)
+		}
+
+		for _, a := range expired {
+			if err = j.artifacts.Delete(ctx, a); err != nil {
+				return "", fmt.Errorf("failed to delete artifact %d: %w", a.ID, err)
+			}
+			nExpired++
+		}
+
+		if len(expired) < artifactsCleanupBatchSize {
+			break
+		}
+	}
+
+	nOrphaned, err := j.purgeOrphaned(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	result := "no expired or orphaned artifacts found"
+	if nExpired > 0 || nOrphaned > 0 {
+		result = fmt.Sprintf("deleted %d expired and %d orphaned artifacts", nExpired, nOrphaned)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// purgeOrphaned deletes the content of artifacts whose execution got deleted
+// (the artifacts themselves are removed from the database together with the execution).
+func (j *artifactsCleanupJob) purgeOrphaned(ctx context.Context) (int, error) {
+	files, err := j.blobStore.List(ctx, artifact.BlobPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list artifacts in blob store: %w", err)
+	}
+
+	exists := map[int64]bool{}
+	n := 0
+	for _, file := range files {
+		executionID, ok := artifact.ParseBlobPath(file.Path)
+		if !ok {
+			continue
+		}
+
+		found, checked := exists[executionID]
+		if !checked {
+			_, err = j.executionStore.Find(ctx, executionID)
+			if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return n, fmt.Errorf("failed to find execution %d: %w", executionID, err)
+			}
+			found = err == nil
+			exists[executionID] = found
+		}
+		if found {
+			continue
+		}
+
+		if err = j.blobStore.Delete(ctx, file.Path); err != nil {
+			return n, fmt.Errorf("failed to delete orphaned artifact %q: %w", file.Path, err)
+		}
+		n++
+	}
+
+	return n, nil
+}
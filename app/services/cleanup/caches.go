@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pipelinecache "github.com/harness/gitness/app/pipeline/cache"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCaches        = "gitness:cleanup:caches"
+	jobCronCaches        = "37 */2 * * *" // At minute 37 past every 2nd hour.
+	jobMaxDurationCaches = 15 * time.Minute
+
+	cachesCleanupBatchSize = 100
+)
+
+type cachesCleanupJob struct {
+	caches    *pipelinecache.Service
+	repoStore store.RepoStore
+	blobStore blob.Store
+}
+
+func newCachesCleanupJob(
+	caches *pipelinecache.Service,
+	repoStore store.RepoStore,
+	blobStore blob.Store,
+) *cachesCleanupJob {
+	return &cachesCleanupJob{
+		caches:    caches,
+		repoStore: repoStore,
+		blobStore: blobStore,
+	}
+}
+
+// Handle evicts pipeline caches that weren't used within the retention duration,
+// as well as the content of caches that belong to repositories that don't exist anymore.
+func (j *cachesCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	log.Ctx(ctx).Info().Msg("start evicting stale pipeline caches")
+
+	nStale := 0
+	for {
+		stale, err := j.caches.ListStale(ctx, cachesCleanupBatchSize)
+		if err != nil {
+			return "", err
+		}
+
+		for _, entry := range stale {
+			if err = j.caches.Delete(ctx, entry); err != nil {
+				return "", fmt.Errorf("failed to delete cache %d: %w", entry.ID, err)
+			}
+			nStale++
+		}
+
+		if len(stale) < cachesCleanupBatchSize {
+			break
+		}
+	}
+
+	nOrphaned, err := j.purgeOrphaned(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	result := "no stale or orphaned caches found"
+	if nStale > 0 || nOrphaned > 0 {
+		result = fmt.Sprintf("deleted %d stale and %d orphaned caches", nStale, nOrphaned)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// purgeOrphaned deletes the content of caches whose repository got deleted
+// (the cache entries themselves are removed from the database together with the repository).
+func (j *cachesCleanupJob) purgeOrphaned(ctx context.Context) (int, error) {
+	files, err := j.blobStore.List(ctx, pipelinecache.BlobPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list caches in blob store: %w", err)
+	}
+
+	exists := map[int64]bool{}
+	n := 0
+	for _, file := range files {
+		repoID, ok := pipelinecache.ParseBlobPath(file.Path)
+		if !ok {
+			continue
+		}
+
+		found, checked := exists[repoID]
+		if !checked {
+			_, err = j.repoStore.Find(ctx, repoID)
+			if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return n, fmt.Errorf("failed to find repo %d: %w", repoID, err)
+			}
+			found = err == nil
+			exists[repoID] = found
+		}
+		if found {
+			continue
+		}
+
+		if err = j.blobStore.Delete(ctx, file.Path); err != nil {
+			return n, fmt.Errorf("failed to delete orphaned cache %q: %w", file.Path, err)
+		}
+		n++
+	}
+
+	return n, nil
+}
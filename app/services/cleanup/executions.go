@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeExecutions        = "gitness:cleanup:executions"
+	jobCronExecutions        = "47 */4 * * *" // At minute 47 past every 4th hour.
+	jobMaxDurationExecutions = 30 * time.Minute
+
+	executionsCleanupPageSize = 100
+)
+
+type executionsCleanupJob struct {
+	retentionCount int64
+	retentionDays  int64
+
+	pipelineStore  store.PipelineStore
+	executionStore store.ExecutionStore
+	stageStore     store.StageStore
+	logStore       store.LogStore
+}
+
+func newExecutionsCleanupJob(
+	retentionCount int64,
+	retentionDays int64,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	logStore store.LogStore,
+) *executionsCleanupJob {
+	return &executionsCleanupJob{
+		retentionCount: retentionCount,
+		retentionDays:  retentionDays,
+
+		pipelineStore:  pipelineStore,
+		executionStore: executionStore,
+		stageStore:     stageStore,
+		logStore:       logStore,
+	}
+}
+
+// Handle purges pipeline executions, including their stages, steps and logs,
+// that are outside the retention policy of their pipeline.
+func (j *executionsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	log.Ctx(ctx).Info().Msgf(
+		"start purging pipeline executions (default retention: count %d, days %d)",
+		j.retentionCount,
+		j.retentionDays,
+	)
+
+	now := time.Now()
+	n := 0
+	afterID := int64(0)
+	for {
+		pipelines, err := j.pipelineStore.ListAll(ctx, afterID, executionsCleanupPageSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list pipelines: %w", err)
+		}
+
+		for _, pipeline := range pipelines {
+			afterID = pipeline.ID
+
+			count, days := j.retentionOf(pipeline)
+			if count == 0 && days == 0 {
+				continue
+			}
+
+			deleted, err := j.purgePipeline(ctx, pipeline, count, days, now)
+			n += deleted
+			if err != nil {
+				// continue with the other pipelines, the remaining executions are purged in the next run.
+				log.Ctx(ctx).Warn().Err(err).
+					Int64("pipeline_id", pipeline.ID).
+					Msg("failed to purge pipeline executions")
+			}
+		}
+
+		if len(pipelines) < executionsCleanupPageSize {
+			break
+		}
+	}
+
+	result := "no expired pipeline executions found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d pipeline executions", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// retentionOf returns the retention policy of the pipeline, falling back to the defaults.
+// Zero values mean that no limit applies.
+func (j *executionsCleanupJob) retentionOf(pipeline *types.Pipeline) (int64, int64) {
+	return retentionValue(pipeline.RetentionCount, j.retentionCount),
+		retentionValue(pipeline.RetentionDays, j.retentionDays)
+}
+
+// retentionValue returns the pipeline specific value if set, or the default otherwise.
+// A negative pipeline value disables the limit.
+func retentionValue(value int64, defaultValue int64) int64 {
+	switch {
+	case value < 0:
+		return 0
+	case value == 0:
+		return defaultValue
+	default:
+		return value
+	}
+}
+
+// purgePipeline deletes the expired executions of the pipeline and returns the number of deleted executions.
+func (j *executionsCleanupJob) purgePipeline(
+	ctx context.Context,
+	pipeline *types.Pipeline,
+	count int64,
+	days int64,
+	now time.Time,
+) (int, error) {
+	// collect all executions first, deleting while paginating would shift the pages.
+	var executions []*types.Execution
+	for page := 1; ; page++ {
+		list, err := j.executionStore.List(ctx, pipeline.ID, types.Pagination{
+			Page: page,
+			Size: executionsCleanupPageSize,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to list executions: %w", err)
+		}
+
+		executions = append(executions, list...)
+
+		if len(list) < executionsCleanupPageSize {
+			break
+		}
+	}
+
+	n := 0
+	for _, execution := range expiredExecutions(executions, count, days, now) {
+		if err := j.purgeExecution(ctx, execution); err != nil {
+			return n, fmt.Errorf("failed to purge execution %d: %w", execution.Number, err)
+		}
+		n++
+	}
+
+	return n, nil
+}
+
+// purgeExecution deletes the logs of all steps of the execution, followed by the execution itself.
+// Stages and steps are removed together with the execution.
+func (j *executionsCleanupJob) purgeExecution(ctx context.Context, execution *types.Execution) error {
+	stages, err := j.stageStore.ListWithSteps(ctx, execution.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list stages: %w", err)
+	}
+
+	for _, stage := range stages {
+		for _, step := range stage.Steps {
+			if err = j.logStore.Delete(ctx, step.ID); err != nil {
+				return fmt.Errorf("failed to delete logs of step %d: %w", step.ID, err)
+			}
+		}
+	}
+
+	if err = j.executionStore.Delete(ctx, execution.PipelineID, execution.Number); err != nil {
+		return fmt.Errorf("failed to delete execution: %w", err)
+	}
+
+	return nil
+}
+
+// expiredExecutions returns the executions (expected in descending order of number)
+// that are beyond the most recent count executions and older than the provided number of days.
+// In case both limits are set, executions within either of them are kept.
+// Executions that didn't complete yet and the last successful execution of each ref are never expired.
+func expiredExecutions(executions []*types.Execution, count int64, days int64, now time.Time) []*types.Execution {
+	if count <= 0 && days <= 0 {
+		return nil
+	}
+
+	createdBefore := now.AddDate(0, 0, -int(days)).UnixMilli()
+	lastSuccess := map[string]struct{}{}
+
+	var expired []*types.Execution
+	for i, execution := range executions {
+		if !execution.Status.IsDone() {
+			continue
+		}
+
+		if execution.Status == enum.CIStatusSuccess {
+			if _, ok := lastSuccess[execution.Ref]; !ok {
+				lastSuccess[execution.Ref] = struct{}{}
+				continue
+			}
+		}
+
+		// a limit that isn't set doesn't keep any execution.
+		beyondCount := count <= 0 || int64(i) >= count
+		beyondAge := days <= 0 || execution.Created < createdBefore
+		if beyondCount && beyondAge {
+			expired = append(expired, execution)
+		}
+	}
+
+	return expired
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestExpiredExecutions(t *testing.T) {
+	now := time.Now()
+	daysAgo := func(days int) int64 {
+		return now.AddDate(0, 0, -days).UnixMilli()
+	}
+
+	// executions in descending order of number, as returned by the store.
+	executions := []*types.Execution{
+		{Number: 6, Status: enum.CIStatusRunning, Ref: "refs/heads/main", Created: daysAgo(0)},
+		{Number: 5, Status: enum.CIStatusFailure, Ref: "refs/heads/main", Created: daysAgo(1)},
+		{Number: 4, Status: enum.CIStatusSuccess, Ref: "refs/heads/dev", Created: daysAgo(2)},
+		{Number: 3, Status: enum.CIStatusFailure, Ref: "refs/heads/dev", Created: daysAgo(10)},
+		{Number: 2, Status: enum.CIStatusSuccess, Ref: "refs/heads/main", Created: daysAgo(20)},
+		{Number: 1, Status: enum.CIStatusSuccess, Ref: "refs/heads/main", Created: daysAgo(30)},
+	}
+
+	tests := []struct {
+		name  string
+		count int64
+		days  int64
+		want  []int64
+	}{
+		{name: "no limits", want: nil},
+		{name: "count", count: 2, want: []int64{3, 1}},
+		{name: "days", days: 5, want: []int64{3, 1}},
+		{name: "count and days", count: 4, days: 25, want: []int64{1}},
+		{name: "count and days keep executions within count", count: 4, days: 5, want: []int64{1}},
+		{name: "count and days keep executions within days", count: 1, days: 15, want: []int64{1}},
+		{name: "count keeps running and last successful", count: 1, want: []int64{5, 3, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []int64
+			for _, execution := range expiredExecutions(executions, test.count, test.days, now) {
+				got = append(got, execution.Number)
+			}
+
+			if len(got) != len(test.want) {
+				t.Fatalf("expected %v, got %v", test.want, got)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("expected %v, got %v", test.want, got)
+				}
+			}
+		})
+	}
+}
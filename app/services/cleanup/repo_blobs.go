@@ -0,0 +1,143 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeArchives        = "gitness:cleanup:archives"
+	jobCronArchives        = "53 4 * * *" // At 04:53 every day.
+	jobMaxDurationArchives = 10 * time.Minute
+
+	// archivesPrefix is the blob store prefix under which the repo controller caches archives of tags
+	// (archives/<repoID>/<file>).
+	archivesPrefix = "archives/"
+
+	jobTypeLFSObjects        = "gitness:cleanup:lfs-objects"
+	jobCronLFSObjects        = "23 5 * * *" // At 05:23 every day.
+	jobMaxDurationLFSObjects = 30 * time.Minute
+
+	// lfsObjectsPrefix is the blob store prefix under which the LFS service stores objects (lfs/<repoID>/<oid>).
+	lfsObjectsPrefix = "lfs/"
+)
+
+// repoBlobsCleanupJob purges the blob store files of deleted repositories.
+// It handles files stored under a common prefix in the form "<prefix><repoID>/<fileName>".
+type repoBlobsCleanupJob struct {
+	prefix    string
+	name      string
+	blobStore blob.Store
+	repoStore store.RepoStore
+}
+
+func newRepoBlobsCleanupJob(
+	prefix string,
+	name string,
+	blobStore blob.Store,
+	repoStore store.RepoStore,
+) *repoBlobsCleanupJob {
+	return &repoBlobsCleanupJob{
+		prefix:    prefix,
+		name:      name,
+		blobStore: blobStore,
+		repoStore: repoStore,
+	}
+}
+
+// Handle purges files that belong to deleted repositories.
+// Files of existing repositories are kept (e.g. to guarantee stable archives of tags).
+func (j *repoBlobsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	log.Ctx(ctx).Info().Msgf("start purging %s of deleted repositories", j.name)
+
+	files, err := j.blobStore.List(ctx, j.prefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to list %s: %w", j.name, err)
+	}
+
+	// cache the result of the repo lookups, a repository usually has many files.
+	repoExists := map[int64]bool{}
+
+	n := 0
+	for _, file := range files {
+		repoID, ok := parseRepoBlobPath(j.prefix, file.Path)
+		if !ok {
+			// not created by gitness - leave it alone.
+			continue
+		}
+
+		exists, ok := repoExists[repoID]
+		if !ok {
+			_, err = j.repoStore.Find(ctx, repoID)
+			if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+				return "", fmt.Errorf("failed to find repo: %w", err)
+			}
+			exists = err == nil
+			repoExists[repoID] = exists
+		}
+		if exists {
+			continue
+		}
+
+		err = j.blobStore.Delete(ctx, file.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to delete %q: %w", file.Path, err)
+		}
+
+		n++
+	}
+
+	result := fmt.Sprintf("no orphaned %s found", j.name)
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d %s", n, j.name)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// parseRepoBlobPath parses the repo id of a path of the form "<prefix><repoID>/<fileName>".
+func parseRepoBlobPath(prefix string, filePath string) (int64, bool) {
+	rest, ok := strings.CutPrefix(filePath, prefix)
+	if !ok {
+		return 0, false
+	}
+
+	repoIDStr, fileName, ok := strings.Cut(rest, "/")
+	if !ok || fileName == "" || strings.Contains(fileName, "/") {
+		return 0, false
+	}
+
+	repoID, err := strconv.ParseInt(repoIDStr, 10, 64)
+	if err != nil || repoID <= 0 {
+		return 0, false
+	}
+
+	return repoID, true
+}
@@ -20,12 +20,20 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/harness/gitness/app/pipeline/artifact"
+	pipelinecache "github.com/harness/gitness/app/pipeline/cache"
 	"github.com/harness/gitness/app/services/job"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 )
 
 type Config struct {
 	WebhookExecutionsRetentionTime time.Duration
+
+	// ExecutionRetentionCount is the default number of executions kept per pipeline (0 keeps all).
+	ExecutionRetentionCount int64
+	// ExecutionRetentionDays is the default number of days executions are kept (0 keeps them forever).
+	ExecutionRetentionDays int64
 }
 
 func (c *Config) Prepare() error {
@@ -35,6 +43,12 @@ func (c *Config) Prepare() error {
 	if c.WebhookExecutionsRetentionTime <= 0 {
 		return errors.New("config.WebhookExecutionsRetentionTime has to be provided")
 	}
+	if c.ExecutionRetentionCount < 0 {
+		return errors.New("config.ExecutionRetentionCount can't be negative")
+	}
+	if c.ExecutionRetentionDays < 0 {
+		return errors.New("config.ExecutionRetentionDays can't be negative")
+	}
 	return nil
 }
 
@@ -45,6 +59,17 @@ type Service struct {
 	executor              *job.Executor
 	webhookExecutionStore store.WebhookExecutionStore
 	tokenStore            store.TokenStore
+	blobStore             blob.Store
+	repoStore             store.RepoStore
+	pullReqStore          store.PullReqStore
+	activityStore         store.PullReqActivityStore
+	pipelineStore         store.PipelineStore
+	executionStore        store.ExecutionStore
+	stageStore            store.StageStore
+	logStore              store.LogStore
+	artifactStore         store.ArtifactStore
+	artifacts             *artifact.Service
+	caches                *pipelinecache.Service
 }
 
 func NewService(
@@ -53,6 +78,17 @@ func NewService(
 	executor *job.Executor,
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
+	blobStore blob.Store,
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	logStore store.LogStore,
+	artifactStore store.ArtifactStore,
+	artifacts *artifact.Service,
+	caches *pipelinecache.Service,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided cleanup config is invalid: %w", err)
@@ -65,6 +101,17 @@ func NewService(
 		executor:              executor,
 		webhookExecutionStore: webhookExecutionStore,
 		tokenStore:            tokenStore,
+		blobStore:             blobStore,
+		repoStore:             repoStore,
+		pullReqStore:          pullReqStore,
+		activityStore:         activityStore,
+		pipelineStore:         pipelineStore,
+		executionStore:        executionStore,
+		stageStore:            stageStore,
+		logStore:              logStore,
+		artifactStore:         artifactStore,
+		artifacts:             artifacts,
+		caches:                caches,
 	}, nil
 }
 
@@ -104,6 +151,72 @@ func (s *Service) scheduleRecurringCleanupJobs(ctx context.Context) error {
 		return fmt.Errorf("failed to schedule token job: %w", err)
 	}
 
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeUploads,
+		jobTypeUploads,
+		jobCronUploads,
+		jobMaxDurationUploads,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule uploads job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeExecutions,
+		jobTypeExecutions,
+		jobCronExecutions,
+		jobMaxDurationExecutions,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule executions job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeArtifacts,
+		jobTypeArtifacts,
+		jobCronArtifacts,
+		jobMaxDurationArtifacts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule artifacts job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeCaches,
+		jobTypeCaches,
+		jobCronCaches,
+		jobMaxDurationCaches,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule caches job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeArchives,
+		jobTypeArchives,
+		jobCronArchives,
+		jobMaxDurationArchives,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule archives job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeLFSObjects,
+		jobTypeLFSObjects,
+		jobCronLFSObjects,
+		jobMaxDurationLFSObjects,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule LFS objects job: %w", err)
+	}
+
 	return nil
 }
 
@@ -128,5 +241,78 @@ func (s *Service) registerJobHandlers() error {
 		return fmt.Errorf("failed to register job handler for token cleanup: %w", err)
 	}
 
+	if err := s.executor.Register(
+		jobTypeUploads,
+		newUploadsCleanupJob(
+			s.blobStore,
+			s.repoStore,
+			s.pullReqStore,
+			s.activityStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for uploads cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeExecutions,
+		newExecutionsCleanupJob(
+			s.config.ExecutionRetentionCount,
+			s.config.ExecutionRetentionDays,
+			s.pipelineStore,
+			s.executionStore,
+			s.stageStore,
+			s.logStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for executions cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeArtifacts,
+		newArtifactsCleanupJob(
+			s.artifacts,
+			s.artifactStore,
+			s.executionStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for artifacts cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeCaches,
+		newCachesCleanupJob(
+			s.caches,
+			s.repoStore,
+			s.blobStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for caches cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeArchives,
+		newRepoBlobsCleanupJob(
+			archivesPrefix,
+			"archives",
+			s.blobStore,
+			s.repoStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for archives cleanup: %w", err)
+	}
+
+	if err := s.executor.Register(
+		jobTypeLFSObjects,
+		newRepoBlobsCleanupJob(
+			lfsObjectsPrefix,
+			"LFS objects",
+			s.blobStore,
+			s.repoStore,
+		),
+	); err != nil {
+		return fmt.Errorf("failed to register job handler for LFS objects cleanup: %w", err)
+	}
+
 	return nil
 }
@@ -0,0 +1,164 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeUploads        = "gitness:cleanup:uploads"
+	jobCronUploads        = "33 3 * * *" // At 03:33 every day.
+	jobMaxDurationUploads = 10 * time.Minute
+
+	// uploadsPrefix is the blob store prefix under which the upload controller stores files (uploads/<repoID>/<file>).
+	uploadsPrefix = "uploads/"
+
+	// uploadGracePeriod specifies the time an upload is kept before it's considered orphaned.
+	// This gives users time to reference an uploaded file in a pull request description or comment.
+	uploadGracePeriod = 72 * time.Hour // 3d
+)
+
+type uploadsCleanupJob struct {
+	blobStore     blob.Store
+	repoStore     store.RepoStore
+	pullReqStore  store.PullReqStore
+	activityStore store.PullReqActivityStore
+}
+
+func newUploadsCleanupJob(
+	blobStore blob.Store,
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+) *uploadsCleanupJob {
+	return &uploadsCleanupJob{
+		blobStore:     blobStore,
+		repoStore:     repoStore,
+		pullReqStore:  pullReqStore,
+		activityStore: activityStore,
+	}
+}
+
+// Handle purges uploaded files that belong to deleted repositories
+// or that aren't referenced by any pull request description or activity.
+func (j *uploadsCleanupJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	uploadedBefore := time.Now().Add(-uploadGracePeriod)
+	log.Ctx(ctx).Info().Msgf(
+		"start purging orphaned uploads (uploaded before: %s)",
+		uploadedBefore.Format(time.RFC3339Nano),
+	)
+
+	files, err := j.blobStore.List(ctx, uploadsPrefix)
+	if err != nil {
+		return "", fmt.Errorf("failed to list uploads: %w", err)
+	}
+
+	n := 0
+	for _, file := range files {
+		if file.Modified.After(uploadedBefore) {
+			continue
+		}
+
+		orphaned, err := j.isOrphaned(ctx, file.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to check whether upload %q is orphaned: %w", file.Path, err)
+		}
+		if !orphaned {
+			continue
+		}
+
+		err = j.blobStore.Delete(ctx, file.Path)
+		if err != nil {
+			return "", fmt.Errorf("failed to delete upload %q: %w", file.Path, err)
+		}
+
+		n++
+	}
+
+	result := "no orphaned uploads found"
+	if n > 0 {
+		result = fmt.Sprintf("deleted %d uploads", n)
+	}
+
+	log.Ctx(ctx).Info().Msg(result)
+
+	return result, nil
+}
+
+// isOrphaned returns true in case the repo of the upload doesn't exist anymore,
+// or the file isn't referenced in any pull request of the repo.
+func (j *uploadsCleanupJob) isOrphaned(ctx context.Context, filePath string) (bool, error) {
+	repoID, fileName, ok := parseUploadPath(filePath)
+	if !ok {
+		// not created by the upload controller - leave it alone.
+		return false, nil
+	}
+
+	_, err := j.repoStore.Find(ctx, repoID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	found, err := j.pullReqStore.ContainsText(ctx, repoID, fileName)
+	if err != nil {
+		return false, fmt.Errorf("failed to search pull request descriptions: %w", err)
+	}
+	if found {
+		return false, nil
+	}
+
+	found, err = j.activityStore.ContainsText(ctx, repoID, fileName)
+	if err != nil {
+		return false, fmt.Errorf("failed to search pull request activities: %w", err)
+	}
+
+	return !found, nil
+}
+
+// parseUploadPath parses a path of the form "uploads/<repoID>/<fileName>".
+func parseUploadPath(filePath string) (int64, string, bool) {
+	rest, ok := strings.CutPrefix(filePath, uploadsPrefix)
+	if !ok {
+		return 0, "", false
+	}
+
+	repoIDStr, fileName, ok := strings.Cut(rest, "/")
+	if !ok || fileName == "" || strings.Contains(fileName, "/") {
+		return 0, "", false
+	}
+
+	repoID, err := strconv.ParseInt(repoIDStr, 10, 64)
+	if err != nil || repoID <= 0 {
+		return 0, "", false
+	}
+
+	return repoID, fileName, true
+}
@@ -0,0 +1,153 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+func TestParseUploadPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		repoID   int64
+		fileName string
+		ok       bool
+	}{
+		{path: "uploads/12/abc.png", repoID: 12, fileName: "abc.png", ok: true},
+		{path: "uploads/12/", ok: false},
+		{path: "uploads/12", ok: false},
+		{path: "uploads/12/dir/abc.png", ok: false},
+		{path: "uploads/0/abc.png", ok: false},
+		{path: "uploads/-1/abc.png", ok: false},
+		{path: "uploads/x/abc.png", ok: false},
+		{path: "lfs/12/abc", ok: false},
+	}
+
+	for _, test := range tests {
+		repoID, fileName, ok := parseUploadPath(test.path)
+		if repoID != test.repoID || fileName != test.fileName || ok != test.ok {
+			t.Errorf("parseUploadPath(%q): expected (%d, %q, %t), got (%d, %q, %t)",
+				test.path, test.repoID, test.fileName, test.ok, repoID, fileName, ok)
+		}
+	}
+}
+
+func TestUploadsCleanupJob(t *testing.T) {
+	ctx := context.Background()
+	basePath := t.TempDir()
+
+	blobStore, err := blob.NewFileSystemStore(blob.Config{Bucket: basePath})
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+
+	old := time.Now().Add(-2 * uploadGracePeriod)
+	files := map[string]time.Time{
+		"uploads/1/in-description.png": old,
+		"uploads/1/in-comment.png":     old,
+		"uploads/1/unreferenced.png":   old,
+		"uploads/1/recent.png":         time.Now(),
+		"uploads/2/deleted-repo.png":   old,
+		"uploads/1/dir/unknown.png":    old,
+	}
+	for filePath, modified := range files {
+		if err = blobStore.Upload(ctx, strings.NewReader("content"), filePath); err != nil {
+			t.Fatalf("failed to upload %s: %v", filePath, err)
+		}
+		if err = os.Chtimes(filepath.Join(basePath, filePath), modified, modified); err != nil {
+			t.Fatalf("failed to change time of %s: %v", filePath, err)
+		}
+	}
+
+	j := newUploadsCleanupJob(
+		blobStore,
+		repoStoreMock{repoIDs: []int64{1}},
+		pullReqStoreMock{texts: map[int64]string{1: "![image](in-description.png)"}},
+		activityStoreMock{texts: map[int64]string{1: "![image](in-comment.png)"}},
+	)
+
+	result, err := j.Handle(ctx, "", nil)
+	if err != nil {
+		t.Fatalf("failed to handle job: %v", err)
+	}
+	if result != "deleted 2 uploads" {
+		t.Errorf("unexpected result: %q", result)
+	}
+
+	remaining, err := blobStore.List(ctx, uploadsPrefix)
+	if err != nil {
+		t.Fatalf("failed to list uploads: %v", err)
+	}
+
+	got := make([]string, len(remaining))
+	for i, file := range remaining {
+		got[i] = file.Path
+	}
+	sort.Strings(got)
+
+	want := []string{
+		"uploads/1/dir/unknown.png",
+		"uploads/1/in-comment.png",
+		"uploads/1/in-description.png",
+		"uploads/1/recent.png",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected remaining uploads %v, got %v", want, got)
+	}
+}
+
+type repoStoreMock struct {
+	store.RepoStore
+	repoIDs []int64
+}
+
+func (m repoStoreMock) Find(_ context.Context, id int64) (*types.Repository, error) {
+	for _, repoID := range m.repoIDs {
+		if repoID == id {
+			return &types.Repository{ID: id}, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type pullReqStoreMock struct {
+	store.PullReqStore
+	texts map[int64]string
+}
+
+func (m pullReqStoreMock) ContainsText(_ context.Context, repoID int64, text string) (bool, error) {
+	return strings.Contains(m.texts[repoID], text), nil
+}
+
+type activityStoreMock struct {
+	store.PullReqActivityStore
+	texts map[int64]string
+}
+
+func (m activityStoreMock) ContainsText(_ context.Context, repoID int64, text string) (bool, error) {
+	return strings.Contains(m.texts[repoID], text), nil
+}
@@ -15,8 +15,11 @@
 package cleanup
 
 import (
+	"github.com/harness/gitness/app/pipeline/artifact"
+	pipelinecache "github.com/harness/gitness/app/pipeline/cache"
 	"github.com/harness/gitness/app/services/job"
 	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
 
 	"github.com/google/wire"
 )
@@ -31,6 +34,17 @@ func ProvideService(
 	executor *job.Executor,
 	webhookExecutionStore store.WebhookExecutionStore,
 	tokenStore store.TokenStore,
+	blobStore blob.Store,
+	repoStore store.RepoStore,
+	pullReqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	stageStore store.StageStore,
+	logStore store.LogStore,
+	artifactStore store.ArtifactStore,
+	artifacts *artifact.Service,
+	caches *pipelinecache.Service,
 ) (*Service, error) {
 	return NewService(
 		config,
@@ -38,5 +52,16 @@ func ProvideService(
 		executor,
 		webhookExecutionStore,
 		tokenStore,
+		blobStore,
+		repoStore,
+		pullReqStore,
+		activityStore,
+		pipelineStore,
+		executionStore,
+		stageStore,
+		logStore,
+		artifactStore,
+		artifacts,
+		caches,
 	)
 }
@@ -21,6 +21,7 @@ import (
 	"io"
 	"strings"
 
+	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/errors"
@@ -321,7 +322,7 @@ func (s *Service) Evaluate(
 		for _, owner := range entry.Owners {
 			// check for usrgrp
 			if strings.HasPrefix(owner, userGroupPrefixMarker) {
-				userGroupCodeOwner, err := s.resolveUserGroupCodeOwner(ctx, owner[1:], reviewers)
+				userGroupCodeOwner, err := s.resolveUserGroupCodeOwner(ctx, repo, owner[1:], reviewers)
 				if errors.Is(err, usergroup.ErrNotFound) {
 					log.Ctx(ctx).Debug().Msgf("usergroup %q not found hence skipping for code owner", owner)
 					continue
@@ -359,15 +360,16 @@ func (s *Service) Evaluate(
 
 func (s *Service) resolveUserGroupCodeOwner(
 	ctx context.Context,
+	repo *types.Repository,
 	owner string,
 	reviewers []*types.PullReqReviewer,
 ) (*UserGroupOwnerEvaluation, error) {
-	usrgrp, err := s.userGroupResolver.Resolve(ctx, owner)
+	usrgrp, err := s.userGroupResolver.Resolve(ctx, userGroupScopedID(repo, owner))
 	if err != nil {
 		return nil, fmt.Errorf("not able to resolve usergroup : %w", err)
 	}
 	userGroupEvaluation := &UserGroupOwnerEvaluation{
-		ID:   usrgrp.ID,
+		ID:   usrgrp.UID,
 		Name: usrgrp.Name,
 	}
 	ownersEvaluations := make([]OwnerEvaluation, 0, len(usrgrp.Users))
@@ -428,8 +430,16 @@ func (s *Service) Validate(
 	for _, entry := range codeowners.Entries {
 		// check for users in file
 		for _, owner := range entry.Owners {
-			// todo: handle usergroup better
 			if strings.HasPrefix(owner, userGroupPrefixMarker) {
+				_, err := s.userGroupResolver.Resolve(ctx, userGroupScopedID(repo, owner[1:]))
+				if errors.Is(err, usergroup.ErrNotFound) {
+					codeOwnerValidation.Addf(enum.CodeOwnerViolationCodeUserGroupNotFound,
+						"usergroup %q not found", owner)
+					continue
+				}
+				if err != nil {
+					return nil, fmt.Errorf("error encountered resolving usergroup %q: %w", owner, err)
+				}
 				continue
 			}
 			_, err := s.principalStore.FindByEmail(ctx, owner)
@@ -460,6 +470,18 @@ func (s *Service) Validate(
 	return &codeOwnerValidation, nil
 }
 
+// userGroupScopedID returns the scoped id of a user group referenced in the codeowners file.
+// Groups can either be referenced by uid (e.g. @team), in which case they are resolved relative to
+// the space of the repository, or by their full path (e.g. @space/team).
+func userGroupScopedID(repo *types.Repository, userGroup string) string {
+	if strings.Contains(userGroup, types.PathSeparator) {
+		return userGroup
+	}
+
+	spacePath, _, _ := paths.DisectLeaf(repo.Path)
+	return paths.Concatinate(spacePath, userGroup)
+}
+
 func findReviewerInList(email string, uid string, reviewers []*types.PullReqReviewer) *types.PullReqReviewer {
 	for _, reviewer := range reviewers {
 		if uid == reviewer.Reviewer.UID || email == reviewer.Reviewer.Email {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
 )
 
 func TestService_ParseCodeOwner(t *testing.T) {
@@ -280,3 +281,31 @@ func Test_contains(t *testing.T) {
 		})
 	}
 }
+
+func Test_userGroupScopedID(t *testing.T) {
+	repo := &types.Repository{Path: "space1/space2/repo"}
+
+	tests := []struct {
+		name      string
+		userGroup string
+		want      string
+	}{
+		{
+			name:      "Test relative user group",
+			userGroup: "developers",
+			want:      "space1/space2/developers",
+		},
+		{
+			name:      "Test scoped user group",
+			userGroup: "space1/developers",
+			want:      "space1/developers",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := userGroupScopedID(repo, tt.userGroup); got != tt.want {
+				t.Errorf("userGroupScopedID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
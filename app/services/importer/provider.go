@@ -101,6 +101,11 @@ func (r *RepositoryInfo) ToRepo(
 	}
 }
 
+// Slug returns the identifier of the repository at the provider.
+func (r *RepositoryInfo) Slug() string {
+	return r.Space + "/" + r.UID
+}
+
 func hash(s string) string {
 	h := sha512.New()
 	_, _ = h.Write([]byte(s))
@@ -0,0 +1,824 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/rs/zerolog/log"
+)
+
+// PullReqOption defines the supported pull request import options for repository import.
+type PullReqOption string
+
+func (PullReqOption) Enum() []any {
+	return []any{PullReqOptionImport, PullReqOptionIgnore}
+}
+
+const (
+	PullReqOptionImport PullReqOption = "import"
+	PullReqOptionIgnore PullReqOption = "ignore"
+)
+
+const pullReqPageSize = 100
+
+// errPullReqNotImportable is returned in case a pull request can't be imported,
+// e.g. because its commits aren't available in the imported repository.
+var errPullReqNotImportable = errors.New("pull request can't be imported")
+
+// pullReqRefSpecs returns the refspecs that fetch the head commits of all pull requests of the provider
+// to the pull request head references of the repository. This keeps the commits of pull requests
+// available even if the source branch got deleted or lives in a fork.
+func pullReqRefSpecs(providerType ProviderType) []string {
+	const pullReqHeadRefs = "refs/pullreq/*/head"
+
+	switch providerType {
+	case ProviderTypeGitHub, ProviderTypeGitea, ProviderTypeGogs:
+		return []string{"refs/pull/*/head:" + pullReqHeadRefs}
+	case ProviderTypeGitLab:
+		return []string{"refs/merge-requests/*/head:" + pullReqHeadRefs}
+	case ProviderTypeStash:
+		return []string{"refs/pull-requests/*/from:" + pullReqHeadRefs}
+	case ProviderTypeBitbucket:
+		// bitbucket doesn't expose pull request references, only the source branches are available.
+		return nil
+	default:
+		return nil
+	}
+}
+
+// externalPullReq is a pull request of the provider together with its conversation.
+type externalPullReq struct {
+	pr           *scm.PullRequest
+	comments     []*scm.Comment
+	codeComments []externalCodeComment
+	reviews      []externalReview
+}
+
+// externalCodeComment is a pull request comment that is anchored to lines of a file.
+type externalCodeComment struct {
+	id        int
+	replyToID int
+	path      string
+	commitSHA string
+	lineStart int
+	lineEnd   int
+	lineNew   bool
+	outdated  bool
+	body      string
+	author    scm.User
+	created   time.Time
+	updated   time.Time
+}
+
+// externalReview is a submitted pull request review.
+type externalReview struct {
+	author    scm.User
+	body      string
+	decision  enum.PullReqReviewDecision
+	commitSHA string
+	submitted time.Time
+}
+
+// importPullReqs imports all pull requests of the repository from the provider, including comments,
+// code comments and reviews. It returns the highest pull request number of the provider.
+// Pull requests that have already been imported by a previous attempt are skipped.
+func (r *Repository) importPullReqs(
+	ctx context.Context,
+	provider Provider,
+	repoSlug string,
+	repo *types.Repository,
+) (int64, error) {
+	if repoSlug == "" {
+		return 0, errors.New("missing provider repository identifier")
+	}
+
+	scmClient, err := getScmClientWithTransport(provider, false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create scm client: %w", err)
+	}
+
+	return r.importPullReqsWithClient(ctx, scmClient, provider.Type, repoSlug, repo)
+}
+
+// importPullReqsWithClient imports the pull requests using the scm client of the provider.
+// Parts of the conversation that the provider can't supply are skipped and logged.
+func (r *Repository) importPullReqsWithClient(
+	ctx context.Context,
+	scmClient *scm.Client,
+	providerType ProviderType,
+	repoSlug string,
+	repo *types.Repository,
+) (int64, error) {
+	fallback, err := r.principalStore.Find(ctx, repo.CreatedBy)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find creator of the repository: %w", err)
+	}
+
+	authors := &authorMapper{
+		principalStore: r.principalStore,
+		scmClient:      scmClient,
+		fallback:       fallback,
+		cache:          make(map[string]mappedAuthor),
+	}
+
+	scmPullReqs, err := listPullReqs(ctx, scmClient, repoSlug)
+	if errors.Is(err, scm.ErrNotSupported) {
+		log.Ctx(ctx).Warn().
+			Str("provider", string(providerType)).
+			Msg("provider doesn't support listing pull requests, skipping import of pull requests")
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	loader := &pullReqLoader{
+		scmClient:    scmClient,
+		providerType: providerType,
+		repoSlug:     repoSlug,
+	}
+
+	if !loader.supportsReviews() {
+		log.Ctx(ctx).Info().
+			Str("provider", string(providerType)).
+			Msg("provider doesn't supply code comments and reviews, importing pull requests without them")
+	}
+
+	var maxNumber int64
+	for _, scmPullReq := range scmPullReqs {
+		// numbers of skipped pull requests are reserved as well to keep references to pull requests valid.
+		number := int64(scmPullReq.Number)
+		if number > maxNumber {
+			maxNumber = number
+		}
+
+		_, err := r.pullReqStore.FindByNumber(ctx, repo.ID, number)
+		if err == nil {
+			log.Ctx(ctx).Debug().
+				Int("pullreq.number", scmPullReq.Number).
+				Msg("pull request has already been imported")
+			continue
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return 0, fmt.Errorf("failed to find pull request #%d: %w", scmPullReq.Number, err)
+		}
+
+		ext, err := loader.load(ctx, scmPullReq)
+		if err != nil {
+			return 0, fmt.Errorf("failed to load pull request #%d: %w", scmPullReq.Number, err)
+		}
+
+		err = r.importPullReq(ctx, repo, repoSlug, authors, ext)
+		if errors.Is(err, errPullReqNotImportable) {
+			log.Ctx(ctx).Warn().Err(err).
+				Int("pullreq.number", scmPullReq.Number).
+				Msg("skipping import of pull request")
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to import pull request #%d: %w", scmPullReq.Number, err)
+		}
+	}
+
+	return maxNumber, nil
+}
+
+func listPullReqs(ctx context.Context, scmClient *scm.Client, repoSlug string) ([]*scm.PullRequest, error) {
+	opts := scm.PullRequestListOptions{
+		Page:   1,
+		Size:   pullReqPageSize,
+		Open:   true,
+		Closed: true,
+	}
+
+	var pullReqs []*scm.PullRequest
+	for {
+		page, res, err := scmClient.PullRequests.List(ctx, repoSlug, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list pull requests: %w", err)
+		}
+
+		pullReqs = append(pullReqs, page...)
+
+		if len(page) == 0 || res == nil || res.Page.Next == 0 {
+			break
+		}
+		opts.Page = res.Page.Next
+	}
+
+	sort.Slice(pullReqs, func(i, j int) bool { return pullReqs[i].Number < pullReqs[j].Number })
+
+	return pullReqs, nil
+}
+
+// pullReqLoader loads the conversation of pull requests from the provider.
+type pullReqLoader struct {
+	scmClient    *scm.Client
+	providerType ProviderType
+	repoSlug     string
+
+	// commentsSkipped is set once the provider turned out to not supply comments of pull requests.
+	commentsSkipped bool
+}
+
+// supportsReviews returns whether code comments and reviews of pull requests can be loaded from the provider.
+// go-scm doesn't expose them, they are only loaded from the GitHub API directly.
+func (l *pullReqLoader) supportsReviews() bool {
+	return l.providerType == ProviderTypeGitHub
+}
+
+func (l *pullReqLoader) load(ctx context.Context, pr *scm.PullRequest) (*externalPullReq, error) {
+	ext := &externalPullReq{pr: pr}
+
+	var err error
+
+	ext.comments, err = l.listComments(ctx, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list comments: %w", err)
+	}
+
+	if !l.supportsReviews() {
+		return ext, nil
+	}
+
+	ext.codeComments, err = listGitHubCodeComments(ctx, l.scmClient, l.repoSlug, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list code comments: %w", err)
+	}
+
+	ext.reviews, err = listGitHubReviews(ctx, l.scmClient, l.repoSlug, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviews: %w", err)
+	}
+
+	return ext, nil
+}
+
+// listComments returns the general comments of the pull request. Some providers only supply them
+// as comments of the issue that backs the pull request. In case neither is supported, no comments are returned.
+func (l *pullReqLoader) listComments(ctx context.Context, number int) ([]*scm.Comment, error) {
+	if l.commentsSkipped {
+		return nil, nil
+	}
+
+	comments, err := listPages(func(opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+		return l.scmClient.PullRequests.ListComments(ctx, l.repoSlug, number, opts)
+	})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		return comments, err
+	}
+
+	comments, err = listPages(func(opts scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
+		return l.scmClient.Issues.ListComments(ctx, l.repoSlug, number, opts)
+	})
+	if !errors.Is(err, scm.ErrNotSupported) {
+		return comments, err
+	}
+
+	log.Ctx(ctx).Warn().
+		Str("provider", string(l.providerType)).
+		Msg("provider doesn't supply comments of pull requests, importing pull requests without comments")
+
+	l.commentsSkipped = true
+
+	return nil, nil
+}
+
+// listPages returns the items of all pages of the list function.
+func listPages[T any](list func(opts scm.ListOptions) ([]T, *scm.Response, error)) ([]T, error) {
+	opts := scm.ListOptions{Page: 1, Size: pullReqPageSize}
+
+	var items []T
+	for {
+		page, res, err := list(opts)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, page...)
+
+		if len(page) == 0 || res == nil || res.Page.Next == 0 {
+			return items, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// timelineEntry is a top level pull request activity with its replies.
+type timelineEntry struct {
+	activity *types.PullReqActivity
+	replies  []*types.PullReqActivity
+}
+
+// importPullReq stores the pull request with its timeline. Pull request numbers of the provider are kept.
+//
+//nolint:gocognit,funlen // refactor if needed.
+func (r *Repository) importPullReq(
+	ctx context.Context,
+	repo *types.Repository,
+	repoSlug string,
+	authors *authorMapper,
+	ext *externalPullReq,
+) error {
+	scmPullReq := ext.pr
+	readParams := git.ReadParams{RepoUID: repo.GitUID}
+
+	sourceSHA := scmPullReq.Sha
+	if sourceSHA == "" {
+		sourceSHA = scmPullReq.Head.Sha
+	}
+	if sourceSHA == "" {
+		return fmt.Errorf("%w: source commit is unknown", errPullReqNotImportable)
+	}
+
+	_, err := r.git.GetCommit(ctx, &git.GetCommitParams{ReadParams: readParams, SHA: sourceSHA})
+	if err != nil {
+		return fmt.Errorf("%w: source commit %s isn't available: %w", errPullReqNotImportable, sourceSHA, err)
+	}
+
+	mergeBaseSHA, err := r.findMergeBase(ctx, readParams, sourceSHA, scmPullReq)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errPullReqNotImportable, err)
+	}
+
+	sourceBranch := scmPullReq.Source
+	if scmPullReq.Fork != "" && !strings.EqualFold(scmPullReq.Fork, repoSlug) {
+		// The source branch of a fork doesn't exist in the repository. Prefix it with the fork,
+		// ':' isn't allowed in branch names so it can't be confused with a branch of the repository.
+		sourceBranch = scmPullReq.Fork + ":" + scmPullReq.Source
+	}
+
+	author, authorMapped := authors.principalOf(ctx, scmPullReq.Author)
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+
+	created := toMillis(scmPullReq.Created, time.Now().UnixMilli())
+	updated := toMillis(scmPullReq.Updated, created)
+
+	pr := &types.PullReq{
+		Number:           int64(scmPullReq.Number),
+		CreatedBy:        author.ID,
+		Created:          created,
+		Updated:          updated,
+		Edited:           updated,
+		State:            enum.PullReqStateOpen,
+		Title:            scmPullReq.Title,
+		Description:      attributeText(scmPullReq.Body, scmPullReq.Author, authorMapped),
+		SourceRepoID:     repo.ID,
+		SourceBranch:     sourceBranch,
+		SourceSHA:        sourceSHA,
+		TargetRepoID:     repo.ID,
+		TargetBranch:     scmPullReq.Target,
+		MergeCheckStatus: enum.MergeCheckStatusUnchecked,
+		MergeBaseSHA:     mergeBaseSHA,
+		Author:           *author.ToPrincipalInfo(),
+	}
+
+	switch {
+	case scmPullReq.Merged:
+		// the provider doesn't tell who merged the pull request.
+		pr.State = enum.PullReqStateMerged
+		pr.MergeCheckStatus = enum.MergeCheckStatusMergeable
+		pr.Merged = &updated
+		pr.MergedBy = &systemPrincipal.ID
+		pr.Merger = systemPrincipal.ToPrincipalInfo()
+		if scmPullReq.Merge != "" {
+			mergeSHA := scmPullReq.Merge
+			pr.MergeSHA = &mergeSHA
+		}
+	case scmPullReq.Closed:
+		pr.State = enum.PullReqStateClosed
+	}
+
+	var entries []*timelineEntry
+
+	for _, comment := range ext.comments {
+		principal, mapped := authors.principalOf(ctx, comment.Author)
+		act := newImportedActivity(pr, principal, comment.Created, comment.Updated)
+		act.Text = attributeText(comment.Body, comment.Author, mapped)
+		_ = act.SetPayload(types.PullRequestActivityPayloadComment{})
+
+		entries = append(entries, &timelineEntry{activity: act})
+	}
+
+	threads := make(map[int]*timelineEntry)
+	for _, codeComment := range ext.codeComments {
+		principal, mapped := authors.principalOf(ctx, codeComment.author)
+		act := newImportedActivity(pr, principal, codeComment.created, codeComment.updated)
+		act.Text = attributeText(codeComment.body, codeComment.author, mapped)
+
+		if thread, ok := threads[codeComment.replyToID]; ok {
+			act.Kind = thread.activity.Kind
+			_ = act.SetPayload(types.PullRequestActivityPayloadComment{})
+
+			thread.replies = append(thread.replies, act)
+			threads[codeComment.id] = thread
+			continue
+		}
+
+		r.setCodeComment(ctx, readParams, pr, act, codeComment)
+
+		thread := &timelineEntry{activity: act}
+		threads[codeComment.id] = thread
+		entries = append(entries, thread)
+	}
+
+	var reviews []*types.PullReqReview
+	for _, review := range ext.reviews {
+		principal, mapped := authors.principalOf(ctx, review.author)
+
+		// reviews of unknown users can't be attributed and authors can't review their own pull requests.
+		if mapped && principal.ID != pr.CreatedBy {
+			act := newImportedActivity(pr, principal, review.submitted, review.submitted)
+			act.Type = enum.PullReqActivityTypeReviewSubmit
+			act.Kind = enum.PullReqActivityKindSystem
+			_ = act.SetPayload(&types.PullRequestActivityPayloadReviewSubmit{
+				CommitSHA: review.commitSHA,
+				Decision:  review.decision,
+			})
+			entries = append(entries, &timelineEntry{activity: act})
+
+			reviews = append(reviews, &types.PullReqReview{
+				CreatedBy: principal.ID,
+				Created:   act.Created,
+				Updated:   act.Created,
+				Decision:  review.decision,
+				SHA:       review.commitSHA,
+			})
+		}
+
+		if review.body != "" {
+			act := newImportedActivity(pr, principal, review.submitted, review.submitted)
+			act.Text = attributeText(review.body, review.author, mapped)
+			_ = act.SetPayload(types.PullRequestActivityPayloadComment{})
+			entries = append(entries, &timelineEntry{activity: act})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].activity.Created < entries[j].activity.Created
+	})
+
+	switch pr.State {
+	case enum.PullReqStateMerged:
+		act := newImportedActivity(pr, &systemPrincipal, time.UnixMilli(updated), time.UnixMilli(updated))
+		act.Type = enum.PullReqActivityTypeMerge
+		act.Kind = enum.PullReqActivityKindSystem
+		_ = act.SetPayload(&types.PullRequestActivityPayloadMerge{
+			MergeSHA:  scmPullReq.Merge,
+			TargetSHA: scmPullReq.Base.Sha,
+			SourceSHA: sourceSHA,
+		})
+		entries = append(entries, &timelineEntry{activity: act})
+	case enum.PullReqStateClosed:
+		act := newImportedActivity(pr, &systemPrincipal, time.UnixMilli(updated), time.UnixMilli(updated))
+		act.Type = enum.PullReqActivityTypeStateChange
+		act.Kind = enum.PullReqActivityKindSystem
+		_ = act.SetPayload(&types.PullRequestActivityPayloadStateChange{
+			Old: enum.PullReqStateOpen,
+			New: enum.PullReqStateClosed,
+		})
+		entries = append(entries, &timelineEntry{activity: act})
+	case enum.PullReqStateOpen:
+	}
+
+	for _, entry := range entries {
+		if entry.activity.Kind == enum.PullReqActivityKindSystem {
+			continue
+		}
+
+		pr.CommentCount += 1 + len(entry.replies)
+		if entry.activity.IsBlocking() {
+			pr.UnresolvedCount++
+		}
+	}
+	pr.ActivitySeq = int64(len(entries))
+
+	return r.tx.WithTx(ctx, func(ctx context.Context) error {
+		err := r.pullReqStore.Create(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request: %w", err)
+		}
+
+		for i, entry := range entries {
+			entry.activity.PullReqID = pr.ID
+			entry.activity.Order = int64(i + 1)
+			entry.activity.ReplySeq = int64(len(entry.replies))
+
+			err = r.pullReqActivityStore.Create(ctx, entry.activity)
+			if err != nil {
+				return fmt.Errorf("failed to create pull request activity: %w", err)
+			}
+
+			for j, reply := range entry.replies {
+				reply.PullReqID = pr.ID
+				reply.ParentID = &entry.activity.ID
+				reply.Order = entry.activity.Order
+				reply.SubOrder = int64(j + 1)
+
+				err = r.pullReqActivityStore.Create(ctx, reply)
+				if err != nil {
+					return fmt.Errorf("failed to create pull request activity reply: %w", err)
+				}
+			}
+		}
+
+		err = r.createReviews(ctx, pr, reviews)
+		if err != nil {
+			return err
+		}
+
+		return r.updatePullReqCounters(ctx, repo.ID, pr.State)
+	}, dbtx.TxDefault)
+}
+
+// updatePullReqCounters updates the pull request counters of the repository for an imported pull request.
+func (r *Repository) updatePullReqCounters(ctx context.Context, repoID int64, state enum.PullReqState) error {
+	repo, err := r.repoStore.Find(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository to update pull request counters: %w", err)
+	}
+
+	_, err = r.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+		repo.NumPulls++
+		switch state {
+		case enum.PullReqStateOpen:
+			repo.NumOpenPulls++
+		case enum.PullReqStateClosed:
+			repo.NumClosedPulls++
+		case enum.PullReqStateMerged:
+			repo.NumMergedPulls++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update pull request counters of repository: %w", err)
+	}
+
+	return nil
+}
+
+// findMergeBase returns the merge base of the source commit and the target of the pull request.
+// The target commit known by the provider is preferred as the target branch might have advanced since.
+func (r *Repository) findMergeBase(
+	ctx context.Context,
+	readParams git.ReadParams,
+	sourceSHA string,
+	scmPullReq *scm.PullRequest,
+) (string, error) {
+	targetRefs := []string{scmPullReq.Base.Sha, "refs/heads/" + scmPullReq.Target}
+
+	var err error
+	for _, targetRef := range targetRefs {
+		if targetRef == "" || targetRef == "refs/heads/" {
+			continue
+		}
+
+		var out git.MergeBaseOutput
+		out, err = r.git.MergeBase(ctx, git.MergeBaseParams{
+			ReadParams: readParams,
+			Ref1:       sourceSHA,
+			Ref2:       targetRef,
+		})
+		if err == nil {
+			return out.MergeBaseSHA, nil
+		}
+	}
+
+	if err == nil {
+		err = errors.New("target of the pull request is unknown")
+	}
+
+	return "", fmt.Errorf("failed to find merge base: %w", err)
+}
+
+// setCodeComment anchors the activity to the commented lines of the file. In case the lines can't be found,
+// e.g. because the commit is gone after a force push, the activity remains a regular comment
+// that references the file and line.
+func (r *Repository) setCodeComment(
+	ctx context.Context,
+	readParams git.ReadParams,
+	pr *types.PullReq,
+	act *types.PullReqActivity,
+	codeComment externalCodeComment,
+) {
+	if !git.ValidateCommitSHA(codeComment.commitSHA) ||
+		codeComment.lineStart <= 0 || codeComment.lineEnd < codeComment.lineStart {
+		log.Ctx(ctx).Debug().
+			Str("path", codeComment.path).
+			Msg("code comment has no valid commit or lines, import it as regular comment")
+
+		setCodeCommentLocation(act, codeComment)
+		return
+	}
+
+	cut, err := r.git.DiffCut(ctx, &git.DiffCutParams{
+		ReadParams:      readParams,
+		SourceCommitSHA: codeComment.commitSHA,
+		SourceBranch:    pr.SourceSHA,
+		TargetCommitSHA: pr.MergeBaseSHA,
+		TargetBranch:    pr.MergeBaseSHA,
+		Path:            codeComment.path,
+		LineStart:       codeComment.lineStart,
+		LineStartNew:    codeComment.lineNew,
+		LineEnd:         codeComment.lineEnd,
+		LineEndNew:      codeComment.lineNew,
+	})
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).
+			Str("path", codeComment.path).
+			Msg("failed to anchor code comment, import it as regular comment")
+
+		setCodeCommentLocation(act, codeComment)
+		return
+	}
+
+	act.Type = enum.PullReqActivityTypeCodeComment
+	act.Kind = enum.PullReqActivityKindChangeComment
+	act.CodeComment = &types.CodeCommentFields{
+		Outdated:     codeComment.outdated,
+		MergeBaseSHA: cut.MergeBaseSHA,
+		SourceSHA:    codeComment.commitSHA,
+		Path:         codeComment.path,
+		LineNew:      cut.Header.NewLine,
+		SpanNew:      cut.Header.NewSpan,
+		LineOld:      cut.Header.OldLine,
+		SpanOld:      cut.Header.OldSpan,
+	}
+	_ = act.SetPayload(&types.PullRequestActivityPayloadCodeComment{
+		Title:        cut.LinesHeader,
+		Lines:        cut.Lines,
+		LineStartNew: codeComment.lineNew,
+		LineEndNew:   codeComment.lineNew,
+	})
+}
+
+// setCodeCommentLocation turns the activity into a regular comment that references the file and line.
+func setCodeCommentLocation(act *types.PullReqActivity, codeComment externalCodeComment) {
+	location := fmt.Sprintf("`%s`", codeComment.path)
+	if codeComment.lineEnd > 0 {
+		location = fmt.Sprintf("`%s` (line %d)", codeComment.path, codeComment.lineEnd)
+	}
+	act.Text = location + "\n\n" + act.Text
+	_ = act.SetPayload(types.PullRequestActivityPayloadComment{})
+}
+
+// createReviews stores the reviews and sets the latest review of every reviewer.
+func (r *Repository) createReviews(ctx context.Context, pr *types.PullReq, reviews []*types.PullReqReview) error {
+	reviewers := make(map[int64]*types.PullReqReviewer)
+	var reviewerIDs []int64
+
+	for _, review := range reviews {
+		review.PullReqID = pr.ID
+
+		err := r.pullReqReviewStore.Create(ctx, review)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request review: %w", err)
+		}
+
+		reviewer, ok := reviewers[review.CreatedBy]
+		if !ok {
+			reviewer = &types.PullReqReviewer{
+				PullReqID:   pr.ID,
+				PrincipalID: review.CreatedBy,
+				CreatedBy:   review.CreatedBy,
+				Created:     review.Created,
+				RepoID:      pr.TargetRepoID,
+				Type:        enum.PullReqReviewerTypeSelfAssigned,
+			}
+			reviewers[review.CreatedBy] = reviewer
+			reviewerIDs = append(reviewerIDs, review.CreatedBy)
+		}
+
+		reviewer.Updated = review.Created
+		reviewer.LatestReviewID = &review.ID
+		reviewer.ReviewDecision = review.Decision
+		reviewer.SHA = review.SHA
+	}
+
+	for _, id := range reviewerIDs {
+		err := r.pullReqReviewerStore.Create(ctx, reviewers[id])
+		if err != nil {
+			return fmt.Errorf("failed to create pull request reviewer: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func newImportedActivity(
+	pr *types.PullReq,
+	principal *types.Principal,
+	created time.Time,
+	updated time.Time,
+) *types.PullReqActivity {
+	createdMillis := toMillis(created, pr.Created)
+	updatedMillis := toMillis(updated, createdMillis)
+
+	return &types.PullReqActivity{
+		CreatedBy: principal.ID,
+		Created:   createdMillis,
+		Updated:   updatedMillis,
+		Edited:    updatedMillis,
+		RepoID:    pr.TargetRepoID,
+		Type:      enum.PullReqActivityTypeComment,
+		Kind:      enum.PullReqActivityKindComment,
+		Author:    *principal.ToPrincipalInfo(),
+	}
+}
+
+func toMillis(t time.Time, def int64) int64 {
+	if t.IsZero() {
+		return def
+	}
+	return t.UnixMilli()
+}
+
+// attributeText keeps the original author of the text in case it couldn't be mapped to a principal.
+func attributeText(text string, user scm.User, mapped bool) string {
+	if mapped || user.Login == "" {
+		return text
+	}
+
+	return fmt.Sprintf("*Originally created by @%s*\n\n%s", user.Login, text)
+}
+
+type mappedAuthor struct {
+	principal *types.Principal
+	mapped    bool
+}
+
+// authorMapper maps users of the provider to principals by their email address.
+// Users that can't be mapped are replaced with the fallback principal.
+type authorMapper struct {
+	principalStore store.PrincipalStore
+	scmClient      *scm.Client
+	fallback       *types.Principal
+	cache          map[string]mappedAuthor
+}
+
+// principalOf returns the principal of the user and whether the user could be mapped.
+func (m *authorMapper) principalOf(ctx context.Context, user scm.User) (*types.Principal, bool) {
+	key := user.Login
+	if key == "" {
+		key = user.Email
+	}
+	if key == "" {
+		return m.fallback, false
+	}
+
+	if author, ok := m.cache[key]; ok {
+		return author.principal, author.mapped
+	}
+
+	email := user.Email
+	if email == "" && user.Login != "" {
+		// the email is often not part of the listed objects, but might be visible in the user's profile.
+		scmUser, _, err := m.scmClient.Users.FindLogin(ctx, user.Login)
+		if err == nil && scmUser != nil {
+			email = scmUser.Email
+		}
+	}
+
+	author := mappedAuthor{principal: m.fallback}
+	if email != "" {
+		principal, err := m.principalStore.FindByEmail(ctx, email)
+		switch {
+		case errors.Is(err, gitness_store.ErrResourceNotFound):
+		case err != nil:
+			log.Ctx(ctx).Warn().Err(err).Str("login", user.Login).Msg("failed to find principal by email")
+		case principal.Type == enum.PrincipalTypeUser:
+			author = mappedAuthor{principal: principal, mapped: true}
+		}
+	}
+
+	m.cache[key] = author
+
+	return author.principal, author.mapped
+}
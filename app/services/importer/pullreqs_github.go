@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+)
+
+// go-scm doesn't expose reviews and only returns diff positions for review comments,
+// which is why they are loaded directly from the GitHub API.
+
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+type githubReviewComment struct {
+	ID                int        `json:"id"`
+	InReplyToID       int        `json:"in_reply_to_id"`
+	Path              string     `json:"path"`
+	OriginalCommitID  string     `json:"original_commit_id"`
+	Line              *int       `json:"line"`
+	OriginalLine      *int       `json:"original_line"`
+	OriginalStartLine *int       `json:"original_start_line"`
+	Side              string     `json:"side"`
+	Body              string     `json:"body"`
+	User              githubUser `json:"user"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+type githubReview struct {
+	User        githubUser `json:"user"`
+	Body        string     `json:"body"`
+	State       string     `json:"state"`
+	CommitID    string     `json:"commit_id"`
+	SubmittedAt time.Time  `json:"submitted_at"`
+}
+
+func listGitHubCodeComments(
+	ctx context.Context,
+	scmClient *scm.Client,
+	repoSlug string,
+	number int,
+) ([]externalCodeComment, error) {
+	comments, err := listGitHub[githubReviewComment](ctx, scmClient,
+		fmt.Sprintf("repos/%s/pulls/%d/comments", repoSlug, number))
+	if err != nil {
+		return nil, err
+	}
+
+	codeComments := make([]externalCodeComment, len(comments))
+	for i, comment := range comments {
+		codeComment := externalCodeComment{
+			id:        comment.ID,
+			replyToID: comment.InReplyToID,
+			path:      comment.Path,
+			commitSHA: comment.OriginalCommitID,
+			lineNew:   comment.Side != "LEFT",
+			outdated:  comment.Line == nil, // GitHub removes the line if it's not part of the latest diff.
+			body:      comment.Body,
+			author:    scm.User{Login: comment.User.Login},
+			created:   comment.CreatedAt,
+			updated:   comment.UpdatedAt,
+		}
+
+		// file level comments don't have a line.
+		if comment.OriginalLine != nil {
+			codeComment.lineStart = *comment.OriginalLine
+			codeComment.lineEnd = *comment.OriginalLine
+		}
+		if comment.OriginalStartLine != nil {
+			codeComment.lineStart = *comment.OriginalStartLine
+		}
+
+		codeComments[i] = codeComment
+	}
+
+	return codeComments, nil
+}
+
+func listGitHubReviews(
+	ctx context.Context,
+	scmClient *scm.Client,
+	repoSlug string,
+	number int,
+) ([]externalReview, error) {
+	githubReviews, err := listGitHub[githubReview](ctx, scmClient,
+		fmt.Sprintf("repos/%s/pulls/%d/reviews", repoSlug, number))
+	if err != nil {
+		return nil, err
+	}
+
+	reviews := make([]externalReview, 0, len(githubReviews))
+	for _, review := range githubReviews {
+		decision, ok := githubReviewDecision(review.State)
+		if !ok {
+			continue
+		}
+
+		// GitHub creates a review without body for every batch of code comments, these are skipped.
+		if decision == enum.PullReqReviewDecisionReviewed && review.Body == "" {
+			continue
+		}
+
+		reviews = append(reviews, externalReview{
+			author:    scm.User{Login: review.User.Login},
+			body:      review.Body,
+			decision:  decision,
+			commitSHA: review.CommitID,
+			submitted: review.SubmittedAt,
+		})
+	}
+
+	return reviews, nil
+}
+
+// githubReviewDecision maps the state of a GitHub review to a review decision.
+// Pending and dismissed reviews aren't imported.
+func githubReviewDecision(state string) (enum.PullReqReviewDecision, bool) {
+	switch state {
+	case "APPROVED":
+		return enum.PullReqReviewDecisionApproved, true
+	case "CHANGES_REQUESTED":
+		return enum.PullReqReviewDecisionChangeReq, true
+	case "COMMENTED":
+		return enum.PullReqReviewDecisionReviewed, true
+	default:
+		return "", false
+	}
+}
+
+// listGitHub loads all pages of a list endpoint of the GitHub API.
+func listGitHub[T any](ctx context.Context, scmClient *scm.Client, path string) ([]T, error) {
+	var items []T
+	for page := 1; page != 0; {
+		res, err := scmClient.Do(ctx, &scm.Request{
+			Method: http.MethodGet,
+			Path:   fmt.Sprintf("%s?per_page=%d&page=%d", path, pullReqPageSize, page),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to request %s: %w", path, err)
+		}
+
+		pageItems, err := func() ([]T, error) {
+			defer func() {
+				_ = res.Body.Close()
+			}()
+
+			if res.Status >= http.StatusMultipleChoices {
+				return nil, fmt.Errorf("request %s failed with HTTP status %d", path, res.Status)
+			}
+
+			var pageItems []T
+			if err := json.NewDecoder(res.Body).Decode(&pageItems); err != nil {
+				return nil, fmt.Errorf("failed to decode response of %s: %w", path, err)
+			}
+
+			return pageItems, nil
+		}()
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, pageItems...)
+
+		page = res.Page.Next
+		if len(pageItems) == 0 {
+			page = 0
+		}
+	}
+
+	return items, nil
+}
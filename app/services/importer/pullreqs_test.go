@@ -0,0 +1,623 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package importer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+)
+
+const (
+	testSourceSHA    = "1111111111111111111111111111111111111111"
+	testMergeBaseSHA = "2222222222222222222222222222222222222222"
+	testSystemID     = 99
+)
+
+var errTestDiff = errors.New("diff failure")
+
+func TestMain(m *testing.M) {
+	config := &types.Config{}
+	config.Principal.System.UID = "gitness"
+
+	principals := &principalStoreMock{}
+	err := bootstrap.SystemService(context.Background(), config, service.NewController(nil, nil, principals))
+	if err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+type principalStoreMock struct {
+	store.PrincipalStore
+	principals []*types.Principal
+	// lookups counts the email lookups.
+	lookups int
+}
+
+func (s *principalStoreMock) Find(_ context.Context, id int64) (*types.Principal, error) {
+	for _, principal := range s.principals {
+		if principal.ID == id {
+			return principal, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreMock) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	s.lookups++
+	for _, principal := range s.principals {
+		if principal.Email == email {
+			return principal, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *principalStoreMock) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: testSystemID, UID: uid, Admin: true}, nil
+}
+
+type userServiceMock struct {
+	scm.UserService
+	users map[string]*scm.User
+}
+
+func (s userServiceMock) FindLogin(_ context.Context, login string) (*scm.User, *scm.Response, error) {
+	if user, ok := s.users[login]; ok {
+		return user, nil, nil
+	}
+	return nil, nil, scm.ErrNotFound
+}
+
+type pullReqServiceMock struct {
+	scm.PullRequestService
+	pullReqs []*scm.PullRequest
+	listErr  error
+	comments map[int][]*scm.Comment
+	// commentsErr is returned when listing comments of pull requests.
+	commentsErr error
+}
+
+func (s pullReqServiceMock) List(
+	_ context.Context,
+	_ string,
+	_ scm.PullRequestListOptions,
+) ([]*scm.PullRequest, *scm.Response, error) {
+	return s.pullReqs, nil, s.listErr
+}
+
+func (s pullReqServiceMock) ListComments(
+	_ context.Context,
+	_ string,
+	number int,
+	_ scm.ListOptions,
+) ([]*scm.Comment, *scm.Response, error) {
+	if s.commentsErr != nil {
+		return nil, nil, s.commentsErr
+	}
+	return s.comments[number], nil, nil
+}
+
+type issueServiceMock struct {
+	scm.IssueService
+	comments map[int][]*scm.Comment
+}
+
+func (s issueServiceMock) ListComments(
+	_ context.Context,
+	_ string,
+	number int,
+	_ scm.ListOptions,
+) ([]*scm.Comment, *scm.Response, error) {
+	if s.comments == nil {
+		return nil, nil, scm.ErrNotSupported
+	}
+	return s.comments[number], nil, nil
+}
+
+type gitMock struct {
+	git.Interface
+	diffCutErr error
+}
+
+func (g gitMock) GetCommit(_ context.Context, params *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	if params.SHA != testSourceSHA {
+		return nil, errors.New("commit not found")
+	}
+	return &git.GetCommitOutput{}, nil
+}
+
+func (g gitMock) MergeBase(_ context.Context, _ git.MergeBaseParams) (git.MergeBaseOutput, error) {
+	return git.MergeBaseOutput{MergeBaseSHA: testMergeBaseSHA}, nil
+}
+
+func (g gitMock) DiffCut(_ context.Context, _ *git.DiffCutParams) (git.DiffCutOutput, error) {
+	if g.diffCutErr != nil {
+		return git.DiffCutOutput{}, g.diffCutErr
+	}
+	return git.DiffCutOutput{
+		Header:       git.HunkHeader{OldLine: 3, OldSpan: 2, NewLine: 4, NewSpan: 2},
+		LinesHeader:  "@@ -3,2 +4,2 @@",
+		Lines:        []string{" a", "+b"},
+		MergeBaseSHA: testMergeBaseSHA,
+	}, nil
+}
+
+type transactorMock struct{}
+
+func (transactorMock) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...interface{}) error {
+	return txFn(ctx)
+}
+
+type repoStoreMock struct {
+	store.RepoStore
+	repo *types.Repository
+}
+
+func (s *repoStoreMock) Find(_ context.Context, _ int64) (*types.Repository, error) {
+	return s.repo, nil
+}
+
+func (s *repoStoreMock) UpdateOptLock(
+	_ context.Context,
+	repo *types.Repository,
+	mutateFn func(repository *types.Repository) error,
+) (*types.Repository, error) {
+	if err := mutateFn(repo); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+type pullReqStoreMock struct {
+	store.PullReqStore
+	// imported are the numbers of pull requests that already exist.
+	imported map[int64]bool
+	created  []*types.PullReq
+}
+
+func (s *pullReqStoreMock) FindByNumber(_ context.Context, _, number int64) (*types.PullReq, error) {
+	if s.imported[number] {
+		return &types.PullReq{Number: number}, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *pullReqStoreMock) Create(_ context.Context, pr *types.PullReq) error {
+	pr.ID = int64(len(s.created) + 1)
+	s.created = append(s.created, pr)
+	return nil
+}
+
+type pullReqActivityStoreMock struct {
+	store.PullReqActivityStore
+	created []*types.PullReqActivity
+}
+
+func (s *pullReqActivityStoreMock) Create(_ context.Context, act *types.PullReqActivity) error {
+	act.ID = int64(len(s.created) + 1)
+	s.created = append(s.created, act)
+	return nil
+}
+
+// testImport holds the repository importer together with the fakes it works on.
+type testImport struct {
+	importer   *Repository
+	repo       *types.Repository
+	pullReqs   *pullReqStoreMock
+	activities *pullReqActivityStoreMock
+}
+
+func newTestImport(imported map[int64]bool) *testImport {
+	repo := &types.Repository{ID: 1, GitUID: "repo", CreatedBy: 1}
+	pullReqs := &pullReqStoreMock{imported: imported}
+	activities := &pullReqActivityStoreMock{}
+
+	return &testImport{
+		importer: &Repository{
+			git: gitMock{},
+			tx:  transactorMock{},
+			principalStore: &principalStoreMock{principals: []*types.Principal{
+				{ID: 1, UID: "creator", Email: "creator@example.com", Type: enum.PrincipalTypeUser},
+				{ID: 2, UID: "jane", Email: "jane@example.com", Type: enum.PrincipalTypeUser},
+			}},
+			repoStore:            &repoStoreMock{repo: repo},
+			pullReqStore:         pullReqs,
+			pullReqActivityStore: activities,
+		},
+		repo:       repo,
+		pullReqs:   pullReqs,
+		activities: activities,
+	}
+}
+
+func testPullReq(number int) *scm.PullRequest {
+	return &scm.PullRequest{
+		Number:  number,
+		Title:   "change",
+		Sha:     testSourceSHA,
+		Source:  "feature",
+		Target:  "main",
+		Author:  scm.User{Login: "jane", Email: "jane@example.com"},
+		Created: time.UnixMilli(1000),
+		Updated: time.UnixMilli(2000),
+	}
+}
+
+func TestAuthorMapper_PrincipalOf(t *testing.T) {
+	fallback := &types.Principal{ID: 1, Type: enum.PrincipalTypeUser}
+	jane := &types.Principal{ID: 2, Email: "jane@example.com", Type: enum.PrincipalTypeUser}
+	bot := &types.Principal{ID: 3, Email: "bot@example.com", Type: enum.PrincipalTypeService}
+
+	tests := []struct {
+		name          string
+		user          scm.User
+		wantPrincipal *types.Principal
+		wantMapped    bool
+	}{
+		{
+			name:          "email",
+			user:          scm.User{Login: "jane", Email: "jane@example.com"},
+			wantPrincipal: jane,
+			wantMapped:    true,
+		},
+		{
+			name:          "email-of-profile",
+			user:          scm.User{Login: "jane-profile"},
+			wantPrincipal: jane,
+			wantMapped:    true,
+		},
+		{
+			name:          "unknown-email",
+			user:          scm.User{Login: "john", Email: "john@example.com"},
+			wantPrincipal: fallback,
+		},
+		{
+			name:          "no-email",
+			user:          scm.User{Login: "ghost"},
+			wantPrincipal: fallback,
+		},
+		{
+			name:          "no-user",
+			user:          scm.User{},
+			wantPrincipal: fallback,
+		},
+		{
+			name:          "service-principal",
+			user:          scm.User{Login: "bot", Email: "bot@example.com"},
+			wantPrincipal: fallback,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			principals := &principalStoreMock{principals: []*types.Principal{fallback, jane, bot}}
+			m := &authorMapper{
+				principalStore: principals,
+				scmClient: &scm.Client{Users: userServiceMock{users: map[string]*scm.User{
+					"jane-profile": {Login: "jane-profile", Email: "jane@example.com"},
+				}}},
+				fallback: fallback,
+				cache:    make(map[string]mappedAuthor),
+			}
+
+			for i := 0; i < 2; i++ {
+				principal, mapped := m.principalOf(context.Background(), test.user)
+				if principal != test.wantPrincipal {
+					t.Errorf("principal: got %d, want %d", principal.ID, test.wantPrincipal.ID)
+				}
+				if mapped != test.wantMapped {
+					t.Errorf("mapped: got %t, want %t", mapped, test.wantMapped)
+				}
+			}
+
+			if principals.lookups > 1 {
+				t.Errorf("expected the mapped author to be cached, got %d email lookups", principals.lookups)
+			}
+		})
+	}
+}
+
+func TestRepository_SetCodeComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		codeComment externalCodeComment
+		diffCutErr  error
+		wantType    enum.PullReqActivityType
+		wantFields  *types.CodeCommentFields
+		wantText    string
+	}{
+		{
+			name: "anchored",
+			codeComment: externalCodeComment{
+				path: "a.go", commitSHA: testSourceSHA, lineStart: 4, lineEnd: 5, lineNew: true,
+			},
+			wantType: enum.PullReqActivityTypeCodeComment,
+			wantFields: &types.CodeCommentFields{
+				MergeBaseSHA: testMergeBaseSHA,
+				SourceSHA:    testSourceSHA,
+				Path:         "a.go",
+				LineNew:      4,
+				SpanNew:      2,
+				LineOld:      3,
+				SpanOld:      2,
+			},
+			wantText: "text",
+		},
+		{
+			name: "invalid-sha",
+			codeComment: externalCodeComment{
+				path: "a.go", commitSHA: "main", lineStart: 4, lineEnd: 5,
+			},
+			wantType: enum.PullReqActivityTypeComment,
+			wantText: "`a.go` (line 5)\n\ntext",
+		},
+		{
+			name: "no-lines",
+			codeComment: externalCodeComment{
+				path: "a.go", commitSHA: testSourceSHA,
+			},
+			wantType: enum.PullReqActivityTypeComment,
+			wantText: "`a.go`\n\ntext",
+		},
+		{
+			name: "reversed-lines",
+			codeComment: externalCodeComment{
+				path: "a.go", commitSHA: testSourceSHA, lineStart: 5, lineEnd: 4,
+			},
+			wantType: enum.PullReqActivityTypeComment,
+			wantText: "`a.go` (line 4)\n\ntext",
+		},
+		{
+			name: "lines-not-found",
+			codeComment: externalCodeComment{
+				path: "a.go", commitSHA: testSourceSHA, lineStart: 4, lineEnd: 5,
+			},
+			diffCutErr: errTestDiff,
+			wantType:   enum.PullReqActivityTypeComment,
+			wantText:   "`a.go` (line 5)\n\ntext",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Repository{git: gitMock{diffCutErr: test.diffCutErr}}
+			pr := &types.PullReq{SourceSHA: testSourceSHA, MergeBaseSHA: testMergeBaseSHA}
+			act := &types.PullReqActivity{
+				Type: enum.PullReqActivityTypeComment,
+				Kind: enum.PullReqActivityKindComment,
+				Text: "text",
+			}
+
+			r.setCodeComment(context.Background(), git.ReadParams{}, pr, act, test.codeComment)
+
+			if act.Type != test.wantType {
+				t.Errorf("type: got %s, want %s", act.Type, test.wantType)
+			}
+			if !reflect.DeepEqual(act.CodeComment, test.wantFields) {
+				t.Errorf("code comment: got %+v, want %+v", act.CodeComment, test.wantFields)
+			}
+			if act.Text != test.wantText {
+				t.Errorf("text: got %q, want %q", act.Text, test.wantText)
+			}
+		})
+	}
+}
+
+func TestRepository_ImportPullReq_State(t *testing.T) {
+	tests := []struct {
+		name         string
+		merged       bool
+		closed       bool
+		wantState    enum.PullReqState
+		wantActivity enum.PullReqActivityType
+		wantCounters [4]int
+	}{
+		{
+			name:         "open",
+			wantState:    enum.PullReqStateOpen,
+			wantCounters: [4]int{1, 1, 0, 0},
+		},
+		{
+			name:         "closed",
+			closed:       true,
+			wantState:    enum.PullReqStateClosed,
+			wantActivity: enum.PullReqActivityTypeStateChange,
+			wantCounters: [4]int{1, 0, 1, 0},
+		},
+		{
+			name:         "merged",
+			merged:       true,
+			closed:       true,
+			wantState:    enum.PullReqStateMerged,
+			wantActivity: enum.PullReqActivityTypeMerge,
+			wantCounters: [4]int{1, 0, 0, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ti := newTestImport(nil)
+			authors := &authorMapper{
+				principalStore: ti.importer.principalStore,
+				fallback:       &types.Principal{ID: 1},
+				cache:          make(map[string]mappedAuthor),
+			}
+
+			scmPullReq := testPullReq(7)
+			scmPullReq.Merged = test.merged
+			scmPullReq.Closed = test.closed
+
+			err := ti.importer.importPullReq(context.Background(), ti.repo, "org/repo", authors,
+				&externalPullReq{pr: scmPullReq})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(ti.pullReqs.created) != 1 {
+				t.Fatalf("expected one pull request, got %d", len(ti.pullReqs.created))
+			}
+			pr := ti.pullReqs.created[0]
+			if pr.Number != 7 || pr.State != test.wantState {
+				t.Errorf("got pull request #%d in state %s, want #7 in state %s", pr.Number, pr.State, test.wantState)
+			}
+			if pr.CreatedBy != 2 || pr.MergeBaseSHA != testMergeBaseSHA {
+				t.Errorf("got author %d and merge base %s", pr.CreatedBy, pr.MergeBaseSHA)
+			}
+			if test.merged && (pr.MergedBy == nil || *pr.MergedBy != testSystemID) {
+				t.Errorf("expected the pull request to be merged by the system principal")
+			}
+
+			var gotActivity enum.PullReqActivityType
+			if len(ti.activities.created) > 0 {
+				gotActivity = ti.activities.created[len(ti.activities.created)-1].Type
+			}
+			if gotActivity != test.wantActivity {
+				t.Errorf("activity: got %q, want %q", gotActivity, test.wantActivity)
+			}
+
+			gotCounters := [4]int{ti.repo.NumPulls, ti.repo.NumOpenPulls, ti.repo.NumClosedPulls, ti.repo.NumMergedPulls}
+			if gotCounters != test.wantCounters {
+				t.Errorf("counters: got %v, want %v", gotCounters, test.wantCounters)
+			}
+		})
+	}
+}
+
+func TestRepository_ImportPullReqsWithClient(t *testing.T) {
+	comment := &scm.Comment{Body: "looks good", Author: scm.User{Login: "john"}}
+
+	tests := []struct {
+		name         string
+		providerType ProviderType
+		pullReqs     pullReqServiceMock
+		issues       issueServiceMock
+		imported     map[int64]bool
+		wantNumber   int64
+		wantCreated  []int64
+		wantComments int
+	}{
+		{
+			name:         "skip-imported",
+			providerType: ProviderTypeGitLab,
+			pullReqs: pullReqServiceMock{
+				pullReqs: []*scm.PullRequest{testPullReq(3), testPullReq(1), testPullReq(2)},
+				comments: map[int][]*scm.Comment{3: {comment}},
+			},
+			imported:     map[int64]bool{1: true, 2: true},
+			wantNumber:   3,
+			wantCreated:  []int64{3},
+			wantComments: 1,
+		},
+		{
+			name:         "skip-unavailable-commits",
+			providerType: ProviderTypeGitLab,
+			pullReqs: pullReqServiceMock{
+				pullReqs: func() []*scm.PullRequest {
+					pr := testPullReq(2)
+					pr.Sha = testMergeBaseSHA
+					return []*scm.PullRequest{testPullReq(1), pr}
+				}(),
+			},
+			wantNumber:  2,
+			wantCreated: []int64{1},
+		},
+		{
+			name:         "comments-of-issue",
+			providerType: ProviderTypeGitea,
+			pullReqs: pullReqServiceMock{
+				pullReqs:    []*scm.PullRequest{testPullReq(1)},
+				commentsErr: scm.ErrNotSupported,
+			},
+			issues:       issueServiceMock{comments: map[int][]*scm.Comment{1: {comment}}},
+			wantNumber:   1,
+			wantCreated:  []int64{1},
+			wantComments: 1,
+		},
+		{
+			name:         "comments-not-supported",
+			providerType: ProviderTypeBitbucket,
+			pullReqs: pullReqServiceMock{
+				pullReqs:    []*scm.PullRequest{testPullReq(1), testPullReq(2)},
+				commentsErr: scm.ErrNotSupported,
+			},
+			wantNumber:  2,
+			wantCreated: []int64{1, 2},
+		},
+		{
+			name:         "pull-requests-not-supported",
+			providerType: ProviderTypeGogs,
+			pullReqs:     pullReqServiceMock{listErr: scm.ErrNotSupported},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ti := newTestImport(test.imported)
+			scmClient := &scm.Client{
+				PullRequests: test.pullReqs,
+				Issues:       test.issues,
+				Users:        userServiceMock{},
+			}
+
+			number, err := ti.importer.importPullReqsWithClient(context.Background(), scmClient,
+				test.providerType, "org/repo", ti.repo)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if number != test.wantNumber {
+				t.Errorf("max number: got %d, want %d", number, test.wantNumber)
+			}
+
+			var gotCreated []int64
+			for _, pr := range ti.pullReqs.created {
+				gotCreated = append(gotCreated, pr.Number)
+			}
+			if !reflect.DeepEqual(gotCreated, test.wantCreated) {
+				t.Errorf("created: got %v, want %v", gotCreated, test.wantCreated)
+			}
+
+			var gotComments int
+			for _, act := range ti.activities.created {
+				if act.Type == enum.PullReqActivityTypeComment {
+					gotComments++
+				}
+			}
+			if gotComments != test.wantComments {
+				t.Errorf("comments: got %d, want %d", gotComments, test.wantComments)
+			}
+
+			if ti.repo.NumPulls != len(test.wantCreated) {
+				t.Errorf("pull request counter: got %d, want %d", ti.repo.NumPulls, len(test.wantCreated))
+			}
+		})
+	}
+}
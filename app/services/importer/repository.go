@@ -52,17 +52,22 @@ var (
 )
 
 type Repository struct {
-	defaultBranch string
-	urlProvider   gitnessurl.Provider
-	git           git.Interface
-	tx            dbtx.Transactor
-	repoStore     store.RepoStore
-	pipelineStore store.PipelineStore
-	triggerStore  store.TriggerStore
-	encrypter     encrypt.Encrypter
-	scheduler     *job.Scheduler
-	sseStreamer   sse.Streamer
-	indexer       keywordsearch.Indexer
+	defaultBranch        string
+	urlProvider          gitnessurl.Provider
+	git                  git.Interface
+	tx                   dbtx.Transactor
+	repoStore            store.RepoStore
+	pipelineStore        store.PipelineStore
+	triggerStore         store.TriggerStore
+	principalStore       store.PrincipalStore
+	pullReqStore         store.PullReqStore
+	pullReqActivityStore store.PullReqActivityStore
+	pullReqReviewStore   store.PullReqReviewStore
+	pullReqReviewerStore store.PullReqReviewerStore
+	encrypter            encrypt.Encrypter
+	scheduler            *job.Scheduler
+	sseStreamer          sse.Streamer
+	indexer              keywordsearch.Indexer
 }
 
 var _ job.Handler = (*Repository)(nil)
@@ -80,11 +85,15 @@ const (
 )
 
 type Input struct {
-	RepoID    int64          `json:"repo_id"`
-	GitUser   string         `json:"git_user"`
-	GitPass   string         `json:"git_pass"`
-	CloneURL  string         `json:"clone_url"`
-	Pipelines PipelineOption `json:"pipelines"`
+	RepoID       int64          `json:"repo_id"`
+	GitUser      string         `json:"git_user"`
+	GitPass      string         `json:"git_pass"`
+	CloneURL     string         `json:"clone_url"`
+	Pipelines    PipelineOption `json:"pipelines"`
+	ProviderType ProviderType   `json:"provider_type"`
+	ProviderHost string         `json:"provider_host"`
+	RepoSlug     string         `json:"repo_slug"`
+	PullReqs     PullReqOption  `json:"pull_requests"`
 }
 
 const jobType = "repository_import"
@@ -99,14 +108,20 @@ func (r *Repository) Run(
 	provider Provider,
 	repo *types.Repository,
 	cloneURL string,
+	repoSlug string,
 	pipelines PipelineOption,
+	pullReqs PullReqOption,
 ) error {
 	jobDef, err := r.getJobDef(JobIDFromRepoID(repo.ID), Input{
-		RepoID:    repo.ID,
-		GitUser:   provider.Username,
-		GitPass:   provider.Password,
-		CloneURL:  cloneURL,
-		Pipelines: pipelines,
+		RepoID:       repo.ID,
+		GitUser:      provider.Username,
+		GitPass:      provider.Password,
+		CloneURL:     cloneURL,
+		Pipelines:    pipelines,
+		ProviderType: provider.Type,
+		ProviderHost: provider.Host,
+		RepoSlug:     repoSlug,
+		PullReqs:     pullReqs,
 	})
 	if err != nil {
 		return err
@@ -121,11 +136,13 @@ func (r *Repository) RunMany(ctx context.Context,
 	provider Provider,
 	repoIDs []int64,
 	cloneURLs []string,
+	repoSlugs []string,
 	pipelines PipelineOption,
+	pullReqs PullReqOption,
 ) error {
-	if len(repoIDs) != len(cloneURLs) {
-		return fmt.Errorf("slice length mismatch: have %d repositories and %d clone URLs",
-			len(repoIDs), len(cloneURLs))
+	if len(repoIDs) != len(cloneURLs) || len(repoIDs) != len(repoSlugs) {
+		return fmt.Errorf("slice length mismatch: have %d repositories, %d clone URLs and %d repository slugs",
+			len(repoIDs), len(cloneURLs), len(repoSlugs))
 	}
 
 	n := len(repoIDs)
@@ -134,13 +151,18 @@ func (r *Repository) RunMany(ctx context.Context,
 	for k := 0; k < n; k++ {
 		repoID := repoIDs[k]
 		cloneURL := cloneURLs[k]
+		repoSlug := repoSlugs[k]
 
 		jobDef, err := r.getJobDef(JobIDFromRepoID(repoID), Input{
-			RepoID:    repoID,
-			GitUser:   provider.Username,
-			GitPass:   provider.Password,
-			CloneURL:  cloneURL,
-			Pipelines: pipelines,
+			RepoID:       repoID,
+			GitUser:      provider.Username,
+			GitPass:      provider.Password,
+			CloneURL:     cloneURL,
+			Pipelines:    pipelines,
+			ProviderType: provider.Type,
+			ProviderHost: provider.Host,
+			RepoSlug:     repoSlug,
+			PullReqs:     pullReqs,
 		})
 		if err != nil {
 			return err
@@ -260,7 +282,12 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 
 		log.Info().Msg("sync repository")
 
-		defaultBranch, err := r.syncGitRepository(ctx, &systemPrincipal, repo, cloneURLWithAuth)
+		refSpecs := []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"}
+		if input.PullReqs == PullReqOptionImport {
+			refSpecs = append(refSpecs, pullReqRefSpecs(input.ProviderType)...)
+		}
+
+		defaultBranch, err := r.syncGitRepository(ctx, &systemPrincipal, repo, cloneURLWithAuth, refSpecs)
 		if err != nil {
 			return fmt.Errorf("failed to sync git repository from '%s': %w", input.CloneURL, err)
 		}
@@ -271,6 +298,23 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 			defaultBranch = r.defaultBranch
 		}
 
+		var pullReqSeq int64
+		if input.PullReqs == PullReqOptionImport {
+			log.Info().Msg("import pull requests")
+
+			pullReqSeq, err = r.importPullReqs(ctx, Provider{
+				Type:     input.ProviderType,
+				Host:     input.ProviderHost,
+				Username: input.GitUser,
+				Password: input.GitPass,
+			}, input.RepoSlug, repo)
+			if err != nil {
+				return fmt.Errorf("failed to import pull requests from '%s': %w", input.RepoSlug, err)
+			}
+
+			log.Info().Msgf("successfully imported pull requests (last number: %d)", pullReqSeq)
+		}
+
 		log.Info().Msg("update repo in DB")
 
 		repo, err = r.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
@@ -281,6 +325,9 @@ func (r *Repository) Handle(ctx context.Context, data string, _ job.ProgressRepo
 			repo.GitUID = gitUID
 			repo.DefaultBranch = defaultBranch
 			repo.Importing = false
+			if pullReqSeq > repo.PullReqSeq {
+				repo.PullReqSeq = pullReqSeq
+			}
 
 			return nil
 		})
@@ -400,6 +447,7 @@ func (r *Repository) syncGitRepository(ctx context.Context,
 	principal *types.Principal,
 	repo *types.Repository,
 	sourceCloneURL string,
+	refSpecs []string,
 ) (string, error) {
 	writeParams, err := r.createRPCWriteParams(ctx, principal, repo)
 	if err != nil {
@@ -410,7 +458,7 @@ func (r *Repository) syncGitRepository(ctx context.Context,
 		WriteParams:       writeParams,
 		Source:            sourceCloneURL,
 		CreateIfNotExists: false,
-		RefSpecs:          []string{"refs/heads/*:refs/heads/*", "refs/tags/*:refs/tags/*"},
+		RefSpecs:          refSpecs,
 	})
 	if err != nil {
 		return "", fmt.Errorf("failed to sync repository: %w", err)
@@ -40,6 +40,11 @@ func ProvideRepoImporter(
 	repoStore store.RepoStore,
 	pipelineStore store.PipelineStore,
 	triggerStore store.TriggerStore,
+	principalStore store.PrincipalStore,
+	pullReqStore store.PullReqStore,
+	pullReqActivityStore store.PullReqActivityStore,
+	pullReqReviewStore store.PullReqReviewStore,
+	pullReqReviewerStore store.PullReqReviewerStore,
 	encrypter encrypt.Encrypter,
 	scheduler *job.Scheduler,
 	executor *job.Executor,
@@ -47,17 +52,22 @@ func ProvideRepoImporter(
 	indexer keywordsearch.Indexer,
 ) (*Repository, error) {
 	importer := &Repository{
-		defaultBranch: config.Git.DefaultBranch,
-		urlProvider:   urlProvider,
-		git:           git,
-		tx:            tx,
-		repoStore:     repoStore,
-		pipelineStore: pipelineStore,
-		triggerStore:  triggerStore,
-		encrypter:     encrypter,
-		scheduler:     scheduler,
-		sseStreamer:   sseStreamer,
-		indexer:       indexer,
+		defaultBranch:        config.Git.DefaultBranch,
+		urlProvider:          urlProvider,
+		git:                  git,
+		tx:                   tx,
+		repoStore:            repoStore,
+		pipelineStore:        pipelineStore,
+		triggerStore:         triggerStore,
+		principalStore:       principalStore,
+		pullReqStore:         pullReqStore,
+		pullReqActivityStore: pullReqActivityStore,
+		pullReqReviewStore:   pullReqReviewStore,
+		pullReqReviewerStore: pullReqReviewerStore,
+		encrypter:            encrypter,
+		scheduler:            scheduler,
+		sseStreamer:          sseStreamer,
+		indexer:              indexer,
 	}
 
 	err := executor.Register(jobType, importer)
@@ -28,6 +28,9 @@ type Definition struct {
 	MaxRetries int
 	Timeout    time.Duration
 	Data       string
+
+	// Delay [OPTIONAL] postpones the execution of the job.
+	Delay time.Duration
 }
 
 func (def *Definition) Validate() error {
@@ -47,6 +50,10 @@ func (def *Definition) Validate() error {
 		return errors.New("job Timeout too short")
 	}
 
+	if def.Delay < 0 {
+		return errors.New("job Delay must be positive")
+	}
+
 	return nil
 }
 
@@ -63,7 +70,7 @@ func (def *Definition) toNewJob() *types.Job {
 		MaxDurationSeconds:  int(def.Timeout / time.Second),
 		MaxRetries:          def.MaxRetries,
 		State:               enum.JobStateScheduled,
-		Scheduled:           nowMilli,
+		Scheduled:           nowMilli + def.Delay.Milliseconds(),
 		TotalExecutions:     0,
 		RunBy:               "",
 		RunDeadline:         nowMilli,
@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"context"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+)
+
+func (s *Service) handleEventRepoDeleted(ctx context.Context,
+	event *events.Event[*repoevents.DeletedPayload]) error {
+	err := s.indexer.Delete(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to delete index of repo %d: %w", event.Payload.RepoID, err)
+	}
+
+	return nil
+}
+
+func (s *Service) handleEventDefaultBranchUpdated(ctx context.Context,
+	event *events.Event[*repoevents.DefaultBranchUpdatedPayload]) error {
+	repo, err := s.repoStore.Find(ctx, event.Payload.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository in db: %w", err)
+	}
+
+	err = s.indexer.Index(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("index update failed for repo %d: %w", repo.ID, err)
+	}
+
+	return nil
+}
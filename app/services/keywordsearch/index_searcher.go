@@ -21,10 +21,16 @@ import (
 )
 
 type Indexer interface {
+	// Index updates the index of the repository to the latest state of its default branch.
 	Index(ctx context.Context, repo *types.Repository) error
+	// Indexed returns true if the repository is indexed on its current default branch.
+	Indexed(ctx context.Context, repo *types.Repository) (bool, error)
+	// Delete removes the repository from the index.
+	Delete(ctx context.Context, repoID int64) error
 }
 
 type Searcher interface {
+	// Search returns the matches of the query in the provided repositories.
 	Search(ctx context.Context, repoIDs []int64, query string, maxResultCount int) (
 		types.SearchResult, error)
 }
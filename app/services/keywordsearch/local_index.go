@@ -0,0 +1,252 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/harness/gitness/types"
+)
+
+// localIndexVersion is the version of the on-disk index format.
+// Indexes stored with a different version are discarded and rebuilt from scratch.
+const localIndexVersion = 2
+
+// localIndex is the index of a single repository. It only contains the metadata of the files and the trigram
+// postings, the file contents are stored in a separate data file and are only read for candidate files of a search.
+type localIndex struct {
+	Version int
+	RepoID  int64
+	// Branch is the branch the index was built from.
+	Branch string
+	// DataFile is the name of the file containing the contents of the indexed files.
+	DataFile string
+
+	// Files contains all files of the indexed tree, sorted by path.
+	// Files that weren't indexed (binary, too large, ...) are kept to avoid reloading them on every update.
+	Files []indexedFile
+
+	// Trigrams maps every trigram to the sorted list of positions in Files that contain it.
+	Trigrams map[uint32][]int32
+}
+
+type indexedFile struct {
+	Path     string
+	SHA      string
+	Language string
+	Indexed  bool
+	// Offset and Size locate the content of the file in the data file.
+	Offset int64
+	Size   int64
+}
+
+// contentReader returns the content of an indexed file.
+type contentReader func(file *indexedFile) ([]byte, error)
+
+func (idx *localIndex) buildTrigrams(readContent contentReader) error {
+	idx.Trigrams = make(map[uint32][]int32)
+	for i := range idx.Files {
+		if !idx.Files[i].Indexed {
+			continue
+		}
+
+		content, err := readContent(&idx.Files[i])
+		if err != nil {
+			return err
+		}
+
+		seen := make(map[uint32]struct{})
+		forEachTrigram(toLowerASCII(content), func(t uint32) {
+			if _, ok := seen[t]; ok {
+				return
+			}
+			seen[t] = struct{}{}
+			idx.Trigrams[t] = append(idx.Trigrams[t], int32(i))
+		})
+	}
+
+	return nil
+}
+
+func (idx *localIndex) candidates(query []byte) []int32 {
+	var trigrams []uint32
+	forEachTrigram(query, func(t uint32) {
+		trigrams = append(trigrams, t)
+	})
+
+	// queries shorter than a trigram can't use the index - all indexed files are candidates.
+	if len(trigrams) == 0 {
+		all := make([]int32, 0, len(idx.Files))
+		for i := range idx.Files {
+			if idx.Files[i].Indexed {
+				all = append(all, int32(i))
+			}
+		}
+		return all
+	}
+
+	// start with the smallest posting list to keep the intersection cheap.
+	sort.Slice(trigrams, func(i, j int) bool {
+		return len(idx.Trigrams[trigrams[i]]) < len(idx.Trigrams[trigrams[j]])
+	})
+
+	result := idx.Trigrams[trigrams[0]]
+	for _, t := range trigrams[1:] {
+		if len(result) == 0 {
+			break
+		}
+		result = intersect(result, idx.Trigrams[t])
+	}
+
+	return result
+}
+
+// search returns the matches of the query in the index. At most maxMatches lines are returned.
+func (idx *localIndex) search(query string, maxMatches int, readContent contentReader) ([]types.FileMatch, error) {
+	needle := toLowerASCII([]byte(query))
+
+	var fileMatches []types.FileMatch
+	for _, pos := range idx.candidates(needle) {
+		if maxMatches <= 0 {
+			break
+		}
+
+		file := &idx.Files[pos]
+		content, err := readContent(file)
+		if err != nil {
+			return nil, err
+		}
+
+		matches := matchLines(content, needle, maxMatches)
+		if len(matches) == 0 {
+			continue
+		}
+
+		maxMatches -= len(matches)
+		fileMatches = append(fileMatches, types.FileMatch{
+			FileName: file.Path,
+			RepoID:   idx.RepoID,
+			Language: file.Language,
+			Matches:  matches,
+		})
+	}
+
+	return fileMatches, nil
+}
+
+func matchLines(content []byte, needle []byte, maxMatches int) []types.Match {
+	lower := toLowerASCII(content)
+	lines := bytes.Split(content, []byte{'\n'})
+	linesLower := bytes.Split(lower, []byte{'\n'})
+
+	var matches []types.Match
+	for i := 0; i < len(lines) && len(matches) < maxMatches; i++ {
+		fragments := matchFragments(lines[i], linesLower[i], needle)
+		if len(fragments) == 0 {
+			continue
+		}
+
+		match := types.Match{
+			LineNum:   i + 1,
+			Fragments: fragments,
+		}
+		if i > 0 {
+			match.Before = string(lines[i-1])
+		}
+		if i < len(lines)-1 {
+			match.After = string(lines[i+1])
+		}
+
+		matches = append(matches, match)
+	}
+
+	return matches
+}
+
+// matchFragments splits the line into fragments around every occurrence of the needle.
+// The Pre of a fragment contains the text since the end of the previous match,
+// only the last fragment has a Post containing the remainder of the line.
+func matchFragments(line []byte, lineLower []byte, needle []byte) []types.Fragment {
+	if len(needle) == 0 {
+		return nil
+	}
+
+	var fragments []types.Fragment
+	offset := 0
+	for {
+		i := bytes.Index(lineLower[offset:], needle)
+		if i < 0 {
+			break
+		}
+
+		start := offset + i
+		end := start + len(needle)
+		fragments = append(fragments, types.Fragment{
+			Pre:   string(line[offset:start]),
+			Match: string(line[start:end]),
+		})
+		offset = end
+	}
+
+	if len(fragments) > 0 {
+		fragments[len(fragments)-1].Post = string(line[offset:])
+	}
+
+	return fragments
+}
+
+// forEachTrigram calls fn for every trigram of the (lower case) data.
+// Trigrams spanning a line break are skipped as matches never cross lines.
+func forEachTrigram(data []byte, fn func(uint32)) {
+	for i := 0; i+3 <= len(data); i++ {
+		if data[i] == '\n' || data[i+1] == '\n' || data[i+2] == '\n' {
+			continue
+		}
+		fn(uint32(data[i])<<16 | uint32(data[i+1])<<8 | uint32(data[i+2]))
+	}
+}
+
+// toLowerASCII returns a copy of the data with all ASCII letters in lower case.
+// Contrary to bytes.ToLower, the length of the data is guaranteed to stay the same,
+// which allows to map match positions back to the original data.
+func toLowerASCII(data []byte) []byte {
+	lower := make([]byte, len(data))
+	for i, b := range data {
+		if 'A' <= b && b <= 'Z' {
+			b += 'a' - 'A'
+		}
+		lower[i] = b
+	}
+	return lower
+}
+
+// intersect returns the intersection of two sorted lists.
+func intersect(a, b []int32) []int32 {
+	var result []int32
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
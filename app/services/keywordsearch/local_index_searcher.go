@@ -15,28 +15,462 @@
 package keywordsearch
 
 import (
+	"bytes"
 	"context"
+	"encoding/gob"
 	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
 
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
 	"github.com/harness/gitness/types"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	localIndexFileExtension = ".idx"
+	localDataFileExtension  = ".dat"
+
+	// defaultMaxResultCount is the number of matches returned in case the caller didn't provide a limit.
+	defaultMaxResultCount = 100
+
+	// binarySniffLength is the number of bytes that are checked for a NUL byte to detect binary files.
+	binarySniffLength = 8000
 )
 
+// errIndexNotFound is returned in case a repository isn't indexed (yet).
+var errIndexNotFound = errors.New("index not found")
+
+// LocalIndexSearcher maintains an on-disk trigram index of the default branch of every repository.
+// Every repository is stored in a separate index file which is updated incrementally,
+// meaning only files with a changed blob SHA are read from git again.
 type LocalIndexSearcher struct {
+	config LocalIndexConfig
+	git    git.Interface
+
+	locksMx sync.Mutex
+	locks   map[int64]*sync.Mutex
+}
+
+type LocalIndexConfig struct {
+	// IndexPath is the directory in which the index files are stored.
+	IndexPath string
+	// MaxFileSize is the maximum size of a file to be indexed, larger files are skipped.
+	MaxFileSize int64
+}
+
+func (c *LocalIndexConfig) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.IndexPath == "" {
+		return errors.New("config.IndexPath is required")
+	}
+	if c.MaxFileSize <= 0 {
+		return errors.New("config.MaxFileSize has to be a positive number")
+	}
+	return nil
 }
 
-func NewLocalIndexSearcher() *LocalIndexSearcher {
-	return &LocalIndexSearcher{}
+func NewLocalIndexSearcher(config LocalIndexConfig, git git.Interface) (*LocalIndexSearcher, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided local index config is invalid: %w", err)
+	}
+
+	err := os.MkdirAll(config.IndexPath, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create index directory: %w", err)
+	}
+
+	return &LocalIndexSearcher{
+		config: config,
+		git:    git,
+		locks:  make(map[int64]*sync.Mutex),
+	}, nil
 }
 
 func (s *LocalIndexSearcher) Search(
-	_ context.Context,
-	_ []int64,
-	_ string,
-	_ int,
+	ctx context.Context,
+	repoIDs []int64,
+	query string,
+	maxResultCount int,
 ) (types.SearchResult, error) {
-	return types.SearchResult{}, fmt.Errorf("not implemented")
+	if maxResultCount <= 0 {
+		maxResultCount = defaultMaxResultCount
+	}
+
+	// sort to get stable results independent of the order of the provided repos.
+	repoIDs = append([]int64(nil), repoIDs...)
+	sort.Slice(repoIDs, func(i, j int) bool { return repoIDs[i] < repoIDs[j] })
+
+	result := types.SearchResult{
+		FileMatches: []types.FileMatch{},
+	}
+	for _, repoID := range repoIDs {
+		if result.Stats.TotalMatches >= maxResultCount {
+			break
+		}
+
+		fileMatches, err := s.searchRepo(repoID, query, maxResultCount-result.Stats.TotalMatches)
+		if errors.Is(err, errIndexNotFound) {
+			log.Ctx(ctx).Debug().Int64("repo_id", repoID).Msg("repo isn't indexed, skip search")
+			continue
+		}
+		if err != nil {
+			return types.SearchResult{}, fmt.Errorf("failed to search index of repo %d: %w", repoID, err)
+		}
+
+		for _, fileMatch := range fileMatches {
+			result.Stats.TotalMatches += len(fileMatch.Matches)
+		}
+		result.Stats.TotalFiles += len(fileMatches)
+		result.FileMatches = append(result.FileMatches, fileMatches...)
+	}
+
+	return result, nil
+}
+
+func (s *LocalIndexSearcher) searchRepo(repoID int64, query string, maxMatches int) ([]types.FileMatch, error) {
+	// the data file of a loaded index can get removed by a concurrent update of the index - retry once.
+	for attempt := 0; ; attempt++ {
+		idx, err := s.load(repoID)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := os.Open(s.dataFilePath(idx.DataFile))
+		if os.IsNotExist(err) && attempt == 0 {
+			continue
+		}
+		if os.IsNotExist(err) {
+			return nil, errIndexNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open index data file: %w", err)
+		}
+
+		fileMatches, err := idx.search(query, maxMatches, dataFileReader(data))
+
+		_ = data.Close()
+
+		return fileMatches, err
+	}
+}
+
+// Indexed returns true if the repository is indexed on its current default branch.
+func (s *LocalIndexSearcher) Indexed(_ context.Context, repo *types.Repository) (bool, error) {
+	idx, err := s.load(repo.ID)
+	if errors.Is(err, errIndexNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return idx.Branch == repo.DefaultBranch, nil
+}
+
+func (s *LocalIndexSearcher) Index(ctx context.Context, repo *types.Repository) error {
+	unlock := s.lock(repo.ID)
+	defer unlock()
+
+	readParams := git.CreateReadParams(repo)
+
+	output, err := s.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     "refs/heads/" + repo.DefaultBranch,
+		Recursive:  true,
+	})
+	if errors.IsNotFound(err) {
+		// the default branch doesn't exist (yet) - there's nothing to index.
+		return s.delete(repo.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to list files of default branch: %w", err)
+	}
+
+	oldFiles := make(map[string]*indexedFile)
+	var oldData *os.File
+	oldIdx, err := s.load(repo.ID)
+	switch {
+	case errors.Is(err, errIndexNotFound):
+		// first time the repo is indexed
+	case err != nil:
+		log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to load existing index, rebuild it")
+	default:
+		oldData, err = os.Open(s.dataFilePath(oldIdx.DataFile))
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to open existing index data, rebuild it")
+			break
+		}
+		defer func() {
+			_ = oldData.Close()
+		}()
+
+		for i := range oldIdx.Files {
+			oldFiles[oldIdx.Files[i].Path] = &oldIdx.Files[i]
+		}
+	}
+
+	// the data file gets a unique name, so a concurrent search still reads the data of the old index.
+	data, err := os.CreateTemp(s.config.IndexPath,
+		strconv.FormatInt(repo.ID, 10)+"-*"+localDataFileExtension)
+	if err != nil {
+		return fmt.Errorf("failed to create index data file: %w", err)
+	}
+	committed := false
+	defer func() {
+		_ = data.Close()
+		if !committed {
+			_ = os.Remove(data.Name())
+		}
+	}()
+
+	idx := &localIndex{
+		Version:  localIndexVersion,
+		RepoID:   repo.ID,
+		Branch:   repo.DefaultBranch,
+		DataFile: filepath.Base(data.Name()),
+		Files:    make([]indexedFile, 0, len(output.Nodes)),
+	}
+
+	offset := int64(0)
+	reloaded := 0
+	for _, node := range output.Nodes {
+		if node.Type != git.TreeNodeTypeBlob || node.Mode == git.TreeNodeModeSymlink {
+			continue
+		}
+
+		var (
+			file    indexedFile
+			content []byte
+		)
+
+		if oldFile, ok := oldFiles[node.Path]; ok && oldFile.SHA == node.SHA {
+			file = *oldFile
+			if file.Indexed {
+				content, err = dataFileReader(oldData)(oldFile)
+				if err != nil {
+					return fmt.Errorf("failed to read indexed content of file %q: %w", node.Path, err)
+				}
+			}
+		} else {
+			file, content, err = s.readFile(ctx, readParams, node)
+			if err != nil {
+				return fmt.Errorf("failed to read file %q: %w", node.Path, err)
+			}
+			reloaded++
+		}
+
+		if file.Indexed {
+			if _, err = data.Write(content); err != nil {
+				return fmt.Errorf("failed to write index data: %w", err)
+			}
+
+			file.Offset = offset
+			file.Size = int64(len(content))
+			offset += file.Size
+		}
+
+		idx.Files = append(idx.Files, file)
+	}
+
+	sort.Slice(idx.Files, func(i, j int) bool { return idx.Files[i].Path < idx.Files[j].Path })
+
+	err = idx.buildTrigrams(dataFileReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build trigrams: %w", err)
+	}
+
+	err = s.store(idx)
+	if err != nil {
+		return fmt.Errorf("failed to store index: %w", err)
+	}
+
+	committed = true
+
+	if oldIdx != nil && oldIdx.DataFile != "" {
+		err = os.Remove(s.dataFilePath(oldIdx.DataFile))
+		if err != nil && !os.IsNotExist(err) {
+			log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to remove old index data file")
+		}
+	}
+
+	log.Ctx(ctx).Debug().
+		Int64("repo_id", repo.ID).
+		Int("files", len(idx.Files)).
+		Int("reloaded", reloaded).
+		Msg("updated repository index")
+
+	return nil
+}
+
+func (s *LocalIndexSearcher) Delete(_ context.Context, repoID int64) error {
+	unlock := s.lock(repoID)
+	defer unlock()
+
+	return s.delete(repoID)
+}
+
+func (s *LocalIndexSearcher) delete(repoID int64) error {
+	err := os.Remove(s.indexFilePath(repoID))
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete index file: %w", err)
+	}
+
+	// remove all data files of the repo, including leftovers of failed updates.
+	dataFiles, err := filepath.Glob(filepath.Join(s.config.IndexPath,
+		strconv.FormatInt(repoID, 10)+"-*"+localDataFileExtension))
+	if err != nil {
+		return fmt.Errorf("failed to list index data files: %w", err)
+	}
+
+	for _, dataFile := range dataFiles {
+		err = os.Remove(dataFile)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete index data file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *LocalIndexSearcher) readFile(
+	ctx context.Context,
+	readParams git.ReadParams,
+	node git.TreeNode,
+) (indexedFile, []byte, error) {
+	file := indexedFile{
+		Path: node.Path,
+		SHA:  node.SHA,
+	}
+
+	output, err := s.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        node.SHA,
+		SizeLimit:  s.config.MaxFileSize,
+	})
+	if err != nil {
+		return indexedFile{}, nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	if output.Size > s.config.MaxFileSize {
+		return file, nil, nil
+	}
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return indexedFile{}, nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	if bytes.IndexByte(content[:minInt(len(content), binarySniffLength)], 0) >= 0 {
+		return file, nil, nil
+	}
+
+	file.Indexed = true
+	file.Language = enry.GetLanguage(path.Base(node.Path), content)
+
+	return file, content, nil
+}
+
+func (s *LocalIndexSearcher) load(repoID int64) (*localIndex, error) {
+	f, err := os.Open(s.indexFilePath(repoID))
+	if os.IsNotExist(err) {
+		return nil, errIndexNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open index file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	idx := &localIndex{}
+	err = gob.NewDecoder(f).Decode(idx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode index file: %w", err)
+	}
+
+	if idx.Version != localIndexVersion {
+		return nil, errIndexNotFound
+	}
+
+	return idx, nil
+}
+
+func (s *LocalIndexSearcher) store(idx *localIndex) error {
+	f, err := os.CreateTemp(s.config.IndexPath, "tmp-*"+localIndexFileExtension)
+	if err != nil {
+		return fmt.Errorf("failed to create temporary index file: %w", err)
+	}
+	defer func() {
+		// noop in case the file got renamed successfully
+		_ = os.Remove(f.Name())
+	}()
+
+	err = gob.NewEncoder(f).Encode(idx)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to encode index: %w", err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("failed to close temporary index file: %w", err)
+	}
+
+	err = os.Rename(f.Name(), s.indexFilePath(idx.RepoID))
+	if err != nil {
+		return fmt.Errorf("failed to replace index file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *LocalIndexSearcher) indexFilePath(repoID int64) string {
+	return filepath.Join(s.config.IndexPath, strconv.FormatInt(repoID, 10)+localIndexFileExtension)
+}
+
+func (s *LocalIndexSearcher) dataFilePath(name string) string {
+	return filepath.Join(s.config.IndexPath, name)
+}
+
+func (s *LocalIndexSearcher) lock(repoID int64) func() {
+	s.locksMx.Lock()
+	mx, ok := s.locks[repoID]
+	if !ok {
+		mx = &sync.Mutex{}
+		s.locks[repoID] = mx
+	}
+	s.locksMx.Unlock()
+
+	mx.Lock()
+	return mx.Unlock
+}
+
+// dataFileReader returns a contentReader that reads the file contents from the provided index data file.
+func dataFileReader(data io.ReaderAt) contentReader {
+	return func(file *indexedFile) ([]byte, error) {
+		content := make([]byte, file.Size)
+		if _, err := data.ReadAt(content, file.Offset); err != nil {
+			return nil, fmt.Errorf("failed to read content of %q from index data file: %w", file.Path, err)
+		}
+
+		return content, nil
+	}
 }
 
-func (s *LocalIndexSearcher) Index(_ context.Context, _ *types.Repository) error {
-	return fmt.Errorf("not implemented")
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
 }
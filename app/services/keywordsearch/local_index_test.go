@@ -0,0 +1,271 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package keywordsearch
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+func TestLocalIndexSearch(t *testing.T) {
+	contents := map[string][]byte{
+		"main.go":   []byte("package main\n\nfunc main() {\n\tprintln(\"Hello\", \"hello\")\n}\n"),
+		"README.md": []byte("# Readme\nNothing to see here."),
+	}
+
+	idx := &localIndex{
+		Version: localIndexVersion,
+		RepoID:  42,
+		Files: []indexedFile{
+			{
+				Path:     "main.go",
+				Language: "Go",
+				Indexed:  true,
+			},
+			{
+				Path:    "image.png",
+				Indexed: false,
+			},
+			{
+				Path:     "README.md",
+				Language: "Markdown",
+				Indexed:  true,
+			},
+		},
+	}
+
+	// store the contents the same way as the index data file does.
+	data := &bytes.Buffer{}
+	for i := range idx.Files {
+		if !idx.Files[i].Indexed {
+			continue
+		}
+		idx.Files[i].Offset = int64(data.Len())
+		idx.Files[i].Size = int64(len(contents[idx.Files[i].Path]))
+		data.Write(contents[idx.Files[i].Path])
+	}
+
+	readContent := dataFileReader(bytes.NewReader(data.Bytes()))
+
+	if err := idx.buildTrigrams(readContent); err != nil {
+		t.Fatalf("failed to build trigrams: %s", err)
+	}
+
+	tests := []struct {
+		name       string
+		query      string
+		maxMatches int
+		expected   []types.FileMatch
+	}{
+		{
+			name:       "no match",
+			query:      "goodbye",
+			maxMatches: 10,
+			expected:   nil,
+		},
+		{
+			name:       "case insensitive with multiple fragments",
+			query:      "HELLO",
+			maxMatches: 10,
+			expected: []types.FileMatch{
+				{
+					FileName: "main.go",
+					RepoID:   42,
+					Language: "Go",
+					Matches: []types.Match{
+						{
+							LineNum: 4,
+							Fragments: []types.Fragment{
+								{Pre: "\tprintln(\"", Match: "Hello"},
+								{Pre: "\", \"", Match: "hello", Post: "\")"},
+							},
+							Before: "func main() {",
+							After:  "}",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "short query",
+			query:      "e.",
+			maxMatches: 10,
+			expected: []types.FileMatch{
+				{
+					FileName: "README.md",
+					RepoID:   42,
+					Language: "Markdown",
+					Matches: []types.Match{
+						{
+							LineNum: 2,
+							Fragments: []types.Fragment{
+								{Pre: "Nothing to see her", Match: "e.", Post: ""},
+							},
+							Before: "# Readme",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:       "limited matches",
+			query:      "main",
+			maxMatches: 1,
+			expected: []types.FileMatch{
+				{
+					FileName: "main.go",
+					RepoID:   42,
+					Language: "Go",
+					Matches: []types.Match{
+						{
+							LineNum: 1,
+							Fragments: []types.Fragment{
+								{Pre: "package ", Match: "main", Post: ""},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := idx.search(test.query, test.maxMatches, readContent)
+			if err != nil {
+				t.Fatalf("search failed: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.expected) {
+				t.Errorf("expected: %+v\ngot: %+v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestIntersect(t *testing.T) {
+	got := intersect([]int32{1, 3, 5, 7, 9}, []int32{2, 3, 4, 7, 10})
+	expected := []int32{3, 7}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+type gitMock struct {
+	git.Interface
+	files map[string]string
+}
+
+func (g *gitMock) ListTreeNodes(context.Context, *git.ListTreeNodeParams) (*git.ListTreeNodeOutput, error) {
+	out := &git.ListTreeNodeOutput{}
+	for path, content := range g.files {
+		out.Nodes = append(out.Nodes, git.TreeNode{
+			Type: git.TreeNodeTypeBlob,
+			Mode: git.TreeNodeModeFile,
+			SHA:  content, // the content is used as SHA to detect changes
+			Path: path,
+		})
+	}
+	return out, nil
+}
+
+func (g *gitMock) GetBlob(_ context.Context, params *git.GetBlobParams) (*git.GetBlobOutput, error) {
+	return &git.GetBlobOutput{
+		SHA:     params.SHA,
+		Size:    int64(len(params.SHA)),
+		Content: strings.NewReader(params.SHA),
+	}, nil
+}
+
+func TestLocalIndexSearcher(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	gitMock := &gitMock{files: map[string]string{
+		"a.txt": "alpha beta",
+		"b.txt": "gamma delta",
+	}}
+
+	searcher, err := NewLocalIndexSearcher(LocalIndexConfig{IndexPath: dir, MaxFileSize: 1024}, gitMock)
+	if err != nil {
+		t.Fatalf("failed to create searcher: %s", err)
+	}
+
+	repo := &types.Repository{ID: 1, DefaultBranch: "main"}
+
+	search := func(query string) []string {
+		result, err := searcher.Search(ctx, []int64{repo.ID}, query, 10)
+		if err != nil {
+			t.Fatalf("failed to search: %s", err)
+		}
+
+		var names []string
+		for _, fileMatch := range result.FileMatches {
+			names = append(names, fileMatch.FileName)
+		}
+		return names
+	}
+
+	if indexed, _ := searcher.Indexed(ctx, repo); indexed {
+		t.Errorf("expected repo not to be indexed")
+	}
+
+	if err = searcher.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to index: %s", err)
+	}
+
+	if got := search("delta"); !reflect.DeepEqual(got, []string{"b.txt"}) {
+		t.Errorf("unexpected search result: %v", got)
+	}
+
+	// update one file, the unchanged file has to be taken over from the old index data.
+	gitMock.files["b.txt"] = "epsilon"
+	if err = searcher.Index(ctx, repo); err != nil {
+		t.Fatalf("failed to update index: %s", err)
+	}
+
+	if got := search("delta"); got != nil {
+		t.Errorf("unexpected search result: %v", got)
+	}
+	if got := search("beta"); !reflect.DeepEqual(got, []string{"a.txt"}) {
+		t.Errorf("unexpected search result: %v", got)
+	}
+
+	// the old data file must be removed after the update.
+	dataFiles, _ := filepath.Glob(filepath.Join(dir, "*"+localDataFileExtension))
+	if len(dataFiles) != 1 {
+		t.Errorf("expected exactly one data file, got %v", dataFiles)
+	}
+
+	if indexed, _ := searcher.Indexed(ctx, &types.Repository{ID: 1, DefaultBranch: "develop"}); indexed {
+		t.Errorf("expected repo not to be indexed on a different default branch")
+	}
+
+	if err = searcher.Delete(ctx, repo.ID); err != nil {
+		t.Fatalf("failed to delete index: %s", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 0 {
+		t.Errorf("expected empty index directory after delete, got %d entries", len(entries))
+	}
+}
@@ -21,9 +21,12 @@ import (
 	"time"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/stream"
+
+	"github.com/rs/zerolog/log"
 )
 
 const (
@@ -63,6 +66,7 @@ func NewService(
 	ctx context.Context,
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	repoStore store.RepoStore,
 	indexer Indexer,
 ) (*Service, error) {
@@ -92,8 +96,77 @@ func NewService(
 			return nil
 		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to launch git event reader for webhooks: %w", err)
+		return nil, fmt.Errorf("failed to launch git event reader for keyword search: %w", err)
+	}
+
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterRepoDeleted(service.handleEventRepoDeleted)
+			_ = r.RegisterDefaultBranchUpdated(service.handleEventDefaultBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for keyword search: %w", err)
 	}
 
+	go service.backfill(ctx)
+
 	return service, nil
 }
+
+// backfill indexes all repositories that aren't indexed on their current default branch
+// (e.g. repositories that existed before the index was introduced).
+func (s *Service) backfill(ctx context.Context) {
+	const pageSize = 100
+
+	indexed := 0
+	afterID := int64(0)
+	for {
+		repos, err := s.repoStore.ListAll(ctx, afterID, pageSize)
+		if err != nil {
+			log.Ctx(ctx).Err(err).Msg("keyword search backfill failed to list repositories")
+			return
+		}
+
+		for _, repo := range repos {
+			afterID = repo.ID
+
+			if repo.Importing {
+				// the repo is indexed once the import is done.
+				continue
+			}
+
+			ok, err := s.indexer.Indexed(ctx, repo)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to check index of repository")
+				continue
+			}
+			if ok {
+				continue
+			}
+
+			if err = s.indexer.Index(ctx, repo); err != nil {
+				log.Ctx(ctx).Warn().Err(err).Int64("repo_id", repo.ID).Msg("failed to index repository")
+				continue
+			}
+
+			indexed++
+		}
+
+		if len(repos) < pageSize || ctx.Err() != nil {
+			break
+		}
+	}
+
+	log.Ctx(ctx).Info().Int("indexed", indexed).Msg("keyword search backfill finished")
+}
@@ -18,8 +18,10 @@ import (
 	"context"
 
 	gitevents "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
 
 	"github.com/google/wire"
 )
@@ -35,18 +37,20 @@ var WireSet = wire.NewSet(
 func ProvideService(ctx context.Context,
 	config Config,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
 	repoStore store.RepoStore,
 	indexer Indexer,
 ) (*Service, error) {
 	return NewService(ctx,
 		config,
 		gitReaderFactory,
+		repoReaderFactory,
 		repoStore,
 		indexer)
 }
 
-func ProvideLocalIndexSearcher() *LocalIndexSearcher {
-	return NewLocalIndexSearcher()
+func ProvideLocalIndexSearcher(config LocalIndexConfig, git git.Interface) (*LocalIndexSearcher, error) {
+	return NewLocalIndexSearcher(config, git)
 }
 
 func ProvideIndexer(l *LocalIndexSearcher) Indexer {
@@ -0,0 +1,88 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+)
+
+const (
+	// MaxPointerSize is the maximum size of a Git LFS pointer file, bigger blobs are never pointers.
+	MaxPointerSize = 1024
+
+	pointerVersion = "version https://git-lfs.github.com/spec/v1"
+	oidPrefix      = "sha256:"
+)
+
+var oidRegex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Pointer is the content of a Git LFS pointer file that is committed instead of the actual file.
+type Pointer struct {
+	OID  string
+	Size int64
+}
+
+// IsValidOID returns true if the oid is a valid sha256 Git LFS object id.
+func IsValidOID(oid string) bool {
+	return oidRegex.MatchString(oid)
+}
+
+// ParsePointer parses the content of a Git LFS pointer file.
+// It returns false if the content isn't a valid pointer.
+func ParsePointer(data []byte) (Pointer, bool) {
+	if len(data) > MaxPointerSize {
+		return Pointer{}, false
+	}
+
+	lines := bytes.Split(bytes.TrimSpace(data), []byte("\n"))
+	if len(lines) < 3 || string(lines[0]) != pointerVersion {
+		return Pointer{}, false
+	}
+
+	var (
+		p       Pointer
+		hasSize bool
+	)
+	for _, line := range lines[1:] {
+		key, value, ok := bytes.Cut(line, []byte(" "))
+		if !ok {
+			return Pointer{}, false
+		}
+
+		switch string(key) {
+		case "oid":
+			oid, ok := bytes.CutPrefix(value, []byte(oidPrefix))
+			if !ok || !IsValidOID(string(oid)) {
+				return Pointer{}, false
+			}
+			p.OID = string(oid)
+		case "size":
+			size, err := strconv.ParseInt(string(value), 10, 64)
+			if err != nil || size < 0 {
+				return Pointer{}, false
+			}
+			p.Size = size
+			hasSize = true
+		}
+	}
+
+	if p.OID == "" || !hasSize {
+		return Pointer{}, false
+	}
+
+	return p, true
+}
@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"testing"
+)
+
+func TestParsePointer(t *testing.T) {
+	const oid = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+	tests := []struct {
+		name string
+		data string
+		want Pointer
+		ok   bool
+	}{
+		{
+			name: "valid pointer",
+			data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+			want: Pointer{OID: oid, Size: 12345},
+			ok:   true,
+		},
+		{
+			name: "unknown version",
+			data: "version https://hawser.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+		},
+		{
+			name: "invalid oid",
+			data: "version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize 12345\n",
+		},
+		{
+			name: "missing size",
+			data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\next-0-foo sha256:" + oid + "\n",
+		},
+		{
+			name: "regular file",
+			data: "hello world\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParsePointer([]byte(tt.data))
+			if ok != tt.ok {
+				t.Fatalf("ParsePointer() ok = %v, want %v", ok, tt.ok)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePointer() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// objectsPathFmt is the blob store path of LFS objects (lfs/<repoID>/<oid>).
+const objectsPathFmt = "lfs/%d/%s"
+
+var ErrObjectHashMismatch = usererror.BadRequest("The content of the object doesn't match its oid.")
+
+// Service stores the Git LFS objects of repositories in the blob store.
+type Service struct {
+	lfsObjectStore store.LFSObjectStore
+	blobStore      blob.Store
+}
+
+func NewService(
+	lfsObjectStore store.LFSObjectStore,
+	blobStore blob.Store,
+) *Service {
+	return &Service{
+		lfsObjectStore: lfsObjectStore,
+		blobStore:      blobStore,
+	}
+}
+
+// Download returns the LFS object of the repository and a reader of its content, which has to be closed by the caller.
+func (s *Service) Download(ctx context.Context, repoID int64, oid string) (*types.LFSObject, io.ReadCloser, error) {
+	obj, err := s.lfsObjectStore.Find(ctx, repoID, oid)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find LFS object: %w", err)
+	}
+
+	content, err := s.blobStore.Download(ctx, blobPath(repoID, oid))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to download LFS object: %w", err)
+	}
+
+	return obj, content, nil
+}
+
+// Upload stores the content of the LFS object in the blob store. The content is verified against the oid.
+func (s *Service) Upload(
+	ctx context.Context,
+	principalID int64,
+	repoID int64,
+	oid string,
+	r io.Reader,
+) (*types.LFSObject, error) {
+	obj, err := s.lfsObjectStore.Find(ctx, repoID, oid)
+	if err == nil {
+		// objects are immutable, there's no need to upload them twice.
+		return obj, nil
+	}
+	if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find LFS object: %w", err)
+	}
+
+	// the content is stored under a temporary path until it's verified, the object path only ever holds valid content.
+	filePath := blobPath(repoID, oid)
+	tmpPath := filePath + ".tmp-" + uuid.NewString()
+	hash := sha256.New()
+	counter := &countingReader{r: io.TeeReader(r, hash)}
+
+	if err = s.blobStore.Upload(ctx, counter, tmpPath); err != nil {
+		// blob stores might keep the partially uploaded content.
+		s.deleteTmp(ctx, tmpPath)
+		return nil, fmt.Errorf("failed to upload LFS object: %w", err)
+	}
+
+	if hex.EncodeToString(hash.Sum(nil)) != oid {
+		s.deleteTmp(ctx, tmpPath)
+		return nil, ErrObjectHashMismatch
+	}
+
+	if err = s.blobStore.Move(ctx, tmpPath, filePath); err != nil {
+		s.deleteTmp(ctx, tmpPath)
+		return nil, fmt.Errorf("failed to move LFS object into place: %w", err)
+	}
+
+	obj = &types.LFSObject{
+		OID:       oid,
+		Size:      counter.n,
+		RepoID:    repoID,
+		CreatedBy: principalID,
+		Created:   time.Now().UnixMilli(),
+	}
+
+	err = s.lfsObjectStore.Create(ctx, obj)
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the same object got uploaded concurrently.
+		return s.lfsObjectStore.Find(ctx, repoID, oid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LFS object: %w", err)
+	}
+
+	return obj, nil
+}
+
+// Resolve returns the LFS object the content points to, in case the content is a pointer to an object of the repo.
+func (s *Service) Resolve(ctx context.Context, repoID int64, content []byte) (*types.LFSObject, bool, error) {
+	pointer, ok := ParsePointer(content)
+	if !ok {
+		return nil, false, nil
+	}
+
+	obj, err := s.lfsObjectStore.Find(ctx, repoID, pointer.OID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the object was never uploaded - the pointer is all we have.
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find LFS object: %w", err)
+	}
+
+	return obj, true, nil
+}
+
+// FindMany returns the LFS objects of the repository with one of the given oids, keyed by their oid.
+func (s *Service) FindMany(ctx context.Context, repoID int64, oids []string) (map[string]*types.LFSObject, error) {
+	if len(oids) == 0 {
+		return map[string]*types.LFSObject{}, nil
+	}
+
+	objects, err := s.lfsObjectStore.FindMany(ctx, repoID, oids)
+	if err != nil {
+		return nil, err
+	}
+
+	objectMap := make(map[string]*types.LFSObject, len(objects))
+	for _, obj := range objects {
+		objectMap[obj.OID] = obj
+	}
+
+	return objectMap, nil
+}
+
+// Usage returns the number and total size of the LFS objects of the repository.
+func (s *Service) Usage(ctx context.Context, repoID int64) (types.LFSUsage, error) {
+	return s.lfsObjectStore.Usage(ctx, repoID)
+}
+
+// deleteTmp deletes the temporary file of an upload, failures are only logged.
+func (s *Service) deleteTmp(ctx context.Context, tmpPath string) {
+	if err := s.blobStore.Delete(ctx, tmpPath); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("path", tmpPath).Msg("failed to delete temporary LFS object")
+	}
+}
+
+func blobPath(repoID int64, oid string) string {
+	return fmt.Sprintf(objectsPathFmt, repoID, oid)
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
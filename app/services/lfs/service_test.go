@@ -0,0 +1,173 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lfs
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/blob"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+type fakeLFSObjectStore struct {
+	objects map[string]*types.LFSObject
+}
+
+func (f *fakeLFSObjectStore) Find(_ context.Context, _ int64, oid string) (*types.LFSObject, error) {
+	obj, ok := f.objects[oid]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return obj, nil
+}
+
+func (f *fakeLFSObjectStore) FindMany(context.Context, int64, []string) ([]*types.LFSObject, error) {
+	return nil, nil
+}
+
+func (f *fakeLFSObjectStore) Create(_ context.Context, obj *types.LFSObject) error {
+	f.objects[obj.OID] = obj
+	return nil
+}
+
+func (f *fakeLFSObjectStore) Usage(context.Context, int64) (types.LFSUsage, error) {
+	return types.LFSUsage{}, nil
+}
+
+// partialUploadStore keeps the content that was read before the upload failed,
+// like blob stores that don't clean up interrupted uploads.
+type partialUploadStore struct {
+	blob.Store
+}
+
+func (s partialUploadStore) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	content, readErr := io.ReadAll(file)
+	if err := s.Store.Upload(ctx, bytes.NewReader(content), filePath); err != nil {
+		return err
+	}
+	return readErr
+}
+
+// failingReader returns an error after the content has been read.
+type failingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	n, err := f.r.Read(p)
+	if errors.Is(err, io.EOF) {
+		return n, f.err
+	}
+	return n, err
+}
+
+func TestService_Upload(t *testing.T) {
+	ctx := context.Background()
+
+	blobStore, err := blob.NewFileSystemStore(blob.Config{Bucket: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+	objectStore := &fakeLFSObjectStore{objects: map[string]*types.LFSObject{}}
+	service := NewService(objectStore, blobStore)
+
+	sum := sha256.Sum256([]byte("content"))
+	oid := hex.EncodeToString(sum[:])
+
+	// content that doesn't match the oid must never show up under the object path.
+	_, err = service.Upload(ctx, 1, 1, oid, strings.NewReader("other content"))
+	if !errors.Is(err, ErrObjectHashMismatch) {
+		t.Fatalf("expected ErrObjectHashMismatch, got %v", err)
+	}
+	files, err := blobStore.List(ctx, "lfs/")
+	if err != nil {
+		t.Fatalf("failed to list files: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected no files after rejected upload, got %v", files)
+	}
+
+	obj, err := service.Upload(ctx, 1, 1, oid, strings.NewReader("content"))
+	if err != nil {
+		t.Fatalf("failed to upload object: %v", err)
+	}
+	if obj.Size != int64(len("content")) {
+		t.Errorf("expected size %d, got %d", len("content"), obj.Size)
+	}
+
+	files, err = blobStore.List(ctx, "lfs/")
+	if err != nil {
+		t.Fatalf("failed to list files: %v", err)
+	}
+	if len(files) != 1 || files[0].Path != blobPath(1, oid) {
+		t.Fatalf("expected only the object at %s, got %v", blobPath(1, oid), files)
+	}
+
+	rc, err := blobStore.Download(ctx, blobPath(1, oid))
+	if err != nil {
+		t.Fatalf("failed to download object: %v", err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("failed to read object: %v", err)
+	}
+	if string(content) != "content" {
+		t.Errorf("unexpected content of object: %q", content)
+	}
+}
+
+func TestService_Upload_ReaderError(t *testing.T) {
+	ctx := context.Background()
+
+	fileSystemStore, err := blob.NewFileSystemStore(blob.Config{Bucket: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create blob store: %v", err)
+	}
+	blobStore := partialUploadStore{Store: fileSystemStore}
+	objectStore := &fakeLFSObjectStore{objects: map[string]*types.LFSObject{}}
+	service := NewService(objectStore, blobStore)
+
+	sum := sha256.Sum256([]byte("content"))
+	oid := hex.EncodeToString(sum[:])
+
+	errRead := errors.New("connection reset")
+	_, err = service.Upload(ctx, 1, 1, oid, &failingReader{r: strings.NewReader("cont"), err: errRead})
+	if !errors.Is(err, errRead) {
+		t.Fatalf("expected the read error, got %v", err)
+	}
+
+	files, err := blobStore.List(ctx, "lfs/")
+	if err != nil {
+		t.Fatalf("failed to list files: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("expected no files after failed upload, got %v", files)
+	}
+
+	if len(objectStore.objects) != 0 {
+		t.Errorf("expected no LFS object after failed upload, got %v", objectStore.objects)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypePush = "gitness:mirror:push"
+
+	// maxPushErrors is the number of push errors kept in the error history of a push mirror.
+	maxPushErrors = 10
+)
+
+// tagsRefSpec pushes all tags, they aren't affected by the branch filter of a push mirror.
+const tagsRefSpec = "+refs/tags/*:refs/tags/*"
+
+// pushHandler executes the (debounced) push jobs of push mirrors.
+type pushHandler struct {
+	service *Service
+}
+
+func (h pushHandler) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	mirrorID, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid push mirror id %q: %w", data, err)
+	}
+
+	return h.service.push(ctx, mirrorID)
+}
+
+func (s *Service) handleEventBranchCreated(ctx context.Context,
+	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	return s.EnqueuePush(ctx, event.Payload.RepoID)
+}
+
+func (s *Service) handleEventBranchUpdated(ctx context.Context,
+	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	return s.EnqueuePush(ctx, event.Payload.RepoID)
+}
+
+func (s *Service) handleEventBranchDeleted(ctx context.Context,
+	event *events.Event[*gitevents.BranchDeletedPayload]) error {
+	return s.EnqueuePush(ctx, event.Payload.RepoID)
+}
+
+func (s *Service) handleEventTagCreated(ctx context.Context,
+	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	return s.EnqueuePush(ctx, event.Payload.RepoID)
+}
+
+func (s *Service) handleEventTagUpdated(ctx context.Context,
+	event *events.Event[*gitevents.TagUpdatedPayload]) error {
+	return s.EnqueuePush(ctx, event.Payload.RepoID)
+}
+
+func (s *Service) handleEventTagDeleted(ctx context.Context,
+	event *events.Event[*gitevents.TagDeletedPayload]) error {
+	return s.EnqueuePush(ctx, event.Payload.RepoID)
+}
+
+// EnqueuePush schedules a push to all enabled push mirrors of the repository.
+func (s *Service) EnqueuePush(ctx context.Context, repoID int64) error {
+	mirrors, err := s.pushMirrorStore.List(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to list push mirrors: %w", err)
+	}
+
+	for _, mirror := range mirrors {
+		if !mirror.Enabled {
+			continue
+		}
+
+		if err := s.schedulePush(ctx, mirror, s.config.PushDebounce); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PushNow schedules an immediate push to the push mirror.
+func (s *Service) PushNow(ctx context.Context, mirror *types.PushMirror) error {
+	return s.schedulePush(ctx, mirror, 0)
+}
+
+// schedulePush schedules a push job of the mirror. All pushes requested within the same debounce window
+// share a single job (identified by its UID) that is executed at the end of the window.
+func (s *Service) schedulePush(ctx context.Context, mirror *types.PushMirror, debounce time.Duration) error {
+	var (
+		uid   string
+		delay time.Duration
+	)
+
+	if debounce > 0 {
+		now := time.Now()
+		windowEnd := now.Truncate(debounce).Add(debounce)
+		uid = fmt.Sprintf("%s:%d:%d", jobTypePush, mirror.ID, windowEnd.UnixMilli())
+		delay = windowEnd.Sub(now)
+	} else {
+		var err error
+		uid, err = job.UID()
+		if err != nil {
+			return fmt.Errorf("failed to generate job uid: %w", err)
+		}
+	}
+
+	err := s.scheduler.RunJob(ctx, job.Definition{
+		UID:        uid,
+		Type:       jobTypePush,
+		MaxRetries: 0,
+		Timeout:    s.config.SyncTimeout,
+		Data:       strconv.FormatInt(mirror.ID, 10),
+		Delay:      delay,
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// a push is already scheduled for the current debounce window.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to run mirror push job: %w", err)
+	}
+
+	return nil
+}
+
+// push pushes the repository to the push mirror and records the outcome in the mirror's status.
+func (s *Service) push(ctx context.Context, mirrorID int64) (string, error) {
+	mirror, err := s.pushMirrorStore.Find(ctx, mirrorID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "mirror got deleted", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find push mirror: %w", err)
+	}
+
+	if !mirror.Enabled {
+		return "mirror is disabled", nil
+	}
+
+	mirror, err = s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		mirror.State = enum.MirrorSyncStateRunning
+		mirror.LastPush = time.Now().UnixMilli()
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to update push mirror state: %w", err)
+	}
+
+	pushErr := s.pushRepository(ctx, mirror)
+	if pushErr != nil {
+		log.Ctx(ctx).Info().Err(pushErr).
+			Int64("repo.id", mirror.RepoID).
+			Int64("push_mirror.id", mirror.ID).
+			Msg("push to push mirror failed")
+	}
+
+	_, err = s.pushMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PushMirror) error {
+		if pushErr != nil {
+			mirror.State = enum.MirrorSyncStateFailed
+			mirror.Errors = appendPushError(mirror.Errors, types.PushMirrorError{
+				Time:  time.Now().UnixMilli(),
+				Error: truncate(pushErr.Error(), maxErrorLength),
+			})
+			return nil
+		}
+
+		mirror.State = enum.MirrorSyncStateSuccess
+		mirror.LastPushed = time.Now().UnixMilli()
+		return nil
+	})
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return "mirror got deleted", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to update push status of push mirror: %w", err)
+	}
+
+	if pushErr != nil {
+		return "push failed", nil
+	}
+
+	return "push succeeded", nil
+}
+
+func (s *Service) pushRepository(ctx context.Context, mirror *types.PushMirror) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.SyncTimeout)
+	defer cancel()
+
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	remote, password, err := s.remoteURL(mirror.URL, mirror.Username, mirror.Password)
+	if err != nil {
+		return err
+	}
+
+	err = s.git.PushRemote(ctx, &git.PushRemoteParams{
+		ReadParams: git.ReadParams{RepoUID: repo.GitUID},
+		RemoteURL:  remote,
+		RefSpecs:   PushRefSpecs(mirror.BranchFilter),
+	})
+	if err != nil {
+		// never expose the credentials of the mirror.
+		return errors.New(redact(err.Error(), remote, mirror.URL, password))
+	}
+
+	return nil
+}
+
+// PushRefSpecs returns the refspecs that are pushed to a push mirror with the provided branch filter.
+func PushRefSpecs(branchFilter []string) []string {
+	if len(branchFilter) == 0 {
+		return refSpecs
+	}
+
+	specs := make([]string, 0, len(branchFilter)+1)
+	for _, pattern := range branchFilter {
+		specs = append(specs, "+refs/heads/"+pattern+":refs/heads/"+pattern)
+	}
+
+	return append(specs, tagsRefSpec)
+}
+
+// ValidateBranchPattern checks whether the branch filter pattern can be used in a refspec.
+func ValidateBranchPattern(pattern string) error {
+	if pattern == "" {
+		return errors.New("branch pattern can't be empty")
+	}
+	if strings.Count(pattern, "*") > 1 {
+		return fmt.Errorf("branch pattern %q can contain at most one '*'", pattern)
+	}
+	if strings.HasPrefix(pattern, "-") || strings.HasPrefix(pattern, "/") || strings.HasSuffix(pattern, "/") ||
+		strings.Contains(pattern, "..") || strings.Contains(pattern, "//") ||
+		strings.ContainsAny(pattern, ": \t\n\\?[~^") {
+		return fmt.Errorf("branch pattern %q is invalid", pattern)
+	}
+	return nil
+}
+
+// appendPushError adds the error to the front of the error history, keeping at most maxPushErrors entries.
+func appendPushError(history []types.PushMirrorError, pushErr types.PushMirrorError) []types.PushMirrorError {
+	n := len(history) + 1
+	if n > maxPushErrors {
+		n = maxPushErrors
+	}
+
+	res := make([]types.PushMirrorError, 0, n)
+	res = append(res, pushErr)
+	return append(res, history[:n-1]...)
+}
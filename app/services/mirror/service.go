@@ -0,0 +1,449 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	gitevents "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
+	gitnessurl "github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:mirror"
+
+	jobTypeSync = "gitness:mirror:sync"
+	jobCronSync = "* * * * *" // Every minute.
+
+	// syncBatchSize is the maximum number of mirrors synced by a single run of the sync job.
+	syncBatchSize = 10
+
+	// maxErrorLength is the maximum length of the last sync error stored with a mirror.
+	maxErrorLength = 1024
+)
+
+// refSpecs are the references that are synced from the upstream repository.
+// Other references (like pull request refs) are owned by gitness and never touched by a sync.
+var refSpecs = []string{
+	"+refs/heads/*:refs/heads/*",
+	"+refs/tags/*:refs/tags/*",
+}
+
+type Config struct {
+	// MinInterval is the minimum time between two syncs of a pull mirror.
+	MinInterval time.Duration
+	// DefaultInterval is the time between two syncs of a pull mirror if none is configured.
+	DefaultInterval time.Duration
+	// SyncTimeout is the maximum duration of a single sync.
+	SyncTimeout time.Duration
+
+	// PushDebounce is the time changes of a repository are collected before they are pushed to its push mirrors.
+	PushDebounce time.Duration
+
+	EventReaderName string
+	Concurrency     int
+	MaxRetries      int
+}
+
+func (c *Config) Prepare() error {
+	if c == nil {
+		return errors.New("config is required")
+	}
+	if c.MinInterval < time.Minute {
+		return errors.New("config.MinInterval has to be at least a minute")
+	}
+	if c.DefaultInterval < c.MinInterval {
+		return errors.New("config.DefaultInterval can't be less than config.MinInterval")
+	}
+	if c.SyncTimeout < time.Minute {
+		return errors.New("config.SyncTimeout has to be at least a minute")
+	}
+	if c.PushDebounce < 0 {
+		return errors.New("config.PushDebounce can't be negative")
+	}
+	if c.EventReaderName == "" {
+		return errors.New("config.EventReaderName is required")
+	}
+	if c.Concurrency < 1 {
+		return errors.New("config.Concurrency has to be a positive number")
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("config.MaxRetries can't be negative")
+	}
+	return nil
+}
+
+// Service keeps pull mirrors in sync with their upstream repository
+// and replicates all changes of a repository to its push mirrors.
+type Service struct {
+	config          Config
+	scheduler       *job.Scheduler
+	pullMirrorStore store.PullMirrorStore
+	pushMirrorStore store.PushMirrorStore
+	repoStore       store.RepoStore
+	git             git.Interface
+	encrypter       encrypt.Encrypter
+	urlProvider     gitnessurl.Provider
+	indexer         keywordsearch.Indexer
+	repoReporter    *repoevents.Reporter
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	repoStore store.RepoStore,
+	git git.Interface,
+	encrypter encrypt.Encrypter,
+	urlProvider gitnessurl.Provider,
+	indexer keywordsearch.Indexer,
+	repoReporter *repoevents.Reporter,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided mirror config is invalid: %w", err)
+	}
+
+	service := &Service{
+		config:          config,
+		scheduler:       scheduler,
+		pullMirrorStore: pullMirrorStore,
+		pushMirrorStore: pushMirrorStore,
+		repoStore:       repoStore,
+		git:             git,
+		encrypter:       encrypter,
+		urlProvider:     urlProvider,
+		indexer:         indexer,
+		repoReporter:    repoReporter,
+	}
+
+	err := executor.Register(jobTypeSync, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register mirror sync job handler: %w", err)
+	}
+
+	err = executor.Register(jobTypePush, pushHandler{service: service})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register mirror push job handler: %w", err)
+	}
+
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *gitevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterBranchCreated(service.handleEventBranchCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterBranchDeleted(service.handleEventBranchDeleted)
+
+			_ = r.RegisterTagCreated(service.handleEventTagCreated)
+			_ = r.RegisterTagUpdated(service.handleEventTagUpdated)
+			_ = r.RegisterTagDeleted(service.handleEventTagDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch git events reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// Register schedules the recurring job that syncs all due pull mirrors.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeSync, jobTypeSync, jobCronSync, s.jobMaxDuration())
+	if err != nil {
+		return fmt.Errorf("failed to schedule mirror sync job: %w", err)
+	}
+
+	return nil
+}
+
+// SyncNow marks the pull mirror as due and starts a job that syncs it right away.
+func (s *Service) SyncNow(ctx context.Context, mirror *types.PullMirror) (*types.PullMirror, error) {
+	mirror, err := s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		syncDeadline := time.UnixMilli(mirror.LastSync).Add(s.config.SyncTimeout)
+		if mirror.State == enum.MirrorSyncStateRunning && time.Now().Before(syncDeadline) {
+			return ErrSyncInProgress
+		}
+		mirror.State = enum.MirrorSyncStatePending
+		mirror.NextSync = time.Now().UnixMilli()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uid, err := job.UID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate job uid: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        uid,
+		Type:       jobTypeSync,
+		MaxRetries: 0,
+		Timeout:    s.jobMaxDuration(),
+		Data:       strconv.FormatInt(mirror.RepoID, 10),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run mirror sync job: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// Handle syncs the pull mirror of the repository provided as data, or all due pull mirrors if no data is provided.
+// It's executed every minute by the job scheduler.
+func (s *Service) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	now := time.Now().UnixMilli()
+
+	var mirrors []*types.PullMirror
+	if data != "" {
+		repoID, err := strconv.ParseInt(data, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid repo id %q: %w", data, err)
+		}
+
+		mirror, err := s.pullMirrorStore.Find(ctx, repoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return "mirror got deleted", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to find pull mirror: %w", err)
+		}
+
+		if mirror.NextSync <= now {
+			mirrors = append(mirrors, mirror)
+		}
+	} else {
+		var err error
+		mirrors, err = s.pullMirrorStore.ListDue(ctx, now, syncBatchSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to list due pull mirrors: %w", err)
+		}
+	}
+
+	synced := 0
+	for _, mirror := range mirrors {
+		ok, err := s.sync(ctx, mirror)
+		if err != nil {
+			// a single broken mirror shouldn't block the others.
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("repo.id", mirror.RepoID).
+				Msg("failed to sync pull mirror")
+			continue
+		}
+		if ok {
+			synced++
+		}
+	}
+
+	return fmt.Sprintf("synced %d of %d due pull mirrors", synced, len(mirrors)), nil
+}
+
+// sync claims the pull mirror and syncs it with its upstream repository.
+// It returns false in case the mirror was claimed by someone else in the meantime.
+func (s *Service) sync(ctx context.Context, mirror *types.PullMirror) (bool, error) {
+	// the next sync is updated before syncing to guarantee that
+	// a mirror whose sync never finishes isn't retried every minute.
+	now := time.Now()
+	mirror.State = enum.MirrorSyncStateRunning
+	mirror.LastSync = now.UnixMilli()
+	mirror.NextSync = now.Add(s.interval(mirror)).UnixMilli()
+
+	err := s.pullMirrorStore.Update(ctx, mirror)
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim pull mirror: %w", err)
+	}
+
+	syncErr := s.syncRepository(ctx, mirror)
+	if syncErr != nil {
+		log.Ctx(ctx).Info().Err(syncErr).
+			Int64("repo.id", mirror.RepoID).
+			Msg("sync of pull mirror failed")
+	}
+
+	_, err = s.pullMirrorStore.UpdateOptLock(ctx, mirror, func(mirror *types.PullMirror) error {
+		if syncErr != nil {
+			mirror.State = enum.MirrorSyncStateFailed
+			mirror.LastError = truncate(syncErr.Error(), maxErrorLength)
+			return nil
+		}
+
+		mirror.State = enum.MirrorSyncStateSuccess
+		mirror.LastSynced = time.Now().UnixMilli()
+		mirror.LastError = ""
+		return nil
+	})
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		// the mirror got deleted during the sync.
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update sync status of pull mirror: %w", err)
+	}
+
+	return true, nil
+}
+
+func (s *Service) syncRepository(ctx context.Context, mirror *types.PullMirror) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.SyncTimeout)
+	defer cancel()
+
+	repo, err := s.repoStore.Find(ctx, mirror.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	source, password, err := s.remoteURL(mirror.URL, mirror.Username, mirror.Password)
+	if err != nil {
+		return err
+	}
+
+	writeParams, err := s.createRPCWriteParams(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	out, err := s.git.SyncRepository(ctx, &git.SyncRepositoryParams{
+		WriteParams:       writeParams,
+		Source:            source,
+		CreateIfNotExists: false,
+		RefSpecs:          refSpecs,
+	})
+	if err != nil {
+		// never expose the credentials of the mirror.
+		return errors.New(redact(err.Error(), source, mirror.URL, password))
+	}
+
+	if out.DefaultBranch != "" && out.DefaultBranch != repo.DefaultBranch {
+		oldBranch := repo.DefaultBranch
+		repo, err = s.repoStore.UpdateOptLock(ctx, repo, func(repo *types.Repository) error {
+			repo.DefaultBranch = out.DefaultBranch
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to update default branch of repo: %w", err)
+		}
+
+		s.repoReporter.DefaultBranchUpdated(ctx, &repoevents.DefaultBranchUpdatedPayload{
+			RepoID:    repo.ID,
+			OldBranch: oldBranch,
+			NewBranch: repo.DefaultBranch,
+		})
+	}
+
+	if err = s.indexer.Index(ctx, repo); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo.id", repo.ID).Msg("failed to index repository after sync")
+	}
+
+	// fetching doesn't run any git hooks, hence the synced changes are forwarded to the push mirrors explicitly.
+	if err = s.EnqueuePush(ctx, repo.ID); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("repo.id", repo.ID).Msg("failed to push synced changes to push mirrors")
+	}
+
+	return nil
+}
+
+// remoteURL returns the URL of the remote repository including the credentials,
+// as well as the decrypted password.
+func (s *Service) remoteURL(rawURL string, username string, encryptedPassword []byte) (string, string, error) {
+	if username == "" && len(encryptedPassword) == 0 {
+		return rawURL, "", nil
+	}
+
+	password, err := s.encrypter.Decrypt(encryptedPassword)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to decrypt mirror password: %w", err)
+	}
+
+	remote, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse mirror url: %w", err)
+	}
+
+	remote.User = url.UserPassword(username, password)
+
+	return remote.String(), password, nil
+}
+
+func (s *Service) createRPCWriteParams(ctx context.Context, repo *types.Repository) (git.WriteParams, error) {
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(),
+		repo.ID,
+		principal.ID,
+		false,
+		true,
+	)
+	if err != nil {
+		return git.WriteParams{}, fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	return git.WriteParams{
+		Actor: git.Identity{
+			Name:  principal.DisplayName,
+			Email: principal.Email,
+		},
+		RepoUID: repo.GitUID,
+		EnvVars: envVars,
+	}, nil
+}
+
+func (s *Service) interval(mirror *types.PullMirror) time.Duration {
+	if mirror.Interval <= 0 {
+		return s.config.DefaultInterval
+	}
+	return time.Duration(mirror.Interval) * time.Second
+}
+
+// jobMaxDuration leaves enough time for a sync of every mirror of a batch.
+func (s *Service) jobMaxDuration() time.Duration {
+	return s.config.SyncTimeout * syncBatchSize
+}
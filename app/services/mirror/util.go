@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+)
+
+// ErrSyncInProgress is returned if a sync is requested while the mirror is being synced.
+var ErrSyncInProgress = usererror.Conflict("The mirror is already being synced.")
+
+// redact removes the credentials from the provided (error) message.
+func redact(s string, source string, plain string, password string) string {
+	if source != plain {
+		s = strings.ReplaceAll(s, source, plain)
+	}
+	if password != "" {
+		s = strings.ReplaceAll(s, password, "******")
+	}
+	return s
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mirror
+
+import (
+	"context"
+
+	gitevents "github.com/harness/gitness/app/events/git"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	pullMirrorStore store.PullMirrorStore,
+	pushMirrorStore store.PushMirrorStore,
+	repoStore store.RepoStore,
+	git git.Interface,
+	encrypter encrypt.Encrypter,
+	urlProvider url.Provider,
+	indexer keywordsearch.Indexer,
+	repoReporter *repoevents.Reporter,
+	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
+) (*Service, error) {
+	return NewService(
+		ctx,
+		config,
+		scheduler,
+		executor,
+		pullMirrorStore,
+		pushMirrorStore,
+		repoStore,
+		git,
+		encrypter,
+		urlProvider,
+		indexer,
+		repoReporter,
+		gitReaderFactory,
+	)
+}
@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+)
+
+// pullReqData is the data available to all pull request email templates.
+type pullReqData struct {
+	Repo    *types.Repository
+	PullReq *types.PullReq
+	Actor   *types.Principal
+	URL     string
+
+	// event specific fields
+	Reviewer    *types.Principal
+	CommentText string
+	NewSHA      string
+	Forced      bool
+}
+
+func (s *Service) notifyPullReqCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	data, err := s.getPullReqData(ctx, event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	reviewerIDs, err := s.getReviewerIDs(ctx, data.PullReq.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, event.ID, templatePullReqCreated, data, reviewerIDs,
+		func(settings *types.NotificationSettings) bool { return settings.PullReqCreated })
+}
+
+func (s *Service) notifyReviewerAdded(ctx context.Context,
+	event *events.Event[*pullreqevents.ReviewerAddedPayload],
+) error {
+	data, err := s.getPullReqData(ctx, event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	data.Reviewer, err = s.principalStore.Find(ctx, event.Payload.ReviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to find reviewer: %w", err)
+	}
+
+	return s.notify(ctx, event.ID, templateReviewerAdded, data, []int64{event.Payload.ReviewerID},
+		func(settings *types.NotificationSettings) bool { return settings.ReviewerAdded })
+}
+
+func (s *Service) notifyCommentCreated(ctx context.Context,
+	event *events.Event[*pullreqevents.CommentCreatedPayload],
+) error {
+	data, err := s.getPullReqData(ctx, event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	activity, err := s.activityStore.Find(ctx, event.Payload.ActivityID)
+	if err != nil {
+		return fmt.Errorf("failed to find comment: %w", err)
+	}
+
+	data.CommentText = activity.Text
+
+	recipientIDs, err := s.getParticipantIDs(ctx, data.PullReq)
+	if err != nil {
+		return err
+	}
+
+	for _, uid := range parseMentions(activity.Text) {
+		user, err := s.principalStore.FindUserByUID(ctx, uid)
+		if err != nil {
+			// mentions of unknown users are ignored
+			continue
+		}
+
+		recipientIDs = append(recipientIDs, user.ID)
+	}
+
+	return s.notify(ctx, event.ID, templateCommentCreated, data, recipientIDs,
+		func(settings *types.NotificationSettings) bool { return settings.CommentCreated })
+}
+
+func (s *Service) notifyPullReqMerged(ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	data, err := s.getPullReqData(ctx, event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	recipientIDs, err := s.getParticipantIDs(ctx, data.PullReq)
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, event.ID, templatePullReqMerged, data, recipientIDs,
+		func(settings *types.NotificationSettings) bool { return settings.PullReqMerged })
+}
+
+func (s *Service) notifyPullReqClosed(ctx context.Context,
+	event *events.Event[*pullreqevents.ClosedPayload],
+) error {
+	data, err := s.getPullReqData(ctx, event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	recipientIDs, err := s.getParticipantIDs(ctx, data.PullReq)
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, event.ID, templatePullReqClosed, data, recipientIDs,
+		func(settings *types.NotificationSettings) bool { return settings.PullReqClosed })
+}
+
+func (s *Service) notifyBranchUpdated(ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	data, err := s.getPullReqData(ctx, event.Payload.Base)
+	if err != nil {
+		return err
+	}
+
+	data.NewSHA = event.Payload.NewSHA
+	data.Forced = event.Payload.Forced
+
+	reviewerIDs, err := s.getReviewerIDs(ctx, data.PullReq.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.notify(ctx, event.ID, templateBranchUpdated, data, reviewerIDs,
+		func(settings *types.NotificationSettings) bool { return settings.BranchUpdated })
+}
+
+func (s *Service) getPullReqData(ctx context.Context, base pullreqevents.Base) (*pullReqData, error) {
+	pr, err := s.pullreqStore.Find(ctx, base.PullReqID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	repo, err := s.repoStore.Find(ctx, base.TargetRepoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository: %w", err)
+	}
+
+	actor, err := s.principalStore.Find(ctx, base.PrincipalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	return &pullReqData{
+		Repo:    repo,
+		PullReq: pr,
+		Actor:   actor,
+		URL:     s.urlProvider.GenerateUIPRURL(repo.Path, pr.Number),
+	}, nil
+}
+
+func (s *Service) getReviewerIDs(ctx context.Context, prID int64) ([]int64, error) {
+	reviewers, err := s.reviewerStore.List(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviewers: %w", err)
+	}
+
+	ids := make([]int64, len(reviewers))
+	for i, reviewer := range reviewers {
+		ids[i] = reviewer.PrincipalID
+	}
+
+	return ids, nil
+}
+
+// getParticipantIDs returns the author and the reviewers of the pull request.
+func (s *Service) getParticipantIDs(ctx context.Context, pr *types.PullReq) ([]int64, error) {
+	reviewerIDs, err := s.getReviewerIDs(ctx, pr.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]int64{pr.CreatedBy}, reviewerIDs...), nil
+}
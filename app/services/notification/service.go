@@ -0,0 +1,305 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notification sends email notifications about pull request activity to the involved users.
+package notification
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/mailer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	eventsReaderGroupName = "gitness:notification"
+
+	jobTypeEmail    = "gitness:notification:email"
+	jobEmailTimeout = time.Minute
+)
+
+// Service consumes pull request events and sends email notifications to the involved users.
+// The emails are delivered by background jobs, which are retried in case the delivery fails.
+type Service struct {
+	config             Config
+	authorizer         authz.Authorizer
+	mailer             *mailer.Service
+	scheduler          *job.Scheduler
+	urlProvider        url.Provider
+	repoStore          store.RepoStore
+	pullreqStore       store.PullReqStore
+	activityStore      store.PullReqActivityStore
+	reviewerStore      store.PullReqReviewerStore
+	principalStore     store.PrincipalStore
+	notificationsStore store.NotificationSettingsStore
+	templates          *templates
+}
+
+func NewService(
+	ctx context.Context,
+	config Config,
+	authorizer authz.Authorizer,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	mailSvc *mailer.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	urlProvider url.Provider,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	principalStore store.PrincipalStore,
+	notificationsStore store.NotificationSettingsStore,
+) (*Service, error) {
+	if err := config.Prepare(); err != nil {
+		return nil, fmt.Errorf("provided notification service config is invalid: %w", err)
+	}
+
+	tmpls, err := parseTemplates()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email templates: %w", err)
+	}
+
+	service := &Service{
+		config:             config,
+		authorizer:         authorizer,
+		mailer:             mailSvc,
+		scheduler:          scheduler,
+		urlProvider:        urlProvider,
+		repoStore:          repoStore,
+		pullreqStore:       pullreqStore,
+		activityStore:      activityStore,
+		reviewerStore:      reviewerStore,
+		principalStore:     principalStore,
+		notificationsStore: notificationsStore,
+		templates:          tmpls,
+	}
+
+	if !config.Enabled {
+		return service, nil
+	}
+
+	err = executor.Register(jobTypeEmail, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register email job handler: %w", err)
+	}
+
+	_, err = pullreqEvReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterCreated(service.notifyPullReqCreated)
+			_ = r.RegisterReviewerAdded(service.notifyReviewerAdded)
+			_ = r.RegisterCommentCreated(service.notifyCommentCreated)
+			_ = r.RegisterMerged(service.notifyPullReqMerged)
+			_ = r.RegisterClosed(service.notifyPullReqClosed)
+			_ = r.RegisterBranchUpdated(service.notifyBranchUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pull request event reader: %w", err)
+	}
+
+	return service, nil
+}
+
+// Handle sends the email described by the job data.
+func (s *Service) Handle(_ context.Context, data string, _ job.ProgressReporter) (string, error) {
+	mail := &mailer.MailRequest{}
+	err := json.Unmarshal([]byte(data), mail)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal email job data: %w", err)
+	}
+
+	err = s.mailer.SendMail(mail)
+	if err != nil {
+		return "", fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return "", nil
+}
+
+// notify renders the email and schedules its delivery to every recipient that didn't opt out
+// and has access to the repository. The principal that caused the event is never notified.
+func (s *Service) notify(
+	ctx context.Context,
+	eventID string,
+	tmpl string,
+	data *pullReqData,
+	recipientIDs []int64,
+	isEnabled func(*types.NotificationSettings) bool,
+) error {
+	emails, err := s.recipientEmails(ctx, data.Repo, data.Actor.ID, recipientIDs, isEnabled)
+	if err != nil {
+		return err
+	}
+
+	if len(emails) == 0 {
+		return nil
+	}
+
+	subject, body, err := s.templates.render(tmpl, data)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+
+	for _, email := range emails {
+		err = s.scheduleEmail(ctx, emailJobUID(eventID, tmpl, email), &mailer.MailRequest{
+			ToRecipients: []string{email},
+			Subject:      subject,
+			Body:         body,
+			ContentType:  "text/html",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Ctx(ctx).Debug().
+		Str("template", tmpl).
+		Int("recipients", len(emails)).
+		Msg("scheduled email notifications")
+
+	return nil
+}
+
+func (s *Service) scheduleEmail(ctx context.Context, uid string, mail *mailer.MailRequest) error {
+	data, err := json.Marshal(mail)
+	if err != nil {
+		return fmt.Errorf("failed to marshal email job data: %w", err)
+	}
+
+	err = s.scheduler.RunJob(ctx, job.Definition{
+		UID:        uid,
+		Type:       jobTypeEmail,
+		MaxRetries: s.config.MaxRetries,
+		Timeout:    jobEmailTimeout,
+		Data:       string(data),
+	})
+	if errors.Is(err, gitness_store.ErrDuplicate) {
+		// the email was already scheduled by a previous attempt of the event handler.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to schedule email job: %w", err)
+	}
+
+	return nil
+}
+
+// emailJobUID returns the UID of the email job. The UID is deterministic,
+// so a retried event handler doesn't send the same email again.
+func emailJobUID(eventID string, tmpl string, email string) string {
+	h := sha256.Sum256([]byte(eventID + "\x00" + tmpl + "\x00" + email))
+	return "notification-" + hex.EncodeToString(h[:])
+}
+
+// recipientEmails returns the email addresses of the users that should receive the notification.
+func (s *Service) recipientEmails(
+	ctx context.Context,
+	repo *types.Repository,
+	actorID int64,
+	recipientIDs []int64,
+	isEnabled func(*types.NotificationSettings) bool,
+) ([]string, error) {
+	emails := make([]string, 0, len(recipientIDs))
+	seen := make(map[int64]struct{}, len(recipientIDs))
+
+	for _, id := range recipientIDs {
+		if _, ok := seen[id]; ok || id == actorID {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		principal, err := s.principalStore.Find(ctx, id)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find recipient: %w", err)
+		}
+
+		if principal.Type != enum.PrincipalTypeUser || principal.Blocked || principal.Email == "" {
+			continue
+		}
+
+		settings, err := s.notificationsStore.Find(ctx, id)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			settings = types.DefaultNotificationSettings(id)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to find notification settings of recipient: %w", err)
+		}
+
+		if !isEnabled(settings) {
+			continue
+		}
+
+		canView, err := s.canViewRepo(ctx, principal, repo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check repository access of recipient: %w", err)
+		}
+		if !canView {
+			continue
+		}
+
+		emails = append(emails, principal.Email)
+	}
+
+	return emails, nil
+}
+
+// canViewRepo returns true if the principal has access to the repository,
+// so no content of private repositories is sent to users that can't see it.
+func (s *Service) canViewRepo(ctx context.Context, principal *types.Principal, repo *types.Repository) (bool, error) {
+	if repo.IsPublic {
+		return true, nil
+	}
+
+	parentSpace, name, err := paths.DisectLeaf(repo.Path)
+	if err != nil {
+		return false, fmt.Errorf("failed to disect path %q: %w", repo.Path, err)
+	}
+
+	return s.authorizer.Check(ctx,
+		&auth.Session{Principal: *principal},
+		&types.Scope{SpacePath: parentSpace},
+		&types.Resource{Type: enum.ResourceTypeRepo, Name: name},
+		enum.PermissionRepoView)
+}
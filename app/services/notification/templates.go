@@ -0,0 +1,113 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"regexp"
+	"strings"
+	texttemplate "text/template"
+)
+
+const (
+	templatePullReqCreated = "pullreq_created.html"
+	templateReviewerAdded  = "reviewer_added.html"
+	templateCommentCreated = "comment_created.html"
+	templatePullReqMerged  = "pullreq_merged.html"
+	templatePullReqClosed  = "pullreq_closed.html"
+	templateBranchUpdated  = "branch_updated.html"
+)
+
+//go:embed templates/*.html
+var templateFS embed.FS
+
+// templates holds the email templates. Every template defines a "subject" and a "body" template.
+// The subject is rendered as plain text (it ends up in the mail header), the body is rendered as HTML.
+type templates struct {
+	subjects map[string]*texttemplate.Template
+	bodies   map[string]*htmltemplate.Template
+}
+
+func parseTemplates() (*templates, error) {
+	entries, err := templateFS.ReadDir("templates")
+	if err != nil {
+		return nil, err
+	}
+
+	t := &templates{
+		subjects: make(map[string]*texttemplate.Template, len(entries)),
+		bodies:   make(map[string]*htmltemplate.Template, len(entries)),
+	}
+	for _, entry := range entries {
+		subject, err := texttemplate.ParseFS(templateFS, "templates/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse subject of template %q: %w", entry.Name(), err)
+		}
+
+		body, err := htmltemplate.ParseFS(templateFS, "templates/"+entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse body of template %q: %w", entry.Name(), err)
+		}
+
+		t.subjects[entry.Name()] = subject
+		t.bodies[entry.Name()] = body
+	}
+
+	return t, nil
+}
+
+// render renders the subject and body of the email.
+func (t *templates) render(name string, data any) (string, string, error) {
+	subjectTmpl, ok := t.subjects[name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown template %q", name)
+	}
+
+	subject := &bytes.Buffer{}
+	if err := subjectTmpl.ExecuteTemplate(subject, "subject", data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject: %w", err)
+	}
+
+	body := &bytes.Buffer{}
+	if err := t.bodies[name].ExecuteTemplate(body, "body", data); err != nil {
+		return "", "", fmt.Errorf("failed to render body: %w", err)
+	}
+
+	return strings.TrimSpace(subject.String()), body.String(), nil
+}
+
+var mentionRegex = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_.-])@([a-zA-Z_][a-zA-Z0-9-_.]*)`)
+
+// parseMentions returns the unique UIDs of all users mentioned in the text (e.g. "@john.doe").
+func parseMentions(text string) []string {
+	matches := mentionRegex.FindAllStringSubmatch(text, -1)
+
+	uids := make([]string, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, match := range matches {
+		// allow mentions at the end of a sentence
+		uid := strings.TrimRight(match[1], ".")
+		if _, ok := seen[uid]; ok || uid == "" {
+			continue
+		}
+		seen[uid] = struct{}{}
+		uids = append(uids, uid)
+	}
+
+	return uids
+}
@@ -0,0 +1,99 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "no mentions",
+			text: "looks good to me",
+			want: []string{},
+		},
+		{
+			name: "multiple mentions",
+			text: "@john please check with @jane.doe.",
+			want: []string{"john", "jane.doe"},
+		},
+		{
+			name: "duplicate mentions",
+			text: "@john, @john!",
+			want: []string{"john"},
+		},
+		{
+			name: "email address",
+			text: "send it to john@example.com",
+			want: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseMentions(test.text)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestTemplatesRender(t *testing.T) {
+	tmpls, err := parseTemplates()
+	if err != nil {
+		t.Fatalf("failed to parse templates: %s", err)
+	}
+
+	data := &pullReqData{
+		Repo:        &types.Repository{Path: "space/repo"},
+		PullReq:     &types.PullReq{Number: 7, Title: "Fix <bug>", SourceBranch: "fix", TargetBranch: "main"},
+		Actor:       &types.Principal{DisplayName: "John"},
+		Reviewer:    &types.Principal{DisplayName: "Jane"},
+		URL:         "http://localhost/space/repo/pulls/7",
+		CommentText: "LGTM",
+	}
+
+	for _, name := range []string{
+		templatePullReqCreated,
+		templateReviewerAdded,
+		templateCommentCreated,
+		templatePullReqMerged,
+		templatePullReqClosed,
+		templateBranchUpdated,
+	} {
+		subject, body, err := tmpls.render(name, data)
+		if err != nil {
+			t.Errorf("failed to render %s: %s", name, err)
+			continue
+		}
+
+		if !strings.Contains(subject, "Fix <bug>") || !strings.Contains(subject, "PR #7") {
+			t.Errorf("%s: unexpected subject %q", name, subject)
+		}
+		if !strings.Contains(body, data.URL) || strings.Contains(body, "<bug>") {
+			t.Errorf("%s: unexpected body %q", name, body)
+		}
+	}
+}
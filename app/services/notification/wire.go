@@ -0,0 +1,54 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/auth/authz"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/job"
+	"github.com/harness/gitness/app/services/mailer"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config Config,
+	authorizer authz.Authorizer,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	mailSvc *mailer.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
+	urlProvider url.Provider,
+	repoStore store.RepoStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	principalStore store.PrincipalStore,
+	notificationsStore store.NotificationSettingsStore,
+) (*Service, error) {
+	return NewService(ctx, config, authorizer, pullreqEvReaderFactory, mailSvc, scheduler, executor, urlProvider,
+		repoStore, pullreqStore, activityStore, reviewerStore, principalStore, notificationsStore)
+}
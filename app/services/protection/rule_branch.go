@@ -44,6 +44,13 @@ func (v *Branch) MergeVerify(
 		return
 	}
 
+	lifecycleViolations, err := v.Lifecycle.MergeVerify(ctx, in)
+	if err != nil {
+		return
+	}
+
+	violations = append(violations, lifecycleViolations...)
+
 	bypassable := v.Bypass.matches(in.Actor, in.IsRepoOwner)
 	bypassed := in.AllowBypass && bypassable
 	for i := range violations {
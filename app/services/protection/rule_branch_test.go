@@ -156,6 +156,47 @@ func TestBranch_MergeVerify(t *testing.T) {
 			},
 			expVs: []types.RuleViolations{},
 		},
+		{
+			name: "signed-commits",
+			branch: Branch{
+				Bypass:    DefBypass{RepoOwners: true},
+				Lifecycle: DefLifecycle{RequireSignedCommits: true},
+			},
+			in: MergeVerifyInput{
+				Actor:       user,
+				AllowBypass: true,
+				IsRepoOwner: true,
+				PullReq:     &types.PullReq{TargetBranch: "main"},
+			},
+			expOut: MergeVerifyOutput{
+				DeleteSourceBranch: false,
+				AllowedMethods:     enum.MergeMethods,
+			},
+			expVs: []types.RuleViolations{
+				{
+					Bypassable: true,
+					Bypassed:   true,
+					Violations: []types.Violation{
+						{Code: codeLifecycleSignedCommits},
+					},
+				},
+			},
+		},
+		{
+			name: "signed-commits-fast-forward",
+			branch: Branch{
+				Bypass:    DefBypass{RepoOwners: true},
+				Lifecycle: DefLifecycle{RequireSignedCommits: true},
+			},
+			in: MergeVerifyInput{
+				Actor:          user,
+				Method:         enum.MergeMethodFastForward,
+				PullReq:        &types.PullReq{TargetBranch: "main"},
+				CommitVerifier: commitVerifierMock{},
+			},
+			expOut: MergeVerifyOutput{},
+			expVs:  []types.RuleViolations{},
+		},
 	}
 
 	ctx := context.Background()
@@ -16,8 +16,10 @@ package protection
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type (
@@ -33,6 +35,21 @@ type (
 		RefAction   RefAction
 		RefType     RefType
 		RefNames    []string
+
+		// CommitVerifier is used to verify signatures of the commits introduced by the change.
+		// It's set only for changes made by a git push, changes without it violate the signed commits rule.
+		CommitVerifier CommitVerifier
+	}
+
+	// CommitVerifier provides the signature verification of new commits of branches.
+	CommitVerifier interface {
+		// UnverifiedCommits returns the commits introduced to the branch which don't have a verified signature.
+		UnverifiedCommits(ctx context.Context, branchName string) ([]UnverifiedCommit, error)
+	}
+
+	UnverifiedCommit struct {
+		SHA    string
+		Status enum.SignatureVerificationStatus
 	}
 
 	RefType int
@@ -43,6 +60,8 @@ type (
 		CreateForbidden bool `json:"create_forbidden,omitempty"`
 		DeleteForbidden bool `json:"delete_forbidden,omitempty"`
 		UpdateForbidden bool `json:"update_forbidden,omitempty"`
+
+		RequireSignedCommits bool `json:"require_signed_commits,omitempty"`
 	}
 )
 
@@ -68,9 +87,11 @@ const (
 	codeLifecycleCreate = "lifecycle.create"
 	codeLifecycleDelete = "lifecycle.delete"
 	codeLifecycleUpdate = "lifecycle.update"
+
+	codeLifecycleSignedCommits = "lifecycle.signed_commits"
 )
 
-func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
+func (v *DefLifecycle) RefChangeVerify(ctx context.Context, in RefChangeVerifyInput) ([]types.RuleViolations, error) {
 	var violations types.RuleViolations
 
 	switch in.RefAction {
@@ -91,6 +112,70 @@ func (v *DefLifecycle) RefChangeVerify(_ context.Context, in RefChangeVerifyInpu
 		}
 	}
 
+	if v.RequireSignedCommits && (in.RefAction == RefActionCreate || in.RefAction == RefActionUpdate) {
+		for _, refName := range in.RefNames {
+			if in.CommitVerifier == nil {
+				// the commits of changes made through the API can't be verified.
+				violations.Addf(codeLifecycleSignedCommits,
+					"Branch %q requires verified signatures of commits. Please push signed commits.", refName)
+				continue
+			}
+
+			unverified, err := in.CommitVerifier.UnverifiedCommits(ctx, refName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to verify commits of branch %q: %w", refName, err)
+			}
+
+			if len(unverified) == 0 {
+				continue
+			}
+
+			violations.Addf(codeLifecycleSignedCommits,
+				"Branch %q requires verified signatures of commits. Commit %s has status %q.",
+				refName, unverified[0].SHA, unverified[0].Status)
+		}
+	}
+
+	if len(violations.Violations) > 0 {
+		return []types.RuleViolations{violations}, nil
+	}
+
+	return nil, nil
+}
+
+// MergeVerify reports merges into branches that require verified signatures of commits.
+// A fast-forward merge adds the commits of the pull request to the branch, so their signatures are verified.
+// All other merge methods create commits on the server which aren't signed.
+// Without a merge method (e.g. a dry run) the commits are verified, as only a fast-forward merge is possible.
+func (v *DefLifecycle) MergeVerify(ctx context.Context, in MergeVerifyInput) ([]types.RuleViolations, error) {
+	if !v.RequireSignedCommits {
+		return nil, nil
+	}
+
+	var violations types.RuleViolations
+
+	switch {
+	case in.Method != "" && in.Method != enum.MergeMethodFastForward:
+		violations.Addf(codeLifecycleSignedCommits,
+			"Branch %q requires verified signatures of commits. Merge method %q creates commits that aren't signed.",
+			in.PullReq.TargetBranch, in.Method)
+	case in.CommitVerifier == nil:
+		violations.Addf(codeLifecycleSignedCommits,
+			"Branch %q requires verified signatures of commits. Commits of the pull request can't be verified.",
+			in.PullReq.TargetBranch)
+	default:
+		unverified, err := in.CommitVerifier.UnverifiedCommits(ctx, in.PullReq.TargetBranch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify commits of pull request: %w", err)
+		}
+
+		for _, commit := range unverified {
+			violations.Addf(codeLifecycleSignedCommits,
+				"Branch %q requires verified signatures of commits. Commit %s has status %q.",
+				in.PullReq.TargetBranch, commit.SHA, commit.Status)
+		}
+	}
+
 	if len(violations.Violations) > 0 {
 		return []types.RuleViolations{violations}, nil
 	}
@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 // nolint:gocognit // it's a unit test
@@ -29,6 +30,7 @@ func TestDefLifecycle_RefChangeVerify(t *testing.T) {
 		name      string
 		def       DefLifecycle
 		action    RefAction
+		verifier  CommitVerifier
 		expCodes  []string
 		expParams [][]any
 	}{
@@ -56,14 +58,47 @@ func TestDefLifecycle_RefChangeVerify(t *testing.T) {
 			expCodes:  []string{"lifecycle.update"},
 			expParams: [][]any{{refName}},
 		},
+		{
+			name:      "lifecycle.signed_commits-no-verifier",
+			def:       DefLifecycle{RequireSignedCommits: true},
+			action:    RefActionUpdate,
+			expCodes:  []string{"lifecycle.signed_commits"},
+			expParams: [][]any{{refName}},
+		},
+		{
+			name:     "lifecycle.signed_commits-success",
+			def:      DefLifecycle{RequireSignedCommits: true},
+			action:   RefActionUpdate,
+			verifier: commitVerifierMock{},
+		},
+		{
+			name:   "lifecycle.signed_commits-fail",
+			def:    DefLifecycle{RequireSignedCommits: true},
+			action: RefActionCreate,
+			verifier: commitVerifierMock{
+				{SHA: "abc", Status: enum.SignatureVerificationStatusUnsigned},
+				{SHA: "def", Status: enum.SignatureVerificationStatusUnknownKey},
+			},
+			expCodes:  []string{"lifecycle.signed_commits"},
+			expParams: [][]any{{refName, "abc", enum.SignatureVerificationStatusUnsigned}},
+		},
+		{
+			name:   "lifecycle.signed_commits-delete",
+			def:    DefLifecycle{RequireSignedCommits: true},
+			action: RefActionDelete,
+			verifier: commitVerifierMock{
+				{SHA: "abc", Status: enum.SignatureVerificationStatusUnsigned},
+			},
+		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			in := RefChangeVerifyInput{
-				RefNames:  []string{refName},
-				RefAction: test.action,
-				RefType:   RefTypeBranch,
+				RefNames:       []string{refName},
+				RefAction:      test.action,
+				RefType:        RefTypeBranch,
+				CommitVerifier: test.verifier,
 			}
 
 			if err := test.def.Sanitize(); err != nil {
@@ -82,6 +117,112 @@ func TestDefLifecycle_RefChangeVerify(t *testing.T) {
 	}
 }
 
+// nolint:gocognit // it's a unit test
+func TestDefLifecycle_MergeVerify(t *testing.T) {
+	const targetBranch = "main"
+	unverified := commitVerifierMock{
+		{SHA: "abc", Status: enum.SignatureVerificationStatusUnsigned},
+		{SHA: "def", Status: enum.SignatureVerificationStatusUnknownKey},
+	}
+
+	tests := []struct {
+		name      string
+		def       DefLifecycle
+		method    enum.MergeMethod
+		verifier  CommitVerifier
+		expCodes  []string
+		expParams [][]any
+	}{
+		{
+			name:     "empty",
+			method:   enum.MergeMethodSquash,
+			verifier: unverified,
+		},
+		{
+			name:     "lifecycle.signed_commits-fast-forward-success",
+			def:      DefLifecycle{RequireSignedCommits: true},
+			method:   enum.MergeMethodFastForward,
+			verifier: commitVerifierMock{},
+		},
+		{
+			name:     "lifecycle.signed_commits-fast-forward-fail",
+			def:      DefLifecycle{RequireSignedCommits: true},
+			method:   enum.MergeMethodFastForward,
+			verifier: unverified,
+			expCodes: []string{"lifecycle.signed_commits", "lifecycle.signed_commits"},
+			expParams: [][]any{
+				{targetBranch, "abc", enum.SignatureVerificationStatusUnsigned},
+				{targetBranch, "def", enum.SignatureVerificationStatusUnknownKey},
+			},
+		},
+		{
+			name:      "lifecycle.signed_commits-fast-forward-no-verifier",
+			def:       DefLifecycle{RequireSignedCommits: true},
+			method:    enum.MergeMethodFastForward,
+			expCodes:  []string{"lifecycle.signed_commits"},
+			expParams: [][]any{{targetBranch}},
+		},
+		{
+			name:     "lifecycle.signed_commits-no-method-success",
+			def:      DefLifecycle{RequireSignedCommits: true},
+			verifier: commitVerifierMock{},
+		},
+		{
+			name:      "lifecycle.signed_commits-merge",
+			def:       DefLifecycle{RequireSignedCommits: true},
+			method:    enum.MergeMethodMerge,
+			verifier:  commitVerifierMock{},
+			expCodes:  []string{"lifecycle.signed_commits"},
+			expParams: [][]any{{targetBranch, enum.MergeMethodMerge}},
+		},
+		{
+			name:      "lifecycle.signed_commits-squash",
+			def:       DefLifecycle{RequireSignedCommits: true},
+			method:    enum.MergeMethodSquash,
+			verifier:  commitVerifierMock{},
+			expCodes:  []string{"lifecycle.signed_commits"},
+			expParams: [][]any{{targetBranch, enum.MergeMethodSquash}},
+		},
+		{
+			name:      "lifecycle.signed_commits-rebase",
+			def:       DefLifecycle{RequireSignedCommits: true},
+			method:    enum.MergeMethodRebase,
+			verifier:  commitVerifierMock{},
+			expCodes:  []string{"lifecycle.signed_commits"},
+			expParams: [][]any{{targetBranch, enum.MergeMethodRebase}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := MergeVerifyInput{
+				PullReq:        &types.PullReq{TargetBranch: targetBranch},
+				Method:         test.method,
+				CommitVerifier: test.verifier,
+			}
+
+			if err := test.def.Sanitize(); err != nil {
+				t.Errorf("def invalid: %s", err.Error())
+				return
+			}
+
+			violations, err := test.def.MergeVerify(context.Background(), in)
+			if err != nil {
+				t.Errorf("got an error: %s", err.Error())
+				return
+			}
+
+			inspectBranchViolations(t, test.expCodes, test.expParams, violations)
+		})
+	}
+}
+
+type commitVerifierMock []UnverifiedCommit
+
+func (m commitVerifierMock) UnverifiedCommits(context.Context, string) ([]UnverifiedCommit, error) {
+	return m, nil
+}
+
 func inspectBranchViolations(t *testing.T,
 	expCodes []string,
 	expParams [][]any,
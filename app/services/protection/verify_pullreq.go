@@ -43,6 +43,10 @@ type (
 		Method       enum.MergeMethod
 		CheckResults []types.CheckResult
 		CodeOwners   *codeowners.Evaluation
+
+		// CommitVerifier is used to verify signatures of the commits of the pull request
+		// that a fast-forward merge adds to the target branch.
+		CommitVerifier CommitVerifier
 	}
 
 	MergeVerifyOutput struct {
@@ -317,6 +317,23 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 			},
 			expOut: MergeVerifyOutput{},
 		},
+		{
+			name: codePullReqMergeStrategiesAllowed + "-fast-forward-fail",
+			def: DefPullReq{Merge: DefMerge{StrategiesAllowed: []enum.MergeMethod{
+				enum.MergeMethodMerge,
+			}}},
+			in: MergeVerifyInput{
+				Method: enum.MergeMethodFastForward,
+			},
+			expCodes: []string{codePullReqMergeStrategiesAllowed},
+			expParams: [][]any{{
+				enum.MergeMethodFastForward,
+				[]enum.MergeMethod{
+					enum.MergeMethodMerge,
+				}},
+			},
+			expOut: MergeVerifyOutput{},
+		},
 		{
 			name: codePullReqMergeDeleteBranch,
 			def:  DefPullReq{Merge: DefMerge{DeleteBranch: true}},
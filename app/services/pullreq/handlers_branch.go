@@ -57,17 +57,19 @@ func (s *Service) triggerPREventOnBranchUpdate(ctx context.Context,
 		}
 	}
 
-	// TODO: This function is currently executed directly on branch update event.
-	// TODO: But it should be executed after the PR's head ref has been updated.
-	// TODO: This is to make sure the commit exists on the target repository for forked repositories.
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
-		// First check if the merge base has changed
-
 		targetRepo, err := s.repoGitInfoCache.Get(ctx, pr.TargetRepoID)
 		if err != nil {
 			return fmt.Errorf("failed to get repo git info: %w", err)
 		}
 
+		// For forks, make sure the new commits exist in the target repository.
+		if err = s.fetchSourceCommits(ctx, pr.SourceRepoID, targetRepo, event.Payload.NewSHA); err != nil {
+			return err
+		}
+
+		// First check if the merge base has changed
+
 		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
 			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
 			Ref1:       event.Payload.NewSHA,
@@ -279,3 +281,36 @@ func getBranchFromRef(ref string) (string, error) {
 	}
 	return branch, nil
 }
+
+// fetchSourceCommits makes the commits of the source repository available in the target repository.
+// It's a noop for pull requests within the same repository.
+func (s *Service) fetchSourceCommits(ctx context.Context,
+	sourceRepoID int64,
+	targetRepo *types.RepositoryGitInfo,
+	sha string,
+) error {
+	if sourceRepoID == targetRepo.ID {
+		return nil
+	}
+
+	sourceRepo, err := s.repoGitInfoCache.Get(ctx, sourceRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get source repo git info: %w", err)
+	}
+
+	writeParams, err := createSystemRPCWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate rpc write params: %w", err)
+	}
+
+	err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+		WriteParams: writeParams,
+		Source:      sourceRepo.GitUID,
+		ObjectSHAs:  []string{sha},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch commits of source repository: %w", err)
+	}
+
+	return nil
+}
@@ -40,8 +40,8 @@ func (s *Service) createHeadRefOnCreated(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo).
-	// This is because commits from the source repository must be first pulled into the target repository.
+	// NOTE: For forked repos the commits of the source repository are fetched into the target repository
+	// before the event is triggered.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -71,8 +71,8 @@ func (s *Service) updateHeadRefOnBranchUpdate(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	// NOTE: For forked repos the commits of the source repository are fetched into the target repository
+	// before the event is triggered.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
@@ -102,8 +102,8 @@ func (s *Service) updateHeadRefOnReopen(ctx context.Context,
 		return fmt.Errorf("failed to generate rpc write params: %w", err)
 	}
 
-	// TODO: This doesn't work for forked repos (only works when sourceRepo==targetRepo)
-	// This is because commits from the source repository must be first pulled into the target repository.
+	// NOTE: For forked repos the commits of the source repository are fetched into the target repository
+	// before the event is triggered.
 	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
 		WriteParams: writeParams,
 		Name:        strconv.Itoa(int(event.Payload.Number)),
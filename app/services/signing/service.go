@@ -0,0 +1,368 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/42wim/sshsig"
+	"github.com/42wim/sshsig/pem"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	gpgSignatureBeginToken = "-----BEGIN PGP SIGNATURE-----"
+	sshSignatureBeginToken = "-----BEGIN SSH SIGNATURE-----"
+	gpgKeyBeginToken       = "-----BEGIN PGP"
+
+	// sshNamespace is the namespace git uses when it signs objects with SSH keys.
+	sshNamespace = "git"
+)
+
+// ErrInvalidKey is returned by ParseKey if the provided content isn't a valid public key.
+var ErrInvalidKey = errors.New("invalid signing key")
+
+// Service verifies signatures of commits and tags against the signing keys registered by users.
+type Service struct {
+	signingKeyStore store.SigningKeyStore
+	principalStore  store.PrincipalStore
+}
+
+func NewService(
+	signingKeyStore store.SigningKeyStore,
+	principalStore store.PrincipalStore,
+) *Service {
+	return &Service{
+		signingKeyStore: signingKeyStore,
+		principalStore:  principalStore,
+	}
+}
+
+// ParseKey parses an armored GPG public key or an SSH public key in the authorized keys format.
+// The returned key has the type, fingerprint, key ids and the normalized content set.
+func ParseKey(content string) (*types.SigningKey, error) {
+	content = strings.TrimSpace(content)
+
+	if strings.HasPrefix(content, gpgKeyBeginToken) {
+		return parseGPGKey(content)
+	}
+
+	return parseSSHKey(content)
+}
+
+func parseGPGKey(content string) (*types.SigningKey, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	if len(entities) != 1 {
+		return nil, fmt.Errorf("%w: expected exactly one GPG key, got %d", ErrInvalidKey, len(entities))
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey != nil {
+		return nil, fmt.Errorf("%w: a private key is provided, only the public key is required", ErrInvalidKey)
+	}
+
+	keyIDs := make([]string, 0, len(entity.Subkeys)+1)
+	keyIDs = append(keyIDs, entity.PrimaryKey.KeyIdString())
+	for _, subkey := range entity.Subkeys {
+		keyIDs = append(keyIDs, subkey.PublicKey.KeyIdString())
+	}
+
+	// re-encode the key to get rid of any text surrounding the armored key block.
+	buf := &bytes.Buffer{}
+	w, err := armor.Encode(buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create armor encoder: %w", err)
+	}
+	if err = entity.Serialize(w); err != nil {
+		return nil, fmt.Errorf("failed to serialize GPG key: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close armor encoder: %w", err)
+	}
+
+	return &types.SigningKey{
+		Type:        enum.SigningKeyTypeGPG,
+		Fingerprint: fmt.Sprintf("%X", entity.PrimaryKey.Fingerprint),
+		KeyIDs:      keyIDs,
+		Content:     buf.String(),
+	}, nil
+}
+
+func parseSSHKey(content string) (*types.SigningKey, error) {
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(content))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidKey, err)
+	}
+
+	fingerprint := ssh.FingerprintSHA256(key)
+
+	return &types.SigningKey{
+		Type:        enum.SigningKeyTypeSSH,
+		Fingerprint: fingerprint,
+		KeyIDs:      []string{fingerprint},
+		Content:     strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key))),
+	}, nil
+}
+
+// NewVerifier returns a new Verifier. The verifier caches the signing keys and their owners it loads,
+// so it should be used for a single request only.
+func (s *Service) NewVerifier() *Verifier {
+	return &Verifier{
+		service: s,
+		keys:    make(map[string][]*types.SigningKey),
+		users:   make(map[int64]*types.User),
+	}
+}
+
+// Verifier verifies signatures of git objects.
+type Verifier struct {
+	service *Service
+	keys    map[string][]*types.SigningKey
+	users   map[int64]*types.User
+}
+
+// Verify verifies the signature of a git object. The email is the email of the committer (or tagger)
+// and it must match the verified email of the owner of the signing key for the signature to be reported as verified.
+func (v *Verifier) Verify(
+	ctx context.Context,
+	signedData *git.SignedData,
+	email string,
+) (*types.SignatureVerification, error) {
+	if signedData == nil || len(signedData.Signature) == 0 {
+		return &types.SignatureVerification{Status: enum.SignatureVerificationStatusUnsigned}, nil
+	}
+
+	var (
+		keyType enum.SigningKeyType
+		keyID   string
+		verify  func(key *types.SigningKey) error
+	)
+
+	signature := bytes.TrimSpace(signedData.Signature)
+
+	switch {
+	case bytes.HasPrefix(signature, []byte(gpgSignatureBeginToken)):
+		sig, err := readGPGSignature(signature)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to read GPG signature")
+			return &types.SignatureVerification{Status: enum.SignatureVerificationStatusUnverified}, nil
+		}
+
+		keyType = enum.SigningKeyTypeGPG
+		keyID = gpgIssuerKeyID(sig)
+		verify = func(key *types.SigningKey) error {
+			return verifyGPG(key, signedData, sig.CreationTime)
+		}
+
+	case bytes.HasPrefix(signature, []byte(sshSignatureBeginToken)):
+		fingerprint, err := sshSignatureFingerprint(signature)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to read SSH signature")
+			return &types.SignatureVerification{Status: enum.SignatureVerificationStatusUnverified}, nil
+		}
+
+		keyType = enum.SigningKeyTypeSSH
+		keyID = fingerprint
+		verify = func(key *types.SigningKey) error {
+			return sshsig.Verify(bytes.NewReader(signedData.SignedContent), signedData.Signature,
+				[]byte(key.Content), sshNamespace)
+		}
+
+	default:
+		// other signature formats (e.g. x509) aren't supported.
+		return &types.SignatureVerification{Status: enum.SignatureVerificationStatusUnverified}, nil
+	}
+
+	result := &types.SignatureVerification{
+		Status:  enum.SignatureVerificationStatusUnknownKey,
+		KeyType: keyType,
+		KeyID:   keyID,
+	}
+
+	if keyID == "" {
+		return result, nil
+	}
+
+	keys, err := v.findKeys(ctx, keyType, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(keys) == 0 {
+		return result, nil
+	}
+
+	result.Status = enum.SignatureVerificationStatusUnverified
+
+	for _, key := range keys {
+		if err := verify(key); err != nil {
+			continue
+		}
+
+		signer, err := v.findUser(ctx, key.PrincipalID)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Signer = signer.ToPrincipalInfo()
+		result.Status = enum.SignatureVerificationStatusMismatchedEmail
+
+		if !strings.EqualFold(signer.Email, email) {
+			continue
+		}
+
+		// users can change their email at will, only an email the user proved to own is trusted.
+		result.Status = enum.SignatureVerificationStatusUnverifiedEmail
+
+		if signer.EmailVerified {
+			result.Status = enum.SignatureVerificationStatusVerified
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func (v *Verifier) findUser(ctx context.Context, principalID int64) (*types.User, error) {
+	if user, ok := v.users[principalID]; ok {
+		return user, nil
+	}
+
+	user, err := v.service.principalStore.FindUser(ctx, principalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find signing key owner: %w", err)
+	}
+
+	v.users[principalID] = user
+
+	return user, nil
+}
+
+func (v *Verifier) findKeys(
+	ctx context.Context,
+	keyType enum.SigningKeyType,
+	keyID string,
+) ([]*types.SigningKey, error) {
+	if keys, ok := v.keys[keyID]; ok {
+		return keys, nil
+	}
+
+	allKeys, err := v.service.signingKeyStore.ListByKeyID(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys by key id: %w", err)
+	}
+
+	keys := make([]*types.SigningKey, 0, len(allKeys))
+	for _, key := range allKeys {
+		if key.Type == keyType {
+			keys = append(keys, key)
+		}
+	}
+
+	v.keys[keyID] = keys
+
+	return keys, nil
+}
+
+func readGPGSignature(signature []byte) (*packet.Signature, error) {
+	block, err := armor.Decode(bytes.NewReader(signature))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode armored signature: %w", err)
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signature packet: %w", err)
+	}
+
+	sig, ok := p.(*packet.Signature)
+	if !ok {
+		return nil, fmt.Errorf("unexpected packet type %T", p)
+	}
+
+	return sig, nil
+}
+
+// gpgIssuerKeyID returns the id of the key that created the signature in the same format
+// as it's stored in the signing key ids (16 upper case hex characters).
+func gpgIssuerKeyID(sig *packet.Signature) string {
+	if sig.IssuerKeyId != nil {
+		return fmt.Sprintf("%016X", *sig.IssuerKeyId)
+	}
+
+	// the key id of a v4 key are the last 8 bytes of its fingerprint.
+	if len(sig.IssuerFingerprint) >= 8 {
+		return fmt.Sprintf("%016X", binary.BigEndian.Uint64(sig.IssuerFingerprint[len(sig.IssuerFingerprint)-8:]))
+	}
+
+	return ""
+}
+
+func verifyGPG(key *types.SigningKey, signedData *git.SignedData, signedAt time.Time) error {
+	keyRing, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.Content))
+	if err != nil {
+		return fmt.Errorf("failed to read GPG key: %w", err)
+	}
+
+	// the signature is verified at the time it was created, so that the signatures
+	// created before the key expired are still considered valid.
+	config := &packet.Config{
+		Time: func() time.Time { return signedAt },
+	}
+
+	_, err = openpgp.CheckArmoredDetachedSignature(keyRing,
+		bytes.NewReader(signedData.SignedContent), bytes.NewReader(signedData.Signature), config)
+
+	return err
+}
+
+// sshSignatureFingerprint returns the fingerprint of the public key embedded in the SSH signature.
+func sshSignatureFingerprint(signature []byte) (string, error) {
+	block, _ := pem.Decode(signature)
+	if block == nil {
+		return "", errors.New("failed to decode armored signature")
+	}
+
+	sig := sshsig.WrappedSig{}
+	if err := ssh.Unmarshal(block.Bytes, &sig); err != nil {
+		return "", fmt.Errorf("failed to unmarshal signature: %w", err)
+	}
+
+	publicKey, err := ssh.ParsePublicKey([]byte(sig.PublicKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse signature public key: %w", err)
+	}
+
+	return ssh.FingerprintSHA256(publicKey), nil
+}
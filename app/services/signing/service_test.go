@@ -0,0 +1,180 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signing
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/42wim/sshsig"
+	"golang.org/x/crypto/ssh"
+)
+
+type keyStoreMock struct {
+	store.SigningKeyStore
+	keys []*types.SigningKey
+}
+
+func (s keyStoreMock) ListByKeyID(_ context.Context, keyID string) ([]*types.SigningKey, error) {
+	var keys []*types.SigningKey
+	for _, key := range s.keys {
+		for _, id := range key.KeyIDs {
+			if id == keyID {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+type principalStoreMock struct {
+	store.PrincipalStore
+	users map[int64]*types.User
+}
+
+func (s principalStoreMock) FindUser(_ context.Context, id int64) (*types.User, error) {
+	return s.users[id], nil
+}
+
+func TestVerifySSH(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %s", err)
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("failed to create ssh public key: %s", err)
+	}
+
+	pkcs8Key, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %s", err)
+	}
+
+	key, err := ParseKey(string(ssh.MarshalAuthorizedKey(sshPublicKey)))
+	if err != nil {
+		t.Fatalf("failed to parse key: %s", err)
+	}
+	if key.Type != enum.SigningKeyTypeSSH {
+		t.Fatalf("expected key type %s, got %s", enum.SigningKeyTypeSSH, key.Type)
+	}
+	key.PrincipalID = 42
+
+	content := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n\nmessage\n")
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8Key})
+
+	signature, err := sshsig.Sign(privateKeyPEM, bytes.NewReader(content), sshNamespace)
+	if err != nil {
+		t.Fatalf("failed to sign: %s", err)
+	}
+
+	service := NewService(
+		keyStoreMock{keys: []*types.SigningKey{key}},
+		principalStoreMock{users: map[int64]*types.User{
+			42: {ID: 42, Email: "john@example.com", EmailVerified: true},
+		}},
+	)
+
+	tests := []struct {
+		name   string
+		data   *git.SignedData
+		email  string
+		status enum.SignatureVerificationStatus
+	}{
+		{
+			name:   "unsigned",
+			data:   nil,
+			status: enum.SignatureVerificationStatusUnsigned,
+		},
+		{
+			name:   "verified",
+			data:   &git.SignedData{Signature: signature, SignedContent: content},
+			email:  "John@Example.com",
+			status: enum.SignatureVerificationStatusVerified,
+		},
+		{
+			name:   "mismatched-email",
+			data:   &git.SignedData{Signature: signature, SignedContent: content},
+			email:  "jane@example.com",
+			status: enum.SignatureVerificationStatusMismatchedEmail,
+		},
+		{
+			name:   "modified-content",
+			data:   &git.SignedData{Signature: signature, SignedContent: append(content, 'x')},
+			email:  "john@example.com",
+			status: enum.SignatureVerificationStatusUnverified,
+		},
+		{
+			name:   "unknown-signature-format",
+			data:   &git.SignedData{Signature: []byte("-----BEGIN SIGNED MESSAGE-----"), SignedContent: content},
+			email:  "john@example.com",
+			status: enum.SignatureVerificationStatusUnverified,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := service.NewVerifier().Verify(context.Background(), test.data, test.email)
+			if err != nil {
+				t.Fatalf("failed to verify: %s", err)
+			}
+
+			if result.Status != test.status {
+				t.Errorf("expected status %s, got %s", test.status, result.Status)
+			}
+		})
+	}
+
+	service = NewService(
+		keyStoreMock{keys: []*types.SigningKey{key}},
+		principalStoreMock{users: map[int64]*types.User{
+			42: {ID: 42, Email: "john@example.com", EmailVerified: false},
+		}},
+	)
+
+	result, err := service.NewVerifier().Verify(context.Background(),
+		&git.SignedData{Signature: signature, SignedContent: content}, "john@example.com")
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if result.Status != enum.SignatureVerificationStatusUnverifiedEmail {
+		t.Errorf("expected status %s, got %s", enum.SignatureVerificationStatusUnverifiedEmail, result.Status)
+	}
+
+	service = NewService(keyStoreMock{}, principalStoreMock{})
+
+	result, err = service.NewVerifier().Verify(context.Background(),
+		&git.SignedData{Signature: signature, SignedContent: content}, "john@example.com")
+	if err != nil {
+		t.Fatalf("failed to verify: %s", err)
+	}
+	if result.Status != enum.SignatureVerificationStatusUnknownKey {
+		t.Errorf("expected status %s, got %s", enum.SignatureVerificationStatusUnknownKey, result.Status)
+	}
+	if result.KeyID != key.Fingerprint {
+		t.Errorf("expected key id %s, got %s", key.Fingerprint, result.KeyID)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trigger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/bootstrap"
+	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/job"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/drone/go-scm/scm"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeCron        = "gitness:trigger:cron"
+	jobCronCron        = "* * * * *" // Every minute.
+	jobMaxDurationCron = 5 * time.Minute
+)
+
+// Register schedules the recurring job that fires all due cron triggers.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(ctx, jobTypeCron, jobTypeCron, jobCronCron, jobMaxDurationCron)
+	if err != nil {
+		return fmt.Errorf("failed to schedule cron trigger job: %w", err)
+	}
+
+	return nil
+}
+
+// Handle fires all cron triggers that are due. It's executed every minute by the job scheduler.
+func (s *Service) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	now := time.Now()
+
+	triggers, err := s.triggerStore.ListDueCron(ctx, now.UnixMilli())
+	if err != nil {
+		return "", fmt.Errorf("failed to list due cron triggers: %w", err)
+	}
+
+	fired := 0
+	for _, t := range triggers {
+		ok, err := s.triggerCron(ctx, t, now)
+		if err != nil {
+			// a single broken trigger shouldn't block the others.
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("trigger.id", t.ID).
+				Int64("pipeline.id", t.PipelineID).
+				Msg("failed to fire cron trigger")
+			continue
+		}
+		if ok {
+			fired++
+		}
+	}
+
+	return fmt.Sprintf("fired %d of %d due cron triggers", fired, len(triggers)), nil
+}
+
+// triggerCron moves the next run of the trigger forward and fires it.
+// It returns false in case the trigger was claimed by someone else in the meantime or the pipeline is disabled.
+func (s *Service) triggerCron(ctx context.Context, t *types.Trigger, now time.Time) (bool, error) {
+	// the next run is updated before triggering the execution to guarantee that
+	// a failing pipeline isn't retriggered every minute.
+	next, err := triggerer.NextCronRun(t.Cron, t.Timezone, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to calculate next run: %w", err)
+	}
+
+	err = s.triggerStore.UpdateNextRun(ctx, t.ID, t.NextRun, next.UnixMilli())
+	if errors.Is(err, gitness_store.ErrVersionConflict) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to update next run: %w", err)
+	}
+
+	pipeline, err := s.pipelineStore.Find(ctx, t.PipelineID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find pipeline: %w", err)
+	}
+
+	if pipeline.Disabled {
+		return false, nil
+	}
+
+	repo, err := s.repoStore.Find(ctx, pipeline.RepoID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find repo: %w", err)
+	}
+
+	branch := pipeline.DefaultBranch
+	if branch == "" {
+		branch = repo.DefaultBranch
+	}
+	ref := scm.ExpandRef(branch, "refs/heads")
+
+	commit, err := s.commitSvc.FindRef(ctx, repo, ref)
+	if err != nil {
+		return false, fmt.Errorf("failed to find commit of branch %q: %w", branch, err)
+	}
+
+	hook := &triggerer.Hook{
+		Trigger:     enum.TriggerCron,
+		Action:      enum.TriggerActionCron,
+		Cron:        t.UID,
+		TriggeredBy: bootstrap.NewSystemServiceSession().Principal.ID,
+		Ref:         ref,
+		Before:      commit.SHA,
+		After:       commit.SHA,
+		Source:      branch,
+		Target:      branch,
+		AuthorLogin: commit.Author.Identity.Name,
+		AuthorName:  commit.Author.Identity.Name,
+		AuthorEmail: commit.Author.Identity.Email,
+		Title:       commit.Title,
+		Message:     commit.Message,
+		Timestamp:   commit.Committer.When.UnixMilli(),
+		Params:      map[string]string{},
+	}
+
+	_, err = s.triggerSvc.Trigger(ctx, pipeline, hook)
+	if err != nil {
+		return false, fmt.Errorf("failed to trigger execution: %w", err)
+	}
+
+	return true, nil
+}
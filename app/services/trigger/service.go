@@ -24,6 +24,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/triggerer"
+	"github.com/harness/gitness/app/services/job"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/stream"
@@ -67,6 +68,7 @@ type Service struct {
 	pipelineStore store.PipelineStore
 	triggerSvc    triggerer.Triggerer
 	commitSvc     commit.Service
+	scheduler     *job.Scheduler
 }
 
 func New(
@@ -78,6 +80,8 @@ func New(
 	pipelineStore store.PipelineStore,
 	triggerSvc triggerer.Triggerer,
 	commitSvc commit.Service,
+	scheduler *job.Scheduler,
+	executor *job.Executor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
 ) (*Service, error) {
@@ -92,9 +96,15 @@ func New(
 		commitSvc:     commitSvc,
 		pipelineStore: pipelineStore,
 		triggerSvc:    triggerSvc,
+		scheduler:     scheduler,
 	}
 
-	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+	err := executor.Register(jobTypeCron, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register cron trigger job handler: %w", err)
+	}
+
+	_, err = gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
 		func(r *gitevents.Reader) error {
 			const idleTimeout = 1 * time.Minute
 			r.Configure(
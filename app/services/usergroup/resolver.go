@@ -16,20 +16,79 @@ package usergroup
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
 	"github.com/harness/gitness/types"
 )
 
 var _ Resolver = (*GitnessResolver)(nil)
 
 type GitnessResolver struct {
+	spaceStore           store.SpaceStore
+	userGroupStore       store.UserGroupStore
+	userGroupMemberStore store.UserGroupMemberStore
 }
 
-func NewGitnessResolver() *GitnessResolver {
-	return &GitnessResolver{}
+func NewGitnessResolver(
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+) *GitnessResolver {
+	return &GitnessResolver{
+		spaceStore:           spaceStore,
+		userGroupStore:       userGroupStore,
+		userGroupMemberStore: userGroupMemberStore,
+	}
 }
 
-func (s *GitnessResolver) Resolve(context.Context, string) (*types.UserGroup, error) {
-	// todo: implement once usergroup is supported
+// Resolve resolves the user group with the scoped id "<space path>/<user group uid>".
+// User groups are visible in all descendants of their space, hence the group is searched in the
+// provided space first and then in its ancestors - the group of the nearest space wins.
+func (s *GitnessResolver) Resolve(ctx context.Context, scopedID string) (*types.UserGroup, error) {
+	spacePath, uid, err := paths.DisectLeaf(scopedID)
+	if err != nil || spacePath == "" {
+		return nil, ErrNotFound
+	}
+
+	space, err := s.spaceStore.FindByRef(ctx, spacePath)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find space %q: %w", spacePath, err)
+	}
+
+	// limit the depth to be safe (e.g. root/space1/space2 => maxDepth of 3)
+	maxDepth := len(paths.Segments(space.Path))
+
+	for depth := 0; depth < maxDepth; depth++ {
+		userGroup, err := s.userGroupStore.Find(ctx, space.ID, uid)
+		if err == nil {
+			userGroup.Users, err = s.userGroupMemberStore.ListPrincipalUIDs(ctx, userGroup.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to list members of user group: %w", err)
+			}
+
+			return userGroup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find user group: %w", err)
+		}
+
+		if space.ParentID == 0 {
+			break
+		}
+
+		parentID := space.ParentID
+		space, err = s.spaceStore.Find(ctx, parentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent space with id %d: %w", parentID, err)
+		}
+	}
+
 	return nil, ErrNotFound
 }
@@ -0,0 +1,175 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package usergroup
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+)
+
+var errTestStore = errors.New("store failure")
+
+type spaceStoreMock struct {
+	store.SpaceStore
+	spaces []*types.Space
+	// failID is the id of the space for which Find fails.
+	failID int64
+}
+
+func (s spaceStoreMock) Find(_ context.Context, id int64) (*types.Space, error) {
+	if id == s.failID {
+		return nil, errTestStore
+	}
+	for _, space := range s.spaces {
+		if space.ID == id {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s spaceStoreMock) FindByRef(_ context.Context, spaceRef string) (*types.Space, error) {
+	for _, space := range s.spaces {
+		if space.Path == spaceRef {
+			return space, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type userGroupStoreMock struct {
+	store.UserGroupStore
+	userGroups []*types.UserGroup
+}
+
+func (s userGroupStoreMock) Find(_ context.Context, spaceID int64, uid string) (*types.UserGroup, error) {
+	for _, userGroup := range s.userGroups {
+		if userGroup.SpaceID == spaceID && userGroup.UID == uid {
+			found := *userGroup
+			return &found, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type userGroupMemberStoreMock struct {
+	store.UserGroupMemberStore
+	members map[int64][]string
+}
+
+func (s userGroupMemberStoreMock) ListPrincipalUIDs(_ context.Context, userGroupID int64) ([]string, error) {
+	return s.members[userGroupID], nil
+}
+
+func TestGitnessResolver_Resolve(t *testing.T) {
+	spaces := []*types.Space{
+		{ID: 1, Path: "root"},
+		{ID: 2, ParentID: 1, Path: "root/team"},
+		{ID: 3, ParentID: 2, Path: "root/team/sub"},
+	}
+	userGroups := []*types.UserGroup{
+		{ID: 10, SpaceID: 1, UID: "admins"},
+		{ID: 11, SpaceID: 2, UID: "devs"},
+		{ID: 12, SpaceID: 3, UID: "admins"},
+	}
+	members := map[int64][]string{
+		10: {"root-admin"},
+		11: {"alice", "bob"},
+		12: {"sub-admin"},
+	}
+
+	tests := []struct {
+		name      string
+		scopedID  string
+		failID    int64
+		wantID    int64
+		wantUsers []string
+		wantErr   error
+	}{
+		{
+			name:      "current-space",
+			scopedID:  "root/team/devs",
+			wantID:    11,
+			wantUsers: []string{"alice", "bob"},
+		},
+		{
+			name:      "ancestor-space",
+			scopedID:  "root/team/sub/devs",
+			wantID:    11,
+			wantUsers: []string{"alice", "bob"},
+		},
+		{
+			name:      "nearest-space-wins",
+			scopedID:  "root/team/sub/admins",
+			wantID:    12,
+			wantUsers: []string{"sub-admin"},
+		},
+		{
+			name:     "not-found",
+			scopedID: "root/team/sub/ops",
+			wantErr:  ErrNotFound,
+		},
+		{
+			name:     "space-not-found",
+			scopedID: "other/devs",
+			wantErr:  ErrNotFound,
+		},
+		{
+			name:     "no-space",
+			scopedID: "devs",
+			wantErr:  ErrNotFound,
+		},
+		{
+			name:     "parent-store-error",
+			scopedID: "root/team/sub/ops",
+			failID:   2,
+			wantErr:  errTestStore,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := NewGitnessResolver(
+				spaceStoreMock{spaces: spaces, failID: test.failID},
+				userGroupStoreMock{userGroups: userGroups},
+				userGroupMemberStoreMock{members: members},
+			)
+
+			userGroup, err := resolver.Resolve(context.Background(), test.scopedID)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatalf("expected error %v, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to resolve: %v", err)
+			}
+
+			if userGroup.ID != test.wantID {
+				t.Errorf("expected user group %d, got %d", test.wantID, userGroup.ID)
+			}
+			if !reflect.DeepEqual(userGroup.Users, test.wantUsers) {
+				t.Errorf("expected users %v, got %v", test.wantUsers, userGroup.Users)
+			}
+		})
+	}
+}
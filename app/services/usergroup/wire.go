@@ -15,6 +15,8 @@
 package usergroup
 
 import (
+	"github.com/harness/gitness/app/store"
+
 	"github.com/google/wire"
 )
 
@@ -23,6 +25,10 @@ var WireSet = wire.NewSet(
 	ProvideUserGroupResolver,
 )
 
-func ProvideUserGroupResolver() Resolver {
-	return NewGitnessResolver()
+func ProvideUserGroupResolver(
+	spaceStore store.SpaceStore,
+	userGroupStore store.UserGroupStore,
+	userGroupMemberStore store.UserGroupMemberStore,
+) Resolver {
+	return NewGitnessResolver(spaceStore, userGroupStore, userGroupMemberStore)
 }
@@ -19,6 +19,8 @@ import (
 	"github.com/harness/gitness/app/services/job"
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
@@ -38,6 +40,8 @@ type Services struct {
 	MetricCollector *metric.Collector
 	Cleanup         *cleanup.Service
 	Keywordsearch   *keywordsearch.Service
+	Notification    *notification.Service
+	Mirror          *mirror.Service
 }
 
 func ProvideServices(
@@ -48,6 +52,8 @@ func ProvideServices(
 	metricCollector *metric.Collector,
 	cleanupSvc *cleanup.Service,
 	keywordsearchSvc *keywordsearch.Service,
+	notificationSvc *notification.Service,
+	mirrorSvc *mirror.Service,
 ) Services {
 	return Services{
 		Webhook:         webhooksSvc,
@@ -57,5 +63,7 @@ func ProvideServices(
 		MetricCollector: metricCollector,
 		Cleanup:         cleanupSvc,
 		Keywordsearch:   keywordsearchSvc,
+		Notification:    notificationSvc,
+		Mirror:          mirrorSvc,
 	}
 }
@@ -202,6 +202,12 @@ type (
 
 		// List returns a list of repos in a space.
 		List(ctx context.Context, parentID int64, opts *types.RepoFilter) ([]*types.Repository, error)
+
+		// ListForks returns a list of all repos that are forks of the repo.
+		ListForks(ctx context.Context, forkID int64) ([]*types.Repository, error)
+
+		// ListAll lists all repos ordered by ID, starting after the provided repo ID.
+		ListAll(ctx context.Context, afterID int64, limit int) ([]*types.Repository, error)
 	}
 
 	// RepoGitInfoView defines the repository GitUID view.
@@ -247,6 +253,57 @@ type (
 		Count(ctx context.Context, principalID int64, tokenType enum.TokenType) (int64, error)
 	}
 
+	// PublicKeyStore defines the public key data storage.
+	PublicKeyStore interface {
+		// Find finds the public key by id.
+		Find(ctx context.Context, id int64) (*types.PublicKey, error)
+
+		// FindByUID finds the public key by principal id and public key UID.
+		FindByUID(ctx context.Context, principalID int64, uid string) (*types.PublicKey, error)
+
+		// FindByFingerprint finds the public key by its fingerprint.
+		FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error)
+
+		// Create saves the public key details.
+		Create(ctx context.Context, key *types.PublicKey) error
+
+		// DeleteByUID deletes the public key with the given UID of the principal.
+		DeleteByUID(ctx context.Context, principalID int64, uid string) error
+
+		// Count returns the number of public keys of the principal.
+		Count(ctx context.Context, principalID int64, filter *types.PublicKeyFilter) (int64, error)
+
+		// List returns the public keys of the principal.
+		List(ctx context.Context, principalID int64, filter *types.PublicKeyFilter) ([]types.PublicKey, error)
+	}
+
+	// SigningKeyStore defines the data storage of the keys used to verify signatures of commits and tags.
+	SigningKeyStore interface {
+		// FindByUID finds the signing key by principal id and signing key UID.
+		FindByUID(ctx context.Context, principalID int64, uid string) (*types.SigningKey, error)
+
+		// ListByKeyID returns all signing keys that can be referenced by the key id.
+		ListByKeyID(ctx context.Context, keyID string) ([]*types.SigningKey, error)
+
+		// List returns the signing keys of the principal.
+		List(ctx context.Context, principalID int64) ([]*types.SigningKey, error)
+
+		// Create saves the signing key together with its key ids.
+		Create(ctx context.Context, key *types.SigningKey) error
+
+		// DeleteByUID deletes the signing key with the given UID of the principal.
+		DeleteByUID(ctx context.Context, principalID int64, uid string) error
+	}
+
+	// OIDCIdentityStore defines the data storage of the identities of principals at the OIDC identity provider.
+	OIDCIdentityStore interface {
+		// Find finds the identity by the issuer and the subject.
+		Find(ctx context.Context, issuer, subject string) (*types.OIDCIdentity, error)
+
+		// Create saves the identity.
+		Create(ctx context.Context, identity *types.OIDCIdentity) error
+	}
+
 	// PullReqStore defines the pull request data storage.
 	PullReqStore interface {
 		// Find the pull request by id.
@@ -284,6 +341,9 @@ type (
 
 		// List returns a list of pull requests in a space.
 		List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error)
+
+		// ContainsText returns whether the description of any pull request of the target repo contains the text.
+		ContainsText(ctx context.Context, repoID int64, text string) (bool, error)
 	}
 
 	PullReqActivityStore interface {
@@ -315,6 +375,9 @@ type (
 
 		// List returns a list of pull request activities in a pull request (a timeline).
 		List(ctx context.Context, prID int64, opts *types.PullReqActivityFilter) ([]*types.PullReqActivity, error)
+
+		// ContainsText returns whether the text of any pull request activity of the repo contains the text.
+		ContainsText(ctx context.Context, repoID int64, text string) (bool, error)
 	}
 
 	// CodeCommentView is to manipulate only code-comment subset of PullReqActivity.
@@ -541,6 +604,9 @@ type (
 		// It also returns latest build information for all the returned entries.
 		ListLatest(ctx context.Context, repoID int64, pagination types.ListQueryFilter) ([]*types.Pipeline, error)
 
+		// ListAll lists the pipelines of all repositories ordered by ID, starting after the provided pipeline ID.
+		ListAll(ctx context.Context, afterID int64, limit int) ([]*types.Pipeline, error)
+
 		// UpdateOptLock updates the pipeline using the optimistic locking mechanism.
 		UpdateOptLock(ctx context.Context, pipeline *types.Pipeline,
 			mutateFn func(pipeline *types.Pipeline) error) (*types.Pipeline, error)
@@ -589,6 +655,10 @@ type (
 
 		// ListAll lists all the secrets in a given space.
 		ListAll(ctx context.Context, parentID int64) ([]*types.Secret, error)
+
+		// ListAllInherited lists all the secrets visible in a given space, including the ones
+		// defined in its ancestors. In case of conflicting uids the secret of the nearest space wins.
+		ListAllInherited(ctx context.Context, spaceID int64) ([]*types.Secret, error)
 	}
 
 	ExecutionStore interface {
@@ -641,6 +711,9 @@ type (
 	}
 
 	StepStore interface {
+		// Find returns a step from the datastore by ID.
+		Find(ctx context.Context, stepID int64) (*types.Step, error)
+
 		// FindByNumber returns a step from the datastore by number.
 		FindByNumber(ctx context.Context, stageID int64, stepNum int) (*types.Step, error)
 
@@ -738,6 +811,12 @@ type (
 		// ListAllEnabled lists all enabled triggers for a given repo without pagination.
 		// It's used only internally to trigger builds.
 		ListAllEnabled(ctx context.Context, repoID int64) ([]*types.Trigger, error)
+
+		// ListDueCron lists all enabled cron triggers which are due to run at the provided time (unix millis).
+		ListDueCron(ctx context.Context, now int64) ([]*types.Trigger, error)
+
+		// UpdateNextRun updates the next run of a cron trigger in case it still has the expected next run.
+		UpdateNextRun(ctx context.Context, id int64, expectedNextRun, nextRun int64) error
 	}
 
 	PluginStore interface {
@@ -764,5 +843,220 @@ type (
 	UserGroupStore interface {
 		// Find returns a types.UserGroup given a space ID and uid.
 		Find(ctx context.Context, spaceID int64, uid string) (*types.UserGroup, error)
+
+		// FindByID returns a types.UserGroup given its ID.
+		FindByID(ctx context.Context, id int64) (*types.UserGroup, error)
+
+		// Create creates a new user group.
+		Create(ctx context.Context, userGroup *types.UserGroup) error
+
+		// Update updates the user group details.
+		Update(ctx context.Context, userGroup *types.UserGroup) error
+
+		// Delete deletes the user group with the given ID.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the user groups of a space.
+		List(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) ([]*types.UserGroup, error)
+
+		// Count counts the user groups of a space.
+		Count(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) (int64, error)
+	}
+
+	UserGroupMemberStore interface {
+		// Find finds the membership of a principal in a user group.
+		Find(ctx context.Context, userGroupID, principalID int64) (*types.UserGroupMember, error)
+
+		// Create adds a principal to a user group.
+		Create(ctx context.Context, member *types.UserGroupMember) error
+
+		// Delete removes a principal from a user group.
+		Delete(ctx context.Context, userGroupID, principalID int64) error
+
+		// List lists the members of a user group.
+		List(ctx context.Context, userGroupID int64, filter *types.ListQueryFilter) ([]*types.UserGroupMember, error)
+
+		// Count counts the members of a user group.
+		Count(ctx context.Context, userGroupID int64, filter *types.ListQueryFilter) (int64, error)
+
+		// ListPrincipalUIDs lists the UIDs of all members of a user group.
+		ListPrincipalUIDs(ctx context.Context, userGroupID int64) ([]string, error)
+
+		// ListPrincipalIDs lists the IDs of all members of a user group.
+		ListPrincipalIDs(ctx context.Context, userGroupID int64) ([]int64, error)
+	}
+
+	// MembershipUserGroupStore defines the data storage of space memberships of user groups.
+	MembershipUserGroupStore interface {
+		Find(ctx context.Context, key types.MembershipUserGroupKey) (*types.MembershipUserGroup, error)
+		Create(ctx context.Context, membership *types.MembershipUserGroup) error
+		Update(ctx context.Context, membership *types.MembershipUserGroup) error
+		Delete(ctx context.Context, key types.MembershipUserGroupKey) error
+		Count(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) (int64, error)
+		List(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) ([]*types.MembershipUserGroup, error)
+
+		// ListRoles lists the roles the principal inherits in the space via its user groups.
+		ListRoles(ctx context.Context, spaceID int64, principalID int64) ([]enum.MembershipRole, error)
+	}
+
+	// RunnerStore defines the data storage of external build runners.
+	RunnerStore interface {
+		// Find finds the runner by id.
+		Find(ctx context.Context, id int64) (*types.Runner, error)
+
+		// FindByUID finds the runner by uid.
+		FindByUID(ctx context.Context, uid string) (*types.Runner, error)
+
+		// FindByTokenHash finds the runner by the hash of its registration token.
+		FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error)
+
+		// Create creates a new runner.
+		Create(ctx context.Context, runner *types.Runner) error
+
+		// UpdateHeartbeat updates the heartbeat information of the runner.
+		UpdateHeartbeat(ctx context.Context, id int64, heartbeat types.RunnerHeartbeat) error
+
+		// Delete deletes the runner with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists the runners.
+		List(ctx context.Context, filter *types.ListQueryFilter) ([]*types.Runner, error)
+
+		// Count counts the runners.
+		Count(ctx context.Context, filter *types.ListQueryFilter) (int64, error)
+	}
+
+	// ArtifactStore defines the data storage of pipeline artifacts (the content is kept in the blob store).
+	ArtifactStore interface {
+		// Find finds the artifact by id.
+		Find(ctx context.Context, id int64) (*types.Artifact, error)
+
+		// Upsert creates a new artifact or replaces the artifact with the same name of the stage.
+		Upsert(ctx context.Context, artifact *types.Artifact) error
+
+		// List returns all artifacts of an execution.
+		List(ctx context.Context, executionID int64) ([]*types.Artifact, error)
+
+		// ListExpired returns up to limit artifacts that expired before the provided time (unix milliseconds).
+		ListExpired(ctx context.Context, expiredBefore int64, limit int) ([]*types.Artifact, error)
+
+		// Delete deletes the artifact with the given id.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// PipelineCacheStore defines the data storage of pipeline caches (the content is kept in the blob store).
+	PipelineCacheStore interface {
+		// Find finds the cache entry with the key of the branch.
+		Find(ctx context.Context, repoID int64, branch string, key string) (*types.PipelineCache, error)
+
+		// Upsert creates a new cache entry or replaces the entry with the same key of the branch.
+		Upsert(ctx context.Context, cache *types.PipelineCache) error
+
+		// Touch updates the time the cache entry was last used (unix milliseconds).
+		Touch(ctx context.Context, id int64, lastUsed int64) error
+
+		// ListUnused returns up to limit cache entries that weren't used since the provided time (unix milliseconds).
+		ListUnused(ctx context.Context, usedBefore int64, limit int) ([]*types.PipelineCache, error)
+
+		// Delete deletes the cache entry with the given id.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// PullMirrorStore defines the data storage of pull mirrors (at most one per repository).
+	PullMirrorStore interface {
+		// Find finds the pull mirror of the repository.
+		Find(ctx context.Context, repoID int64) (*types.PullMirror, error)
+
+		// Create creates a new pull mirror.
+		Create(ctx context.Context, mirror *types.PullMirror) error
+
+		// Update updates the pull mirror.
+		Update(ctx context.Context, mirror *types.PullMirror) error
+
+		// UpdateOptLock updates the pull mirror using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, mirror *types.PullMirror,
+			mutateFn func(mirror *types.PullMirror) error) (*types.PullMirror, error)
+
+		// Delete deletes the pull mirror of the repository.
+		Delete(ctx context.Context, repoID int64) error
+
+		// ListDue returns up to limit pull mirrors whose next sync is due at the provided time (unix milliseconds).
+		ListDue(ctx context.Context, now int64, limit int) ([]*types.PullMirror, error)
+	}
+
+	// PushMirrorStore defines the data storage of push mirrors.
+	PushMirrorStore interface {
+		// Find finds the push mirror by id.
+		Find(ctx context.Context, id int64) (*types.PushMirror, error)
+
+		// List returns all push mirrors of the repository.
+		List(ctx context.Context, repoID int64) ([]*types.PushMirror, error)
+
+		// Create creates a new push mirror.
+		Create(ctx context.Context, mirror *types.PushMirror) error
+
+		// Update updates the push mirror.
+		Update(ctx context.Context, mirror *types.PushMirror) error
+
+		// UpdateOptLock updates the push mirror using the optimistic locking mechanism.
+		UpdateOptLock(ctx context.Context, mirror *types.PushMirror,
+			mutateFn func(mirror *types.PushMirror) error) (*types.PushMirror, error)
+
+		// Delete deletes the push mirror with the given id.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// LFSObjectStore defines the data storage of the Git LFS objects of repositories.
+	LFSObjectStore interface {
+		// Find finds the LFS object of the repository with the given oid.
+		Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error)
+
+		// FindMany returns all LFS objects of the repository with one of the given oids.
+		FindMany(ctx context.Context, repoID int64, oids []string) ([]*types.LFSObject, error)
+
+		// Create creates a new LFS object.
+		Create(ctx context.Context, obj *types.LFSObject) error
+
+		// Usage returns the number and total size of the LFS objects of the repository.
+		Usage(ctx context.Context, repoID int64) (types.LFSUsage, error)
+	}
+
+	// LFSLockStore defines the data storage of the Git LFS file locks of repositories.
+	LFSLockStore interface {
+		// Find finds the LFS lock by id.
+		Find(ctx context.Context, id int64) (*types.LFSLock, error)
+
+		// FindByPath finds the LFS lock of the file of the repository.
+		FindByPath(ctx context.Context, repoID int64, path string) (*types.LFSLock, error)
+
+		// List returns the LFS locks of the repository, ordered by id.
+		List(ctx context.Context, repoID int64, filter *types.LFSLockFilter) ([]*types.LFSLock, error)
+
+		// Create creates a new LFS lock.
+		Create(ctx context.Context, lock *types.LFSLock) error
+
+		// Delete deletes the LFS lock with the given id.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// ApprovalStore defines the data storage of the decisions on approval stages of executions.
+	ApprovalStore interface {
+		// Create creates a new approval.
+		Create(ctx context.Context, approval *types.Approval) error
+
+		// List returns all approvals of an execution.
+		List(ctx context.Context, executionID int64) ([]*types.Approval, error)
+
+		// Delete deletes the approval.
+		Delete(ctx context.Context, id int64) error
+	}
+
+	// NotificationSettingsStore defines the data storage of the notification settings of users.
+	NotificationSettingsStore interface {
+		// Find returns the notification settings of the principal.
+		Find(ctx context.Context, principalID int64) (*types.NotificationSettings, error)
+
+		// Upsert creates or updates the notification settings of the principal.
+		Upsert(ctx context.Context, settings *types.NotificationSettings) error
 	}
 )
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ApprovalStore = (*ApprovalStore)(nil)
+
+// NewApprovalStore returns a new ApprovalStore.
+func NewApprovalStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *ApprovalStore {
+	return &ApprovalStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// ApprovalStore implements a store.ApprovalStore backed by a relational database.
+type ApprovalStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type approval struct {
+	ID          int64                 `db:"approval_id"`
+	ExecutionID int64                 `db:"approval_execution_id"`
+	StageID     int64                 `db:"approval_stage_id"`
+	Decision    enum.ApprovalDecision `db:"approval_decision"`
+	Comment     string                `db:"approval_comment"`
+	CreatedBy   int64                 `db:"approval_created_by"`
+	Created     int64                 `db:"approval_created"`
+}
+
+const (
+	approvalColumns = `
+		 approval_id
+		,approval_execution_id
+		,approval_stage_id
+		,approval_decision
+		,approval_comment
+		,approval_created_by
+		,approval_created`
+
+	approvalSelectBase = `
+		SELECT` + approvalColumns + `
+		FROM approvals`
+)
+
+// Create creates a new approval. A stage can only be decided once,
+// a second approval of the same stage fails with a duplicate error.
+func (s *ApprovalStore) Create(ctx context.Context, approval *types.Approval) error {
+	const sqlQuery = `
+		INSERT INTO approvals (
+			 approval_execution_id
+			,approval_stage_id
+			,approval_decision
+			,approval_comment
+			,approval_created_by
+			,approval_created
+		) values (
+			 :approval_execution_id
+			,:approval_stage_id
+			,:approval_decision
+			,:approval_comment
+			,:approval_created_by
+			,:approval_created
+		) RETURNING approval_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalApproval(approval))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind approval object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&approval.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert approval query failed")
+	}
+
+	return nil
+}
+
+// List returns all approvals of an execution.
+func (s *ApprovalStore) List(ctx context.Context, executionID int64) ([]*types.Approval, error) {
+	const sqlQuery = approvalSelectBase + `
+		WHERE approval_execution_id = $1
+		ORDER BY approval_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*approval, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, executionID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list approvals")
+	}
+
+	return s.mapSliceApproval(ctx, dst)
+}
+
+// Delete deletes the approval.
+func (s *ApprovalStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM approvals
+		WHERE approval_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete approval")
+	}
+
+	return nil
+}
+
+func (s *ApprovalStore) mapSliceApproval(ctx context.Context, approvals []*approval) ([]*types.Approval, error) {
+	// collect all principal IDs
+	ids := make([]int64, len(approvals))
+	for i, a := range approvals {
+		ids[i] = a.CreatedBy
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load approval authors: %w", err)
+	}
+
+	// attach the principal infos back to the slice items
+	m := make([]*types.Approval, len(approvals))
+	for i, a := range approvals {
+		m[i] = mapToApproval(a)
+		if author, ok := infoMap[a.CreatedBy]; ok {
+			m[i].Author = *author
+		}
+	}
+
+	return m, nil
+}
+
+func mapToApproval(a *approval) *types.Approval {
+	return &types.Approval{
+		ID:          a.ID,
+		ExecutionID: a.ExecutionID,
+		StageID:     a.StageID,
+		Decision:    a.Decision,
+		Comment:     a.Comment,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+	}
+}
+
+func mapToInternalApproval(a *types.Approval) *approval {
+	return &approval{
+		ID:          a.ID,
+		ExecutionID: a.ExecutionID,
+		StageID:     a.StageID,
+		Decision:    a.Decision,
+		Comment:     a.Comment,
+		CreatedBy:   a.CreatedBy,
+		Created:     a.Created,
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.ArtifactStore = (*ArtifactStore)(nil)
+
+// NewArtifactStore returns a new ArtifactStore.
+func NewArtifactStore(db *sqlx.DB) *ArtifactStore {
+	return &ArtifactStore{
+		db: db,
+	}
+}
+
+// ArtifactStore implements a store.ArtifactStore backed by a relational database.
+type ArtifactStore struct {
+	db *sqlx.DB
+}
+
+type artifact struct {
+	ID          int64  `db:"artifact_id"`
+	ExecutionID int64  `db:"artifact_execution_id"`
+	StageID     int64  `db:"artifact_stage_id"`
+	Name        string `db:"artifact_name"`
+	Size        int64  `db:"artifact_size"`
+	Created     int64  `db:"artifact_created"`
+	Expires     int64  `db:"artifact_expires"`
+}
+
+const (
+	artifactColumns = `
+		 artifact_id
+		,artifact_execution_id
+		,artifact_stage_id
+		,artifact_name
+		,artifact_size
+		,artifact_created
+		,artifact_expires`
+
+	artifactSelectBase = `
+		SELECT` + artifactColumns + `
+		FROM artifacts`
+)
+
+// Find finds the artifact by id.
+func (s *ArtifactStore) Find(ctx context.Context, id int64) (*types.Artifact, error) {
+	const sqlQuery = artifactSelectBase + `
+		WHERE artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &artifact{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find artifact")
+	}
+
+	return mapToArtifact(dst), nil
+}
+
+// Upsert creates a new artifact or replaces the artifact with the same name of the stage.
+func (s *ArtifactStore) Upsert(ctx context.Context, artifact *types.Artifact) error {
+	const sqlQuery = `
+		INSERT INTO artifacts (
+			 artifact_execution_id
+			,artifact_stage_id
+			,artifact_name
+			,artifact_size
+			,artifact_created
+			,artifact_expires
+		) values (
+			 :artifact_execution_id
+			,:artifact_stage_id
+			,:artifact_name
+			,:artifact_size
+			,:artifact_created
+			,:artifact_expires
+		)
+		ON CONFLICT (artifact_stage_id, artifact_name) DO
+		UPDATE SET
+			 artifact_size = :artifact_size
+			,artifact_created = :artifact_created
+			,artifact_expires = :artifact_expires
+		RETURNING artifact_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalArtifact(artifact))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind artifact object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&artifact.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert artifact query failed")
+	}
+
+	return nil
+}
+
+// List returns all artifacts of an execution ordered by stage and name.
+func (s *ArtifactStore) List(ctx context.Context, executionID int64) ([]*types.Artifact, error) {
+	const sqlQuery = artifactSelectBase + `
+		WHERE artifact_execution_id = $1
+		ORDER BY artifact_stage_id, artifact_name`
+
+	return s.list(ctx, sqlQuery, executionID)
+}
+
+// ListExpired returns up to limit artifacts that expired before the provided time (unix milliseconds).
+func (s *ArtifactStore) ListExpired(ctx context.Context, expiredBefore int64, limit int) ([]*types.Artifact, error) {
+	const sqlQuery = artifactSelectBase + `
+		WHERE artifact_expires < $1
+		ORDER BY artifact_expires
+		LIMIT $2`
+
+	return s.list(ctx, sqlQuery, expiredBefore, limit)
+}
+
+func (s *ArtifactStore) list(ctx context.Context, sqlQuery string, args ...any) ([]*types.Artifact, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*artifact, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list artifacts")
+	}
+
+	result := make([]*types.Artifact, len(dst))
+	for i, a := range dst {
+		result[i] = mapToArtifact(a)
+	}
+
+	return result, nil
+}
+
+// Delete deletes the artifact with the given id.
+func (s *ArtifactStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM artifacts
+		WHERE artifact_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete artifact")
+	}
+
+	return nil
+}
+
+func mapToArtifact(a *artifact) *types.Artifact {
+	return &types.Artifact{
+		ID:          a.ID,
+		ExecutionID: a.ExecutionID,
+		StageID:     a.StageID,
+		Name:        a.Name,
+		Size:        a.Size,
+		Created:     a.Created,
+		Expires:     a.Expires,
+	}
+}
+
+func mapToInternalArtifact(a *types.Artifact) *artifact {
+	return &artifact{
+		ID:          a.ID,
+		ExecutionID: a.ExecutionID,
+		StageID:     a.StageID,
+		Name:        a.Name,
+		Size:        a.Size,
+		Created:     a.Created,
+		Expires:     a.Expires,
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LFSLockStore = (*LFSLockStore)(nil)
+
+// NewLFSLockStore returns a new LFSLockStore.
+func NewLFSLockStore(db *sqlx.DB) *LFSLockStore {
+	return &LFSLockStore{
+		db: db,
+	}
+}
+
+// LFSLockStore implements a store.LFSLockStore backed by a relational database.
+type LFSLockStore struct {
+	db *sqlx.DB
+}
+
+type lfsLock struct {
+	ID          int64  `db:"lfs_lock_id"`
+	RepoID      int64  `db:"lfs_lock_repo_id"`
+	Path        string `db:"lfs_lock_path"`
+	PrincipalID int64  `db:"lfs_lock_principal_id"`
+	Created     int64  `db:"lfs_lock_created"`
+}
+
+const (
+	lfsLockColumns = `
+		 lfs_lock_id
+		,lfs_lock_repo_id
+		,lfs_lock_path
+		,lfs_lock_principal_id
+		,lfs_lock_created`
+
+	lfsLockSelectBase = `
+		SELECT` + lfsLockColumns + `
+		FROM lfs_locks`
+)
+
+// Find finds the LFS lock by id.
+func (s *LFSLockStore) Find(ctx context.Context, id int64) (*types.LFSLock, error) {
+	const sqlQuery = lfsLockSelectBase + `
+		WHERE lfs_lock_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsLock{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find LFS lock")
+	}
+
+	return mapToLFSLock(dst), nil
+}
+
+// FindByPath finds the LFS lock of the file of the repository.
+func (s *LFSLockStore) FindByPath(ctx context.Context, repoID int64, path string) (*types.LFSLock, error) {
+	const sqlQuery = lfsLockSelectBase + `
+		WHERE lfs_lock_repo_id = $1 AND lfs_lock_path = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsLock{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, path); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find LFS lock by path")
+	}
+
+	return mapToLFSLock(dst), nil
+}
+
+// List returns the LFS locks of the repository, ordered by id.
+func (s *LFSLockStore) List(ctx context.Context, repoID int64, filter *types.LFSLockFilter) ([]*types.LFSLock, error) {
+	stmt := database.Builder.
+		Select(lfsLockColumns).
+		From("lfs_locks").
+		Where("lfs_lock_repo_id = ?", repoID).
+		Where("lfs_lock_id > ?", filter.Cursor)
+
+	if filter.Path != "" {
+		stmt = stmt.Where("lfs_lock_path = ?", filter.Path)
+	}
+
+	if filter.ID > 0 {
+		stmt = stmt.Where("lfs_lock_id = ?", filter.ID)
+	}
+
+	stmt = stmt.OrderBy("lfs_lock_id").Limit(uint64(filter.Limit))
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*lfsLock{}
+	if err = db.SelectContext(ctx, &dst, sqlQuery, params...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list LFS locks")
+	}
+
+	res := make([]*types.LFSLock, len(dst))
+	for i := range dst {
+		res[i] = mapToLFSLock(dst[i])
+	}
+
+	return res, nil
+}
+
+// Create creates a new LFS lock.
+func (s *LFSLockStore) Create(ctx context.Context, lock *types.LFSLock) error {
+	const sqlQuery = `
+		INSERT INTO lfs_locks (
+			 lfs_lock_repo_id
+			,lfs_lock_path
+			,lfs_lock_principal_id
+			,lfs_lock_created
+		) values (
+			 :lfs_lock_repo_id
+			,:lfs_lock_path
+			,:lfs_lock_principal_id
+			,:lfs_lock_created
+		) RETURNING lfs_lock_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLFSLock(lock))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind LFS lock")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&lock.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert LFS lock query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the LFS lock with the given id.
+func (s *LFSLockStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM lfs_locks
+		WHERE lfs_lock_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete LFS lock")
+	}
+
+	return nil
+}
+
+func mapToLFSLock(l *lfsLock) *types.LFSLock {
+	return &types.LFSLock{
+		ID:          l.ID,
+		RepoID:      l.RepoID,
+		Path:        l.Path,
+		PrincipalID: l.PrincipalID,
+		Created:     l.Created,
+	}
+}
+
+func mapToInternalLFSLock(l *types.LFSLock) *lfsLock {
+	return &lfsLock{
+		ID:          l.ID,
+		RepoID:      l.RepoID,
+		Path:        l.Path,
+		PrincipalID: l.PrincipalID,
+		Created:     l.Created,
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.LFSObjectStore = (*LFSObjectStore)(nil)
+
+// NewLFSObjectStore returns a new LFSObjectStore.
+func NewLFSObjectStore(db *sqlx.DB) *LFSObjectStore {
+	return &LFSObjectStore{
+		db: db,
+	}
+}
+
+// LFSObjectStore implements a store.LFSObjectStore backed by a relational database.
+type LFSObjectStore struct {
+	db *sqlx.DB
+}
+
+type lfsObject struct {
+	ID        int64  `db:"lfs_object_id"`
+	OID       string `db:"lfs_object_oid"`
+	Size      int64  `db:"lfs_object_size"`
+	RepoID    int64  `db:"lfs_object_repo_id"`
+	CreatedBy int64  `db:"lfs_object_created_by"`
+	Created   int64  `db:"lfs_object_created"`
+}
+
+const (
+	lfsObjectColumns = `
+		 lfs_object_id
+		,lfs_object_oid
+		,lfs_object_size
+		,lfs_object_repo_id
+		,lfs_object_created_by
+		,lfs_object_created`
+
+	lfsObjectSelectBase = `
+		SELECT` + lfsObjectColumns + `
+		FROM lfs_objects`
+)
+
+// Find finds the LFS object of the repository with the given oid.
+func (s *LFSObjectStore) Find(ctx context.Context, repoID int64, oid string) (*types.LFSObject, error) {
+	const sqlQuery = lfsObjectSelectBase + `
+		WHERE lfs_object_repo_id = $1 AND lfs_object_oid = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &lfsObject{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, oid); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find LFS object")
+	}
+
+	return mapToLFSObject(dst), nil
+}
+
+// FindMany returns all LFS objects of the repository with one of the given oids.
+func (s *LFSObjectStore) FindMany(ctx context.Context, repoID int64, oids []string) ([]*types.LFSObject, error) {
+	stmt := database.Builder.
+		Select(lfsObjectColumns).
+		From("lfs_objects").
+		Where("lfs_object_repo_id = ?", repoID).
+		Where(squirrel.Eq{"lfs_object_oid": oids})
+
+	sqlQuery, params, err := stmt.ToSql()
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*lfsObject{}
+	if err = db.SelectContext(ctx, &dst, sqlQuery, params...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find LFS objects")
+	}
+
+	res := make([]*types.LFSObject, len(dst))
+	for i := range dst {
+		res[i] = mapToLFSObject(dst[i])
+	}
+
+	return res, nil
+}
+
+// Create creates a new LFS object.
+func (s *LFSObjectStore) Create(ctx context.Context, obj *types.LFSObject) error {
+	const sqlQuery = `
+		INSERT INTO lfs_objects (
+			 lfs_object_oid
+			,lfs_object_size
+			,lfs_object_repo_id
+			,lfs_object_created_by
+			,lfs_object_created
+		) values (
+			 :lfs_object_oid
+			,:lfs_object_size
+			,:lfs_object_repo_id
+			,:lfs_object_created_by
+			,:lfs_object_created
+		) RETURNING lfs_object_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalLFSObject(obj))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind LFS object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&obj.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert LFS object query failed")
+	}
+
+	return nil
+}
+
+// Usage returns the number and total size of the LFS objects of the repository.
+func (s *LFSObjectStore) Usage(ctx context.Context, repoID int64) (types.LFSUsage, error) {
+	const sqlQuery = `
+		SELECT COUNT(*), COALESCE(SUM(lfs_object_size), 0)
+		FROM lfs_objects
+		WHERE lfs_object_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var usage types.LFSUsage
+	if err := db.QueryRowContext(ctx, sqlQuery, repoID).Scan(&usage.ObjectCount, &usage.Size); err != nil {
+		return types.LFSUsage{}, database.ProcessSQLErrorf(err, "Failed to get LFS usage")
+	}
+
+	return usage, nil
+}
+
+func mapToLFSObject(o *lfsObject) *types.LFSObject {
+	return &types.LFSObject{
+		ID:        o.ID,
+		OID:       o.OID,
+		Size:      o.Size,
+		RepoID:    o.RepoID,
+		CreatedBy: o.CreatedBy,
+		Created:   o.Created,
+	}
+}
+
+func mapToInternalLFSObject(o *types.LFSObject) *lfsObject {
+	return &lfsObject{
+		ID:        o.ID,
+		OID:       o.OID,
+		Size:      o.Size,
+		RepoID:    o.RepoID,
+		CreatedBy: o.CreatedBy,
+		Created:   o.Created,
+	}
+}
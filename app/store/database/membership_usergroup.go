@@ -0,0 +1,315 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.MembershipUserGroupStore = (*MembershipUserGroupStore)(nil)
+
+// NewMembershipUserGroupStore returns a new MembershipUserGroupStore.
+func NewMembershipUserGroupStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *MembershipUserGroupStore {
+	return &MembershipUserGroupStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// MembershipUserGroupStore implements store.MembershipUserGroupStore backed by a relational database.
+type MembershipUserGroupStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type membershipUserGroup struct {
+	SpaceID     int64 `db:"usergroup_membership_space_id"`
+	UserGroupID int64 `db:"usergroup_membership_usergroup_id"`
+
+	CreatedBy int64 `db:"usergroup_membership_created_by"`
+	Created   int64 `db:"usergroup_membership_created"`
+	Updated   int64 `db:"usergroup_membership_updated"`
+
+	Role enum.MembershipRole `db:"usergroup_membership_role"`
+}
+
+type membershipUserGroupWithGroup struct {
+	membershipUserGroup
+	userGroup
+}
+
+const (
+	membershipUserGroupColumns = `
+		 usergroup_membership_space_id
+		,usergroup_membership_usergroup_id
+		,usergroup_membership_created_by
+		,usergroup_membership_created
+		,usergroup_membership_updated
+		,usergroup_membership_role`
+
+	membershipUserGroupSelectBase = `
+	SELECT` + membershipUserGroupColumns + "," + userGroupColumns + `
+	FROM usergroup_memberships
+	INNER JOIN usergroups ON usergroup_membership_usergroup_id = usergroup_id`
+)
+
+// Find finds the membership of the user group in the space.
+func (s *MembershipUserGroupStore) Find(
+	ctx context.Context,
+	key types.MembershipUserGroupKey,
+) (*types.MembershipUserGroup, error) {
+	const sqlQuery = membershipUserGroupSelectBase + `
+	WHERE usergroup_membership_space_id = $1 AND usergroup_membership_usergroup_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &membershipUserGroupWithGroup{}
+	if err := db.GetContext(ctx, dst, sqlQuery, key.SpaceID, key.UserGroupID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find user group membership")
+	}
+
+	result, err := s.mapToMembershipUserGroups(ctx, []*membershipUserGroupWithGroup{dst})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[0], nil
+}
+
+// Create creates a new membership of a user group in a space.
+func (s *MembershipUserGroupStore) Create(ctx context.Context, membership *types.MembershipUserGroup) error {
+	const sqlQuery = `
+	INSERT INTO usergroup_memberships (
+		 usergroup_membership_space_id
+		,usergroup_membership_usergroup_id
+		,usergroup_membership_created_by
+		,usergroup_membership_created
+		,usergroup_membership_updated
+		,usergroup_membership_role
+	) values (
+		 :usergroup_membership_space_id
+		,:usergroup_membership_usergroup_id
+		,:usergroup_membership_created_by
+		,:usergroup_membership_created
+		,:usergroup_membership_updated
+		,:usergroup_membership_role
+	)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalMembershipUserGroup(membership))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group membership object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to insert user group membership")
+	}
+
+	return nil
+}
+
+// Update updates the role of a user group in a space.
+func (s *MembershipUserGroupStore) Update(ctx context.Context, membership *types.MembershipUserGroup) error {
+	const sqlQuery = `
+	UPDATE usergroup_memberships
+	SET
+		 usergroup_membership_updated = :usergroup_membership_updated
+		,usergroup_membership_role = :usergroup_membership_role
+	WHERE usergroup_membership_space_id = :usergroup_membership_space_id AND
+	      usergroup_membership_usergroup_id = :usergroup_membership_usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMembership := mapToInternalMembershipUserGroup(membership)
+	dbMembership.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMembership)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group membership object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update user group membership role")
+	}
+
+	membership.Updated = dbMembership.Updated
+
+	return nil
+}
+
+// Delete deletes the membership of the user group in the space.
+func (s *MembershipUserGroupStore) Delete(ctx context.Context, key types.MembershipUserGroupKey) error {
+	const sqlQuery = `
+	DELETE from usergroup_memberships
+	WHERE usergroup_membership_space_id = $1 AND
+	      usergroup_membership_usergroup_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, key.SpaceID, key.UserGroupID); err != nil {
+		return database.ProcessSQLErrorf(err, "delete user group membership query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of user group memberships of the space.
+func (s *MembershipUserGroupStore) Count(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("usergroup_memberships").
+		InnerJoin("usergroups ON usergroup_membership_usergroup_id = usergroup_id").
+		Where("usergroup_membership_space_id = ?", spaceID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(usergroup_uid) LIKE ?", "%"+strings.ToLower(filter.Query)+"%")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert user group membership count query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing user group membership count query")
+	}
+
+	return count, nil
+}
+
+// List returns the user group memberships of the space.
+func (s *MembershipUserGroupStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.MembershipUserGroup, error) {
+	stmt := database.Builder.
+		Select(membershipUserGroupColumns+","+userGroupColumns).
+		From("usergroup_memberships").
+		InnerJoin("usergroups ON usergroup_membership_usergroup_id = usergroup_id").
+		Where("usergroup_membership_space_id = ?", spaceID)
+
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(usergroup_uid) LIKE ?", "%"+strings.ToLower(filter.Query)+"%")
+	}
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("LOWER(usergroup_uid) ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert user group membership list query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*membershipUserGroupWithGroup, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing user group membership list query")
+	}
+
+	return s.mapToMembershipUserGroups(ctx, dst)
+}
+
+// ListRoles returns the roles of all memberships in the space of user groups the principal is a member of.
+func (s *MembershipUserGroupStore) ListRoles(
+	ctx context.Context,
+	spaceID int64,
+	principalID int64,
+) ([]enum.MembershipRole, error) {
+	const sqlQuery = `
+	SELECT DISTINCT usergroup_membership_role
+	FROM usergroup_memberships
+	INNER JOIN usergroup_members ON usergroup_member_usergroup_id = usergroup_membership_usergroup_id
+	WHERE usergroup_membership_space_id = $1 AND usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]enum.MembershipRole, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, spaceID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing user group membership roles query")
+	}
+
+	return dst, nil
+}
+
+func (s *MembershipUserGroupStore) mapToMembershipUserGroups(
+	ctx context.Context,
+	ms []*membershipUserGroupWithGroup,
+) ([]*types.MembershipUserGroup, error) {
+	// collect all principal IDs
+	ids := make([]int64, 0, len(ms))
+	for _, m := range ms {
+		ids = append(ids, m.membershipUserGroup.CreatedBy)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user group membership principal infos: %w", err)
+	}
+
+	res := make([]*types.MembershipUserGroup, len(ms))
+	for i, m := range ms {
+		res[i] = &types.MembershipUserGroup{
+			MembershipUserGroupKey: types.MembershipUserGroupKey{
+				SpaceID:     m.membershipUserGroup.SpaceID,
+				UserGroupID: m.membershipUserGroup.UserGroupID,
+			},
+			CreatedBy: m.membershipUserGroup.CreatedBy,
+			Created:   m.membershipUserGroup.Created,
+			Updated:   m.membershipUserGroup.Updated,
+			Role:      m.membershipUserGroup.Role,
+			UserGroup: *mapToUserGroup(&m.userGroup),
+		}
+		if addedBy, ok := infoMap[m.membershipUserGroup.CreatedBy]; ok {
+			res[i].AddedBy = *addedBy
+		}
+	}
+
+	return res, nil
+}
+
+func mapToInternalMembershipUserGroup(m *types.MembershipUserGroup) membershipUserGroup {
+	return membershipUserGroup{
+		SpaceID:     m.SpaceID,
+		UserGroupID: m.UserGroupID,
+		CreatedBy:   m.CreatedBy,
+		Created:     m.Created,
+		Updated:     m.Updated,
+		Role:        m.Role,
+	}
+}
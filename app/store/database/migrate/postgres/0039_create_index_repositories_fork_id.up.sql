@@ -0,0 +1,2 @@
+CREATE INDEX repositories_fork_id
+ON repositories(repo_fork_id);
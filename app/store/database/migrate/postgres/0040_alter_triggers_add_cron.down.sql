@@ -0,0 +1,6 @@
+DROP INDEX triggers_type_next_run;
+
+ALTER TABLE triggers
+    DROP COLUMN trigger_cron,
+    DROP COLUMN trigger_timezone,
+    DROP COLUMN trigger_next_run;
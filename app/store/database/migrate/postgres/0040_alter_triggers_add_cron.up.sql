@@ -0,0 +1,9 @@
+ALTER TABLE triggers
+    ADD COLUMN trigger_cron TEXT NOT NULL DEFAULT '',
+    ADD COLUMN trigger_timezone TEXT NOT NULL DEFAULT '',
+    ADD COLUMN trigger_next_run BIGINT NOT NULL DEFAULT 0;
+
+UPDATE triggers SET trigger_type = '@hook' WHERE trigger_type = '';
+
+CREATE INDEX triggers_type_next_run
+ON triggers(trigger_type, trigger_next_run);
@@ -0,0 +1,3 @@
+DROP TABLE usergroup_memberships;
+DROP TABLE usergroup_members;
+DROP TABLE usergroups;
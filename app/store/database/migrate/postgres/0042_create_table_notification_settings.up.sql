@@ -0,0 +1,15 @@
+CREATE TABLE notification_settings (
+ notification_setting_principal_id INTEGER PRIMARY KEY
+,notification_setting_pullreq_created BOOLEAN NOT NULL
+,notification_setting_reviewer_added BOOLEAN NOT NULL
+,notification_setting_comment_created BOOLEAN NOT NULL
+,notification_setting_pullreq_merged BOOLEAN NOT NULL
+,notification_setting_pullreq_closed BOOLEAN NOT NULL
+,notification_setting_branch_updated BOOLEAN NOT NULL
+,notification_setting_created BIGINT NOT NULL
+,notification_setting_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_setting_principal_id FOREIGN KEY (notification_setting_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
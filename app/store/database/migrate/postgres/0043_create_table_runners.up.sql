@@ -0,0 +1,23 @@
+CREATE TABLE runners (
+ runner_id SERIAL PRIMARY KEY
+,runner_uid TEXT NOT NULL
+,runner_description TEXT NOT NULL
+,runner_token_hash TEXT NOT NULL
+,runner_created_by INTEGER NOT NULL
+,runner_created BIGINT NOT NULL
+,runner_updated BIGINT NOT NULL
+,runner_last_seen BIGINT NOT NULL DEFAULT 0
+,runner_machine TEXT NOT NULL DEFAULT ''
+,runner_os TEXT NOT NULL DEFAULT ''
+,runner_arch TEXT NOT NULL DEFAULT ''
+,CONSTRAINT fk_runner_created_by FOREIGN KEY (runner_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX runners_uid
+    ON runners(LOWER(runner_uid));
+
+CREATE UNIQUE INDEX runners_token_hash
+    ON runners(runner_token_hash);
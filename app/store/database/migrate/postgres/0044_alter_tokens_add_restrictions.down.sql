@@ -0,0 +1,3 @@
+ALTER TABLE tokens
+    DROP COLUMN token_permissions,
+    DROP COLUMN token_paths;
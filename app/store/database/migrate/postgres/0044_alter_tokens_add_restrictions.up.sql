@@ -0,0 +1,3 @@
+ALTER TABLE tokens
+    ADD COLUMN token_permissions JSON NOT NULL DEFAULT '[]',
+    ADD COLUMN token_paths JSON NOT NULL DEFAULT '[]';
@@ -0,0 +1,3 @@
+ALTER TABLE pipelines
+    DROP COLUMN pipeline_retention_count,
+    DROP COLUMN pipeline_retention_days;
@@ -0,0 +1,3 @@
+ALTER TABLE pipelines
+    ADD COLUMN pipeline_retention_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN pipeline_retention_days INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,27 @@
+CREATE TABLE approvals (
+ approval_id SERIAL PRIMARY KEY
+,approval_execution_id INTEGER NOT NULL
+,approval_stage_id INTEGER NOT NULL
+,approval_decision TEXT NOT NULL
+,approval_comment TEXT NOT NULL
+,approval_created_by INTEGER NOT NULL
+,approval_created BIGINT NOT NULL
+,CONSTRAINT fk_approval_execution_id FOREIGN KEY (approval_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_approval_stage_id FOREIGN KEY (approval_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_approval_created_by FOREIGN KEY (approval_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX approvals_stage_id
+    ON approvals(approval_stage_id);
+
+CREATE INDEX approvals_execution_id
+    ON approvals(approval_execution_id);
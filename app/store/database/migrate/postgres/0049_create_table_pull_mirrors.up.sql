@@ -0,0 +1,31 @@
+CREATE TABLE pull_mirrors (
+ pull_mirror_id SERIAL PRIMARY KEY
+,pull_mirror_repo_id INTEGER NOT NULL
+,pull_mirror_url TEXT NOT NULL
+,pull_mirror_username TEXT NOT NULL
+,pull_mirror_password BYTEA NOT NULL
+,pull_mirror_interval BIGINT NOT NULL
+,pull_mirror_state TEXT NOT NULL
+,pull_mirror_last_sync BIGINT NOT NULL
+,pull_mirror_last_synced BIGINT NOT NULL
+,pull_mirror_last_error TEXT NOT NULL
+,pull_mirror_next_sync BIGINT NOT NULL
+,pull_mirror_created_by INTEGER NOT NULL
+,pull_mirror_created BIGINT NOT NULL
+,pull_mirror_updated BIGINT NOT NULL
+,pull_mirror_version INTEGER NOT NULL
+,CONSTRAINT fk_pull_mirror_repo_id FOREIGN KEY (pull_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pull_mirror_created_by FOREIGN KEY (pull_mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX pull_mirrors_repo_id
+    ON pull_mirrors(pull_mirror_repo_id);
+
+CREATE INDEX pull_mirrors_next_sync
+    ON pull_mirrors(pull_mirror_next_sync);
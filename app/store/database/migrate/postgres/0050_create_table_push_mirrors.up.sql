@@ -0,0 +1,28 @@
+CREATE TABLE push_mirrors (
+ push_mirror_id SERIAL PRIMARY KEY
+,push_mirror_repo_id INTEGER NOT NULL
+,push_mirror_url TEXT NOT NULL
+,push_mirror_username TEXT NOT NULL
+,push_mirror_password BYTEA NOT NULL
+,push_mirror_enabled BOOLEAN NOT NULL
+,push_mirror_branch_filter TEXT NOT NULL
+,push_mirror_state TEXT NOT NULL
+,push_mirror_last_push BIGINT NOT NULL
+,push_mirror_last_pushed BIGINT NOT NULL
+,push_mirror_errors TEXT NOT NULL
+,push_mirror_created_by INTEGER NOT NULL
+,push_mirror_created BIGINT NOT NULL
+,push_mirror_updated BIGINT NOT NULL
+,push_mirror_version INTEGER NOT NULL
+,CONSTRAINT fk_push_mirror_repo_id FOREIGN KEY (push_mirror_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_push_mirror_created_by FOREIGN KEY (push_mirror_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX push_mirrors_repo_id_url
+    ON push_mirrors(push_mirror_repo_id, push_mirror_url);
@@ -0,0 +1,18 @@
+CREATE TABLE lfs_locks (
+ lfs_lock_id SERIAL PRIMARY KEY
+,lfs_lock_repo_id INTEGER NOT NULL
+,lfs_lock_path TEXT NOT NULL
+,lfs_lock_principal_id INTEGER NOT NULL
+,lfs_lock_created BIGINT NOT NULL
+,CONSTRAINT fk_lfs_lock_repo_id FOREIGN KEY (lfs_lock_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_lfs_lock_principal_id FOREIGN KEY (lfs_lock_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX lfs_locks_repo_id_path
+    ON lfs_locks(lfs_lock_repo_id, lfs_lock_path);
@@ -0,0 +1,2 @@
+DROP TABLE signing_key_ids;
+DROP TABLE signing_keys;
@@ -0,0 +1,3 @@
+ALTER TABLE stages
+    DROP CONSTRAINT fk_stage_runner_id,
+    DROP COLUMN stage_runner_id;
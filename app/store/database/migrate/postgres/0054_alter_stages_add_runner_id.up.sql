@@ -0,0 +1,7 @@
+ALTER TABLE stages
+    ADD COLUMN stage_runner_id INTEGER,
+    ADD CONSTRAINT fk_stage_runner_id
+        FOREIGN KEY (stage_runner_id)
+        REFERENCES runners(runner_id)
+        ON DELETE SET NULL
+        ON UPDATE NO ACTION;
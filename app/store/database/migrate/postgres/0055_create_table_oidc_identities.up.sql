@@ -0,0 +1,17 @@
+CREATE TABLE oidc_identities (
+ oidc_identity_id SERIAL PRIMARY KEY
+,oidc_identity_principal_id INTEGER NOT NULL
+,oidc_identity_issuer TEXT NOT NULL
+,oidc_identity_subject TEXT NOT NULL
+,oidc_identity_created BIGINT NOT NULL
+,CONSTRAINT fk_oidc_identity_principal_id FOREIGN KEY (oidc_identity_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX oidc_identities_issuer_subject
+    ON oidc_identities(oidc_identity_issuer, oidc_identity_subject);
+
+CREATE UNIQUE INDEX oidc_identities_principal_id_issuer
+    ON oidc_identities(oidc_identity_principal_id, oidc_identity_issuer);
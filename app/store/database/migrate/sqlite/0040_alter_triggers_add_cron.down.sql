@@ -0,0 +1,5 @@
+DROP INDEX triggers_type_next_run;
+
+ALTER TABLE triggers DROP COLUMN trigger_cron;
+ALTER TABLE triggers DROP COLUMN trigger_timezone;
+ALTER TABLE triggers DROP COLUMN trigger_next_run;
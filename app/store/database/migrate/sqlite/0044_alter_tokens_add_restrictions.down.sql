@@ -0,0 +1,2 @@
+ALTER TABLE tokens DROP COLUMN token_permissions;
+ALTER TABLE tokens DROP COLUMN token_paths;
@@ -0,0 +1,2 @@
+ALTER TABLE tokens ADD COLUMN token_permissions TEXT NOT NULL DEFAULT '[]';
+ALTER TABLE tokens ADD COLUMN token_paths TEXT NOT NULL DEFAULT '[]';
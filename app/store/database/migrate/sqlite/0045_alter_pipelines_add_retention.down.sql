@@ -0,0 +1,2 @@
+ALTER TABLE pipelines DROP COLUMN pipeline_retention_count;
+ALTER TABLE pipelines DROP COLUMN pipeline_retention_days;
@@ -0,0 +1,2 @@
+ALTER TABLE pipelines ADD COLUMN pipeline_retention_count INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE pipelines ADD COLUMN pipeline_retention_days INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,26 @@
+CREATE TABLE artifacts (
+ artifact_id INTEGER PRIMARY KEY AUTOINCREMENT
+,artifact_execution_id INTEGER NOT NULL
+,artifact_stage_id INTEGER NOT NULL
+,artifact_name TEXT NOT NULL
+,artifact_size BIGINT NOT NULL
+,artifact_created BIGINT NOT NULL
+,artifact_expires BIGINT NOT NULL
+,CONSTRAINT fk_artifact_execution_id FOREIGN KEY (artifact_execution_id)
+    REFERENCES executions (execution_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_artifact_stage_id FOREIGN KEY (artifact_stage_id)
+    REFERENCES stages (stage_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX artifacts_stage_id_name
+    ON artifacts(artifact_stage_id, artifact_name);
+
+CREATE INDEX artifacts_execution_id
+    ON artifacts(artifact_execution_id);
+
+CREATE INDEX artifacts_expires
+    ON artifacts(artifact_expires);
@@ -0,0 +1,20 @@
+CREATE TABLE pipeline_caches (
+ cache_id INTEGER PRIMARY KEY AUTOINCREMENT
+,cache_repo_id INTEGER NOT NULL
+,cache_branch TEXT NOT NULL
+,cache_key TEXT NOT NULL
+,cache_size BIGINT NOT NULL
+,cache_created BIGINT NOT NULL
+,cache_updated BIGINT NOT NULL
+,cache_last_used BIGINT NOT NULL
+,CONSTRAINT fk_cache_repo_id FOREIGN KEY (cache_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX pipeline_caches_repo_id_branch_key
+    ON pipeline_caches(cache_repo_id, cache_branch, cache_key);
+
+CREATE INDEX pipeline_caches_last_used
+    ON pipeline_caches(cache_last_used);
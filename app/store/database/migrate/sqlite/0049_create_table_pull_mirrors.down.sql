@@ -0,0 +1 @@
+DROP TABLE pull_mirrors;
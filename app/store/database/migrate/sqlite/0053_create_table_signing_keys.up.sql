@@ -0,0 +1,32 @@
+CREATE TABLE signing_keys (
+ signing_key_id INTEGER PRIMARY KEY AUTOINCREMENT
+,signing_key_principal_id INTEGER NOT NULL
+,signing_key_created BIGINT NOT NULL
+,signing_key_uid TEXT NOT NULL
+,signing_key_type TEXT NOT NULL
+,signing_key_fingerprint TEXT NOT NULL
+,signing_key_content TEXT NOT NULL
+,CONSTRAINT fk_signing_key_principal_id FOREIGN KEY (signing_key_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX signing_keys_principal_id_uid
+    ON signing_keys(signing_key_principal_id, LOWER(signing_key_uid));
+
+CREATE UNIQUE INDEX signing_keys_fingerprint
+    ON signing_keys(signing_key_fingerprint);
+
+CREATE TABLE signing_key_ids (
+ signing_key_id_signing_key_id INTEGER NOT NULL
+,signing_key_id_key_id TEXT NOT NULL
+,CONSTRAINT pk_signing_key_ids PRIMARY KEY (signing_key_id_signing_key_id, signing_key_id_key_id)
+,CONSTRAINT fk_signing_key_id_signing_key_id FOREIGN KEY (signing_key_id_signing_key_id)
+    REFERENCES signing_keys (signing_key_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX signing_key_ids_key_id
+    ON signing_key_ids(signing_key_id_key_id);
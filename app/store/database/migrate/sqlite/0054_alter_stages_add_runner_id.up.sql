@@ -0,0 +1 @@
+ALTER TABLE stages ADD COLUMN stage_runner_id INTEGER;
@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationSettingsStore = (*NotificationSettingsStore)(nil)
+
+// NewNotificationSettingsStore returns a new NotificationSettingsStore.
+func NewNotificationSettingsStore(db *sqlx.DB) *NotificationSettingsStore {
+	return &NotificationSettingsStore{
+		db: db,
+	}
+}
+
+// NotificationSettingsStore implements store.NotificationSettingsStore backed by a relational database.
+type NotificationSettingsStore struct {
+	db *sqlx.DB
+}
+
+type notificationSettings struct {
+	PrincipalID int64 `db:"notification_setting_principal_id"`
+
+	PullReqCreated bool `db:"notification_setting_pullreq_created"`
+	ReviewerAdded  bool `db:"notification_setting_reviewer_added"`
+	CommentCreated bool `db:"notification_setting_comment_created"`
+	PullReqMerged  bool `db:"notification_setting_pullreq_merged"`
+	PullReqClosed  bool `db:"notification_setting_pullreq_closed"`
+	BranchUpdated  bool `db:"notification_setting_branch_updated"`
+
+	Created int64 `db:"notification_setting_created"`
+	Updated int64 `db:"notification_setting_updated"`
+}
+
+const (
+	notificationSettingsColumns = `
+		 notification_setting_principal_id
+		,notification_setting_pullreq_created
+		,notification_setting_reviewer_added
+		,notification_setting_comment_created
+		,notification_setting_pullreq_merged
+		,notification_setting_pullreq_closed
+		,notification_setting_branch_updated
+		,notification_setting_created
+		,notification_setting_updated`
+)
+
+// Find returns the notification settings of the principal.
+func (s *NotificationSettingsStore) Find(ctx context.Context, principalID int64) (*types.NotificationSettings, error) {
+	const sqlQuery = `
+	SELECT` + notificationSettingsColumns + `
+	FROM notification_settings
+	WHERE notification_setting_principal_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationSettings{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find notification settings")
+	}
+
+	return mapToNotificationSettings(dst), nil
+}
+
+// Upsert creates or updates the notification settings of the principal.
+func (s *NotificationSettingsStore) Upsert(ctx context.Context, settings *types.NotificationSettings) error {
+	const sqlQuery = `
+	INSERT INTO notification_settings (` + notificationSettingsColumns + `
+	) VALUES (
+		 :notification_setting_principal_id
+		,:notification_setting_pullreq_created
+		,:notification_setting_reviewer_added
+		,:notification_setting_comment_created
+		,:notification_setting_pullreq_merged
+		,:notification_setting_pullreq_closed
+		,:notification_setting_branch_updated
+		,:notification_setting_created
+		,:notification_setting_updated
+	)
+	ON CONFLICT (notification_setting_principal_id) DO
+	UPDATE SET
+		 notification_setting_pullreq_created = :notification_setting_pullreq_created
+		,notification_setting_reviewer_added = :notification_setting_reviewer_added
+		,notification_setting_comment_created = :notification_setting_comment_created
+		,notification_setting_pullreq_merged = :notification_setting_pullreq_merged
+		,notification_setting_pullreq_closed = :notification_setting_pullreq_closed
+		,notification_setting_branch_updated = :notification_setting_branch_updated
+		,notification_setting_updated = :notification_setting_updated
+	RETURNING notification_setting_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalNotificationSettings(settings))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind notification settings object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&settings.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert query failed")
+	}
+
+	return nil
+}
+
+func mapToNotificationSettings(s *notificationSettings) *types.NotificationSettings {
+	return &types.NotificationSettings{
+		PrincipalID:    s.PrincipalID,
+		PullReqCreated: s.PullReqCreated,
+		ReviewerAdded:  s.ReviewerAdded,
+		CommentCreated: s.CommentCreated,
+		PullReqMerged:  s.PullReqMerged,
+		PullReqClosed:  s.PullReqClosed,
+		BranchUpdated:  s.BranchUpdated,
+		Created:        s.Created,
+		Updated:        s.Updated,
+	}
+}
+
+func mapToInternalNotificationSettings(s *types.NotificationSettings) *notificationSettings {
+	return &notificationSettings{
+		PrincipalID:    s.PrincipalID,
+		PullReqCreated: s.PullReqCreated,
+		ReviewerAdded:  s.ReviewerAdded,
+		CommentCreated: s.CommentCreated,
+		PullReqMerged:  s.PullReqMerged,
+		PullReqClosed:  s.PullReqClosed,
+		BranchUpdated:  s.BranchUpdated,
+		Created:        s.Created,
+		Updated:        s.Updated,
+	}
+}
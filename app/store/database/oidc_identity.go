@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.OIDCIdentityStore = (*OIDCIdentityStore)(nil)
+
+// NewOIDCIdentityStore returns a new OIDCIdentityStore.
+func NewOIDCIdentityStore(db *sqlx.DB) *OIDCIdentityStore {
+	return &OIDCIdentityStore{
+		db: db,
+	}
+}
+
+// OIDCIdentityStore implements a store.OIDCIdentityStore backed by a relational database.
+type OIDCIdentityStore struct {
+	db *sqlx.DB
+}
+
+type oidcIdentity struct {
+	ID          int64  `db:"oidc_identity_id"`
+	PrincipalID int64  `db:"oidc_identity_principal_id"`
+	Issuer      string `db:"oidc_identity_issuer"`
+	Subject     string `db:"oidc_identity_subject"`
+	Created     int64  `db:"oidc_identity_created"`
+}
+
+const (
+	oidcIdentityColumns = `
+		 oidc_identity_id
+		,oidc_identity_principal_id
+		,oidc_identity_issuer
+		,oidc_identity_subject
+		,oidc_identity_created`
+
+	oidcIdentitySelectBase = `
+		SELECT` + oidcIdentityColumns + `
+		FROM oidc_identities`
+)
+
+// Find finds the identity by the issuer and the subject.
+func (s *OIDCIdentityStore) Find(ctx context.Context, issuer, subject string) (*types.OIDCIdentity, error) {
+	const sqlQuery = oidcIdentitySelectBase + `
+		WHERE oidc_identity_issuer = $1 AND oidc_identity_subject = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &oidcIdentity{}
+	if err := db.GetContext(ctx, dst, sqlQuery, issuer, subject); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find oidc identity")
+	}
+
+	return mapOIDCIdentity(dst), nil
+}
+
+// Create saves the identity.
+func (s *OIDCIdentityStore) Create(ctx context.Context, identity *types.OIDCIdentity) error {
+	const sqlQuery = `
+		INSERT INTO oidc_identities (
+			 oidc_identity_principal_id
+			,oidc_identity_issuer
+			,oidc_identity_subject
+			,oidc_identity_created
+		) values (
+			 :oidc_identity_principal_id
+			,:oidc_identity_issuer
+			,:oidc_identity_subject
+			,:oidc_identity_created
+		) RETURNING oidc_identity_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalOIDCIdentity(identity))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind oidc identity object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&identity.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert oidc identity query failed")
+	}
+
+	return nil
+}
+
+func mapOIDCIdentity(in *oidcIdentity) *types.OIDCIdentity {
+	return &types.OIDCIdentity{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Issuer:      in.Issuer,
+		Subject:     in.Subject,
+		Created:     in.Created,
+	}
+}
+
+func mapToInternalOIDCIdentity(in *types.OIDCIdentity) *oidcIdentity {
+	return &oidcIdentity{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Issuer:      in.Issuer,
+		Subject:     in.Subject,
+		Created:     in.Created,
+	}
+}
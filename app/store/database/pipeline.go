@@ -48,6 +48,8 @@ const (
 	,pipeline_repo_id
 	,pipeline_default_branch
 	,pipeline_config_path
+	,pipeline_retention_count
+	,pipeline_retention_days
 	,pipeline_created
 	,pipeline_updated
 	,pipeline_version
@@ -103,6 +105,8 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		,pipeline_created_by
 		,pipeline_default_branch
 		,pipeline_config_path
+		,pipeline_retention_count
+		,pipeline_retention_days
 		,pipeline_created
 		,pipeline_updated
 		,pipeline_version
@@ -115,6 +119,8 @@ func (s *pipelineStore) Create(ctx context.Context, pipeline *types.Pipeline) er
 		:pipeline_created_by,
 		:pipeline_default_branch,
 		:pipeline_config_path,
+		:pipeline_retention_count,
+		:pipeline_retention_days,
 		:pipeline_created,
 		:pipeline_updated,
 		:pipeline_version
@@ -144,6 +150,8 @@ func (s *pipelineStore) Update(ctx context.Context, p *types.Pipeline) error {
 		pipeline_disabled = :pipeline_disabled,
 		pipeline_default_branch = :pipeline_default_branch,
 		pipeline_config_path = :pipeline_config_path,
+		pipeline_retention_count = :pipeline_retention_count,
+		pipeline_retention_days = :pipeline_retention_days,
 		pipeline_updated = :pipeline_updated,
 		pipeline_version = :pipeline_version
 	WHERE pipeline_id = :pipeline_id AND pipeline_version = :pipeline_version - 1`
@@ -212,6 +220,34 @@ func (s *pipelineStore) List(
 	return dst, nil
 }
 
+// ListAll lists the pipelines of all repositories ordered by ID, starting after the provided pipeline ID.
+func (s *pipelineStore) ListAll(
+	ctx context.Context,
+	afterID int64,
+	limit int,
+) ([]*types.Pipeline, error) {
+	stmt := database.Builder.
+		Select(pipelineColumns).
+		From("pipelines").
+		Where("pipeline_id > ?", afterID).
+		OrderBy("pipeline_id").
+		Limit(database.Limit(limit))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.Pipeline{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list all query")
+	}
+
+	return dst, nil
+}
+
 // ListLatest lists all the pipelines under a repository with information
 // about the latest build if available.
 func (s *pipelineStore) ListLatest(
@@ -0,0 +1,207 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PipelineCacheStore = (*PipelineCacheStore)(nil)
+
+// NewPipelineCacheStore returns a new PipelineCacheStore.
+func NewPipelineCacheStore(db *sqlx.DB) *PipelineCacheStore {
+	return &PipelineCacheStore{
+		db: db,
+	}
+}
+
+// PipelineCacheStore implements a store.PipelineCacheStore backed by a relational database.
+type PipelineCacheStore struct {
+	db *sqlx.DB
+}
+
+type pipelineCache struct {
+	ID       int64  `db:"cache_id"`
+	RepoID   int64  `db:"cache_repo_id"`
+	Branch   string `db:"cache_branch"`
+	Key      string `db:"cache_key"`
+	Size     int64  `db:"cache_size"`
+	Created  int64  `db:"cache_created"`
+	Updated  int64  `db:"cache_updated"`
+	LastUsed int64  `db:"cache_last_used"`
+}
+
+const (
+	pipelineCacheColumns = `
+		 cache_id
+		,cache_repo_id
+		,cache_branch
+		,cache_key
+		,cache_size
+		,cache_created
+		,cache_updated
+		,cache_last_used`
+
+	pipelineCacheSelectBase = `
+		SELECT` + pipelineCacheColumns + `
+		FROM pipeline_caches`
+)
+
+// Find finds the cache entry with the key of the branch.
+func (s *PipelineCacheStore) Find(
+	ctx context.Context,
+	repoID int64,
+	branch string,
+	key string,
+) (*types.PipelineCache, error) {
+	const sqlQuery = pipelineCacheSelectBase + `
+		WHERE cache_repo_id = $1 AND cache_branch = $2 AND cache_key = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pipelineCache{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID, branch, key); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pipeline cache")
+	}
+
+	return mapToPipelineCache(dst), nil
+}
+
+// Upsert creates a new cache entry or replaces the entry with the same key of the branch.
+func (s *PipelineCacheStore) Upsert(ctx context.Context, cache *types.PipelineCache) error {
+	const sqlQuery = `
+		INSERT INTO pipeline_caches (
+			 cache_repo_id
+			,cache_branch
+			,cache_key
+			,cache_size
+			,cache_created
+			,cache_updated
+			,cache_last_used
+		) values (
+			 :cache_repo_id
+			,:cache_branch
+			,:cache_key
+			,:cache_size
+			,:cache_created
+			,:cache_updated
+			,:cache_last_used
+		)
+		ON CONFLICT (cache_repo_id, cache_branch, cache_key) DO
+		UPDATE SET
+			 cache_size = :cache_size
+			,cache_updated = :cache_updated
+			,cache_last_used = :cache_last_used
+		RETURNING cache_id, cache_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPipelineCache(cache))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pipeline cache object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&cache.ID, &cache.Created); err != nil {
+		return database.ProcessSQLErrorf(err, "Upsert pipeline cache query failed")
+	}
+
+	return nil
+}
+
+// Touch updates the time the cache entry was last used (unix milliseconds).
+func (s *PipelineCacheStore) Touch(ctx context.Context, id int64, lastUsed int64) error {
+	const sqlQuery = `
+		UPDATE pipeline_caches
+		SET cache_last_used = $1
+		WHERE cache_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, lastUsed, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update pipeline cache usage")
+	}
+
+	return nil
+}
+
+// ListUnused returns up to limit cache entries that weren't used since the provided time (unix milliseconds).
+func (s *PipelineCacheStore) ListUnused(ctx context.Context, usedBefore int64, limit int) ([]*types.PipelineCache, error) {
+	const sqlQuery = pipelineCacheSelectBase + `
+		WHERE cache_last_used < $1
+		ORDER BY cache_last_used
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pipelineCache, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, usedBefore, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list unused pipeline caches")
+	}
+
+	result := make([]*types.PipelineCache, len(dst))
+	for i, c := range dst {
+		result[i] = mapToPipelineCache(c)
+	}
+
+	return result, nil
+}
+
+// Delete deletes the cache entry with the given id.
+func (s *PipelineCacheStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM pipeline_caches
+		WHERE cache_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pipeline cache")
+	}
+
+	return nil
+}
+
+func mapToPipelineCache(c *pipelineCache) *types.PipelineCache {
+	return &types.PipelineCache{
+		ID:       c.ID,
+		RepoID:   c.RepoID,
+		Branch:   c.Branch,
+		Key:      c.Key,
+		Size:     c.Size,
+		Created:  c.Created,
+		Updated:  c.Updated,
+		LastUsed: c.LastUsed,
+	}
+}
+
+func mapToInternalPipelineCache(c *types.PipelineCache) *pipelineCache {
+	return &pipelineCache{
+		ID:       c.ID,
+		RepoID:   c.RepoID,
+		Branch:   c.Branch,
+		Key:      c.Key,
+		Size:     c.Size,
+		Created:  c.Created,
+		Updated:  c.Updated,
+		LastUsed: c.LastUsed,
+	}
+}
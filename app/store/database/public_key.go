@@ -0,0 +1,294 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PublicKeyStore = (*PublicKeyStore)(nil)
+
+// NewPublicKeyStore returns a new PublicKeyStore.
+func NewPublicKeyStore(db *sqlx.DB) *PublicKeyStore {
+	return &PublicKeyStore{
+		db: db,
+	}
+}
+
+// PublicKeyStore implements a store.PublicKeyStore backed by a relational database.
+type PublicKeyStore struct {
+	db *sqlx.DB
+}
+
+type publicKey struct {
+	ID          int64  `db:"public_key_id"`
+	PrincipalID int64  `db:"public_key_principal_id"`
+	Created     int64  `db:"public_key_created"`
+	UID         string `db:"public_key_uid"`
+	Fingerprint string `db:"public_key_fingerprint"`
+	Type        string `db:"public_key_type"`
+	Content     string `db:"public_key_content"`
+}
+
+const (
+	publicKeyColumns = `
+		 public_key_id
+		,public_key_principal_id
+		,public_key_created
+		,public_key_uid
+		,public_key_fingerprint
+		,public_key_type
+		,public_key_content`
+
+	publicKeySelectBase = `
+		SELECT` + publicKeyColumns + `
+		FROM public_keys`
+)
+
+// Find finds the public key by id.
+func (s *PublicKeyStore) Find(ctx context.Context, id int64) (*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+		WHERE public_key_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &publicKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find public key")
+	}
+
+	key := mapToPublicKey(dst)
+
+	return &key, nil
+}
+
+// FindByUID finds the public key by principal id and public key UID.
+func (s *PublicKeyStore) FindByUID(ctx context.Context, principalID int64, uid string) (*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+		WHERE public_key_principal_id = $1 AND LOWER(public_key_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &publicKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, strings.ToLower(uid)); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find public key by uid")
+	}
+
+	key := mapToPublicKey(dst)
+
+	return &key, nil
+}
+
+// FindByFingerprint finds the public key by its fingerprint.
+func (s *PublicKeyStore) FindByFingerprint(ctx context.Context, fingerprint string) (*types.PublicKey, error) {
+	const sqlQuery = publicKeySelectBase + `
+		WHERE public_key_fingerprint = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &publicKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, fingerprint); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find public key by fingerprint")
+	}
+
+	key := mapToPublicKey(dst)
+
+	return &key, nil
+}
+
+// Create saves the public key details.
+func (s *PublicKeyStore) Create(ctx context.Context, key *types.PublicKey) error {
+	const sqlQuery = `
+		INSERT INTO public_keys (
+			 public_key_principal_id
+			,public_key_created
+			,public_key_uid
+			,public_key_fingerprint
+			,public_key_type
+			,public_key_content
+		) values (
+			 :public_key_principal_id
+			,:public_key_created
+			,:public_key_uid
+			,:public_key_fingerprint
+			,:public_key_type
+			,:public_key_content
+		) RETURNING public_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbKey := mapToInternalPublicKey(key)
+
+	query, arg, err := db.BindNamed(sqlQuery, &dbKey)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind public key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert public key query failed")
+	}
+
+	return nil
+}
+
+// DeleteByUID deletes the public key with the given UID of the principal.
+func (s *PublicKeyStore) DeleteByUID(ctx context.Context, principalID int64, uid string) error {
+	const sqlQuery = `
+		DELETE FROM public_keys
+		WHERE public_key_principal_id = $1 AND LOWER(public_key_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, strings.ToLower(uid)); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete public key query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of public keys of the principal.
+func (s *PublicKeyStore) Count(
+	ctx context.Context,
+	principalID int64,
+	filter *types.PublicKeyFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("public_keys").
+		Where("public_key_principal_id = ?", principalID)
+
+	stmt = s.applyQueryFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count public keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count public keys query")
+	}
+
+	return count, nil
+}
+
+// List returns the public keys of the principal.
+func (s *PublicKeyStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.PublicKeyFilter,
+) ([]types.PublicKey, error) {
+	stmt := database.Builder.
+		Select(publicKeyColumns).
+		From("public_keys").
+		Where("public_key_principal_id = ?", principalID)
+
+	stmt = s.applyQueryFilter(stmt, filter)
+	stmt = s.applySortFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list public keys query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	keys := make([]publicKey, 0)
+	if err = db.SelectContext(ctx, &keys, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list public keys query")
+	}
+
+	return mapToPublicKeys(keys), nil
+}
+
+func (*PublicKeyStore) applyQueryFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.PublicKeyFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(public_key_uid) LIKE ?",
+			fmt.Sprintf("%%%s%%", strings.ToLower(filter.Query)))
+	}
+
+	return stmt
+}
+
+func (*PublicKeyStore) applySortFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.PublicKeyFilter,
+) squirrel.SelectBuilder {
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	order := filter.Order
+	if order == enum.OrderDefault {
+		order = enum.OrderAsc
+	}
+
+	switch filter.Sort {
+	case enum.PublicKeySortUID:
+		stmt = stmt.OrderBy("LOWER(public_key_uid) " + order.String())
+	case enum.PublicKeySortCreated:
+		stmt = stmt.OrderBy("public_key_created " + order.String())
+	}
+
+	return stmt
+}
+
+func mapToInternalPublicKey(in *types.PublicKey) publicKey {
+	return publicKey{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Created:     in.Created,
+		UID:         in.UID,
+		Fingerprint: in.Fingerprint,
+		Type:        in.Type,
+		Content:     in.Content,
+	}
+}
+
+func mapToPublicKey(in *publicKey) types.PublicKey {
+	return types.PublicKey{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Created:     in.Created,
+		UID:         in.UID,
+		Fingerprint: in.Fingerprint,
+		Type:        in.Type,
+		Content:     in.Content,
+	}
+}
+
+func mapToPublicKeys(keys []publicKey) []types.PublicKey {
+	res := make([]types.PublicKey, len(keys))
+	for i := range keys {
+		res[i] = mapToPublicKey(&keys[i])
+	}
+	return res
+}
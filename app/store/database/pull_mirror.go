@@ -0,0 +1,308 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.PullMirrorStore = (*PullMirrorStore)(nil)
+
+// NewPullMirrorStore returns a new PullMirrorStore.
+func NewPullMirrorStore(db *sqlx.DB) *PullMirrorStore {
+	return &PullMirrorStore{
+		db: db,
+	}
+}
+
+// PullMirrorStore implements a store.PullMirrorStore backed by a relational database.
+type PullMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pullMirror struct {
+	ID         int64                `db:"pull_mirror_id"`
+	RepoID     int64                `db:"pull_mirror_repo_id"`
+	URL        string               `db:"pull_mirror_url"`
+	Username   string               `db:"pull_mirror_username"`
+	Password   []byte               `db:"pull_mirror_password"`
+	Interval   int64                `db:"pull_mirror_interval"`
+	State      enum.MirrorSyncState `db:"pull_mirror_state"`
+	LastSync   int64                `db:"pull_mirror_last_sync"`
+	LastSynced int64                `db:"pull_mirror_last_synced"`
+	LastError  string               `db:"pull_mirror_last_error"`
+	NextSync   int64                `db:"pull_mirror_next_sync"`
+	CreatedBy  int64                `db:"pull_mirror_created_by"`
+	Created    int64                `db:"pull_mirror_created"`
+	Updated    int64                `db:"pull_mirror_updated"`
+	Version    int64                `db:"pull_mirror_version"`
+}
+
+const (
+	pullMirrorColumns = `
+		 pull_mirror_id
+		,pull_mirror_repo_id
+		,pull_mirror_url
+		,pull_mirror_username
+		,pull_mirror_password
+		,pull_mirror_interval
+		,pull_mirror_state
+		,pull_mirror_last_sync
+		,pull_mirror_last_synced
+		,pull_mirror_last_error
+		,pull_mirror_next_sync
+		,pull_mirror_created_by
+		,pull_mirror_created
+		,pull_mirror_updated
+		,pull_mirror_version`
+
+	pullMirrorSelectBase = `
+		SELECT` + pullMirrorColumns + `
+		FROM pull_mirrors`
+)
+
+// Find finds the pull mirror of the repository.
+func (s *PullMirrorStore) Find(ctx context.Context, repoID int64) (*types.PullMirror, error) {
+	const sqlQuery = pullMirrorSelectBase + `
+		WHERE pull_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pullMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find pull mirror")
+	}
+
+	return mapToPullMirror(dst), nil
+}
+
+// Create creates a new pull mirror.
+func (s *PullMirrorStore) Create(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		INSERT INTO pull_mirrors (
+			 pull_mirror_repo_id
+			,pull_mirror_url
+			,pull_mirror_username
+			,pull_mirror_password
+			,pull_mirror_interval
+			,pull_mirror_state
+			,pull_mirror_last_sync
+			,pull_mirror_last_synced
+			,pull_mirror_last_error
+			,pull_mirror_next_sync
+			,pull_mirror_created_by
+			,pull_mirror_created
+			,pull_mirror_updated
+			,pull_mirror_version
+		) values (
+			 :pull_mirror_repo_id
+			,:pull_mirror_url
+			,:pull_mirror_username
+			,:pull_mirror_password
+			,:pull_mirror_interval
+			,:pull_mirror_state
+			,:pull_mirror_last_sync
+			,:pull_mirror_last_synced
+			,:pull_mirror_last_error
+			,:pull_mirror_next_sync
+			,:pull_mirror_created_by
+			,:pull_mirror_created
+			,:pull_mirror_updated
+			,:pull_mirror_version
+		) RETURNING pull_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull mirror object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert pull mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the pull mirror.
+// Returns store.ErrVersionConflict if the pull mirror was updated in the meantime.
+func (s *PullMirrorStore) Update(ctx context.Context, mirror *types.PullMirror) error {
+	const sqlQuery = `
+		UPDATE pull_mirrors
+		SET
+			 pull_mirror_version = :pull_mirror_version
+			,pull_mirror_updated = :pull_mirror_updated
+			,pull_mirror_url = :pull_mirror_url
+			,pull_mirror_username = :pull_mirror_username
+			,pull_mirror_password = :pull_mirror_password
+			,pull_mirror_interval = :pull_mirror_interval
+			,pull_mirror_state = :pull_mirror_state
+			,pull_mirror_last_sync = :pull_mirror_last_sync
+			,pull_mirror_last_synced = :pull_mirror_last_synced
+			,pull_mirror_last_error = :pull_mirror_last_error
+			,pull_mirror_next_sync = :pull_mirror_next_sync
+		WHERE pull_mirror_id = :pull_mirror_id AND pull_mirror_version = :pull_mirror_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMirror := mapToInternalPullMirror(mirror)
+
+	// update Version (used for optimistic locking) and Updated time
+	dbMirror.Version++
+	dbMirror.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind pull mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update pull mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	mirror.Version = dbMirror.Version
+	mirror.Updated = dbMirror.Updated
+
+	return nil
+}
+
+// UpdateOptLock updates the pull mirror using the optimistic locking mechanism.
+func (s *PullMirrorStore) UpdateOptLock(
+	ctx context.Context,
+	mirror *types.PullMirror,
+	mutateFn func(mirror *types.PullMirror) error,
+) (*types.PullMirror, error) {
+	for {
+		dup := *mirror
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, fmt.Errorf("failed to update the pull mirror: %w", err)
+		}
+
+		mirror, err = s.Find(ctx, mirror.RepoID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the latest version of the pull mirror: %w", err)
+		}
+	}
+}
+
+// Delete deletes the pull mirror of the repository.
+func (s *PullMirrorStore) Delete(ctx context.Context, repoID int64) error {
+	const sqlQuery = `
+		DELETE FROM pull_mirrors
+		WHERE pull_mirror_repo_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, repoID); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete pull mirror")
+	}
+
+	return nil
+}
+
+// ListDue returns up to limit pull mirrors whose next sync is due at the provided time (unix milliseconds).
+func (s *PullMirrorStore) ListDue(ctx context.Context, now int64, limit int) ([]*types.PullMirror, error) {
+	const sqlQuery = pullMirrorSelectBase + `
+		WHERE pull_mirror_next_sync <= $1
+		ORDER BY pull_mirror_next_sync
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pullMirror{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, now, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list due pull mirrors")
+	}
+
+	res := make([]*types.PullMirror, len(dst))
+	for i := range dst {
+		res[i] = mapToPullMirror(dst[i])
+	}
+
+	return res, nil
+}
+
+func mapToPullMirror(m *pullMirror) *types.PullMirror {
+	return &types.PullMirror{
+		ID:         m.ID,
+		RepoID:     m.RepoID,
+		URL:        m.URL,
+		Username:   m.Username,
+		Password:   m.Password,
+		Interval:   m.Interval,
+		State:      m.State,
+		LastSync:   m.LastSync,
+		LastSynced: m.LastSynced,
+		LastError:  m.LastError,
+		NextSync:   m.NextSync,
+		CreatedBy:  m.CreatedBy,
+		Created:    m.Created,
+		Updated:    m.Updated,
+		Version:    m.Version,
+	}
+}
+
+func mapToInternalPullMirror(m *types.PullMirror) *pullMirror {
+	return &pullMirror{
+		ID:         m.ID,
+		RepoID:     m.RepoID,
+		URL:        m.URL,
+		Username:   m.Username,
+		Password:   m.Password,
+		Interval:   m.Interval,
+		State:      m.State,
+		LastSync:   m.LastSync,
+		LastSynced: m.LastSynced,
+		LastError:  m.LastError,
+		NextSync:   m.NextSync,
+		CreatedBy:  m.CreatedBy,
+		Created:    m.Created,
+		Updated:    m.Updated,
+		Version:    m.Version,
+	}
+}
@@ -454,6 +454,30 @@ func (s *PullReqStore) Count(ctx context.Context, opts *types.PullReqFilter) (in
 	return count, nil
 }
 
+// ContainsText returns whether the description of any pull request of the target repo contains the text.
+func (s *PullReqStore) ContainsText(ctx context.Context, repoID int64, text string) (bool, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("pullreqs").
+		Where("pullreq_target_repo_id = ?", repoID).
+		Where("pullreq_description LIKE ?", fmt.Sprintf("%%%s%%", text))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(err, "Failed executing contains text query")
+	}
+
+	return count > 0, nil
+}
+
 // List returns a list of pull requests for a repo.
 func (s *PullReqStore) List(ctx context.Context, opts *types.PullReqFilter) ([]*types.PullReq, error) {
 	stmt := database.Builder.
@@ -380,6 +380,30 @@ func (s *PullReqActivityStore) Count(ctx context.Context,
 	return count, nil
 }
 
+// ContainsText returns whether the text of any pull request activity of the repo contains the text.
+func (s *PullReqActivityStore) ContainsText(ctx context.Context, repoID int64, text string) (bool, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("pullreq_activities").
+		Where("pullreq_activity_repo_id = ?", repoID).
+		Where("pullreq_activity_text LIKE ?", fmt.Sprintf("%%%s%%", text))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(err, "Failed executing contains text query")
+	}
+
+	return count > 0, nil
+}
+
 // List returns a list of pull requests for a repo.
 func (s *PullReqActivityStore) List(ctx context.Context,
 	prID int64,
@@ -0,0 +1,332 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
+)
+
+var _ store.PushMirrorStore = (*PushMirrorStore)(nil)
+
+// NewPushMirrorStore returns a new PushMirrorStore.
+func NewPushMirrorStore(db *sqlx.DB) *PushMirrorStore {
+	return &PushMirrorStore{
+		db: db,
+	}
+}
+
+// PushMirrorStore implements a store.PushMirrorStore backed by a relational database.
+type PushMirrorStore struct {
+	db *sqlx.DB
+}
+
+type pushMirror struct {
+	ID           int64                `db:"push_mirror_id"`
+	RepoID       int64                `db:"push_mirror_repo_id"`
+	URL          string               `db:"push_mirror_url"`
+	Username     string               `db:"push_mirror_username"`
+	Password     []byte               `db:"push_mirror_password"`
+	Enabled      bool                 `db:"push_mirror_enabled"`
+	BranchFilter sqlxtypes.JSONText   `db:"push_mirror_branch_filter"`
+	State        enum.MirrorSyncState `db:"push_mirror_state"`
+	LastPush     int64                `db:"push_mirror_last_push"`
+	LastPushed   int64                `db:"push_mirror_last_pushed"`
+	Errors       sqlxtypes.JSONText   `db:"push_mirror_errors"`
+	CreatedBy    int64                `db:"push_mirror_created_by"`
+	Created      int64                `db:"push_mirror_created"`
+	Updated      int64                `db:"push_mirror_updated"`
+	Version      int64                `db:"push_mirror_version"`
+}
+
+const (
+	pushMirrorColumns = `
+		 push_mirror_id
+		,push_mirror_repo_id
+		,push_mirror_url
+		,push_mirror_username
+		,push_mirror_password
+		,push_mirror_enabled
+		,push_mirror_branch_filter
+		,push_mirror_state
+		,push_mirror_last_push
+		,push_mirror_last_pushed
+		,push_mirror_errors
+		,push_mirror_created_by
+		,push_mirror_created
+		,push_mirror_updated
+		,push_mirror_version`
+
+	pushMirrorSelectBase = `
+		SELECT` + pushMirrorColumns + `
+		FROM push_mirrors`
+)
+
+// Find finds the push mirror by id.
+func (s *PushMirrorStore) Find(ctx context.Context, id int64) (*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+		WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &pushMirror{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find push mirror")
+	}
+
+	return mapToPushMirror(dst)
+}
+
+// List returns all push mirrors of the repository.
+func (s *PushMirrorStore) List(ctx context.Context, repoID int64) ([]*types.PushMirror, error) {
+	const sqlQuery = pushMirrorSelectBase + `
+		WHERE push_mirror_repo_id = $1
+		ORDER BY push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*pushMirror{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list push mirrors")
+	}
+
+	res := make([]*types.PushMirror, len(dst))
+	for i := range dst {
+		mirror, err := mapToPushMirror(dst[i])
+		if err != nil {
+			return nil, err
+		}
+		res[i] = mirror
+	}
+
+	return res, nil
+}
+
+// Create creates a new push mirror.
+func (s *PushMirrorStore) Create(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		INSERT INTO push_mirrors (
+			 push_mirror_repo_id
+			,push_mirror_url
+			,push_mirror_username
+			,push_mirror_password
+			,push_mirror_enabled
+			,push_mirror_branch_filter
+			,push_mirror_state
+			,push_mirror_last_push
+			,push_mirror_last_pushed
+			,push_mirror_errors
+			,push_mirror_created_by
+			,push_mirror_created
+			,push_mirror_updated
+			,push_mirror_version
+		) values (
+			 :push_mirror_repo_id
+			,:push_mirror_url
+			,:push_mirror_username
+			,:push_mirror_password
+			,:push_mirror_enabled
+			,:push_mirror_branch_filter
+			,:push_mirror_state
+			,:push_mirror_last_push
+			,:push_mirror_last_pushed
+			,:push_mirror_errors
+			,:push_mirror_created_by
+			,:push_mirror_created
+			,:push_mirror_updated
+			,:push_mirror_version
+		) RETURNING push_mirror_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPushMirror(mirror))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind push mirror object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&mirror.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert push mirror query failed")
+	}
+
+	return nil
+}
+
+// Update updates the push mirror.
+// Returns store.ErrVersionConflict if the push mirror was updated in the meantime.
+func (s *PushMirrorStore) Update(ctx context.Context, mirror *types.PushMirror) error {
+	const sqlQuery = `
+		UPDATE push_mirrors
+		SET
+			 push_mirror_version = :push_mirror_version
+			,push_mirror_updated = :push_mirror_updated
+			,push_mirror_url = :push_mirror_url
+			,push_mirror_username = :push_mirror_username
+			,push_mirror_password = :push_mirror_password
+			,push_mirror_enabled = :push_mirror_enabled
+			,push_mirror_branch_filter = :push_mirror_branch_filter
+			,push_mirror_state = :push_mirror_state
+			,push_mirror_last_push = :push_mirror_last_push
+			,push_mirror_last_pushed = :push_mirror_last_pushed
+			,push_mirror_errors = :push_mirror_errors
+		WHERE push_mirror_id = :push_mirror_id AND push_mirror_version = :push_mirror_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbMirror := mapToInternalPushMirror(mirror)
+
+	// update Version (used for optimistic locking) and Updated time
+	dbMirror.Version++
+	dbMirror.Updated = time.Now().UnixMilli()
+
+	query, arg, err := db.BindNamed(sqlQuery, dbMirror)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind push mirror object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update push mirror")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	mirror.Version = dbMirror.Version
+	mirror.Updated = dbMirror.Updated
+
+	return nil
+}
+
+// UpdateOptLock updates the push mirror using the optimistic locking mechanism.
+func (s *PushMirrorStore) UpdateOptLock(
+	ctx context.Context,
+	mirror *types.PushMirror,
+	mutateFn func(mirror *types.PushMirror) error,
+) (*types.PushMirror, error) {
+	for {
+		dup := *mirror
+
+		err := mutateFn(&dup)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.Update(ctx, &dup)
+		if err == nil {
+			return &dup, nil
+		}
+		if !errors.Is(err, gitness_store.ErrVersionConflict) {
+			return nil, fmt.Errorf("failed to update the push mirror: %w", err)
+		}
+
+		mirror, err = s.Find(ctx, mirror.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the latest version of the push mirror: %w", err)
+		}
+	}
+}
+
+// Delete deletes the push mirror with the given id.
+func (s *PushMirrorStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM push_mirrors
+		WHERE push_mirror_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to delete push mirror")
+	}
+
+	return nil
+}
+
+func mapToPushMirror(m *pushMirror) (*types.PushMirror, error) {
+	var branchFilter []string
+	if err := m.BranchFilter.Unmarshal(&branchFilter); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal push mirror branch filter: %w", err)
+	}
+
+	var pushErrors []types.PushMirrorError
+	if err := m.Errors.Unmarshal(&pushErrors); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal push mirror errors: %w", err)
+	}
+
+	return &types.PushMirror{
+		ID:           m.ID,
+		RepoID:       m.RepoID,
+		URL:          m.URL,
+		Username:     m.Username,
+		Password:     m.Password,
+		Enabled:      m.Enabled,
+		BranchFilter: branchFilter,
+		State:        m.State,
+		LastPush:     m.LastPush,
+		LastPushed:   m.LastPushed,
+		Errors:       pushErrors,
+		CreatedBy:    m.CreatedBy,
+		Created:      m.Created,
+		Updated:      m.Updated,
+		Version:      m.Version,
+	}, nil
+}
+
+func mapToInternalPushMirror(m *types.PushMirror) *pushMirror {
+	branchFilter := m.BranchFilter
+	if branchFilter == nil {
+		branchFilter = []string{}
+	}
+
+	pushErrors := m.Errors
+	if pushErrors == nil {
+		pushErrors = []types.PushMirrorError{}
+	}
+
+	return &pushMirror{
+		ID:           m.ID,
+		RepoID:       m.RepoID,
+		URL:          m.URL,
+		Username:     m.Username,
+		Password:     m.Password,
+		Enabled:      m.Enabled,
+		BranchFilter: EncodeToSQLXJSON(branchFilter),
+		State:        m.State,
+		LastPush:     m.LastPush,
+		LastPushed:   m.LastPushed,
+		Errors:       EncodeToSQLXJSON(pushErrors),
+		CreatedBy:    m.CreatedBy,
+		Created:      m.Created,
+		Updated:      m.Updated,
+		Version:      m.Version,
+	}
+}
@@ -235,6 +235,7 @@ func (s *RepoStore) Update(ctx context.Context, repo *types.Repository) error {
 			,repo_is_public = :repo_is_public
 			,repo_default_branch = :repo_default_branch
 			,repo_pullreq_seq = :repo_pullreq_seq
+			,repo_fork_id = :repo_fork_id
 			,repo_num_forks = :repo_num_forks
 			,repo_num_pulls = :repo_num_pulls
 			,repo_num_closed_pulls = :repo_num_closed_pulls
@@ -395,6 +396,39 @@ func (s *RepoStore) List(ctx context.Context, parentID int64, opts *types.RepoFi
 	return s.mapToRepos(ctx, dst)
 }
 
+// ListForks returns all repositories that are forks of the repository with the provided id.
+func (s *RepoStore) ListForks(ctx context.Context, forkID int64) ([]*types.Repository, error) {
+	const sqlQuery = repoSelectBase + `
+		WHERE repo_fork_id = $1
+		ORDER BY repo_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, forkID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list forks of repo")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
+// ListAll lists all repos ordered by ID, starting after the provided repo ID.
+func (s *RepoStore) ListAll(ctx context.Context, afterID int64, limit int) ([]*types.Repository, error) {
+	const sqlQuery = repoSelectBase + `
+		WHERE repo_id > $1
+		ORDER BY repo_id
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*repository{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, afterID, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list all repos")
+	}
+
+	return s.mapToRepos(ctx, dst)
+}
+
 func (s *RepoStore) mapToRepo(
 	ctx context.Context,
 	in *repository,
@@ -0,0 +1,297 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.RunnerStore = (*RunnerStore)(nil)
+
+// NewRunnerStore returns a new RunnerStore.
+func NewRunnerStore(db *sqlx.DB) *RunnerStore {
+	return &RunnerStore{
+		db: db,
+	}
+}
+
+// RunnerStore implements a store.RunnerStore backed by a relational database.
+type RunnerStore struct {
+	db *sqlx.DB
+}
+
+type runner struct {
+	ID          int64  `db:"runner_id"`
+	UID         string `db:"runner_uid"`
+	Description string `db:"runner_description"`
+	TokenHash   string `db:"runner_token_hash"`
+	CreatedBy   int64  `db:"runner_created_by"`
+	Created     int64  `db:"runner_created"`
+	Updated     int64  `db:"runner_updated"`
+	LastSeen    int64  `db:"runner_last_seen"`
+	Machine     string `db:"runner_machine"`
+	OS          string `db:"runner_os"`
+	Arch        string `db:"runner_arch"`
+}
+
+const (
+	runnerColumns = `
+		 runner_id
+		,runner_uid
+		,runner_description
+		,runner_token_hash
+		,runner_created_by
+		,runner_created
+		,runner_updated
+		,runner_last_seen
+		,runner_machine
+		,runner_os
+		,runner_arch`
+
+	runnerSelectBase = `
+		SELECT` + runnerColumns + `
+		FROM runners`
+)
+
+// Find finds the runner by id.
+func (s *RunnerStore) Find(ctx context.Context, id int64) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+		WHERE runner_id = $1`
+
+	return s.find(ctx, sqlQuery, id)
+}
+
+// FindByUID finds the runner by uid.
+func (s *RunnerStore) FindByUID(ctx context.Context, uid string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+		WHERE LOWER(runner_uid) = $1`
+
+	return s.find(ctx, sqlQuery, strings.ToLower(uid))
+}
+
+// FindByTokenHash finds the runner by the hash of its registration token.
+func (s *RunnerStore) FindByTokenHash(ctx context.Context, tokenHash string) (*types.Runner, error) {
+	const sqlQuery = runnerSelectBase + `
+		WHERE runner_token_hash = $1`
+
+	return s.find(ctx, sqlQuery, tokenHash)
+}
+
+func (s *RunnerStore) find(ctx context.Context, sqlQuery string, arg any) (*types.Runner, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &runner{}
+	if err := db.GetContext(ctx, dst, sqlQuery, arg); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find runner")
+	}
+
+	return mapToRunner(dst), nil
+}
+
+// Create saves the runner details.
+func (s *RunnerStore) Create(ctx context.Context, runner *types.Runner) error {
+	const sqlQuery = `
+		INSERT INTO runners (
+			 runner_uid
+			,runner_description
+			,runner_token_hash
+			,runner_created_by
+			,runner_created
+			,runner_updated
+			,runner_last_seen
+			,runner_machine
+			,runner_os
+			,runner_arch
+		) values (
+			 :runner_uid
+			,:runner_description
+			,:runner_token_hash
+			,:runner_created_by
+			,:runner_created
+			,:runner_updated
+			,:runner_last_seen
+			,:runner_machine
+			,:runner_os
+			,:runner_arch
+		) RETURNING runner_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalRunner(runner))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind runner object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&runner.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert runner query failed")
+	}
+
+	return nil
+}
+
+// UpdateHeartbeat updates the heartbeat information of the runner.
+// Empty machine, os or arch values don't overwrite the existing values.
+func (s *RunnerStore) UpdateHeartbeat(ctx context.Context, id int64, heartbeat types.RunnerHeartbeat) error {
+	stmt := database.Builder.
+		Update("runners").
+		Set("runner_last_seen", heartbeat.LastSeen).
+		Where("runner_id = ?", id)
+
+	if heartbeat.Machine != "" {
+		stmt = stmt.Set("runner_machine", heartbeat.Machine)
+	}
+	if heartbeat.OS != "" {
+		stmt = stmt.Set("runner_os", heartbeat.OS)
+	}
+	if heartbeat.Arch != "" {
+		stmt = stmt.Set("runner_arch", heartbeat.Arch)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to convert update runner heartbeat query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update runner heartbeat query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the runner with the given id.
+func (s *RunnerStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM runners
+		WHERE runner_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete runner query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of runners.
+func (s *RunnerStore) Count(ctx context.Context, filter *types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("runners")
+
+	stmt = applyRunnerFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count runners query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count runners query")
+	}
+
+	return count, nil
+}
+
+// List returns the runners.
+func (s *RunnerStore) List(ctx context.Context, filter *types.ListQueryFilter) ([]*types.Runner, error) {
+	stmt := database.Builder.
+		Select(runnerColumns).
+		From("runners")
+
+	stmt = applyRunnerFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("LOWER(runner_uid) ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list runners query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*runner, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list runners query")
+	}
+
+	result := make([]*types.Runner, len(dst))
+	for i := range dst {
+		result[i] = mapToRunner(dst[i])
+	}
+
+	return result, nil
+}
+
+func applyRunnerFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.ListQueryFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(runner_uid) LIKE ?", "%"+strings.ToLower(filter.Query)+"%")
+	}
+
+	return stmt
+}
+
+func mapToRunner(r *runner) *types.Runner {
+	return &types.Runner{
+		ID:          r.ID,
+		UID:         r.UID,
+		Description: r.Description,
+		TokenHash:   r.TokenHash,
+		CreatedBy:   r.CreatedBy,
+		Created:     r.Created,
+		Updated:     r.Updated,
+		LastSeen:    r.LastSeen,
+		Machine:     r.Machine,
+		OS:          r.OS,
+		Arch:        r.Arch,
+	}
+}
+
+func mapToInternalRunner(r *types.Runner) *runner {
+	return &runner{
+		ID:          r.ID,
+		UID:         r.UID,
+		Description: r.Description,
+		TokenHash:   r.TokenHash,
+		CreatedBy:   r.CreatedBy,
+		Created:     r.Created,
+		Updated:     r.Updated,
+		LastSeen:    r.LastSeen,
+		Machine:     r.Machine,
+		OS:          r.OS,
+		Arch:        r.Arch,
+	}
+}
@@ -246,6 +246,44 @@ func (s *secretStore) ListAll(ctx context.Context, parentID int64) ([]*types.Sec
 	return dst, nil
 }
 
+// ListAllInherited lists all the secrets visible in a space, including the ones defined in its ancestors.
+// The secrets are ordered by uid, in case of conflicting uids the secret of the nearest space wins.
+func (s *secretStore) ListAllInherited(ctx context.Context, spaceID int64) ([]*types.Secret, error) {
+	const query = `
+		WITH RECURSIVE
+			space_ancestors(space_id, space_parent_id, space_depth) AS (
+				SELECT space_id, space_parent_id, 0
+				FROM spaces
+				WHERE space_id = $1
+				UNION ALL
+				SELECT spaces.space_id, spaces.space_parent_id, space_ancestors.space_depth + 1
+				FROM spaces
+				INNER JOIN space_ancestors ON space_ancestors.space_parent_id = spaces.space_id
+			)
+		SELECT` + secretColumns + `
+		FROM secrets
+		INNER JOIN space_ancestors ON space_ancestors.space_id = secrets.secret_space_id
+		ORDER BY secret_uid ASC, space_ancestors.space_depth ASC`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*types.Secret{}
+	if err := db.SelectContext(ctx, &dst, query, spaceID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing custom list query")
+	}
+
+	// secrets are ordered by uid and depth - only keep the first secret of every uid.
+	result := make([]*types.Secret, 0, len(dst))
+	for _, secret := range dst {
+		if len(result) > 0 && result[len(result)-1].UID == secret.UID {
+			continue
+		}
+		result = append(result, secret)
+	}
+
+	return result, nil
+}
+
 // Delete deletes a secret given a secret ID.
 func (s *secretStore) Delete(ctx context.Context, id int64) error {
 	//nolint:gosec // wrong flagging
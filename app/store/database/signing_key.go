@@ -0,0 +1,262 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.SigningKeyStore = (*SigningKeyStore)(nil)
+
+// NewSigningKeyStore returns a new SigningKeyStore.
+func NewSigningKeyStore(db *sqlx.DB) *SigningKeyStore {
+	return &SigningKeyStore{
+		db: db,
+	}
+}
+
+// SigningKeyStore implements a store.SigningKeyStore backed by a relational database.
+type SigningKeyStore struct {
+	db *sqlx.DB
+}
+
+type signingKey struct {
+	ID          int64               `db:"signing_key_id"`
+	PrincipalID int64               `db:"signing_key_principal_id"`
+	Created     int64               `db:"signing_key_created"`
+	UID         string              `db:"signing_key_uid"`
+	Type        enum.SigningKeyType `db:"signing_key_type"`
+	Fingerprint string              `db:"signing_key_fingerprint"`
+	Content     string              `db:"signing_key_content"`
+}
+
+type signingKeyID struct {
+	SigningKeyID int64  `db:"signing_key_id_signing_key_id"`
+	KeyID        string `db:"signing_key_id_key_id"`
+}
+
+const (
+	signingKeyColumns = `
+		 signing_key_id
+		,signing_key_principal_id
+		,signing_key_created
+		,signing_key_uid
+		,signing_key_type
+		,signing_key_fingerprint
+		,signing_key_content`
+
+	signingKeySelectBase = `
+		SELECT` + signingKeyColumns + `
+		FROM signing_keys`
+)
+
+// FindByUID finds the signing key by principal id and signing key UID.
+func (s *SigningKeyStore) FindByUID(ctx context.Context, principalID int64, uid string) (*types.SigningKey, error) {
+	const sqlQuery = signingKeySelectBase + `
+		WHERE signing_key_principal_id = $1 AND LOWER(signing_key_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &signingKey{}
+	if err := db.GetContext(ctx, dst, sqlQuery, principalID, strings.ToLower(uid)); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find signing key by uid")
+	}
+
+	keys, err := s.mapSigningKeys(ctx, []*signingKey{dst})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys[0], nil
+}
+
+// ListByKeyID returns all signing keys that can be referenced by the key id.
+func (s *SigningKeyStore) ListByKeyID(ctx context.Context, keyID string) ([]*types.SigningKey, error) {
+	const sqlQuery = signingKeySelectBase + `
+		WHERE signing_key_id IN (
+			SELECT signing_key_id_signing_key_id
+			FROM signing_key_ids
+			WHERE signing_key_id_key_id = $1
+		)
+		ORDER BY signing_key_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*signingKey, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, keyID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list signing keys by key id")
+	}
+
+	return s.mapSigningKeys(ctx, dst)
+}
+
+// List returns the signing keys of the principal.
+func (s *SigningKeyStore) List(ctx context.Context, principalID int64) ([]*types.SigningKey, error) {
+	const sqlQuery = signingKeySelectBase + `
+		WHERE signing_key_principal_id = $1
+		ORDER BY signing_key_created`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*signingKey, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to list signing keys")
+	}
+
+	return s.mapSigningKeys(ctx, dst)
+}
+
+// Create saves the signing key together with its key ids.
+// It should be called in a transaction.
+func (s *SigningKeyStore) Create(ctx context.Context, key *types.SigningKey) error {
+	const sqlQuery = `
+		INSERT INTO signing_keys (
+			 signing_key_principal_id
+			,signing_key_created
+			,signing_key_uid
+			,signing_key_type
+			,signing_key_fingerprint
+			,signing_key_content
+		) values (
+			 :signing_key_principal_id
+			,:signing_key_created
+			,:signing_key_uid
+			,:signing_key_type
+			,:signing_key_fingerprint
+			,:signing_key_content
+		) RETURNING signing_key_id`
+
+	const sqlQueryKeyID = `
+		INSERT INTO signing_key_ids (
+			 signing_key_id_signing_key_id
+			,signing_key_id_key_id
+		) values (
+			 $1
+			,$2
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbKey := mapToInternalSigningKey(key)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbKey)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind signing key object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&key.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert signing key query failed")
+	}
+
+	for _, keyID := range key.KeyIDs {
+		if _, err = db.ExecContext(ctx, sqlQueryKeyID, key.ID, keyID); err != nil {
+			return database.ProcessSQLErrorf(err, "Insert signing key id query failed")
+		}
+	}
+
+	return nil
+}
+
+// DeleteByUID deletes the signing key with the given UID of the principal.
+func (s *SigningKeyStore) DeleteByUID(ctx context.Context, principalID int64, uid string) error {
+	const sqlQuery = `
+		DELETE FROM signing_keys
+		WHERE signing_key_principal_id = $1 AND LOWER(signing_key_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, principalID, strings.ToLower(uid)); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete signing key query failed")
+	}
+
+	return nil
+}
+
+// mapSigningKeys maps the signing keys and loads their key ids.
+func (s *SigningKeyStore) mapSigningKeys(ctx context.Context, dbKeys []*signingKey) ([]*types.SigningKey, error) {
+	keys := make([]*types.SigningKey, len(dbKeys))
+	if len(dbKeys) == 0 {
+		return keys, nil
+	}
+
+	ids := make([]int64, len(dbKeys))
+	keyMap := make(map[int64]*types.SigningKey, len(dbKeys))
+	for i, dbKey := range dbKeys {
+		keys[i] = mapToSigningKey(dbKey)
+		ids[i] = dbKey.ID
+		keyMap[dbKey.ID] = keys[i]
+	}
+
+	stmt := database.Builder.
+		Select("signing_key_id_signing_key_id, signing_key_id_key_id").
+		From("signing_key_ids").
+		Where(squirrel.Eq{"signing_key_id_signing_key_id": ids}).
+		OrderBy("signing_key_id_key_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list signing key ids query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]signingKeyID, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list signing key ids query")
+	}
+
+	for _, keyID := range dst {
+		key := keyMap[keyID.SigningKeyID]
+		key.KeyIDs = append(key.KeyIDs, keyID.KeyID)
+	}
+
+	return keys, nil
+}
+
+func mapToInternalSigningKey(in *types.SigningKey) *signingKey {
+	return &signingKey{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Created:     in.Created,
+		UID:         in.UID,
+		Type:        in.Type,
+		Fingerprint: in.Fingerprint,
+		Content:     in.Content,
+	}
+}
+
+func mapToSigningKey(in *signingKey) *types.SigningKey {
+	return &types.SigningKey{
+		ID:          in.ID,
+		PrincipalID: in.PrincipalID,
+		Created:     in.Created,
+		UID:         in.UID,
+		Type:        in.Type,
+		Fingerprint: in.Fingerprint,
+		KeyIDs:      []string{},
+		Content:     in.Content,
+	}
+}
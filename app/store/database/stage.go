@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 	sqlxtypes "github.com/jmoiron/sqlx/types"
 )
@@ -46,6 +47,7 @@ const (
 	,stage_errignore
 	,stage_exit_code
 	,stage_machine
+	,stage_runner_id
 	,stage_os
 	,stage_arch
 	,stage_variant
@@ -78,6 +80,7 @@ type stage struct {
 	ErrIgnore     bool               `db:"stage_errignore"`
 	ExitCode      int                `db:"stage_exit_code"`
 	Machine       string             `db:"stage_machine"`
+	RunnerID      null.Int           `db:"stage_runner_id"`
 	OS            string             `db:"stage_os"`
 	Arch          string             `db:"stage_arch"`
 	Variant       string             `db:"stage_variant"`
@@ -136,6 +139,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,stage_errignore
 			,stage_exit_code
 			,stage_machine
+			,stage_runner_id
 			,stage_parent_group_id
 			,stage_os
 			,stage_arch
@@ -164,6 +168,7 @@ func (s *stageStore) Create(ctx context.Context, st *types.Stage) error {
 			,:stage_errignore
 			,:stage_exit_code
 			,:stage_machine
+			,:stage_runner_id
 			,:stage_parent_group_id
 			,:stage_os
 			,:stage_arch
@@ -276,6 +281,7 @@ func (s *stageStore) Update(ctx context.Context, st *types.Stage) error {
 	SET
 		stage_status = :stage_status
 		,stage_machine = :stage_machine
+		,stage_runner_id = COALESCE(:stage_runner_id, stage_runner_id)
 		,stage_started = :stage_started
 		,stage_stopped = :stage_stopped
 		,stage_exit_code = :stage_exit_code
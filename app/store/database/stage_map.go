@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/guregu/null"
 	sqlxtypes "github.com/jmoiron/sqlx/types"
 	"github.com/pkg/errors"
 )
@@ -95,6 +96,7 @@ func mapInternalToStage(in *stage) (*types.Stage, error) {
 		ErrIgnore:   in.ErrIgnore,
 		ExitCode:    in.ExitCode,
 		Machine:     in.Machine,
+		RunnerID:    in.RunnerID.Ptr(),
 		OS:          in.OS,
 		Arch:        in.Arch,
 		Variant:     in.Variant,
@@ -127,6 +129,7 @@ func mapStageToInternal(in *types.Stage) *stage {
 		ErrIgnore:   in.ErrIgnore,
 		ExitCode:    in.ExitCode,
 		Machine:     in.Machine,
+		RunnerID:    null.IntFromPtr(in.RunnerID),
 		OS:          in.OS,
 		Arch:        in.Arch,
 		Variant:     in.Variant,
@@ -192,6 +195,7 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	depJSON := sqlxtypes.JSONText{}
 	labJSON := sqlxtypes.JSONText{}
 	stepDepJSON := sqlxtypes.JSONText{}
+	runnerID := null.Int{}
 	err := rows.Scan(
 		&stage.ID,
 		&stage.ExecutionID,
@@ -205,6 +209,7 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 		&stage.ErrIgnore,
 		&stage.ExitCode,
 		&stage.Machine,
+		&runnerID,
 		&stage.OS,
 		&stage.Arch,
 		&stage.Variant,
@@ -239,6 +244,7 @@ func scanRowStep(rows *sql.Rows, stage *types.Stage, step *nullstep) error {
 	if err != nil {
 		return fmt.Errorf("failed to scan row: %w", err)
 	}
+	stage.RunnerID = runnerID.Ptr()
 	err = json.Unmarshal(depJSON, &stage.DependsOn)
 	if err != nil {
 		return fmt.Errorf("failed to unmarshal depJSON: %w", err)
@@ -27,6 +27,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jmoiron/sqlx"
+	sqlxtypes "github.com/jmoiron/sqlx/types"
 )
 
 var _ store.TokenStore = (*TokenStore)(nil)
@@ -45,31 +46,31 @@ type TokenStore struct {
 func (s *TokenStore) Find(ctx context.Context, id int64) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByID, id); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed to find token")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // FindByUID finds the token by principalId and tokenUID.
 func (s *TokenStore) FindByUID(ctx context.Context, principalID int64, tokenUID string) (*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := new(types.Token)
+	dst := new(token)
 	if err := db.GetContext(ctx, dst, TokenSelectByPrincipalIDAndUID, principalID, tokenUID); err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed to find token by UID")
 	}
 
-	return dst, nil
+	return mapToken(dst)
 }
 
 // Create saves the token details.
 func (s *TokenStore) Create(ctx context.Context, token *types.Token) error {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	query, arg, err := db.BindNamed(tokenInsert, token)
+	query, arg, err := db.BindNamed(tokenInsert, mapInternalToken(token))
 	if err != nil {
 		return database.ProcessSQLErrorf(err, "Failed to bind token object")
 	}
@@ -146,7 +147,7 @@ func (s *TokenStore) List(ctx context.Context,
 	principalID int64, tokenType enum.TokenType) ([]*types.Token, error) {
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dst := []*types.Token{}
+	dst := []*token{}
 
 	// TODO: custom filters / sorting for tokens.
 
@@ -154,7 +155,70 @@ func (s *TokenStore) List(ctx context.Context,
 	if err != nil {
 		return nil, database.ProcessSQLErrorf(err, "Failed executing token list query")
 	}
-	return dst, nil
+
+	res := make([]*types.Token, len(dst))
+	for i := range dst {
+		if res[i], err = mapToken(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func mapToken(t *token) (*types.Token, error) {
+	res := &types.Token{
+		ID:          t.ID,
+		PrincipalID: t.PrincipalID,
+		Type:        t.Type,
+		UID:         t.UID,
+		ExpiresAt:   t.ExpiresAt,
+		IssuedAt:    t.IssuedAt,
+		CreatedBy:   t.CreatedBy,
+	}
+
+	// fail instead of ignoring malformed restrictions, as that would grant the token full access.
+	if err := t.Permissions.Unmarshal(&res.Permissions); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token permissions: %w", err)
+	}
+	if err := t.Paths.Unmarshal(&res.Paths); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token paths: %w", err)
+	}
+
+	return res, nil
+}
+
+func mapInternalToken(t *types.Token) *token {
+	return &token{
+		ID:          t.ID,
+		PrincipalID: t.PrincipalID,
+		Type:        t.Type,
+		UID:         t.UID,
+		ExpiresAt:   t.ExpiresAt,
+		IssuedAt:    t.IssuedAt,
+		CreatedBy:   t.CreatedBy,
+		Permissions: EncodeToSQLXJSON(nonNilSlice(t.Permissions)),
+		Paths:       EncodeToSQLXJSON(nonNilSlice(t.Paths)),
+	}
+}
+
+func nonNilSlice[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+type token struct {
+	ID          int64              `db:"token_id"`
+	PrincipalID int64              `db:"token_principal_id"`
+	Type        enum.TokenType     `db:"token_type"`
+	UID         string             `db:"token_uid"`
+	ExpiresAt   *int64             `db:"token_expires_at"`
+	IssuedAt    int64              `db:"token_issued_at"`
+	CreatedBy   int64              `db:"token_created_by"`
+	Permissions sqlxtypes.JSONText `db:"token_permissions"`
+	Paths       sqlxtypes.JSONText `db:"token_paths"`
 }
 
 const tokenSelectBase = `
@@ -166,6 +230,8 @@ token_id
 ,token_expires_at
 ,token_issued_at
 ,token_created_by
+,token_permissions
+,token_paths
 FROM tokens
 ` //#nosec G101
 
@@ -201,6 +267,8 @@ INSERT INTO tokens (
 	,token_expires_at
 	,token_issued_at
 	,token_created_by
+	,token_permissions
+	,token_paths
 ) values (
 	:token_type
 	,:token_uid
@@ -208,5 +276,7 @@ INSERT INTO tokens (
 	,:token_expires_at
 	,:token_issued_at
 	,:token_created_by
+	,:token_permissions
+	,:token_paths
 ) RETURNING token_id
 `
@@ -46,6 +46,9 @@ type trigger struct {
 	CreatedBy   int64              `db:"trigger_created_by"`
 	Disabled    bool               `db:"trigger_disabled"`
 	Actions     sqlxtypes.JSONText `db:"trigger_actions"`
+	Cron        string             `db:"trigger_cron"`
+	Timezone    string             `db:"trigger_timezone"`
+	NextRun     int64              `db:"trigger_next_run"`
 	Created     int64              `db:"trigger_created"`
 	Updated     int64              `db:"trigger_updated"`
 	Version     int64              `db:"trigger_version"`
@@ -68,6 +71,9 @@ func mapInternalToTrigger(trigger *trigger) (*types.Trigger, error) {
 		CreatedBy:   trigger.CreatedBy,
 		Disabled:    trigger.Disabled,
 		Actions:     actions,
+		Cron:        trigger.Cron,
+		Timezone:    trigger.Timezone,
+		NextRun:     trigger.NextRun,
 		UID:         trigger.UID,
 		Created:     trigger.Created,
 		Updated:     trigger.Updated,
@@ -99,6 +105,9 @@ func mapTriggerToInternal(t *types.Trigger) *trigger {
 		CreatedBy:   t.CreatedBy,
 		Disabled:    t.Disabled,
 		Actions:     EncodeToSQLXJSON(t.Actions),
+		Cron:        t.Cron,
+		Timezone:    t.Timezone,
+		NextRun:     t.NextRun,
 		Created:     t.Created,
 		Updated:     t.Updated,
 		Version:     t.Version,
@@ -123,7 +132,13 @@ const (
 		,trigger_disabled
 		,trigger_actions
 		,trigger_description
+		,trigger_type
 		,trigger_pipeline_id
+		,trigger_repo_id
+		,trigger_created_by
+		,trigger_cron
+		,trigger_timezone
+		,trigger_next_run
 		,trigger_created
 		,trigger_updated
 		,trigger_version
@@ -158,6 +173,9 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,trigger_created_by
 		,trigger_pipeline_id
 		,trigger_repo_id
+		,trigger_cron
+		,trigger_timezone
+		,trigger_next_run
 		,trigger_created
 		,trigger_updated
 		,trigger_version
@@ -171,6 +189,9 @@ func (s *triggerStore) Create(ctx context.Context, t *types.Trigger) error {
 		,:trigger_created_by
 		,:trigger_pipeline_id
 		,:trigger_repo_id
+		,:trigger_cron
+		,:trigger_timezone
+		,:trigger_next_run
 		,:trigger_created
 		,:trigger_updated
 		,:trigger_version
@@ -200,6 +221,9 @@ func (s *triggerStore) Update(ctx context.Context, t *types.Trigger) error {
 		,trigger_disabled = :trigger_disabled
 		,trigger_updated = :trigger_updated
 		,trigger_actions = :trigger_actions
+		,trigger_cron = :trigger_cron
+		,trigger_timezone = :trigger_timezone
+		,trigger_next_run = :trigger_next_run
 		,trigger_version = :trigger_version
 	WHERE trigger_id = :trigger_id AND trigger_version = :trigger_version - 1`
 	updatedAt := time.Now()
@@ -319,6 +343,58 @@ func (s *triggerStore) ListAllEnabled(
 	return mapInternalToTriggerList(dst)
 }
 
+// ListDueCron lists all enabled cron triggers which are due to run at the provided time (unix millis).
+func (s *triggerStore) ListDueCron(ctx context.Context, now int64) ([]*types.Trigger, error) {
+	stmt := database.Builder.
+		Select(triggerColumns).
+		From("triggers").
+		Where("trigger_type = ?", enum.TriggerCron).
+		Where("trigger_disabled = false").
+		Where("trigger_next_run > 0 AND trigger_next_run <= ?", now).
+		OrderBy("trigger_next_run ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*trigger{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing due cron trigger list query")
+	}
+
+	return mapInternalToTriggerList(dst)
+}
+
+// UpdateNextRun updates the next run of a cron trigger in case it still has the expected next run.
+// Returns store.ErrVersionConflict if the next run of the trigger was changed in the meantime.
+func (s *triggerStore) UpdateNextRun(ctx context.Context, id int64, expectedNextRun, nextRun int64) error {
+	const triggerUpdateNextRunStmt = `
+	UPDATE triggers
+	SET trigger_next_run = $1
+	WHERE trigger_id = $2 AND trigger_next_run = $3`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, triggerUpdateNextRunStmt, nextRun, id, expectedNextRun)
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to update next run of trigger")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrVersionConflict
+	}
+
+	return nil
+}
+
 // Count of triggers under a given pipeline.
 func (s *triggerStore) Count(ctx context.Context, pipelineID int64, filter types.ListQueryFilter) (int64, error) {
 	stmt := database.Builder.
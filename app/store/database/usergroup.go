@@ -16,19 +16,258 @@ package database
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
-	gitnessAppStore "github.com/harness/gitness/app/store"
-	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
 )
 
-var _ gitnessAppStore.UserGroupStore = (*UserGroupStore)(nil)
+var _ store.UserGroupStore = (*UserGroupStore)(nil)
+
+// NewUserGroupStore returns a new UserGroupStore.
+func NewUserGroupStore(db *sqlx.DB) *UserGroupStore {
+	return &UserGroupStore{
+		db: db,
+	}
+}
 
+// UserGroupStore implements a store.UserGroupStore backed by a relational database.
 type UserGroupStore struct {
+	db *sqlx.DB
+}
+
+type userGroup struct {
+	ID          int64  `db:"usergroup_id"`
+	SpaceID     int64  `db:"usergroup_space_id"`
+	UID         string `db:"usergroup_uid"`
+	Name        string `db:"usergroup_name"`
+	Description string `db:"usergroup_description"`
+	CreatedBy   int64  `db:"usergroup_created_by"`
+	Created     int64  `db:"usergroup_created"`
+	Updated     int64  `db:"usergroup_updated"`
+}
+
+const (
+	userGroupColumns = `
+		 usergroup_id
+		,usergroup_space_id
+		,usergroup_uid
+		,usergroup_name
+		,usergroup_description
+		,usergroup_created_by
+		,usergroup_created
+		,usergroup_updated`
+
+	userGroupSelectBase = `
+		SELECT` + userGroupColumns + `
+		FROM usergroups`
+)
+
+// Find finds the user group by space id and uid.
+func (s *UserGroupStore) Find(ctx context.Context, spaceID int64, uid string) (*types.UserGroup, error) {
+	const sqlQuery = userGroupSelectBase + `
+		WHERE usergroup_space_id = $1 AND LOWER(usergroup_uid) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroup{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(uid)); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find user group by uid")
+	}
+
+	return mapToUserGroup(dst), nil
+}
+
+// FindByID finds the user group by id.
+func (s *UserGroupStore) FindByID(ctx context.Context, id int64) (*types.UserGroup, error) {
+	const sqlQuery = userGroupSelectBase + `
+		WHERE usergroup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroup{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find user group")
+	}
+
+	return mapToUserGroup(dst), nil
+}
+
+// Create saves the user group details.
+func (s *UserGroupStore) Create(ctx context.Context, userGroup *types.UserGroup) error {
+	const sqlQuery = `
+		INSERT INTO usergroups (
+			 usergroup_space_id
+			,usergroup_uid
+			,usergroup_name
+			,usergroup_description
+			,usergroup_created_by
+			,usergroup_created
+			,usergroup_updated
+		) values (
+			 :usergroup_space_id
+			,:usergroup_uid
+			,:usergroup_name
+			,:usergroup_description
+			,:usergroup_created_by
+			,:usergroup_created
+			,:usergroup_updated
+		) RETURNING usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalUserGroup(userGroup))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&userGroup.ID); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert user group query failed")
+	}
+
+	return nil
+}
+
+// Update updates the user group details.
+func (s *UserGroupStore) Update(ctx context.Context, userGroup *types.UserGroup) error {
+	const sqlQuery = `
+		UPDATE usergroups
+		SET
+			 usergroup_uid = :usergroup_uid
+			,usergroup_name = :usergroup_name
+			,usergroup_description = :usergroup_description
+			,usergroup_updated = :usergroup_updated
+		WHERE usergroup_id = :usergroup_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalUserGroup(userGroup))
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Update user group query failed")
+	}
+
+	return nil
+}
+
+// Delete deletes the user group with the given id.
+func (s *UserGroupStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM usergroups
+		WHERE usergroup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete user group query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of user groups of the space.
+func (s *UserGroupStore) Count(ctx context.Context, spaceID int64, filter *types.ListQueryFilter) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("usergroups").
+		Where("usergroup_space_id = ?", spaceID)
+
+	stmt = applyUserGroupFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count user groups query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count user groups query")
+	}
+
+	return count, nil
+}
+
+// List returns the user groups of the space.
+func (s *UserGroupStore) List(
+	ctx context.Context,
+	spaceID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.UserGroup, error) {
+	stmt := database.Builder.
+		Select(userGroupColumns).
+		From("usergroups").
+		Where("usergroup_space_id = ?", spaceID)
+
+	stmt = applyUserGroupFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("LOWER(usergroup_uid) ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list user groups query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*userGroup, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user groups query")
+	}
+
+	result := make([]*types.UserGroup, len(dst))
+	for i := range dst {
+		result[i] = mapToUserGroup(dst[i])
+	}
+
+	return result, nil
+}
+
+func applyUserGroupFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.ListQueryFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(usergroup_uid) LIKE ?", "%"+strings.ToLower(filter.Query)+"%")
+	}
+
+	return stmt
+}
+
+func mapToUserGroup(g *userGroup) *types.UserGroup {
+	return &types.UserGroup{
+		ID:          g.ID,
+		SpaceID:     g.SpaceID,
+		UID:         g.UID,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedBy:   g.CreatedBy,
+		Created:     g.Created,
+		Updated:     g.Updated,
+	}
 }
 
-// Find Dummy Method: to be implemented later.
-func (s *UserGroupStore) Find(_ context.Context, _ int64, _ string) (*types.UserGroup, error) {
-	//nolint: nilnil
-	return nil, store.ErrResourceNotFound
+func mapToInternalUserGroup(g *types.UserGroup) *userGroup {
+	return &userGroup{
+		ID:          g.ID,
+		SpaceID:     g.SpaceID,
+		UID:         g.UID,
+		Name:        g.Name,
+		Description: g.Description,
+		CreatedBy:   g.CreatedBy,
+		Created:     g.Created,
+		Updated:     g.Updated,
+	}
 }
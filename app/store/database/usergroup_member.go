@@ -0,0 +1,282 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.UserGroupMemberStore = (*UserGroupMemberStore)(nil)
+
+// NewUserGroupMemberStore returns a new UserGroupMemberStore.
+func NewUserGroupMemberStore(db *sqlx.DB, pCache store.PrincipalInfoCache) *UserGroupMemberStore {
+	return &UserGroupMemberStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// UserGroupMemberStore implements a store.UserGroupMemberStore backed by a relational database.
+type UserGroupMemberStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type userGroupMember struct {
+	UserGroupID int64 `db:"usergroup_member_usergroup_id"`
+	PrincipalID int64 `db:"usergroup_member_principal_id"`
+	CreatedBy   int64 `db:"usergroup_member_created_by"`
+	Created     int64 `db:"usergroup_member_created"`
+}
+
+type userGroupMemberPrincipal struct {
+	userGroupMember
+	principalInfo
+}
+
+const (
+	userGroupMemberColumns = `
+		 usergroup_member_usergroup_id
+		,usergroup_member_principal_id
+		,usergroup_member_created_by
+		,usergroup_member_created`
+)
+
+// Find finds the membership of the principal in the user group.
+func (s *UserGroupMemberStore) Find(
+	ctx context.Context,
+	userGroupID int64,
+	principalID int64,
+) (*types.UserGroupMember, error) {
+	const sqlQuery = `
+		SELECT` + userGroupMemberColumns + "," + principalInfoCommonColumns + `
+		FROM usergroup_members
+		INNER JOIN principals ON usergroup_member_principal_id = principal_id
+		WHERE usergroup_member_usergroup_id = $1 AND usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &userGroupMemberPrincipal{}
+	if err := db.GetContext(ctx, dst, sqlQuery, userGroupID, principalID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed to find user group member")
+	}
+
+	result, err := s.mapToUserGroupMembers(ctx, []*userGroupMemberPrincipal{dst})
+	if err != nil {
+		return nil, err
+	}
+
+	return result[0], nil
+}
+
+// Create adds the principal to the user group.
+func (s *UserGroupMemberStore) Create(ctx context.Context, member *types.UserGroupMember) error {
+	const sqlQuery = `
+		INSERT INTO usergroup_members (
+			 usergroup_member_usergroup_id
+			,usergroup_member_principal_id
+			,usergroup_member_created_by
+			,usergroup_member_created
+		) values (
+			 :usergroup_member_usergroup_id
+			,:usergroup_member_principal_id
+			,:usergroup_member_created_by
+			,:usergroup_member_created
+		)`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, userGroupMember{
+		UserGroupID: member.UserGroupID,
+		PrincipalID: member.PrincipalID,
+		CreatedBy:   member.CreatedBy,
+		Created:     member.Created,
+	})
+	if err != nil {
+		return database.ProcessSQLErrorf(err, "Failed to bind user group member object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, arg...); err != nil {
+		return database.ProcessSQLErrorf(err, "Insert user group member query failed")
+	}
+
+	return nil
+}
+
+// Delete removes the principal from the user group.
+func (s *UserGroupMemberStore) Delete(ctx context.Context, userGroupID int64, principalID int64) error {
+	const sqlQuery = `
+		DELETE FROM usergroup_members
+		WHERE usergroup_member_usergroup_id = $1 AND usergroup_member_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, userGroupID, principalID); err != nil {
+		return database.ProcessSQLErrorf(err, "Delete user group member query failed")
+	}
+
+	return nil
+}
+
+// Count returns the number of members of the user group.
+func (s *UserGroupMemberStore) Count(
+	ctx context.Context,
+	userGroupID int64,
+	filter *types.ListQueryFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("count(*)").
+		From("usergroup_members").
+		InnerJoin("principals ON usergroup_member_principal_id = principal_id").
+		Where("usergroup_member_usergroup_id = ?", userGroupID)
+
+	stmt = applyUserGroupMemberFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert count user group members query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	err = db.QueryRowContext(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(err, "Failed executing count user group members query")
+	}
+
+	return count, nil
+}
+
+// List returns the members of the user group.
+func (s *UserGroupMemberStore) List(
+	ctx context.Context,
+	userGroupID int64,
+	filter *types.ListQueryFilter,
+) ([]*types.UserGroupMember, error) {
+	stmt := database.Builder.
+		Select(userGroupMemberColumns+","+principalInfoCommonColumns).
+		From("usergroup_members").
+		InnerJoin("principals ON usergroup_member_principal_id = principal_id").
+		Where("usergroup_member_usergroup_id = ?", userGroupID)
+
+	stmt = applyUserGroupMemberFilter(stmt, filter)
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	stmt = stmt.OrderBy("principal_display_name ASC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert list user group members query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*userGroupMemberPrincipal, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user group members query")
+	}
+
+	return s.mapToUserGroupMembers(ctx, dst)
+}
+
+// ListPrincipalUIDs returns the UIDs of all members of the user group.
+func (s *UserGroupMemberStore) ListPrincipalUIDs(ctx context.Context, userGroupID int64) ([]string, error) {
+	const sqlQuery = `
+		SELECT principal_uid
+		FROM usergroup_members
+		INNER JOIN principals ON usergroup_member_principal_id = principal_id
+		WHERE usergroup_member_usergroup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]string, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, userGroupID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user group member uids query")
+	}
+
+	return dst, nil
+}
+
+// ListPrincipalIDs returns the IDs of all members of the user group.
+func (s *UserGroupMemberStore) ListPrincipalIDs(ctx context.Context, userGroupID int64) ([]int64, error) {
+	const sqlQuery = `
+		SELECT usergroup_member_principal_id
+		FROM usergroup_members
+		WHERE usergroup_member_usergroup_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]int64, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, userGroupID); err != nil {
+		return nil, database.ProcessSQLErrorf(err, "Failed executing list user group member ids query")
+	}
+
+	return dst, nil
+}
+
+func applyUserGroupMemberFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.ListQueryFilter,
+) squirrel.SelectBuilder {
+	if filter.Query != "" {
+		stmt = stmt.Where("LOWER(principal_display_name) LIKE ?", "%"+strings.ToLower(filter.Query)+"%")
+	}
+
+	return stmt
+}
+
+func (s *UserGroupMemberStore) mapToUserGroupMembers(
+	ctx context.Context,
+	ms []*userGroupMemberPrincipal,
+) ([]*types.UserGroupMember, error) {
+	// collect all principal IDs
+	ids := make([]int64, 0, len(ms))
+	for _, m := range ms {
+		ids = append(ids, m.userGroupMember.CreatedBy)
+	}
+
+	// pull principal infos from cache
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user group member principal infos: %w", err)
+	}
+
+	res := make([]*types.UserGroupMember, len(ms))
+	for i, m := range ms {
+		res[i] = &types.UserGroupMember{
+			UserGroupID: m.userGroupMember.UserGroupID,
+			PrincipalID: m.userGroupMember.PrincipalID,
+			CreatedBy:   m.userGroupMember.CreatedBy,
+			Created:     m.userGroupMember.Created,
+			Principal:   mapToPrincipalInfo(&m.principalInfo),
+		}
+		if addedBy, ok := infoMap[m.userGroupMember.CreatedBy]; ok {
+			res[i].AddedBy = *addedBy
+		}
+	}
+
+	return res, nil
+}
@@ -42,7 +42,22 @@ var WireSet = wire.NewSet(
 	ProvideSecretStore,
 	ProvideRepoGitInfoView,
 	ProvideMembershipStore,
+	ProvideMembershipUserGroupStore,
+	ProvideUserGroupStore,
+	ProvideRunnerStore,
+	ProvideArtifactStore,
+	ProvideApprovalStore,
+	ProvidePipelineCacheStore,
+	ProvidePullMirrorStore,
+	ProvidePushMirrorStore,
+	ProvideLFSObjectStore,
+	ProvideLFSLockStore,
+	ProvideUserGroupMemberStore,
+	ProvideNotificationSettingsStore,
 	ProvideTokenStore,
+	ProvidePublicKeyStore,
+	ProvideSigningKeyStore,
+	ProvideOIDCIdentityStore,
 	ProvidePullReqStore,
 	ProvidePullReqActivityStore,
 	ProvideCodeCommentView,
@@ -182,11 +197,47 @@ func ProvideMembershipStore(
 	return NewMembershipStore(db, principalInfoCache, spacePathStore)
 }
 
+// ProvideMembershipUserGroupStore provides a store for the space memberships of user groups.
+func ProvideMembershipUserGroupStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.MembershipUserGroupStore {
+	return NewMembershipUserGroupStore(db, principalInfoCache)
+}
+
+// ProvideUserGroupStore provides a user group store.
+func ProvideUserGroupStore(db *sqlx.DB) store.UserGroupStore {
+	return NewUserGroupStore(db)
+}
+
+// ProvideUserGroupMemberStore provides a user group member store.
+func ProvideUserGroupMemberStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.UserGroupMemberStore {
+	return NewUserGroupMemberStore(db, principalInfoCache)
+}
+
 // ProvideTokenStore provides a token store.
 func ProvideTokenStore(db *sqlx.DB) store.TokenStore {
 	return NewTokenStore(db)
 }
 
+// ProvidePublicKeyStore provides a public key store.
+func ProvidePublicKeyStore(db *sqlx.DB) store.PublicKeyStore {
+	return NewPublicKeyStore(db)
+}
+
+// ProvideSigningKeyStore provides a signing key store.
+func ProvideSigningKeyStore(db *sqlx.DB) store.SigningKeyStore {
+	return NewSigningKeyStore(db)
+}
+
+// ProvideOIDCIdentityStore provides an oidc identity store.
+func ProvideOIDCIdentityStore(db *sqlx.DB) store.OIDCIdentityStore {
+	return NewOIDCIdentityStore(db)
+}
+
 // ProvidePullReqStore provides a pull request store.
 func ProvidePullReqStore(db *sqlx.DB,
 	principalInfoCache store.PrincipalInfoCache,
@@ -246,3 +297,48 @@ func ProvideReqCheckStore(db *sqlx.DB,
 ) store.ReqCheckStore {
 	return NewReqCheckStore(db, principalInfoCache)
 }
+
+// ProvideNotificationSettingsStore provides a notification settings store.
+func ProvideNotificationSettingsStore(db *sqlx.DB) store.NotificationSettingsStore {
+	return NewNotificationSettingsStore(db)
+}
+
+// ProvideRunnerStore provides a runner store.
+func ProvideRunnerStore(db *sqlx.DB) store.RunnerStore {
+	return NewRunnerStore(db)
+}
+
+// ProvideArtifactStore provides an artifact store.
+func ProvideArtifactStore(db *sqlx.DB) store.ArtifactStore {
+	return NewArtifactStore(db)
+}
+
+// ProvideApprovalStore provides an approval store.
+func ProvideApprovalStore(db *sqlx.DB, principalInfoCache store.PrincipalInfoCache) store.ApprovalStore {
+	return NewApprovalStore(db, principalInfoCache)
+}
+
+// ProvidePipelineCacheStore provides a pipeline cache store.
+func ProvidePipelineCacheStore(db *sqlx.DB) store.PipelineCacheStore {
+	return NewPipelineCacheStore(db)
+}
+
+// ProvidePullMirrorStore provides a pull mirror store.
+func ProvidePullMirrorStore(db *sqlx.DB) store.PullMirrorStore {
+	return NewPullMirrorStore(db)
+}
+
+// ProvidePushMirrorStore provides a push mirror store.
+func ProvidePushMirrorStore(db *sqlx.DB) store.PushMirrorStore {
+	return NewPushMirrorStore(db)
+}
+
+// ProvideLFSObjectStore provides a LFS object store.
+func ProvideLFSObjectStore(db *sqlx.DB) store.LFSObjectStore {
+	return NewLFSObjectStore(db)
+}
+
+// ProvideLFSLockStore provides a LFS lock store.
+func ProvideLFSLockStore(db *sqlx.DB) store.LFSLockStore {
+	return NewLFSLockStore(db)
+}
@@ -48,6 +48,8 @@ func CreateUserSession(
 		principal,
 		uid,
 		ptr.Duration(userSessionTokenLifeTime),
+		nil,
+		nil,
 	)
 }
 
@@ -58,6 +60,8 @@ func CreatePAT(
 	createdFor *types.User,
 	uid string,
 	lifetime *time.Duration,
+	permissions []enum.Permission,
+	paths []string,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -67,6 +71,8 @@ func CreatePAT(
 		createdFor.ToPrincipal(),
 		uid,
 		lifetime,
+		permissions,
+		paths,
 	)
 }
 
@@ -77,6 +83,8 @@ func CreateSAT(
 	createdFor *types.ServiceAccount,
 	uid string,
 	lifetime *time.Duration,
+	permissions []enum.Permission,
+	paths []string,
 ) (*types.Token, string, error) {
 	return create(
 		ctx,
@@ -86,6 +94,8 @@ func CreateSAT(
 		createdFor.ToPrincipal(),
 		uid,
 		lifetime,
+		permissions,
+		paths,
 	)
 }
 
@@ -97,6 +107,8 @@ func create(
 	createdFor *types.Principal,
 	uid string,
 	lifetime *time.Duration,
+	permissions []enum.Permission,
+	paths []string,
 ) (*types.Token, string, error) {
 	issuedAt := time.Now()
 
@@ -113,6 +125,8 @@ func create(
 		IssuedAt:    issuedAt.UnixMilli(),
 		ExpiresAt:   expiresAt,
 		CreatedBy:   createdBy.ID,
+		Permissions: permissions,
+		Paths:       paths,
 	}
 
 	err := tokenStore.Create(ctx, &token)
@@ -31,6 +31,9 @@ const (
 
 	// GITMount is the prefix path for the git endpoints.
 	GITMount = "git"
+
+	// RPCMount is the prefix path for the runner rpc endpoints.
+	RPCMount = "rpc"
 )
 
 // Provider is an abstraction of a component that provides system related URLs.
@@ -44,6 +47,10 @@ type Provider interface {
 	// interact with gitness and clone a repo.
 	GenerateContainerGITCloneURL(repoPath string) string
 
+	// GetContainerRPCURL returns the url of the runner rpc endpoints that can be used by CI container builds.
+	// NOTE: url is guaranteed to not have any trailing '/'.
+	GetContainerRPCURL() string
+
 	// GenerateGITCloneURL generates the public git clone URL for the provided repo path.
 	// NOTE: url is guaranteed to not have any trailing '/'.
 	GenerateGITCloneURL(repoPath string) string
@@ -152,6 +159,10 @@ func (p *provider) GenerateContainerGITCloneURL(repoPath string) string {
 	return p.containerURL.JoinPath(GITMount, repoPath).String()
 }
 
+func (p *provider) GetContainerRPCURL() string {
+	return p.containerURL.JoinPath(RPCMount).String()
+}
+
 func (p *provider) GenerateGITCloneURL(repoPath string) string {
 	repoPath = path.Clean(repoPath)
 	if !strings.HasSuffix(repoPath, GITSuffix) {
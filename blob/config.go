@@ -21,6 +21,7 @@ type Provider string
 const (
 	ProviderGCS        Provider = "gcs"
 	ProviderFileSystem Provider = "filesystem"
+	ProviderS3         Provider = "s3"
 )
 
 type Config struct {
@@ -29,4 +30,17 @@ type Config struct {
 	KeyPath               string
 	TargetPrincipal       string
 	ImpersonationLifetime time.Duration
+
+	// S3 contains the configuration of S3 compatible blob stores.
+	S3 S3Config
+}
+
+type S3Config struct {
+	// Endpoint is the URL of the S3 compatible service (e.g. MinIO), empty for AWS.
+	Endpoint  string
+	Region    string
+	AccessKey string
+	SecretKey string
+	// PathStyle enables path-style addressing of the bucket, which is required by most S3 compatible services.
+	PathStyle bool
 }
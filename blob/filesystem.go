@@ -16,10 +16,14 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"path"
+	"path/filepath"
+	"strings"
 
 	"github.com/rs/zerolog/log"
 )
@@ -92,3 +96,86 @@ func (c *FileSystemStore) Download(_ context.Context, filePath string) (io.ReadC
 	}
 	return io.ReadCloser(file), nil
 }
+
+func (c *FileSystemStore) Delete(_ context.Context, filePath string) error {
+	fileDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, filePath)
+
+	err := os.Remove(fileDiskPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+
+	return nil
+}
+
+func (c *FileSystemStore) Move(_ context.Context, srcPath string, dstPath string) error {
+	srcDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, srcPath)
+	dstDiskPath := fmt.Sprintf(fileDiskPathFmt, c.basePath, dstPath)
+
+	dir, _ := path.Split(dstDiskPath)
+	if err := os.MkdirAll(dir, os.ModeDir|os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create parent directory for the file: %w", err)
+	}
+
+	err := os.Rename(srcDiskPath, dstDiskPath)
+	if os.IsNotExist(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to move file: %w", err)
+	}
+
+	return nil
+}
+
+func (c *FileSystemStore) List(_ context.Context, prefix string) ([]File, error) {
+	// only the directory of the prefix has to be walked, the rest of the prefix is matched against the file paths.
+	dir := prefix
+	if !strings.HasSuffix(dir, "/") {
+		dir = path.Dir(dir)
+	}
+
+	root := filepath.Join(c.basePath, filepath.FromSlash(dir))
+
+	var files []File
+	err := filepath.WalkDir(root, func(fileDiskPath string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(c.basePath, fileDiskPath)
+		if err != nil {
+			return fmt.Errorf("failed to get relative path of file: %w", err)
+		}
+
+		filePath := filepath.ToSlash(relPath)
+		if !strings.HasPrefix(filePath, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("failed to get file info: %w", err)
+		}
+
+		files = append(files, File{
+			Path:     filePath,
+			Size:     info.Size(),
+			Modified: info.ModTime(),
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return files, nil
+}
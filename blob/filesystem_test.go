@@ -0,0 +1,151 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestFileSystemStore_ListDelete(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewFileSystemStore(Config{Bucket: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	for _, filePath := range []string{"uploads/1/a.png", "uploads/1/b.png", "uploads/12/c.png", "other/d.txt"} {
+		if err = store.Upload(ctx, strings.NewReader(filePath), filePath); err != nil {
+			t.Fatalf("failed to upload %s: %v", filePath, err)
+		}
+	}
+
+	list := func(prefix string) []string {
+		files, err := store.List(ctx, prefix)
+		if err != nil {
+			t.Fatalf("failed to list %q: %v", prefix, err)
+		}
+
+		paths := make([]string, len(files))
+		for i, file := range files {
+			if file.Size != int64(len(file.Path)) {
+				t.Errorf("expected size %d of %s, got %d", len(file.Path), file.Path, file.Size)
+			}
+			paths[i] = file.Path
+		}
+		sort.Strings(paths)
+
+		return paths
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{prefix: "uploads/", want: []string{"uploads/1/a.png", "uploads/1/b.png", "uploads/12/c.png"}},
+		{prefix: "uploads/1/", want: []string{"uploads/1/a.png", "uploads/1/b.png"}},
+		{prefix: "uploads/1", want: []string{"uploads/1/a.png", "uploads/1/b.png", "uploads/12/c.png"}},
+		{prefix: "uploads/1/a", want: []string{"uploads/1/a.png"}},
+		{prefix: "missing/", want: []string{}},
+	}
+	for _, test := range tests {
+		if got := list(test.prefix); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("list %q: expected %v, got %v", test.prefix, test.want, got)
+		}
+	}
+
+	if err = store.Delete(ctx, "uploads/1/a.png"); err != nil {
+		t.Fatalf("failed to delete file: %v", err)
+	}
+	if err = store.Delete(ctx, "uploads/1/a.png"); err != nil {
+		t.Fatalf("deleting a missing file shouldn't fail: %v", err)
+	}
+
+	if _, err = store.Download(ctx, "uploads/1/a.png"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for deleted file, got %v", err)
+	}
+
+	rc, err := store.Download(ctx, "uploads/1/b.png")
+	if err != nil {
+		t.Fatalf("failed to download remaining file: %v", err)
+	}
+	defer rc.Close()
+
+	content, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("failed to read remaining file: %v", err)
+	}
+	if string(content) != "uploads/1/b.png" {
+		t.Errorf("unexpected content of remaining file: %q", content)
+	}
+
+	if got, want := list("uploads/1/"), []string{"uploads/1/b.png"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v after delete, got %v", want, got)
+	}
+}
+
+func TestFileSystemStore_Move(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewFileSystemStore(Config{Bucket: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	if err = store.Upload(ctx, strings.NewReader("new"), "tmp/a"); err != nil {
+		t.Fatalf("failed to upload file: %v", err)
+	}
+	if err = store.Upload(ctx, strings.NewReader("old"), "lfs/1/b"); err != nil {
+		t.Fatalf("failed to upload file: %v", err)
+	}
+
+	// the destination is replaced and its parent directory created if needed.
+	for _, dstPath := range []string{"lfs/1/b", "lfs/2/c"} {
+		if err = store.Move(ctx, "tmp/a", dstPath); err != nil {
+			t.Fatalf("failed to move file to %s: %v", dstPath, err)
+		}
+
+		rc, err := store.Download(ctx, dstPath)
+		if err != nil {
+			t.Fatalf("failed to download moved file: %v", err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("failed to read moved file: %v", err)
+		}
+		if string(content) != "new" {
+			t.Errorf("unexpected content of moved file %s: %q", dstPath, content)
+		}
+
+		if _, err = store.Download(ctx, "tmp/a"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("expected ErrNotFound for source of move, got %v", err)
+		}
+
+		if err = store.Upload(ctx, strings.NewReader("new"), "tmp/a"); err != nil {
+			t.Fatalf("failed to upload file: %v", err)
+		}
+	}
+
+	if err = store.Move(ctx, "tmp/missing", "lfs/1/d"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound when moving a missing file, got %v", err)
+	}
+}
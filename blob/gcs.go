@@ -16,6 +16,7 @@ package blob
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"golang.org/x/oauth2"
 	"google.golang.org/api/impersonate"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -112,6 +114,73 @@ func (c *GCSStore) Download(_ context.Context, _ string) (io.ReadCloser, error)
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (c *GCSStore) Delete(ctx context.Context, filePath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	err = gcsClient.Bucket(c.config.Bucket).Object(filePath).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func (c *GCSStore) Move(ctx context.Context, srcPath string, dstPath string) error {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	bkt := gcsClient.Bucket(c.config.Bucket)
+	src := bkt.Object(srcPath)
+
+	_, err = bkt.Object(dstPath).CopierFrom(src).Run(ctx)
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy file: %s to: %s in bucket: %s %w", srcPath, dstPath, c.config.Bucket, err)
+	}
+
+	err = src.Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", srcPath, c.config.Bucket, err)
+	}
+
+	return nil
+}
+
+func (c *GCSStore) List(ctx context.Context, prefix string) ([]File, error) {
+	gcsClient, err := c.getLatestClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve latest client: %w", err)
+	}
+
+	var files []File
+	it := gcsClient.Bucket(c.config.Bucket).Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files with prefix: %s in bucket: %s %w",
+				prefix, c.config.Bucket, err)
+		}
+
+		files = append(files, File{
+			Path:     attrs.Name,
+			Size:     attrs.Size,
+			Modified: attrs.Updated,
+		})
+	}
+
+	return files, nil
+}
+
 func createNewImpersonatedClient(ctx context.Context, cfg Config) (*storage.Client, error) {
 	// Use workload identity impersonation default credentials (GKE environment)
 	ts, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 )
 
 var (
@@ -34,4 +35,20 @@ type Store interface {
 
 	// Download returns a reader for a file in the blob store.
 	Download(ctx context.Context, filePath string) (io.ReadCloser, error)
+
+	// Delete deletes a file from the blob store. Deleting a file that doesn't exist isn't an error.
+	Delete(ctx context.Context, filePath string) error
+
+	// Move moves a file to a new path in the blob store, replacing any existing file at the destination.
+	Move(ctx context.Context, srcPath string, dstPath string) error
+
+	// List returns all files in the blob store with a path that starts with the provided prefix.
+	List(ctx context.Context, prefix string) ([]File, error)
+}
+
+// File contains the information about a file in the blob store.
+type File struct {
+	Path     string
+	Size     int64
+	Modified time.Time
 }
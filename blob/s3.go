@@ -0,0 +1,177 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+const s3SignedURLExpiry = 1 * time.Hour
+
+// S3Store is a blob store backed by AWS S3 or any S3 compatible service like MinIO.
+type S3Store struct {
+	bucket   string
+	client   *s3.S3
+	uploader *s3manager.Uploader
+}
+
+func NewS3Store(cfg Config) (Store, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("s3 bucket is required")
+	}
+
+	awsConfig := &aws.Config{
+		S3ForcePathStyle: aws.Bool(cfg.S3.PathStyle),
+	}
+	if cfg.S3.Endpoint != "" {
+		awsConfig.Endpoint = aws.String(cfg.S3.Endpoint)
+		awsConfig.DisableSSL = aws.Bool(!strings.HasPrefix(cfg.S3.Endpoint, "https://"))
+	}
+	if cfg.S3.Region != "" {
+		awsConfig.Region = aws.String(cfg.S3.Region)
+	}
+	// without static credentials the default credential chain of the sdk is used (env, shared config, IAM role).
+	if cfg.S3.AccessKey != "" || cfg.S3.SecretKey != "" {
+		awsConfig.Credentials = credentials.NewStaticCredentials(cfg.S3.AccessKey, cfg.S3.SecretKey, "")
+	}
+
+	sess, err := session.NewSession(awsConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 session: %w", err)
+	}
+
+	return &S3Store{
+		bucket:   cfg.Bucket,
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+	}, nil
+}
+
+func (c *S3Store) Upload(ctx context.Context, file io.Reader, filePath string) error {
+	_, err := c.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(filePath),
+		Body:   file,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write file to s3: %w", err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) GetSignedURL(_ context.Context, filePath string) (string, error) {
+	req, _ := c.client.GetObjectRequest(&s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(filePath),
+	})
+
+	signedURL, err := req.Presign(s3SignedURLExpiry)
+	if err != nil {
+		return "", fmt.Errorf("failed to create signed URL for file: %s %w", filePath, err)
+	}
+
+	return signedURL, nil
+}
+
+func (c *S3Store) Download(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	out, err := c.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(filePath),
+	})
+	if isS3NotFound(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download file from s3: %w", err)
+	}
+
+	return out.Body, nil
+}
+
+func (c *S3Store) Delete(ctx context.Context, filePath string) error {
+	_, err := c.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.bucket),
+		Key:    aws.String(filePath),
+	})
+	if err != nil && !isS3NotFound(err) {
+		return fmt.Errorf("failed to delete file: %s from bucket: %s %w", filePath, c.bucket, err)
+	}
+
+	return nil
+}
+
+func (c *S3Store) Move(ctx context.Context, srcPath string, dstPath string) error {
+	_, err := c.client.CopyObjectWithContext(ctx, &s3.CopyObjectInput{
+		ACL:    aws.String(s3.ObjectCannedACLPrivate),
+		Bucket: aws.String(c.bucket),
+		// the copy source has to be url encoded, the path separators are kept as is.
+		CopySource: aws.String((&url.URL{Path: c.bucket + "/" + srcPath}).EscapedPath()),
+		Key:        aws.String(dstPath),
+	})
+	if isS3NotFound(err) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy file: %s to: %s in bucket: %s %w", srcPath, dstPath, c.bucket, err)
+	}
+
+	return c.Delete(ctx, srcPath)
+}
+
+func (c *S3Store) List(ctx context.Context, prefix string) ([]File, error) {
+	var files []File
+	err := c.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(c.bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			files = append(files, File{
+				Path:     aws.StringValue(object.Key),
+				Size:     aws.Int64Value(object.Size),
+				Modified: aws.TimeValue(object.LastModified),
+			})
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files with prefix: %s in bucket: %s %w", prefix, c.bucket, err)
+	}
+
+	return files, nil
+}
+
+func isS3NotFound(err error) bool {
+	var awsErr awserr.Error
+	if !errors.As(err, &awsErr) {
+		return false
+	}
+
+	return awsErr.Code() == s3.ErrCodeNoSuchKey || awsErr.Code() == "NotFound"
+}
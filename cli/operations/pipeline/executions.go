@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const executionListTmpl = `#{{ .Number }} [{{ .Status }}] {{ .Target }} {{ .Message }}
+`
+
+type executionsCommand struct {
+	repo     string
+	pipeline string
+	page     int
+	size     int
+
+	json bool
+	tmpl string
+}
+
+func (c *executionsCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	list, err := provide.Client().ExecutionList(ctx, c.repo, c.pipeline, types.Pagination{
+		Page: c.page,
+		Size: c.size,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.PrintList(list, c.tmpl, c.json)
+}
+
+// helper function registers the pipeline executions command.
+func registerExecutions(app *kingpin.CmdClause) {
+	c := &executionsCommand{}
+
+	cmd := app.Command("executions", "display a list of executions of a pipeline").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("pipeline", "the identifier of the pipeline").
+		Required().
+		StringVar(&c.pipeline)
+
+	cmd.Flag("page", "page number").
+		IntVar(&c.page)
+
+	cmd.Flag("per-page", "page size").
+		IntVar(&c.size)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(executionListTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
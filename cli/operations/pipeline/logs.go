@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/client"
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type logsCommand struct {
+	repo      string
+	pipeline  string
+	execution int64
+	stage     int64
+	step      int64
+}
+
+func (c *logsCommand) run(*kingpin.ParseContext) error {
+	// tailing the logs of a running step can take arbitrarily long - only stop on interrupt.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cli := provide.Client()
+
+	execution, err := func() (*types.Execution, error) {
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+		return cli.Execution(ctx, c.repo, c.pipeline, c.execution)
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, stage := range execution.Stages {
+		if c.stage != 0 && stage.Number != c.stage {
+			continue
+		}
+		for _, step := range stage.Steps {
+			if c.step != 0 && step.Number != c.step {
+				continue
+			}
+
+			fmt.Printf("--- %s / %s [%s]\n", stage.Name, step.Name, step.Status)
+			if err = c.printStep(ctx, cli, stage, step); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// printStep prints the logs of a completed step, or follows the logs of a step that is still running.
+func (c *logsCommand) printStep(
+	ctx context.Context,
+	cli client.Client,
+	stage *types.Stage,
+	step *types.Step,
+) error {
+	if step.Status.IsDone() {
+		ctx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		lines, err := cli.Logs(ctx, c.repo, c.pipeline, c.execution, stage.Number, step.Number)
+		if err != nil {
+			return err
+		}
+		for _, line := range lines {
+			fmt.Print(line.Message)
+		}
+		return nil
+	}
+
+	linec, errc := cli.LogTail(ctx, c.repo, c.pipeline, c.execution, stage.Number, step.Number)
+	for line := range linec {
+		fmt.Print(line.Message)
+	}
+
+	select {
+	case err := <-errc:
+		return err
+	default:
+		return nil
+	}
+}
+
+// helper function registers the pipeline logs command.
+func registerLogs(app *kingpin.CmdClause) {
+	c := &logsCommand{}
+
+	cmd := app.Command("logs", "display the logs of an execution, following steps that are still running").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("pipeline", "the identifier of the pipeline").
+		Required().
+		StringVar(&c.pipeline)
+
+	cmd.Arg("execution", "the number of the execution").
+		Required().
+		Int64Var(&c.execution)
+
+	cmd.Flag("stage", "only display the logs of the stage with the given number").
+		Int64Var(&c.stage)
+
+	cmd.Flag("step", "only display the logs of the step with the given number").
+		Int64Var(&c.step)
+}
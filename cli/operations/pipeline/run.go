@@ -0,0 +1,77 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pipeline
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type runCommand struct {
+	repo     string
+	pipeline string
+	branch   string
+	params   map[string]string
+
+	json bool
+	tmpl string
+}
+
+func (c *runCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	execution, err := provide.Client().ExecutionCreate(ctx, c.repo, c.pipeline, c.branch, c.params)
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(execution, c.tmpl, c.json)
+}
+
+// helper function registers the pipeline run command.
+func registerRun(app *kingpin.CmdClause) {
+	c := &runCommand{}
+
+	cmd := app.Command("run", "run a pipeline").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("pipeline", "the identifier of the pipeline").
+		Required().
+		StringVar(&c.pipeline)
+
+	cmd.Flag("branch", "the branch to run the pipeline on (the default branch if not provided)").
+		StringVar(&c.branch)
+
+	cmd.Flag("param", "an input parameter of the pipeline (key=value), can be repeated").
+		StringMapVar(&c.params)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(executionTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
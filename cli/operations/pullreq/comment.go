@@ -0,0 +1,87 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const commentTmpl = `
+id:   {{ .ID }}
+text: {{ .Text }}
+`
+
+type commentCommand struct {
+	repo     string
+	number   int64
+	text     string
+	parentID int64
+
+	json bool
+	tmpl string
+}
+
+func (c *commentCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	comment, err := provide.Client().PullReqCommentCreate(ctx, c.repo, c.number, &pullreq.CommentCreateInput{
+		ParentID: c.parentID,
+		Text:     c.text,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(comment, c.tmpl, c.json)
+}
+
+// helper function registers the pull request comment command.
+func registerComment(app *kingpin.CmdClause) {
+	c := &commentCommand{}
+
+	cmd := app.Command("comment", "comment on a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("number", "the number of the pull request").
+		Required().
+		Int64Var(&c.number)
+
+	cmd.Arg("text", "the text of the comment").
+		Required().
+		StringVar(&c.text)
+
+	cmd.Flag("reply-to", "the id of the comment to reply to").
+		Int64Var(&c.parentID)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(commentTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
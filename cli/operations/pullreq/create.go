@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	repo         string
+	sourceBranch string
+	targetBranch string
+	title        string
+	description  string
+	draft        bool
+
+	json bool
+	tmpl string
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client := provide.Client()
+
+	// target the default branch of the repository if not provided.
+	if c.targetBranch == "" {
+		repo, err := client.Repo(ctx, c.repo)
+		if err != nil {
+			return err
+		}
+		c.targetBranch = repo.DefaultBranch
+	}
+
+	title := c.title
+	if title == "" {
+		title = c.sourceBranch
+	}
+
+	pr, err := client.PullReqCreate(ctx, c.repo, &pullreq.CreateInput{
+		IsDraft:      c.draft,
+		Title:        title,
+		Description:  c.description,
+		SourceBranch: c.sourceBranch,
+		TargetBranch: c.targetBranch,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(pr, c.tmpl, c.json)
+}
+
+// helper function registers the pull request create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("source", "the source branch").
+		Required().
+		StringVar(&c.sourceBranch)
+
+	cmd.Flag("target", "the target branch (the default branch if not provided)").
+		StringVar(&c.targetBranch)
+
+	cmd.Flag("title", "the title of the pull request (the source branch if not provided)").
+		StringVar(&c.title)
+
+	cmd.Flag("description", "the description of the pull request").
+		StringVar(&c.description)
+
+	cmd.Flag("draft", "create the pull request as draft").
+		BoolVar(&c.draft)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(pullReqTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
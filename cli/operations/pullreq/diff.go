@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type diffCommand struct {
+	repo   string
+	number int64
+}
+
+func (c *diffCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	diff, err := provide.Client().PullReqDiff(ctx, c.repo, c.number)
+	if err != nil {
+		return err
+	}
+	defer diff.Close()
+
+	_, err = io.Copy(os.Stdout, diff)
+	return err
+}
+
+// helper function registers the pull request diff command.
+func registerDiff(app *kingpin.CmdClause) {
+	c := &diffCommand{}
+
+	cmd := app.Command("diff", "display the changes of a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("number", "the number of the pull request").
+		Required().
+		Int64Var(&c.number)
+}
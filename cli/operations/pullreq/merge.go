@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const mergeTmpl = `
+sha:            {{ .SHA }}
+branch deleted: {{ .BranchDeleted }}
+`
+
+type mergeCommand struct {
+	repo   string
+	number int64
+	method string
+	dryRun bool
+
+	json bool
+	tmpl string
+}
+
+func (c *mergeCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client := provide.Client()
+
+	// the merge is done for the latest commit of the pull request - fails in case it changes meanwhile.
+	pr, err := client.PullReq(ctx, c.repo, c.number)
+	if err != nil {
+		return err
+	}
+
+	out, err := client.PullReqMerge(ctx, c.repo, c.number, &pullreq.MergeInput{
+		Method:    enum.MergeMethod(c.method),
+		SourceSHA: pr.SourceSHA,
+		DryRun:    c.dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(out, c.tmpl, c.json)
+}
+
+// helper function registers the pull request merge command.
+func registerMerge(app *kingpin.CmdClause) {
+	c := &mergeCommand{}
+
+	cmd := app.Command("merge", "merge a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("number", "the number of the pull request").
+		Required().
+		Int64Var(&c.number)
+
+	cmd.Flag("method", "the merge method").
+		Default(string(enum.MergeMethodMerge)).
+		EnumVar(&c.method,
+			string(enum.MergeMethodMerge),
+			string(enum.MergeMethodSquash),
+			string(enum.MergeMethodRebase),
+			string(enum.MergeMethodFastForward))
+
+	cmd.Flag("dry-run", "only check whether the pull request can be merged").
+		BoolVar(&c.dryRun)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(mergeTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
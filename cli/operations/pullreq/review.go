@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/types/enum"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type reviewCommand struct {
+	repo     string
+	number   int64
+	decision string
+}
+
+func (c *reviewCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client := provide.Client()
+
+	// the review is always submitted for the latest commit of the pull request.
+	pr, err := client.PullReq(ctx, c.repo, c.number)
+	if err != nil {
+		return err
+	}
+
+	return client.PullReqReviewSubmit(ctx, c.repo, c.number, &pullreq.ReviewSubmitInput{
+		CommitSHA: pr.SourceSHA,
+		Decision:  enum.PullReqReviewDecision(c.decision),
+	})
+}
+
+// helper function registers the pull request review command.
+func registerReview(app *kingpin.CmdClause) {
+	c := &reviewCommand{}
+
+	cmd := app.Command("review", "review a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("number", "the number of the pull request").
+		Required().
+		Int64Var(&c.number)
+
+	cmd.Flag("decision", "the review decision").
+		Default(string(enum.PullReqReviewDecisionApproved)).
+		EnumVar(&c.decision,
+			string(enum.PullReqReviewDecisionApproved),
+			string(enum.PullReqReviewDecisionChangeReq),
+			string(enum.PullReqReviewDecisionReviewed))
+}
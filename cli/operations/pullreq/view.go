@@ -0,0 +1,73 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const pullReqViewTmpl = pullReqTmpl + `
+{{ .Description }}
+`
+
+type viewCommand struct {
+	repo   string
+	number int64
+
+	json bool
+	tmpl string
+}
+
+func (c *viewCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	pr, err := provide.Client().PullReq(ctx, c.repo, c.number)
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(pr, c.tmpl, c.json)
+}
+
+// helper function registers the pull request view command.
+func registerView(app *kingpin.CmdClause) {
+	c := &viewCommand{}
+
+	cmd := app.Command("view", "display a pull request").
+		Action(c.run)
+
+	cmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&c.repo)
+
+	cmd.Arg("number", "the number of the pull request").
+		Required().
+		Int64Var(&c.number)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(pullReqViewTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
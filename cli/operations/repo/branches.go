@@ -0,0 +1,158 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const branchTmpl = `{{ .SHA }} {{ .Name }}
+`
+
+type branchListCommand struct {
+	repo  string
+	query string
+	page  int
+	size  int
+
+	json bool
+	tmpl string
+}
+
+func (c *branchListCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	list, err := provide.Client().RepoBranchList(ctx, c.repo, types.BranchFilter{
+		Page:  c.page,
+		Size:  c.size,
+		Query: c.query,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.PrintList(list, c.tmpl, c.json)
+}
+
+type branchCreateCommand struct {
+	repo   string
+	name   string
+	target string
+
+	json bool
+	tmpl string
+}
+
+func (c *branchCreateCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	branch, err := provide.Client().RepoBranchCreate(ctx, c.repo, &repo.CreateBranchInput{
+		Name:   c.name,
+		Target: c.target,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(branch, c.tmpl, c.json)
+}
+
+type branchDeleteCommand struct {
+	repo string
+	name string
+}
+
+func (c *branchDeleteCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return provide.Client().RepoBranchDelete(ctx, c.repo, c.name)
+}
+
+// helper function registers the repo branch commands.
+func registerBranches(app *kingpin.CmdClause) {
+	cmd := app.Command("branches", "manage the branches of a repository")
+
+	list := &branchListCommand{}
+	listCmd := cmd.Command("ls", "display a list of branches").
+		Default().
+		Action(list.run)
+
+	listCmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&list.repo)
+
+	listCmd.Flag("query", "filter by name").
+		StringVar(&list.query)
+
+	listCmd.Flag("page", "page number").
+		IntVar(&list.page)
+
+	listCmd.Flag("per-page", "page size").
+		IntVar(&list.size)
+
+	listCmd.Flag("json", "json encode the output").
+		BoolVar(&list.json)
+
+	listCmd.Flag("format", "format the output using a Go template").
+		Default(branchTmpl).
+		Hidden().
+		StringVar(&list.tmpl)
+
+	create := &branchCreateCommand{}
+	createCmd := cmd.Command("create", "create a branch").
+		Action(create.run)
+
+	createCmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&create.repo)
+
+	createCmd.Arg("name", "the name of the branch").
+		Required().
+		StringVar(&create.name)
+
+	createCmd.Flag("target", "the commit or branch the new branch points to (the default branch if not provided)").
+		StringVar(&create.target)
+
+	createCmd.Flag("json", "json encode the output").
+		BoolVar(&create.json)
+
+	createCmd.Flag("format", "format the output using a Go template").
+		Default(branchTmpl).
+		Hidden().
+		StringVar(&create.tmpl)
+
+	del := &branchDeleteCommand{}
+	deleteCmd := cmd.Command("delete", "delete a branch").
+		Action(del.run)
+
+	deleteCmd.Arg("repo", "the path or id of the repository").
+		Required().
+		StringVar(&del.repo)
+
+	deleteCmd.Arg("name", "the name of the branch").
+		Required().
+		StringVar(&del.name)
+}
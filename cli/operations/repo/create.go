@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	path          string
+	description   string
+	defaultBranch string
+	public        bool
+	readme        bool
+	license       string
+	gitIgnore     string
+
+	json bool
+	tmpl string
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	parentRef, uid, err := paths.DisectLeaf(c.path)
+	if err != nil {
+		return err
+	}
+
+	r, err := provide.Client().RepoCreate(ctx, &repo.CreateInput{
+		ParentRef:     parentRef,
+		UID:           uid,
+		DefaultBranch: c.defaultBranch,
+		Description:   c.description,
+		IsPublic:      c.public,
+		Readme:        c.readme,
+		License:       c.license,
+		GitIgnore:     c.gitIgnore,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(r, c.tmpl, c.json)
+}
+
+// helper function registers the repo create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create a repository").
+		Action(c.run)
+
+	cmd.Arg("path", "the path of the repository (e.g. space/repo)").
+		Required().
+		StringVar(&c.path)
+
+	cmd.Flag("description", "the description of the repository").
+		StringVar(&c.description)
+
+	cmd.Flag("default-branch", "the default branch of the repository").
+		StringVar(&c.defaultBranch)
+
+	cmd.Flag("public", "make the repository publicly accessible").
+		BoolVar(&c.public)
+
+	cmd.Flag("readme", "initialize the repository with a readme").
+		BoolVar(&c.readme)
+
+	cmd.Flag("license", "initialize the repository with a license (e.g. mit)").
+		StringVar(&c.license)
+
+	cmd.Flag("gitignore", "initialize the repository with a gitignore (e.g. Go)").
+		StringVar(&c.gitIgnore)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/app/services/importer"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type importCommand struct {
+	path         string
+	description  string
+	providerType string
+	providerRepo string
+	host         string
+	username     string
+	password     string
+	pipelines    bool
+	pullReqs     bool
+
+	json bool
+	tmpl string
+}
+
+func (c *importCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	parentRef, uid, err := paths.DisectLeaf(c.path)
+	if err != nil {
+		return err
+	}
+
+	pipelines := importer.PipelineOptionIgnore
+	if c.pipelines {
+		pipelines = importer.PipelineOptionConvert
+	}
+	pullReqs := importer.PullReqOptionIgnore
+	if c.pullReqs {
+		pullReqs = importer.PullReqOptionImport
+	}
+
+	r, err := provide.Client().RepoImport(ctx, &repo.ImportInput{
+		ParentRef:   parentRef,
+		UID:         uid,
+		Description: c.description,
+		Provider: importer.Provider{
+			Type:     importer.ProviderType(c.providerType),
+			Host:     c.host,
+			Username: c.username,
+			Password: c.password,
+		},
+		ProviderRepo: c.providerRepo,
+		Pipelines:    pipelines,
+		PullReqs:     pullReqs,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(r, c.tmpl, c.json)
+}
+
+// helper function registers the repo import command.
+func registerImport(app *kingpin.CmdClause) {
+	c := &importCommand{}
+
+	cmd := app.Command("import", "import a repository from an external provider").
+		Action(c.run)
+
+	cmd.Arg("path", "the path of the new repository (e.g. space/repo)").
+		Required().
+		StringVar(&c.path)
+
+	cmd.Arg("provider", "the type of the provider (github, gitlab, bitbucket, stash, gitea, gogs)").
+		Required().
+		StringVar(&c.providerType)
+
+	cmd.Arg("provider-repo", "the repository at the provider (e.g. owner/repo)").
+		Required().
+		StringVar(&c.providerRepo)
+
+	cmd.Flag("description", "the description of the repository").
+		StringVar(&c.description)
+
+	cmd.Flag("host", "the host of the provider, required for self hosted providers").
+		StringVar(&c.host)
+
+	cmd.Flag("username", "the username used to authenticate with the provider").
+		StringVar(&c.username)
+
+	cmd.Flag("password", "the password or token used to authenticate with the provider").
+		Envar("GITNESS_IMPORT_PASSWORD").
+		StringVar(&c.password)
+
+	cmd.Flag("pipelines", "convert the pipelines of the repository").
+		BoolVar(&c.pipelines)
+
+	cmd.Flag("pull-requests", "import the pull requests of the repository").
+		BoolVar(&c.pullReqs)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
@@ -0,0 +1,81 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type listCommand struct {
+	space string
+	query string
+	page  int
+	size  int
+
+	json bool
+	tmpl string
+}
+
+func (c *listCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	list, err := provide.Client().RepoList(ctx, c.space, types.RepoFilter{
+		Page:  c.page,
+		Size:  c.size,
+		Query: c.query,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.PrintList(list, c.tmpl, c.json)
+}
+
+// helper function registers the repo list command.
+func registerList(app *kingpin.CmdClause) {
+	c := &listCommand{}
+
+	cmd := app.Command("ls", "display a list of repositories").
+		Action(c.run)
+
+	cmd.Arg("space", "the path or id of the space").
+		Required().
+		StringVar(&c.space)
+
+	cmd.Flag("query", "filter by name").
+		StringVar(&c.query)
+
+	cmd.Flag("page", "page number").
+		IntVar(&c.page)
+
+	cmd.Flag("per-page", "page size").
+		IntVar(&c.size)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(repoTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/paths"
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type createCommand struct {
+	path        string
+	description string
+	public      bool
+
+	json bool
+	tmpl string
+}
+
+func (c *createCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	parentRef, uid, err := paths.DisectLeaf(c.path)
+	if err != nil {
+		return err
+	}
+
+	s, err := provide.Client().SpaceCreate(ctx, &space.CreateInput{
+		ParentRef:   parentRef,
+		UID:         uid,
+		Description: c.description,
+		IsPublic:    c.public,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.Print(s, c.tmpl, c.json)
+}
+
+// helper function registers the space create command.
+func registerCreate(app *kingpin.CmdClause) {
+	c := &createCommand{}
+
+	cmd := app.Command("create", "create a space").
+		Action(c.run)
+
+	cmd.Arg("path", "the path of the space (e.g. parent/child)").
+		Required().
+		StringVar(&c.path)
+
+	cmd.Flag("description", "the description of the space").
+		StringVar(&c.description)
+
+	cmd.Flag("public", "make the space publicly accessible").
+		BoolVar(&c.public)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(spaceTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
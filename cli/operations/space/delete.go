@@ -0,0 +1,47 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+type deleteCommand struct {
+	ref string
+}
+
+func (c *deleteCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	return provide.Client().SpaceDelete(ctx, c.ref)
+}
+
+// helper function registers the space delete command.
+func registerDelete(app *kingpin.CmdClause) {
+	c := &deleteCommand{}
+
+	cmd := app.Command("delete", "delete a space").
+		Action(c.run)
+
+	cmd.Arg("space", "the path or id of the space").
+		Required().
+		StringVar(&c.ref)
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/cli/textui"
+	"github.com/harness/gitness/types"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+)
+
+const membershipSpaceTmpl = `
+id:   {{ .Space.ID }}
+path: {{ .Space.Path }}
+role: {{ .Role }}
+`
+
+type listCommand struct {
+	parent string
+	query  string
+	page   int
+	size   int
+
+	json bool
+	tmpl string
+}
+
+func (c *listCommand) run(*kingpin.ParseContext) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// without parent, list the spaces the user is a member of.
+	if c.parent == "" {
+		list, err := provide.Client().MembershipSpaces(ctx, types.MembershipSpaceFilter{
+			ListQueryFilter: types.ListQueryFilter{
+				Pagination: types.Pagination{Page: c.page, Size: c.size},
+				Query:      c.query,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		tmpl := c.tmpl
+		if tmpl == spaceTmpl {
+			tmpl = membershipSpaceTmpl
+		}
+		return textui.PrintList(list, tmpl, c.json)
+	}
+
+	list, err := provide.Client().SpaceList(ctx, c.parent, types.SpaceFilter{
+		Page:  c.page,
+		Size:  c.size,
+		Query: c.query,
+	})
+	if err != nil {
+		return err
+	}
+
+	return textui.PrintList(list, c.tmpl, c.json)
+}
+
+// helper function registers the space list command.
+func registerList(app *kingpin.CmdClause) {
+	c := &listCommand{}
+
+	cmd := app.Command("ls", "display a list of spaces").
+		Action(c.run)
+
+	cmd.Arg("parent", "the parent space (the spaces of the user if not provided)").
+		StringVar(&c.parent)
+
+	cmd.Flag("query", "filter by name").
+		StringVar(&c.query)
+
+	cmd.Flag("page", "page number").
+		IntVar(&c.page)
+
+	cmd.Flag("per-page", "page size").
+		IntVar(&c.size)
+
+	cmd.Flag("json", "json encode the output").
+		BoolVar(&c.json)
+
+	cmd.Flag("format", "format the output using a Go template").
+		Default(spaceTmpl).
+		Hidden().
+		StringVar(&c.tmpl)
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/cli/provide"
+	"github.com/harness/gitness/types/enum"
 
 	"github.com/drone/funcmap"
 	"github.com/gotidy/ptr"
@@ -39,6 +40,8 @@ token:       {{ .AccessToken }}
 type createPATCommand struct {
 	uid         string
 	lifetimeInS int64
+	permissions []string
+	paths       []string
 
 	json bool
 	tmpl string
@@ -53,9 +56,16 @@ func (c *createPATCommand) run(*kingpin.ParseContext) error {
 		lifeTime = ptr.Duration(time.Duration(int64(time.Second) * c.lifetimeInS))
 	}
 
+	permissions := make([]enum.Permission, len(c.permissions))
+	for i, permission := range c.permissions {
+		permissions[i] = enum.Permission(permission)
+	}
+
 	in := user.CreateTokenInput{
-		UID:      c.uid,
-		Lifetime: lifeTime,
+		UID:         c.uid,
+		Lifetime:    lifeTime,
+		Permissions: permissions,
+		Paths:       c.paths,
 	}
 
 	tokenResp, err := provide.Client().UserCreatePAT(ctx, in)
@@ -87,6 +97,12 @@ func registerCreatePAT(app *kingpin.CmdClause) {
 	cmd.Arg("lifetime", "the lifetime of the token in seconds").
 		Int64Var(&c.lifetimeInS)
 
+	cmd.Flag("permission", "restrict the token to the permission (can be repeated)").
+		StringsVar(&c.permissions)
+
+	cmd.Flag("path", "restrict the token to the space or repository path (can be repeated)").
+		StringsVar(&c.paths)
+
 	cmd.Flag("json", "json encode the output").
 		BoolVar(&c.json)
 
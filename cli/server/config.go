@@ -22,13 +22,20 @@ import (
 	"strings"
 	"unicode"
 
+	"github.com/harness/gitness/app/auth/oidc"
+	"github.com/harness/gitness/app/gitssh"
+	"github.com/harness/gitness/app/pipeline/artifact"
+	pipelinecache "github.com/harness/gitness/app/pipeline/cache"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
 	"github.com/harness/gitness/store/database"
@@ -45,6 +52,9 @@ const (
 	schemeHTTPS    = "https"
 	gitnessHomeDir = ".gitness"
 	blobDir        = "blob"
+	searchIndexDir = "search-index"
+	sshDir         = "ssh"
+	sshHostKeyFile = "gitness_host_ed25519_key"
 )
 
 // LoadConfig returns the system configuration from the
@@ -160,6 +170,12 @@ func backfillURLs(config *types.Config) error {
 	if config.URL.UI == "" {
 		config.URL.UI = baseURL.String()
 	}
+	if config.OIDC.RedirectURL == "" {
+		config.OIDC.RedirectURL, err = url.JoinPath(config.URL.API, "v1/oidc/callback")
+		if err != nil {
+			return fmt.Errorf("failed to derive oidc redirect url: %w", err)
+		}
+	}
 
 	return nil
 }
@@ -253,6 +269,13 @@ func ProvideBlobStoreConfig(config *types.Config) (blob.Config, error) {
 		KeyPath:               config.BlobStore.KeyPath,
 		TargetPrincipal:       config.BlobStore.TargetPrincipal,
 		ImpersonationLifetime: config.BlobStore.ImpersonationLifetime,
+		S3: blob.S3Config{
+			Endpoint:  config.BlobStore.S3.Endpoint,
+			Region:    config.BlobStore.S3.Region,
+			AccessKey: config.BlobStore.S3.AccessKey,
+			SecretKey: config.BlobStore.S3.SecretKey,
+			PathStyle: config.BlobStore.S3.PathStyle,
+		},
 	}, nil
 }
 
@@ -279,6 +302,17 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 	}
 }
 
+// ProvideNotificationConfig loads the notification service config from the main config.
+// Notifications are only sent in case an smtp server is configured.
+func ProvideNotificationConfig(config *types.Config) notification.Config {
+	return notification.Config{
+		Enabled:         config.Notification.Enabled && config.SMTP.Host != "",
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Notification.Concurrency,
+		MaxRetries:      config.Notification.MaxRetries,
+	}
+}
+
 // ProvideTriggerConfig loads the trigger service config from the main config.
 func ProvideTriggerConfig(config *types.Config) trigger.Config {
 	return trigger.Config{
@@ -288,6 +322,19 @@ func ProvideTriggerConfig(config *types.Config) trigger.Config {
 	}
 }
 
+// ProvideMirrorConfig loads the repository mirror config from the main config.
+func ProvideMirrorConfig(config *types.Config) mirror.Config {
+	return mirror.Config{
+		MinInterval:     config.Mirror.MinInterval,
+		DefaultInterval: config.Mirror.DefaultInterval,
+		SyncTimeout:     config.Mirror.SyncTimeout,
+		PushDebounce:    config.Mirror.PushDebounce,
+		EventReaderName: config.InstanceID,
+		Concurrency:     config.Mirror.Concurrency,
+		MaxRetries:      config.Mirror.MaxRetries,
+	}
+}
+
 // ProvideLockConfig generates the `lock` package config from the gitness config.
 func ProvideLockConfig(config *types.Config) lock.Config {
 	return lock.Config{
@@ -314,10 +361,49 @@ func ProvidePubsubConfig(config *types.Config) pubsub.Config {
 	}
 }
 
+// ProvideLogStreamConfig loads the live log stream config from the main config.
+func ProvideLogStreamConfig(config *types.Config) livelog.Config {
+	return livelog.Config{
+		App:       config.LogStream.AppNamespace,
+		Provider:  config.LogStream.Provider,
+		MaxLength: config.LogStream.MaxLength,
+		Expiry:    config.LogStream.Expiry,
+	}
+}
+
 // ProvideCleanupConfig loads the cleanup service config from the main config.
 func ProvideCleanupConfig(config *types.Config) cleanup.Config {
 	return cleanup.Config{
 		WebhookExecutionsRetentionTime: config.Webhook.RetentionTime,
+		ExecutionRetentionCount:        config.CI.ExecutionRetention.Count,
+		ExecutionRetentionDays:         config.CI.ExecutionRetention.Days,
+	}
+}
+
+// ProvideArtifactConfig loads the pipeline artifact config from the main config.
+func ProvideArtifactConfig(config *types.Config) artifact.Config {
+	return artifact.Config{
+		Retention: config.CI.Artifacts.Retention,
+		MaxSize:   config.CI.Artifacts.MaxSize,
+	}
+}
+
+// ProvidePipelineCacheConfig loads the pipeline cache config from the main config.
+func ProvidePipelineCacheConfig(config *types.Config) pipelinecache.Config {
+	return pipelinecache.Config{
+		Retention: config.CI.Cache.Retention,
+		MaxSize:   config.CI.Cache.MaxSize,
+	}
+}
+
+// ProvideOIDCConfig loads the oidc provider config from the main config.
+func ProvideOIDCConfig(config *types.Config) oidc.Config {
+	return oidc.Config{
+		Issuer:       config.OIDC.Issuer,
+		ClientID:     config.OIDC.ClientID,
+		ClientSecret: config.OIDC.ClientSecret,
+		RedirectURL:  config.OIDC.RedirectURL,
+		Scopes:       config.OIDC.Scopes,
 	}
 }
 
@@ -336,3 +422,29 @@ func ProvideKeywordSearchConfig(config *types.Config) keywordsearch.Config {
 		MaxRetries:      config.KeywordSearch.MaxRetries,
 	}
 }
+
+// ProvideLocalIndexConfig loads the local keyword search index config from the main config.
+func ProvideLocalIndexConfig(config *types.Config) keywordsearch.LocalIndexConfig {
+	indexPath := config.KeywordSearch.IndexPath
+	if indexPath == "" {
+		indexPath = filepath.Join(config.Git.Root, searchIndexDir)
+	}
+
+	return keywordsearch.LocalIndexConfig{
+		IndexPath:   indexPath,
+		MaxFileSize: config.KeywordSearch.MaxFileSize,
+	}
+}
+
+// ProvideGitSSHConfig loads the git ssh server config from the main config.
+func ProvideGitSSHConfig(config *types.Config) gitssh.Config {
+	hostKeyPath := config.SSH.HostKeyPath
+	if hostKeyPath == "" {
+		hostKeyPath = filepath.Join(config.Git.Root, sshDir, sshHostKeyFile)
+	}
+
+	return gitssh.Config{
+		Port:        config.SSH.Port,
+		HostKeyPath: hostKeyPath,
+	}
+}
@@ -98,12 +98,31 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.Trigger.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register cron trigger job")
+			return err
+		}
+
+		if err := system.services.Mirror.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register mirror sync job")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
 	// start server
 	gHTTP, shutdownHTTP := system.server.ListenAndServe()
 	g.Go(gHTTP.Wait)
+
+	// start ssh server for git operations (if enabled)
+	shutdownSSH := func(context.Context) error { return nil }
+	if config.SSH.Enabled {
+		var gSSH *errgroup.Group
+		gSSH, shutdownSSH = system.sshServer.ListenAndServe()
+		g.Go(gSSH.Wait)
+	}
+
 	if c.enableCI {
 		// start populating plugins
 		g.Go(func() error {
@@ -113,7 +132,10 @@ func (c *command) run(*kingpin.ParseContext) error {
 			}
 			return nil
 		})
-		// start poller for CI build executions.
+	}
+
+	if c.enableCI && config.CI.EmbeddedRunnerEnabled {
+		// start poller for CI build executions (external runners poll via the rpc endpoint).
 		g.Go(func() error {
 			system.poller.Poll(
 				logger.WithWrappedZerolog(ctx),
@@ -125,6 +147,7 @@ func (c *command) run(*kingpin.ParseContext) error {
 
 	log.Info().
 		Int("port", config.Server.HTTP.Port).
+		Bool("ssh_enabled", config.SSH.Enabled).
 		Str("revision", version.GitCommit).
 		Str("repository", version.GitRepository).
 		Stringer("version", version.Version).
@@ -145,6 +168,10 @@ func (c *command) run(*kingpin.ParseContext) error {
 		log.Err(sErr).Msg("failed to shutdown http server gracefully")
 	}
 
+	if sErr := shutdownSSH(shutdownCtx); sErr != nil {
+		log.Err(sErr).Msg("failed to shutdown ssh server gracefully")
+	}
+
 	system.services.JobScheduler.WaitJobsDone(shutdownCtx)
 
 	log.Info().Msg("wait for subroutines to complete")
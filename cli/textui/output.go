@@ -0,0 +1,56 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package textui
+
+import (
+	"encoding/json"
+	"os"
+	"text/template"
+
+	"github.com/drone/funcmap"
+)
+
+// Print writes the value to stdout, either json encoded or formatted using the Go template.
+func Print(v any, tmpl string, asJSON bool) error {
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+	}
+
+	t, err := template.New("_").Funcs(funcmap.Funcs).Parse(tmpl)
+	if err != nil {
+		return err
+	}
+	return t.Execute(os.Stdout, v)
+}
+
+// PrintList writes the list to stdout, either json encoded or each item formatted using the Go template.
+func PrintList[T any](items []T, tmpl string, asJSON bool) error {
+	if asJSON {
+		return Print(items, "", true)
+	}
+
+	t, err := template.New("_").Funcs(funcmap.Funcs).Parse(tmpl)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err = t.Execute(os.Stdout, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
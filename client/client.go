@@ -23,6 +23,8 @@ import (
 	"net/http"
 	"net/http/httputil"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/harness/gitness/app/api/controller/user"
 	"github.com/harness/gitness/types"
@@ -147,6 +149,42 @@ func (c *HTTPClient) UserDelete(ctx context.Context, key string) error {
 // http request helper functions
 //
 
+// helper function that returns the url of a space, which can be suffixed with sub resources.
+func (c *HTTPClient) spaceURL(spaceRef string) string {
+	return fmt.Sprintf("%s/api/v1/spaces/%s/+", c.base, strings.Trim(spaceRef, "/"))
+}
+
+// helper function that returns the url of a repository, which can be suffixed with sub resources.
+func (c *HTTPClient) repoURL(repoRef string) string {
+	return fmt.Sprintf("%s/api/v1/repos/%s/+", c.base, strings.Trim(repoRef, "/"))
+}
+
+// helper function that appends the non-empty query parameters to the url.
+func withQuery(rawurl string, query url.Values) string {
+	for key, values := range query {
+		if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+			query.Del(key)
+		}
+	}
+	if len(query) == 0 {
+		return rawurl
+	}
+	return rawurl + "?" + query.Encode()
+}
+
+// helper function that returns the query parameters used for pagination and search.
+func listQuery(page, size int, query string) url.Values {
+	values := url.Values{}
+	if page > 0 {
+		values.Set("page", strconv.Itoa(page))
+	}
+	if size > 0 {
+		values.Set("limit", strconv.Itoa(size))
+	}
+	values.Set("query", query)
+	return values
+}
+
 // helper function for making an http GET request.
 func (c *HTTPClient) get(ctx context.Context, rawurl string, out interface{}) error {
 	return c.do(ctx, rawurl, "GET", false, nil, out)
@@ -226,14 +264,34 @@ func (c *HTTPClient) stream(ctx context.Context, rawurl, method string, noToken
 	// http accept header for debugging purposes.
 	req.Header.Set("Accept", "application/json;version="+version.Version.String())
 
-	// send the http request.
+	return c.send(req, true)
+}
+
+// helper function to open a raw http GET stream with the provided accept header
+// (e.g. used for text/plain diffs or text/event-stream logs).
+func (c *HTTPClient) open(ctx context.Context, rawurl, accept string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawurl, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("Accept", accept)
+
+	// never dump the body of raw streams, it could be endless.
+	return c.send(req, false)
+}
+
+// helper function to send the http request and return the response body.
+func (c *HTTPClient) send(req *http.Request, dumpBody bool) (io.ReadCloser, error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	if c.debug {
-		dump, _ := httputil.DumpResponse(resp, true)
-		log.Debug().Msgf("method %s, url %s", method, rawurl)
+		dump, _ := httputil.DumpResponse(resp, dumpBody)
+		log.Debug().Msgf("method %s, url %s", req.Method, req.URL)
 		log.Debug().Msg(string(dump))
 	}
 	if resp.StatusCode >= http.StatusMultipleChoices {
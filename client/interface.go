@@ -16,8 +16,13 @@ package client
 
 import (
 	"context"
+	"io"
 
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/space"
 	"github.com/harness/gitness/app/api/controller/user"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/types"
 )
 
@@ -49,6 +54,91 @@ type Client interface {
 
 	// UserCreatePAT creates a new PAT for the user.
 	UserCreatePAT(ctx context.Context, in user.CreateTokenInput) (*types.TokenResponse, error)
+
+	// Space returns a space by path or id.
+	Space(ctx context.Context, spaceRef string) (*types.Space, error)
+
+	// SpaceList returns the child spaces of a space.
+	SpaceList(ctx context.Context, spaceRef string, params types.SpaceFilter) ([]types.Space, error)
+
+	// SpaceCreate creates a new space.
+	SpaceCreate(ctx context.Context, in *space.CreateInput) (*types.Space, error)
+
+	// SpaceDelete deletes a space by path or id.
+	SpaceDelete(ctx context.Context, spaceRef string) error
+
+	// MembershipSpaces returns the spaces the current user is a member of.
+	MembershipSpaces(ctx context.Context, params types.MembershipSpaceFilter) ([]types.MembershipSpace, error)
+
+	// Repo returns a repository by path or id.
+	Repo(ctx context.Context, repoRef string) (*types.Repository, error)
+
+	// RepoList returns the repositories of a space.
+	RepoList(ctx context.Context, spaceRef string, params types.RepoFilter) ([]types.Repository, error)
+
+	// RepoCreate creates a new repository.
+	RepoCreate(ctx context.Context, in *repo.CreateInput) (*types.Repository, error)
+
+	// RepoImport creates a new repository and starts importing it from an external provider.
+	RepoImport(ctx context.Context, in *repo.ImportInput) (*types.Repository, error)
+
+	// RepoDelete deletes a repository by path or id.
+	RepoDelete(ctx context.Context, repoRef string) error
+
+	// RepoBranchList returns the branches of a repository.
+	RepoBranchList(ctx context.Context, repoRef string, params types.BranchFilter) ([]repo.Branch, error)
+
+	// RepoBranchCreate creates a new branch in a repository.
+	RepoBranchCreate(ctx context.Context, repoRef string, in *repo.CreateBranchInput) (*repo.Branch, error)
+
+	// RepoBranchDelete deletes a branch of a repository.
+	RepoBranchDelete(ctx context.Context, repoRef string, branch string) error
+
+	// PullReq returns a pull request by number.
+	PullReq(ctx context.Context, repoRef string, number int64) (*types.PullReq, error)
+
+	// PullReqList returns the pull requests of a repository.
+	PullReqList(ctx context.Context, repoRef string, params types.PullReqFilter) ([]types.PullReq, error)
+
+	// PullReqCreate creates a new pull request.
+	PullReqCreate(ctx context.Context, repoRef string, in *pullreq.CreateInput) (*types.PullReq, error)
+
+	// PullReqDiff returns the raw git diff of a pull request.
+	PullReqDiff(ctx context.Context, repoRef string, number int64) (io.ReadCloser, error)
+
+	// PullReqReviewSubmit submits a review of a pull request.
+	PullReqReviewSubmit(ctx context.Context, repoRef string, number int64, in *pullreq.ReviewSubmitInput) error
+
+	// PullReqCommentCreate adds a comment to a pull request.
+	PullReqCommentCreate(ctx context.Context, repoRef string, number int64,
+		in *pullreq.CommentCreateInput) (*types.PullReqActivity, error)
+
+	// PullReqMerge merges a pull request.
+	PullReqMerge(ctx context.Context, repoRef string, number int64,
+		in *pullreq.MergeInput) (*types.MergeResponse, error)
+
+	// ExecutionCreate runs a pipeline on the provided branch (the default branch if empty)
+	// with the provided input parameters.
+	ExecutionCreate(
+		ctx context.Context,
+		repoRef, pipelineUID, branch string,
+		params map[string]string,
+	) (*types.Execution, error)
+
+	// ExecutionList returns the executions of a pipeline.
+	ExecutionList(ctx context.Context, repoRef, pipelineUID string,
+		params types.Pagination) ([]types.Execution, error)
+
+	// Execution returns an execution of a pipeline by number, including its stages and steps.
+	Execution(ctx context.Context, repoRef, pipelineUID string, number int64) (*types.Execution, error)
+
+	// Logs returns the logs of a completed step.
+	Logs(ctx context.Context, repoRef, pipelineUID string,
+		executionNumber, stageNumber, stepNumber int64) ([]livelog.Line, error)
+
+	// LogTail streams the logs of a running step until the step completes or the context is canceled.
+	LogTail(ctx context.Context, repoRef, pipelineUID string,
+		executionNumber, stageNumber, stepNumber int64) (<-chan *livelog.Line, <-chan error)
 }
 
 // remoteError store the error payload returned
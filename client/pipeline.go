@@ -0,0 +1,154 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/harness/gitness/livelog"
+	"github.com/harness/gitness/types"
+)
+
+//
+// Pipeline Endpoints
+//
+
+// ExecutionCreate runs a pipeline on the provided branch (the default branch if empty).
+func (c *HTTPClient) ExecutionCreate(
+	ctx context.Context,
+	repoRef string,
+	pipelineUID string,
+	branch string,
+	params map[string]string,
+) (*types.Execution, error) {
+	out := new(types.Execution)
+	in := struct {
+		Params map[string]string `json:"params,omitempty"`
+	}{Params: params}
+	uri := withQuery(c.pipelineURL(repoRef, pipelineUID)+"/executions", url.Values{"branch": {branch}})
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// ExecutionList returns the executions of a pipeline.
+func (c *HTTPClient) ExecutionList(
+	ctx context.Context,
+	repoRef string,
+	pipelineUID string,
+	params types.Pagination,
+) ([]types.Execution, error) {
+	out := []types.Execution{}
+	uri := withQuery(c.pipelineURL(repoRef, pipelineUID)+"/executions", listQuery(params.Page, params.Size, ""))
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// Execution returns an execution of a pipeline by number, including its stages and steps.
+func (c *HTTPClient) Execution(
+	ctx context.Context,
+	repoRef string,
+	pipelineUID string,
+	number int64,
+) (*types.Execution, error) {
+	out := new(types.Execution)
+	err := c.get(ctx, c.executionURL(repoRef, pipelineUID, number), out)
+	return out, err
+}
+
+// Logs returns the logs of a completed step.
+func (c *HTTPClient) Logs(
+	ctx context.Context,
+	repoRef string,
+	pipelineUID string,
+	executionNumber, stageNumber, stepNumber int64,
+) ([]livelog.Line, error) {
+	out := []livelog.Line{}
+	uri := fmt.Sprintf("%s/logs/%d/%d", c.executionURL(repoRef, pipelineUID, executionNumber),
+		stageNumber, stepNumber)
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// LogTail streams the logs of a running step until the step completes or the context is canceled.
+// The line channel is closed once the stream ended, the error channel receives at most one error.
+func (c *HTTPClient) LogTail(
+	ctx context.Context,
+	repoRef string,
+	pipelineUID string,
+	executionNumber, stageNumber, stepNumber int64,
+) (<-chan *livelog.Line, <-chan error) {
+	linec := make(chan *livelog.Line)
+	errc := make(chan error, 1)
+
+	go func() {
+		defer close(linec)
+
+		uri := fmt.Sprintf("%s/logs/%d/%d/stream", c.executionURL(repoRef, pipelineUID, executionNumber),
+			stageNumber, stepNumber)
+		body, err := c.open(ctx, uri, "text/event-stream")
+		if err != nil {
+			errc <- err
+			return
+		}
+		defer body.Close()
+
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			// comments (pings) and empty lines separating events are ignored.
+			text := scanner.Text()
+			if strings.HasPrefix(text, "event: error") {
+				return
+			}
+			data, ok := strings.CutPrefix(text, "data: ")
+			if !ok {
+				continue
+			}
+
+			line := new(livelog.Line)
+			if err = json.Unmarshal([]byte(data), line); err != nil {
+				errc <- fmt.Errorf("failed to decode log line: %w", err)
+				return
+			}
+
+			select {
+			case linec <- line:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		if err = scanner.Err(); err != nil && ctx.Err() == nil {
+			errc <- err
+		}
+	}()
+
+	return linec, errc
+}
+
+// helper function that returns the url of a pipeline.
+func (c *HTTPClient) pipelineURL(repoRef string, pipelineUID string) string {
+	return c.repoURL(repoRef) + "/pipelines/" + url.PathEscape(pipelineUID)
+}
+
+// helper function that returns the url of an execution.
+func (c *HTTPClient) executionURL(repoRef string, pipelineUID string, number int64) string {
+	return fmt.Sprintf("%s/executions/%d", c.pipelineURL(repoRef, pipelineUID), number)
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/types"
+)
+
+//
+// Pull Request Endpoints
+//
+
+// PullReq returns a pull request by number.
+func (c *HTTPClient) PullReq(ctx context.Context, repoRef string, number int64) (*types.PullReq, error) {
+	out := new(types.PullReq)
+	err := c.get(ctx, c.pullReqURL(repoRef, number), out)
+	return out, err
+}
+
+// PullReqList returns the pull requests of a repository.
+func (c *HTTPClient) PullReqList(
+	ctx context.Context,
+	repoRef string,
+	params types.PullReqFilter,
+) ([]types.PullReq, error) {
+	query := listQuery(params.Page, params.Size, params.Query)
+	query.Set("source_branch", params.SourceBranch)
+	query.Set("target_branch", params.TargetBranch)
+	for _, state := range params.States {
+		query.Add("state", string(state))
+	}
+
+	out := []types.PullReq{}
+	err := c.get(ctx, withQuery(c.repoURL(repoRef)+"/pullreq", query), &out)
+	return out, err
+}
+
+// PullReqCreate creates a new pull request.
+func (c *HTTPClient) PullReqCreate(
+	ctx context.Context,
+	repoRef string,
+	in *pullreq.CreateInput,
+) (*types.PullReq, error) {
+	out := new(types.PullReq)
+	err := c.post(ctx, c.repoURL(repoRef)+"/pullreq", false, in, out)
+	return out, err
+}
+
+// PullReqDiff returns the raw git diff of a pull request.
+// The caller is responsible for closing the returned reader.
+func (c *HTTPClient) PullReqDiff(ctx context.Context, repoRef string, number int64) (io.ReadCloser, error) {
+	return c.open(ctx, c.pullReqURL(repoRef, number)+"/diff", "text/plain")
+}
+
+// PullReqReviewSubmit submits a review of a pull request.
+func (c *HTTPClient) PullReqReviewSubmit(
+	ctx context.Context,
+	repoRef string,
+	number int64,
+	in *pullreq.ReviewSubmitInput,
+) error {
+	return c.post(ctx, c.pullReqURL(repoRef, number)+"/reviews", false, in, nil)
+}
+
+// PullReqCommentCreate adds a comment to a pull request.
+func (c *HTTPClient) PullReqCommentCreate(
+	ctx context.Context,
+	repoRef string,
+	number int64,
+	in *pullreq.CommentCreateInput,
+) (*types.PullReqActivity, error) {
+	out := new(types.PullReqActivity)
+	err := c.post(ctx, c.pullReqURL(repoRef, number)+"/comments", false, in, out)
+	return out, err
+}
+
+// PullReqMerge merges a pull request.
+func (c *HTTPClient) PullReqMerge(
+	ctx context.Context,
+	repoRef string,
+	number int64,
+	in *pullreq.MergeInput,
+) (*types.MergeResponse, error) {
+	out := new(types.MergeResponse)
+	err := c.post(ctx, c.pullReqURL(repoRef, number)+"/merge", false, in, out)
+	return out, err
+}
+
+// helper function that returns the url of a pull request.
+func (c *HTTPClient) pullReqURL(repoRef string, number int64) string {
+	return fmt.Sprintf("%s/pullreq/%d", c.repoURL(repoRef), number)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/types"
+)
+
+//
+// Repository Endpoints
+//
+
+// Repo returns a repository by path or id.
+func (c *HTTPClient) Repo(ctx context.Context, repoRef string) (*types.Repository, error) {
+	out := new(types.Repository)
+	err := c.get(ctx, c.repoURL(repoRef), out)
+	return out, err
+}
+
+// RepoList returns the repositories of a space.
+func (c *HTTPClient) RepoList(
+	ctx context.Context,
+	spaceRef string,
+	params types.RepoFilter,
+) ([]types.Repository, error) {
+	out := []types.Repository{}
+	uri := withQuery(c.spaceURL(spaceRef)+"/repos", listQuery(params.Page, params.Size, params.Query))
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// RepoCreate creates a new repository.
+func (c *HTTPClient) RepoCreate(ctx context.Context, in *repo.CreateInput) (*types.Repository, error) {
+	out := new(types.Repository)
+	uri := fmt.Sprintf("%s/api/v1/repos", c.base)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// RepoImport creates a new repository and starts importing it from an external provider.
+func (c *HTTPClient) RepoImport(ctx context.Context, in *repo.ImportInput) (*types.Repository, error) {
+	out := new(types.Repository)
+	uri := fmt.Sprintf("%s/api/v1/repos/import", c.base)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// RepoDelete deletes a repository by path or id.
+func (c *HTTPClient) RepoDelete(ctx context.Context, repoRef string) error {
+	return c.delete(ctx, c.repoURL(repoRef))
+}
+
+// RepoBranchList returns the branches of a repository.
+func (c *HTTPClient) RepoBranchList(
+	ctx context.Context,
+	repoRef string,
+	params types.BranchFilter,
+) ([]repo.Branch, error) {
+	out := []repo.Branch{}
+	uri := withQuery(c.repoURL(repoRef)+"/branches", listQuery(params.Page, params.Size, params.Query))
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// RepoBranchCreate creates a new branch in a repository.
+func (c *HTTPClient) RepoBranchCreate(
+	ctx context.Context,
+	repoRef string,
+	in *repo.CreateBranchInput,
+) (*repo.Branch, error) {
+	out := new(repo.Branch)
+	err := c.post(ctx, c.repoURL(repoRef)+"/branches", false, in, out)
+	return out, err
+}
+
+// RepoBranchDelete deletes a branch of a repository.
+func (c *HTTPClient) RepoBranchDelete(ctx context.Context, repoRef string, branch string) error {
+	// branch names can contain slashes, which are part of the wildcard route.
+	return c.delete(ctx, c.repoURL(repoRef)+"/branches/"+(&url.URL{Path: branch}).EscapedPath())
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/types"
+)
+
+//
+// Space Endpoints
+//
+
+// Space returns a space by path or id.
+func (c *HTTPClient) Space(ctx context.Context, spaceRef string) (*types.Space, error) {
+	out := new(types.Space)
+	err := c.get(ctx, c.spaceURL(spaceRef), out)
+	return out, err
+}
+
+// SpaceList returns the child spaces of a space.
+func (c *HTTPClient) SpaceList(ctx context.Context, spaceRef string, params types.SpaceFilter) ([]types.Space, error) {
+	out := []types.Space{}
+	uri := withQuery(c.spaceURL(spaceRef)+"/spaces", listQuery(params.Page, params.Size, params.Query))
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
+
+// SpaceCreate creates a new space.
+func (c *HTTPClient) SpaceCreate(ctx context.Context, in *space.CreateInput) (*types.Space, error) {
+	out := new(types.Space)
+	uri := fmt.Sprintf("%s/api/v1/spaces", c.base)
+	err := c.post(ctx, uri, false, in, out)
+	return out, err
+}
+
+// SpaceDelete deletes a space by path or id.
+func (c *HTTPClient) SpaceDelete(ctx context.Context, spaceRef string) error {
+	return c.delete(ctx, c.spaceURL(spaceRef))
+}
+
+// MembershipSpaces returns the spaces the current user is a member of.
+func (c *HTTPClient) MembershipSpaces(
+	ctx context.Context,
+	params types.MembershipSpaceFilter,
+) ([]types.MembershipSpace, error) {
+	out := []types.MembershipSpace{}
+	uri := withQuery(fmt.Sprintf("%s/api/v1/user/memberships", c.base),
+		listQuery(params.Page, params.Size, params.Query))
+	err := c.get(ctx, uri, &out)
+	return out, err
+}
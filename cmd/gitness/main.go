@@ -19,6 +19,10 @@ import (
 	"github.com/harness/gitness/cli/operations/account"
 	"github.com/harness/gitness/cli/operations/hooks"
 	"github.com/harness/gitness/cli/operations/migrate"
+	"github.com/harness/gitness/cli/operations/pipeline"
+	"github.com/harness/gitness/cli/operations/pullreq"
+	"github.com/harness/gitness/cli/operations/repo"
+	"github.com/harness/gitness/cli/operations/space"
 	"github.com/harness/gitness/cli/operations/user"
 	"github.com/harness/gitness/cli/operations/users"
 	"github.com/harness/gitness/cli/server"
@@ -43,6 +47,11 @@ func main() {
 	user.Register(app)
 	users.Register(app)
 
+	space.Register(app)
+	repo.Register(app)
+	pullreq.Register(app)
+	pipeline.Register(app)
+
 	account.RegisterLogin(app)
 	account.RegisterRegister(app)
 	account.RegisterLogout(app)
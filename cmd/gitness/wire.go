@@ -15,12 +15,15 @@ import (
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	controllerkeywordsearch "github.com/harness/gitness/app/api/controller/keywordsearch"
+	controllerlfs "github.com/harness/gitness/app/api/controller/lfs"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
+	controlleroidc "github.com/harness/gitness/app/api/controller/oidc"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	controllerrunner "github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -30,18 +33,24 @@ import (
 	controllertrigger "github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	controllerusergroup "github.com/harness/gitness/app/api/controller/usergroup"
 	controllerwebhook "github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
 	gitevents "github.com/harness/gitness/app/events/git"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/gitssh"
+	"github.com/harness/gitness/app/pipeline/artifact"
+	pipelinecache "github.com/harness/gitness/app/pipeline/cache"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
 	pluginmanager "github.com/harness/gitness/app/pipeline/plugin"
+	"github.com/harness/gitness/app/pipeline/rpc"
 	"github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer"
@@ -55,9 +64,14 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/job"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/lfs"
+	"github.com/harness/gitness/app/services/mailer"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/protection"
 	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/signing"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
@@ -127,10 +141,16 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		webhook.WireSet,
 		cliserver.ProvideTriggerConfig,
 		trigger.WireSet,
+		cliserver.ProvideMirrorConfig,
+		mirror.WireSet,
+		cliserver.ProvideNotificationConfig,
+		mailer.WireSet,
+		notification.WireSet,
 		githook.WireSet,
 		cliserver.ProvideLockConfig,
 		lock.WireSet,
 		cliserver.ProvidePubsubConfig,
+		cliserver.ProvideLogStreamConfig,
 		pubsub.WireSet,
 		cliserver.ProvideCleanupConfig,
 		cleanup.WireSet,
@@ -147,6 +167,10 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		connector.WireSet,
 		template.WireSet,
 		manager.WireSet,
+		artifact.WireSet,
+		cliserver.ProvideArtifactConfig,
+		pipelinecache.WireSet,
+		cliserver.ProvidePipelineCacheConfig,
 		triggerer.WireSet,
 		file.WireSet,
 		runner.WireSet,
@@ -163,9 +187,21 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		cliserver.ProvideCodeOwnerConfig,
 		codeowners.WireSet,
 		cliserver.ProvideKeywordSearchConfig,
+		cliserver.ProvideLocalIndexConfig,
+		cliserver.ProvideGitSSHConfig,
+		gitssh.WireSet,
 		keywordsearch.WireSet,
 		controllerkeywordsearch.WireSet,
+		lfs.WireSet,
+		controllerlfs.WireSet,
+		signing.WireSet,
 		usergroup.WireSet,
+		controllerusergroup.WireSet,
+		controllerrunner.WireSet,
+		rpc.WireSet,
+		cliserver.ProvideOIDCConfig,
+		oidc.WireSet,
+		controlleroidc.WireSet,
 	)
 	return &cliserver.System{}, nil
 }
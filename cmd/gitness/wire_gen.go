@@ -14,12 +14,15 @@ import (
 	"github.com/harness/gitness/app/api/controller/execution"
 	"github.com/harness/gitness/app/api/controller/githook"
 	keywordsearch2 "github.com/harness/gitness/app/api/controller/keywordsearch"
+	lfs2 "github.com/harness/gitness/app/api/controller/lfs"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
+	oidc2 "github.com/harness/gitness/app/api/controller/oidc"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
 	pullreq2 "github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/runner"
 	"github.com/harness/gitness/app/api/controller/secret"
 	"github.com/harness/gitness/app/api/controller/service"
 	"github.com/harness/gitness/app/api/controller/serviceaccount"
@@ -29,19 +32,25 @@ import (
 	"github.com/harness/gitness/app/api/controller/trigger"
 	"github.com/harness/gitness/app/api/controller/upload"
 	"github.com/harness/gitness/app/api/controller/user"
+	usergroup2 "github.com/harness/gitness/app/api/controller/usergroup"
 	webhook2 "github.com/harness/gitness/app/api/controller/webhook"
 	"github.com/harness/gitness/app/auth/authn"
 	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/auth/oidc"
 	"github.com/harness/gitness/app/bootstrap"
-	events4 "github.com/harness/gitness/app/events/git"
-	events3 "github.com/harness/gitness/app/events/pullreq"
+	events3 "github.com/harness/gitness/app/events/git"
+	events4 "github.com/harness/gitness/app/events/pullreq"
 	events2 "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/app/gitssh"
+	"github.com/harness/gitness/app/pipeline/artifact"
+	cache2 "github.com/harness/gitness/app/pipeline/cache"
 	"github.com/harness/gitness/app/pipeline/canceler"
 	"github.com/harness/gitness/app/pipeline/commit"
 	"github.com/harness/gitness/app/pipeline/file"
 	"github.com/harness/gitness/app/pipeline/manager"
 	plugin2 "github.com/harness/gitness/app/pipeline/plugin"
-	"github.com/harness/gitness/app/pipeline/runner"
+	"github.com/harness/gitness/app/pipeline/rpc"
+	runner2 "github.com/harness/gitness/app/pipeline/runner"
 	"github.com/harness/gitness/app/pipeline/scheduler"
 	"github.com/harness/gitness/app/pipeline/triggerer"
 	"github.com/harness/gitness/app/router"
@@ -54,9 +63,14 @@ import (
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/job"
 	"github.com/harness/gitness/app/services/keywordsearch"
+	"github.com/harness/gitness/app/services/lfs"
+	"github.com/harness/gitness/app/services/mailer"
 	"github.com/harness/gitness/app/services/metric"
+	"github.com/harness/gitness/app/services/mirror"
+	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/app/services/signing"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/usergroup"
 	"github.com/harness/gitness/app/services/webhook"
@@ -103,12 +117,17 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	principalInfoView := database.ProvidePrincipalInfoView(db)
 	principalInfoCache := cache.ProvidePrincipalInfoCache(principalInfoView)
 	membershipStore := database.ProvideMembershipStore(db, principalInfoCache, spacePathStore)
-	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore)
+	membershipUserGroupStore := database.ProvideMembershipUserGroupStore(db, principalInfoCache)
+	permissionCache := authz.ProvidePermissionCache(spaceStore, membershipStore, membershipUserGroupStore)
 	authorizer := authz.ProvideAuthorizer(permissionCache, spaceStore)
 	principalUIDTransformation := store.ProvidePrincipalUIDTransformation()
 	principalStore := database.ProvidePrincipalStore(db, principalUIDTransformation)
 	tokenStore := database.ProvideTokenStore(db)
-	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore)
+	publicKeyStore := database.ProvidePublicKeyStore(db)
+	signingKeyStore := database.ProvideSigningKeyStore(db)
+	notificationSettingsStore := database.ProvideNotificationSettingsStore(db)
+	mailerService := mailer.ProvideMailService(config)
+	controller := user.ProvideController(transactor, principalUID, authorizer, principalStore, tokenStore, membershipStore, publicKeyStore, signingKeyStore, notificationSettingsStore, config, mailerService)
 	serviceController := service.NewController(principalUID, authorizer, principalStore)
 	bootstrapBootstrap := bootstrap.ProvideBootstrap(config, controller, serviceController)
 	authenticator := authn.ProvideAuthenticator(config, principalStore, tokenStore)
@@ -140,6 +159,10 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	triggerStore := database.ProvideTriggerStore(db)
+	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
+	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
+	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
+	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	encrypter, err := encrypt.ProvideEncrypter(config)
 	if err != nil {
 		return nil, err
@@ -155,14 +178,20 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	streamer := sse.ProvideEventsStreaming(pubSub)
-	localIndexSearcher := keywordsearch.ProvideLocalIndexSearcher()
+	localIndexConfig := server.ProvideLocalIndexConfig(config)
+	localIndexSearcher, err := keywordsearch.ProvideLocalIndexSearcher(localIndexConfig, gitInterface)
+	if err != nil {
+		return nil, err
+	}
 	indexer := keywordsearch.ProvideIndexer(localIndexSearcher)
-	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, encrypter, jobScheduler, executor, streamer, indexer)
+	repository, err := importer.ProvideRepoImporter(config, provider, gitInterface, transactor, repoStore, pipelineStore, triggerStore, principalStore, pullReqStore, pullReqActivityStore, pullReqReviewStore, pullReqReviewerStore, encrypter, jobScheduler, executor, streamer, indexer)
 	if err != nil {
 		return nil, err
 	}
 	codeownersConfig := server.ProvideCodeOwnerConfig(config)
-	resolver := usergroup.ProvideUserGroupResolver()
+	userGroupStore := database.ProvideUserGroupStore(db)
+	userGroupMemberStore := database.ProvideUserGroupMemberStore(db, principalInfoCache)
+	resolver := usergroup.ProvideUserGroupResolver(spaceStore, userGroupStore, userGroupMemberStore)
 	codeownersService := codeowners.ProvideCodeOwners(gitInterface, repoStore, codeownersConfig, principalStore, resolver)
 	eventsConfig := server.ProvideEventsConfig(config)
 	eventsSystem, err := events.ProvideSystem(eventsConfig, universalClient)
@@ -173,7 +202,29 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	repoController := repo.ProvideController(config, transactor, provider, pathUID, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, protectionManager, gitInterface, repository, codeownersService, reporter, indexer)
+	pullMirrorStore := database.ProvidePullMirrorStore(db)
+	pushMirrorStore := database.ProvidePushMirrorStore(db)
+	mirrorConfig := server.ProvideMirrorConfig(config)
+	readerFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	mirrorService, err := mirror.ProvideService(ctx, mirrorConfig, jobScheduler, executor, pullMirrorStore, pushMirrorStore, repoStore, gitInterface, encrypter, provider, indexer, reporter, readerFactory)
+	if err != nil {
+		return nil, err
+	}
+	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	if err != nil {
+		return nil, err
+	}
+	lfsObjectStore := database.ProvideLFSObjectStore(db)
+	lfsService := lfs.ProvideService(lfsObjectStore, blobStore)
+	signingService := signing.ProvideService(signingKeyStore, principalStore)
+	repoController := repo.ProvideController(config, transactor, provider, pathUID, authorizer, repoStore, spaceStore, pipelineStore, principalStore, ruleStore, protectionManager, gitInterface, repository, codeownersService, reporter, indexer, pullMirrorStore, pushMirrorStore, encrypter, mirrorService, blobStore, lfsService, signingService)
 	executionStore := database.ProvideExecutionStore(db)
 	checkStore := database.ProvideCheckStore(db, principalInfoCache)
 	stageStore := database.ProvideStageStore(db)
@@ -186,18 +237,33 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	commitService := commit.ProvideService(gitInterface)
 	fileService := file.ProvideService(gitInterface)
 	triggererTriggerer := triggerer.ProvideTriggerer(executionStore, checkStore, stageStore, transactor, pipelineStore, fileService, schedulerScheduler, repoStore, provider)
-	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore)
+	artifactStore := database.ProvideArtifactStore(db)
+	artifactConfig := server.ProvideArtifactConfig(config)
+	artifactService, err := artifact.ProvideService(artifactConfig, artifactStore, blobStore)
+	if err != nil {
+		return nil, err
+	}
+	approvalStore := database.ProvideApprovalStore(db, principalInfoCache)
 	logStore := logs.ProvideLogStore(db, config)
-	logStream := livelog.ProvideLogStream()
-	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
+	livelogConfig := server.ProvideLogStreamConfig(config)
+	logStream := livelog.ProvideLogStream(livelogConfig, universalClient)
 	secretStore := database.ProvideSecretStore(db)
+	cacheConfig := server.ProvidePipelineCacheConfig(config)
+	pipelineCacheStore := database.ProvidePipelineCacheStore(db)
+	cacheService, err := cache2.ProvideService(cacheConfig, pipelineCacheStore, executionStore, repoStore, blobStore)
+	if err != nil {
+		return nil, err
+	}
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, artifactService, cacheService)
+	executionController := execution.ProvideController(transactor, authorizer, executionStore, checkStore, cancelerCanceler, commitService, triggererTriggerer, repoStore, stageStore, pipelineStore, artifactStore, artifactService, approvalStore, spaceStore, membershipStore, membershipUserGroupStore, executionManager, config)
+	logsController := logs2.ProvideController(authorizer, executionStore, repoStore, pipelineStore, stageStore, stepStore, logStore, logStream)
 	connectorStore := database.ProvideConnectorStore(db)
 	templateStore := database.ProvideTemplateStore(db)
 	exporterRepository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space.ProvideController(config, transactor, provider, streamer, pathUID, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository)
+	spaceController := space.ProvideController(config, transactor, provider, streamer, pathUID, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, repository, exporterRepository, userGroupStore, membershipUserGroupStore, resolver)
 	pipelineController := pipeline.ProvideController(pathUID, repoStore, triggerStore, authorizer, pipelineStore)
 	secretController := secret.ProvideController(pathUID, encrypter, secretStore, authorizer, spaceStore)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pathUID, pipelineStore, repoStore)
@@ -205,22 +271,14 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	templateController := template.ProvideController(pathUID, templateStore, authorizer, spaceStore)
 	pluginStore := database.ProvidePluginStore(db)
 	pluginController := plugin.ProvideController(pluginStore)
-	pullReqStore := database.ProvidePullReqStore(db, principalInfoCache)
-	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
 	codeCommentView := database.ProvideCodeCommentView(db)
-	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
-	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
 	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	eventsReporter, err := events3.ProvideReporter(eventsSystem)
+	eventsReporter, err := events4.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events4.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	eventsReaderFactory, err := events3.ProvideReaderFactory(eventsSystem)
+	eventsReaderFactory, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
@@ -230,7 +288,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, pullReqFileViewStore, membershipStore, checkStore, gitInterface, eventsReporter, mutexManager, migrator, pullreqService, protectionManager, streamer, codeownersService, resolver, userGroupMemberStore, signingService)
 	webhookConfig := server.ProvideWebhookConfig(config)
 	webhookStore := database.ProvideWebhookStore(db)
 	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
@@ -239,41 +297,51 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	webhookController := webhook2.ProvideController(webhookConfig, authorizer, webhookStore, webhookExecutionStore, repoStore, webhookService, encrypter)
-	reporter2, err := events4.ProvideReporter(eventsSystem)
+	reporter2, err := events3.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, pullReqStore, provider, protectionManager)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, reporter2, pullReqStore, provider, protectionManager, pullMirrorStore, gitInterface, signingService)
 	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
 	principalController := principal.ProvideController(principalStore)
 	checkController := check2.ProvideController(transactor, authorizer, repoStore, checkStore, gitInterface)
 	systemController := system.NewController(principalStore, config)
-	blobConfig, err := server.ProvideBlobStoreConfig(config)
+	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
+	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
+	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
+	usergroupController := usergroup2.ProvideController(transactor, pathUID, authorizer, spaceStore, principalStore, userGroupStore, userGroupMemberStore)
+	runnerStore := database.ProvideRunnerStore(db)
+	runnerController := runner.ProvideController(pathUID, authorizer, runnerStore)
+	oidcConfig := server.ProvideOIDCConfig(config)
+	oidcProvider := oidc.ProvideProvider(oidcConfig)
+	oidcIdentityStore := database.ProvideOIDCIdentityStore(db)
+	oidcController, err := oidc2.ProvideController(config, oidcProvider, transactor, authorizer, principalStore, oidcIdentityStore, tokenStore, spaceStore, membershipStore, controller)
 	if err != nil {
 		return nil, err
 	}
-	blobStore, err := blob.ProvideStore(ctx, blobConfig)
+	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController, usergroupController, runnerController, oidcController)
+	lfsLockStore := database.ProvideLFSLockStore(db)
+	lfsController := lfs2.ProvideController(authorizer, repoStore, lfsLockStore, principalInfoCache, lfsService, provider)
+	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController, lfsController)
+	client := manager.ProvideExecutionClient(executionManager, provider, config)
+	rpcServer := rpc.ProvideServer(client, executionManager, provider, runnerStore, stageStore, stepStore)
+	rpcHandler := router.ProvideRPCHandler(rpcServer)
+	webHandler := router.ProvideWebHandler(config)
+	routerRouter := router.ProvideRouter(apiHandler, gitHandler, rpcHandler, webHandler, provider)
+	serverServer := server2.ProvideServer(config, routerRouter)
+	gitsshConfig := server.ProvideGitSSHConfig(config)
+	gitsshServer, err := gitssh.ProvideServer(gitsshConfig, principalStore, publicKeyStore, repoController)
 	if err != nil {
 		return nil, err
 	}
-	uploadController := upload.ProvideController(authorizer, repoStore, blobStore)
-	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
-	keywordsearchController := keywordsearch2.ProvideController(authorizer, searcher, repoController, spaceController)
-	apiHandler := router.ProvideAPIHandler(ctx, config, authenticator, repoController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, serviceaccountController, controller, principalController, checkController, systemController, uploadController, keywordsearchController)
-	gitHandler := router.ProvideGitHandler(provider, authenticator, repoController)
-	webHandler := router.ProvideWebHandler(config)
-	routerRouter := router.ProvideRouter(apiHandler, gitHandler, webHandler, provider)
-	serverServer := server2.ProvideServer(config, routerRouter)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore)
-	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	pluginManager := plugin2.ProvidePluginManager(config, pluginStore)
-	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, pluginManager)
+	runtimeRunner, err := runner2.ProvideExecutionRunner(config, client, pluginManager)
 	if err != nil {
 		return nil, err
 	}
-	poller := runner.ProvideExecutionPoller(runtimeRunner, client)
+	poller := runner2.ProvideExecutionPoller(runtimeRunner, client)
 	triggerConfig := server.ProvideTriggerConfig(config)
-	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, readerFactory, eventsReaderFactory)
+	triggerService, err := trigger2.ProvideService(ctx, triggerConfig, triggerStore, commitService, pullReqStore, repoStore, pipelineStore, triggererTriggerer, jobScheduler, executor, readerFactory, eventsReaderFactory)
 	if err != nil {
 		return nil, err
 	}
@@ -282,16 +350,25 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	cleanupConfig := server.ProvideCleanupConfig(config)
-	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore)
+	cleanupService, err := cleanup.ProvideService(cleanupConfig, jobScheduler, executor, webhookExecutionStore, tokenStore, blobStore, repoStore, pullReqStore, pullReqActivityStore, pipelineStore, executionStore, stageStore, logStore, artifactStore, artifactService, cacheService)
 	if err != nil {
 		return nil, err
 	}
 	keywordsearchConfig := server.ProvideKeywordSearchConfig(config)
-	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, repoStore, indexer)
+	readerFactory2, err := events2.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	keywordsearchService, err := keywordsearch.ProvideService(ctx, keywordsearchConfig, readerFactory, readerFactory2, repoStore, indexer)
+	if err != nil {
+		return nil, err
+	}
+	notificationConfig := server.ProvideNotificationConfig(config)
+	notificationService, err := notification.ProvideService(ctx, notificationConfig, authorizer, eventsReaderFactory, mailerService, jobScheduler, executor, provider, repoStore, pullReqStore, pullReqActivityStore, pullReqReviewerStore, principalStore, notificationSettingsStore)
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, cleanupService, keywordsearchService)
-	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, poller, pluginManager, servicesServices)
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collector, cleanupService, keywordsearchService, notificationService, mirrorService)
+	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, gitsshServer, poller, pluginManager, servicesServices)
 	return serverSystem, nil
 }
@@ -47,7 +47,8 @@ type Adapter interface {
 	Push(ctx context.Context, repoPath string, opts types.PushOptions) error
 	ReadTree(ctx context.Context, repoPath, ref string, w io.Writer, args ...string) error
 	GetTreeNode(ctx context.Context, repoPath string, ref string, treePath string) (*types.TreeNode, error)
-	ListTreeNodes(ctx context.Context, repoPath string, ref string, treePath string) ([]types.TreeNode, error)
+	ListTreeNodes(ctx context.Context, repoPath string, ref string, treePath string,
+		recursive bool) ([]types.TreeNode, error)
 	PathsDetails(ctx context.Context, repoPath string, ref string, paths []string) ([]types.PathDetails, error)
 	GetSubmodule(ctx context.Context, repoPath string, ref string, treePath string) (*types.Submodule, error)
 	GetBlob(ctx context.Context, repoPath string, sha string, sizeLimit int64) (*types.BlobReader, error)
@@ -55,6 +56,8 @@ type Adapter interface {
 		opts *types.WalkReferencesOptions) error
 	GetCommit(ctx context.Context, repoPath string, ref string) (*types.Commit, error)
 	GetCommits(ctx context.Context, repoPath string, refs []string) ([]types.Commit, error)
+	ListNewCommits(ctx context.Context, repoPath string, sha string, excludeSHAs []string,
+		alternateObjectDirs []string) ([]types.Commit, error)
 	ListCommits(ctx context.Context, repoPath string,
 		ref string, page int, limit int, filter types.CommitFilter) ([]types.Commit, []types.PathRenameDetails, error)
 	ListCommitSHAs(ctx context.Context, repoPath string,
@@ -76,6 +79,9 @@ type Adapter interface {
 	GetMergeBase(ctx context.Context, repoPath, remote, base, head string) (string, string, error)
 	Blame(ctx context.Context, repoPath, rev, file string, lineFrom, lineTo int) types.BlameReader
 	Sync(ctx context.Context, repoPath string, source string, refSpecs []string) error
+	FetchObjects(ctx context.Context, repoPath string, source string, objectSHAs []string) error
+	AddAlternateObjects(repoPath string, alternateRepoPath string) error
+	Dissociate(ctx context.Context, repoPath string) error
 
 	//
 	// Diff operations
@@ -98,6 +104,11 @@ type Adapter interface {
 		sha string,
 		w io.Writer) error
 
+	Archive(ctx context.Context,
+		repoPath string,
+		opts types.ArchiveOptions,
+		w io.Writer) error
+
 	DiffShortStat(ctx context.Context,
 		repoPath string,
 		baseRef string,
@@ -135,6 +146,7 @@ type Adapter interface {
 		ctx context.Context,
 		repoPath string,
 		service string,
+		statelessRPC bool,
 		stdin io.Reader,
 		stdout io.Writer,
 		env ...string,
@@ -79,6 +79,29 @@ func TestParseTagDataFromCatFile(t *testing.T) {
 		types.Signature{Identity: types.Identity{Name: "max", Email: "max@mail.com"}, When: when},
 		"gpgsig -----BEGIN PGP SIGNATURE-----\n\nw...B\n-----END PGP SIGNATURE-----\n\nsome message",
 		"some message")
+
+	// test with appended signature
+	testParseTagDataFromCatFileFor(t, "sha012", types.GitObjectTypeCommit, "name3",
+		types.Signature{Identity: types.Identity{Name: "max", Email: "max@mail.com"}, When: when},
+		"\nsome message\n-----BEGIN SSH SIGNATURE-----\nU1N...\n-----END SSH SIGNATURE-----\n",
+		"some message")
+}
+
+func TestParseTagDataFromCatFileSignedData(t *testing.T) {
+	header := "object sha012\ntype commit\ntag v1\ntagger max <max@mail.com> 1666401234 -0700\n"
+	payload := header + "\nsome message\n"
+	signature := "-----BEGIN PGP SIGNATURE-----\n\niQ...\n-----END PGP SIGNATURE-----\n"
+
+	res, err := parseTagDataFromCatFile([]byte(payload + signature))
+	require.NoError(t, err)
+	require.Equal(t, "some message", res.Message)
+	require.NotNil(t, res.SignedData)
+	require.Equal(t, signature, string(res.SignedData.Signature))
+	require.Equal(t, payload, string(res.SignedData.SignedContent))
+
+	res, err = parseTagDataFromCatFile([]byte(payload))
+	require.NoError(t, err)
+	require.Nil(t, res.SignedData)
 }
 
 func testParseTagDataFromCatFileFor(t *testing.T, object string, typ types.GitObjectType, name string,
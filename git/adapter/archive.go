@@ -0,0 +1,64 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+// Archive streams an archive of the tree of the provided tree-ish to w.
+func (a Adapter) Archive(
+	ctx context.Context,
+	repoPath string,
+	opts types.ArchiveOptions,
+	w io.Writer,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	cmd := gitea.NewCommand(ctx, "archive", "--format="+opts.Format)
+	if opts.Prefix != "" {
+		cmd.AddArguments("--prefix=" + opts.Prefix)
+	}
+	cmd.AddArguments(opts.Treeish, "--")
+	cmd.AddArguments(opts.Paths...)
+
+	stderr := new(bytes.Buffer)
+	if err := cmd.Run(&gitea.RunOpts{
+		Dir:    repoPath,
+		Stdout: w,
+		Stderr: stderr,
+	}); err != nil {
+		switch {
+		case strings.Contains(stderr.String(), "not a valid object name"),
+			strings.Contains(stderr.String(), "not a tree object"):
+			return errors.NotFound("reference '%s' not found", opts.Treeish)
+		case strings.Contains(stderr.String(), "did not match any files"):
+			return errors.NotFound("path not found in '%s'", opts.Treeish)
+		}
+		return processGiteaErrorf(err, "failed to create archive: %v", stderr)
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	gitness_errors "github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
+)
+
+func TestAdapter_Archive(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testarchive")
+	defer teardown()
+
+	sha := writeFile(t, repo, "README.md", "readme", nil)
+	sha = writeFile(t, repo, "docs/guide.md", "guide", []string{sha.String()})
+
+	err := repo.SetReference("refs/heads/main", sha.String())
+	if err != nil {
+		t.Fatalf("failed updating reference 'main': %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			name: "full tree",
+			want: []string{"repo-main/", "repo-main/README.md", "repo-main/docs/", "repo-main/docs/guide.md"},
+		},
+		{
+			name:  "sub path",
+			paths: []string{"docs"},
+			want:  []string{"repo-main/", "repo-main/docs/", "repo-main/docs/guide.md"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			err := git.Archive(context.Background(), repo.Path, types.ArchiveOptions{
+				Format:  "zip",
+				Treeish: "main",
+				Prefix:  "repo-main/",
+				Paths:   tt.paths,
+			}, buf)
+			if err != nil {
+				t.Fatalf("Archive() error = %v", err)
+			}
+
+			r, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("failed to read zip archive: %v", err)
+			}
+
+			got := make([]string, len(r.File))
+			for i, f := range r.File {
+				got[i] = f.Name
+			}
+			sort.Strings(got)
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Archive() files = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("Archive() files = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	err = git.Archive(context.Background(), repo.Path, types.ArchiveOptions{
+		Format:  "zip",
+		Treeish: "main",
+		Paths:   []string{"missing"},
+	}, &bytes.Buffer{})
+	if !gitness_errors.IsNotFound(err) {
+		t.Errorf("expected not found error for missing path, got %v", err)
+	}
+}
@@ -15,9 +15,11 @@
 package adapter
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
@@ -394,6 +396,84 @@ func (a Adapter) GetCommits(
 	return commits, nil
 }
 
+// ListNewCommits returns all commits reachable from sha that aren't reachable from any of the excluded commits.
+// The alternate object directories can be used to read objects that aren't part of the repository yet
+// (e.g. objects of a push that are still in quarantine during the pre-receive hook).
+func (a Adapter) ListNewCommits(
+	ctx context.Context,
+	repoPath string,
+	sha string,
+	excludeSHAs []string,
+	alternateObjectDirs []string,
+) ([]types.Commit, error) {
+	if repoPath == "" {
+		return nil, ErrRepositoryPathEmpty
+	}
+
+	var env []string
+	if len(alternateObjectDirs) > 0 {
+		env = append(env, "GIT_ALTERNATE_OBJECT_DIRECTORIES="+strings.Join(alternateObjectDirs, ":"))
+	}
+
+	args := []string{"rev-list", sha}
+	for _, excludeSHA := range excludeSHAs {
+		args = append(args, "^"+excludeSHA)
+	}
+
+	stdout, _, runErr := gitea.NewCommand(ctx, args...).
+		RunStdBytes(&gitea.RunOpts{Dir: repoPath, Env: env})
+	if runErr != nil {
+		return nil, processGiteaErrorf(runErr, "failed to list new commits")
+	}
+
+	shas := parseLinesToSlice(stdout)
+	if len(shas) == 0 {
+		return []types.Commit{}, nil
+	}
+
+	stdin := strings.NewReader(strings.Join(shas, "\n") + "\n")
+	stdout, _, runErr = gitea.NewCommand(ctx, "cat-file", "--batch").
+		RunStdBytes(&gitea.RunOpts{Dir: repoPath, Env: env, Stdin: stdin})
+	if runErr != nil {
+		return nil, processGiteaErrorf(runErr, "failed to read new commits")
+	}
+
+	reader := bufio.NewReader(bytes.NewReader(stdout))
+	commits := make([]types.Commit, len(shas))
+	for i := range shas {
+		commitSHA, typ, size, err := gitea.ReadBatchLine(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit header: %w", err)
+		}
+		if typ != string(types.GitObjectTypeCommit) {
+			return nil, fmt.Errorf("git object '%s' is of type '%s', expected commit", commitSHA, typ)
+		}
+
+		commitID, err := gitea.NewIDFromString(string(commitSHA))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit sha '%s': %w", commitSHA, err)
+		}
+
+		giteaCommit, err := gitea.CommitFromReader(nil, commitID, io.LimitReader(reader, size))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit '%s': %w", commitSHA, err)
+		}
+
+		// discard the new line that terminates the object.
+		if _, err = reader.Discard(1); err != nil {
+			return nil, fmt.Errorf("failed to read commit '%s': %w", commitSHA, err)
+		}
+
+		commit, err := mapGiteaCommit(giteaCommit)
+		if err != nil {
+			return nil, err
+		}
+		commits[i] = *commit
+	}
+
+	return commits, nil
+}
+
 // GetCommitDivergences returns the count of the diverging commits for all branch pairs.
 // IMPORTANT: If a max is provided it limits the overal count of diverging commits
 // (max 10 could lead to (0, 10) while it's actually (2, 12)).
@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAdapter_ListNewCommits(t *testing.T) {
+	git := setupGit(t)
+	repo, teardown := setupRepo(t, git, "testListNewCommits")
+	defer teardown()
+
+	sha1 := writeFile(t, repo, "file.txt", "1", nil).String()
+	sha2 := writeFile(t, repo, "file.txt", "2", []string{sha1}).String()
+	sha3 := writeFile(t, repo, "file.txt", "3", []string{sha2}).String()
+
+	tests := []struct {
+		name        string
+		excludeSHAs []string
+		want        []string
+	}{
+		{
+			name: "whole-history",
+			want: []string{sha3, sha2, sha1},
+		},
+		{
+			name:        "exclude-old",
+			excludeSHAs: []string{sha1},
+			want:        []string{sha3, sha2},
+		},
+		{
+			name:        "exclude-self",
+			excludeSHAs: []string{sha3},
+			want:        []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			commits, err := git.ListNewCommits(context.Background(), repo.Path, sha3, test.excludeSHAs, nil)
+			if err != nil {
+				t.Fatalf("failed to list new commits: %v", err)
+			}
+
+			got := make([]string, len(commits))
+			for i, commit := range commits {
+				got[i] = commit.SHA
+			}
+
+			if !reflect.DeepEqual(test.want, got) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
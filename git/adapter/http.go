@@ -62,6 +62,7 @@ func (a Adapter) ServicePack(
 	ctx context.Context,
 	repoPath string,
 	service string,
+	statelessRPC bool,
 	stdin io.Reader,
 	stdout io.Writer,
 	env ...string,
@@ -72,8 +73,13 @@ func (a Adapter) ServicePack(
 	var (
 		stderr bytes.Buffer
 	)
-	cmd := git.NewCommand(ctx, service, "--stateless-rpc", repoPath)
-	cmd.SetDescription(fmt.Sprintf("%s %s %s [repo_path: %s]", git.GitExecutable, service, "--stateless-rpc", repoPath))
+	cmd := git.NewCommand(ctx, service)
+	if statelessRPC {
+		cmd.AddArguments("--stateless-rpc")
+	}
+	cmd.AddArguments(repoPath)
+	cmd.SetDescription(fmt.Sprintf("%s %s (stateless-rpc: %t) [repo_path: %s]",
+		git.GitExecutable, service, statelessRPC, repoPath))
 	err := cmd.Run(&git.RunOpts{
 		Dir:               repoPath,
 		Env:               env,
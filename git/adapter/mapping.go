@@ -73,13 +73,22 @@ func mapGiteaCommit(giteaCommit *gitea.Commit) (*types.Commit, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to map gitea commiter: %w", err)
 	}
+	var signedData *types.SignedData
+	if giteaCommit.Signature != nil {
+		signedData = &types.SignedData{
+			Signature:     []byte(giteaCommit.Signature.Signature),
+			SignedContent: []byte(giteaCommit.Signature.Payload),
+		}
+	}
+
 	return &types.Commit{
 		SHA:   giteaCommit.ID.String(),
 		Title: giteaCommit.Summary(),
 		// remove potential tailing newlines from message
-		Message:   strings.TrimRight(giteaCommit.Message(), "\n"),
-		Author:    author,
-		Committer: committer,
+		Message:    strings.TrimRight(giteaCommit.Message(), "\n"),
+		Author:     author,
+		Committer:  committer,
+		SignedData: signedData,
 	}, nil
 }
 
@@ -465,6 +465,16 @@ func (a Adapter) Merge(
 		if len(result.conflictFiles) > 0 {
 			return types.MergeResult{ConflictFiles: result.conflictFiles}, nil
 		}
+	case enum.MergeMethodFastForward:
+		// Move the base branch to the head commit, no commit is created.
+		cmd := git.NewCommand(ctx, "merge", "--ff-only", trackingBranch)
+		result, err := runMergeCommand(ctx, pr, mergeMethod, cmd, tmpBasePath, env)
+		if err != nil {
+			return types.MergeResult{}, fmt.Errorf("unable to fast-forward base to tracking: %w", err)
+		}
+		if len(result.conflictFiles) > 0 {
+			return types.MergeResult{ConflictFiles: result.conflictFiles}, nil
+		}
 	default:
 		return types.MergeResult{}, fmt.Errorf("wrong merge method provided: %s", mergeMethod)
 	}
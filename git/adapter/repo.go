@@ -17,6 +17,8 @@ package adapter
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
@@ -299,12 +301,19 @@ func Push(
 	if opts.Mirror {
 		cmd.AddArguments("--mirror")
 	}
+	if opts.Prune {
+		cmd.AddArguments("--prune")
+	}
 	cmd.AddArguments("--", opts.Remote)
 
 	if len(opts.Branch) > 0 {
 		cmd.AddArguments(opts.Branch)
 	}
 
+	if len(opts.RefSpecs) > 0 {
+		cmd.AddArguments(opts.RefSpecs...)
+	}
+
 	// remove credentials if there are any
 	logRemote := opts.Remote
 	if strings.Contains(logRemote, "://") && strings.Contains(logRemote, "@") {
@@ -366,3 +375,106 @@ func Push(
 
 	return err
 }
+
+// AddAlternateObjects adds the object directory of the alternate repository to the alternates of the repository.
+// All objects of the alternate repository are available in the repository without copying them.
+// IMPORTANT: The alternate repository must not be deleted (or pruned) while the repository depends on it.
+func (a Adapter) AddAlternateObjects(
+	repoPath string,
+	alternateRepoPath string,
+) error {
+	if repoPath == "" || alternateRepoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	alternateObjectsPath, err := filepath.Abs(filepath.Join(alternateRepoPath, "objects"))
+	if err != nil {
+		return fmt.Errorf("failed to get absolute path of alternate objects: %w", err)
+	}
+
+	alternates := filepath.Join(repoPath, "objects", "info", "alternates")
+	f, err := os.OpenFile(alternates, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open alternates file '%s': %w", alternates, err)
+	}
+	defer f.Close()
+
+	if _, err = fmt.Fprintln(f, alternateObjectsPath); err != nil {
+		return fmt.Errorf("failed to write alternates file '%s': %w", alternates, err)
+	}
+
+	return nil
+}
+
+// Dissociate copies all objects the repository borrows from its alternates and removes the alternates.
+// Afterwards the repository is independent of the repositories it used to share objects with.
+func (a Adapter) Dissociate(
+	ctx context.Context,
+	repoPath string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+
+	alternates := filepath.Join(repoPath, "objects", "info", "alternates")
+	if _, err := os.Stat(alternates); os.IsNotExist(err) {
+		return nil
+	}
+
+	cmd := gitea.NewCommand(ctx, "repack", "-a", "-d", "-q")
+	_, _, err := cmd.RunStdString(&gitea.RunOpts{
+		Dir:               repoPath,
+		UseContextTimeout: true,
+	})
+	if err != nil {
+		return processGiteaErrorf(err, "failed to repack repository")
+	}
+
+	if err := os.Remove(alternates); err != nil {
+		return fmt.Errorf("failed to remove alternates file '%s': %w", alternates, err)
+	}
+
+	return nil
+}
+
+// FetchObjects fetches the provided objects (and all objects reachable from them) from the source repository.
+// No references are updated - the caller is expected to reference the objects to prevent them from being pruned.
+// NOTE: This is a read operation and doesn't trigger any server side hooks.
+func (a Adapter) FetchObjects(
+	ctx context.Context,
+	repoPath string,
+	source string,
+	objectSHAs []string,
+) error {
+	if repoPath == "" {
+		return ErrRepositoryPathEmpty
+	}
+	if len(objectSHAs) == 0 {
+		return nil
+	}
+
+	args := []string{
+		"-c", "advice.fetchShowForcedUpdates=false",
+		"-c", "credential.helper=",
+		// protocol v2 allows to request any object reachable from a reference of the source repository.
+		"-c", "protocol.version=2",
+		"fetch",
+		"--quiet",
+		"--no-tags",
+		"--no-write-fetch-head",
+		"--no-show-forced-updates",
+		source,
+	}
+	args = append(args, objectSHAs...)
+
+	cmd := gitea.NewCommand(ctx, args...)
+	_, _, err := cmd.RunStdString(&gitea.RunOpts{
+		Dir:               repoPath,
+		UseContextTimeout: true,
+	})
+	if err != nil {
+		return processGiteaErrorf(err, "failed to fetch objects")
+	}
+
+	return nil
+}
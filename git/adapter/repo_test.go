@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adapter_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+// TestAdapter_ForkLifecycle forks a repository by sharing its objects via alternates, pushes to the fork,
+// fetches the pushed commit into the source repository (like a pull request from the fork does)
+// and verifies that the fork keeps all its objects once it's dissociated and the source repository is deleted.
+func TestAdapter_ForkLifecycle(t *testing.T) {
+	ctx := context.Background()
+	git := setupGit(t)
+
+	source, teardownSource := setupRepo(t, git, "testForkSource")
+	defer teardownSource()
+	fork, teardownFork := setupRepo(t, git, "testForkFork")
+	defer teardownFork()
+
+	baseSHA := writeFile(t, source, "file.txt", "base", nil).String()
+	if err := source.SetReference(gitea.BranchPrefix+"main", baseSHA); err != nil {
+		t.Fatalf("failed to update branch of source: %v", err)
+	}
+
+	// fork: the objects of the source are shared, only the references are copied.
+	if err := git.AddAlternateObjects(fork.Path, source.Path); err != nil {
+		t.Fatalf("failed to add alternate objects: %v", err)
+	}
+	if err := fork.SetReference(gitea.BranchPrefix+"main", baseSHA); err != nil {
+		t.Fatalf("failed to update branch of fork: %v", err)
+	}
+	if _, err := git.GetCommit(ctx, fork.Path, baseSHA); err != nil {
+		t.Fatalf("commit of source isn't available in fork: %v", err)
+	}
+
+	// push to the fork: the new objects are only stored in the fork.
+	featureSHA := writeFile(t, fork, "file.txt", "feature", []string{baseSHA}).String()
+	if err := fork.SetReference(gitea.BranchPrefix+"feature", featureSHA); err != nil {
+		t.Fatalf("failed to update branch of fork: %v", err)
+	}
+	if _, err := git.GetCommit(ctx, source.Path, featureSHA); err == nil {
+		t.Fatalf("commit pushed to the fork must not be available in source")
+	}
+
+	// fetch the commit of the fork into the source.
+	if err := git.FetchObjects(ctx, source.Path, fork.Path, []string{featureSHA}); err != nil {
+		t.Fatalf("failed to fetch objects: %v", err)
+	}
+	if _, err := git.GetCommit(ctx, source.Path, featureSHA); err != nil {
+		t.Fatalf("fetched commit isn't available in source: %v", err)
+	}
+
+	// delete the source after the fork has been dissociated.
+	if err := git.Dissociate(ctx, fork.Path); err != nil {
+		t.Fatalf("failed to dissociate fork: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(fork.Path, "objects", "info", "alternates")); !os.IsNotExist(err) {
+		t.Errorf("expected alternates of fork to be removed, got: %v", err)
+	}
+
+	source.Close()
+	teardownSource()
+
+	_, stderr, runErr := gitea.NewCommand(ctx, "fsck", "--connectivity-only", "--no-dangling").
+		RunStdString(&gitea.RunOpts{Dir: fork.Path})
+	if runErr != nil {
+		t.Fatalf("fork is missing objects after the source got deleted: %v - %s", runErr, stderr)
+	}
+
+	commits, err := git.ListNewCommits(ctx, fork.Path, featureSHA, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to list commits of fork: %v", err)
+	}
+	if len(commits) != 2 || commits[0].SHA != featureSHA || commits[1].SHA != baseSHA {
+		t.Errorf("unexpected commits of fork: %v", commits)
+	}
+}
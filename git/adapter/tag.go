@@ -32,6 +32,7 @@ import (
 const (
 	pgpSignatureBeginToken = "\n-----BEGIN PGP SIGNATURE-----\n" //#nosec G101
 	pgpSignatureEndToken   = "\n-----END PGP SIGNATURE-----"     //#nosec G101
+	sshSignatureBeginToken = "\n-----BEGIN SSH SIGNATURE-----\n" //#nosec G101
 )
 
 // GetAnnotatedTag returns the tag for a specific tag sha.
@@ -200,15 +201,24 @@ func parseTagDataFromCatFile(data []byte) (tag types.Tag, err error) {
 		return tag, err
 	}
 
+	// the signature of a signed tag is appended to the message and signs everything before it.
+	remainder := data[p:]
+	if sigStart := findTagSignatureStart(remainder); sigStart > -1 {
+		tag.SignedData = &types.SignedData{
+			Signature:     bytes.Clone(remainder[sigStart+1:]),
+			SignedContent: bytes.Clone(data[:p+sigStart+1]),
+		}
+		remainder = remainder[:sigStart]
+	}
+
 	// remainder is message and gpg (remove leading and tailing new lines)
-	message := string(bytes.Trim(data[p:], "\n"))
+	message := string(bytes.Trim(remainder, "\n"))
 
-	// handle gpg signature
+	// handle gpg signature header
 	pgpEnd := strings.Index(message, pgpSignatureEndToken)
 	if pgpEnd > -1 {
 		messageStart := pgpEnd + len(pgpSignatureEndToken)
 		// for now we just remove the signature (and trim any separating new lines)
-		// TODO: add support for GPG signature of tags
 		message = strings.TrimLeft(message[messageStart:], "\n")
 	}
 
@@ -224,6 +234,26 @@ func parseTagDataFromCatFile(data []byte) (tag types.Tag, err error) {
 	return tag, nil
 }
 
+// findTagSignatureStart returns the index of the new line preceding the signature appended to the tag message,
+// or -1 if the tag isn't signed.
+func findTagSignatureStart(remainder []byte) int {
+	for _, token := range []string{pgpSignatureBeginToken, sshSignatureBeginToken} {
+		idx := bytes.LastIndex(remainder, []byte(token))
+		if idx == -1 {
+			continue
+		}
+
+		// the signature has to be the last part of the tag.
+		if !bytes.HasSuffix(bytes.TrimRight(remainder, "\n"), []byte("-----")) {
+			continue
+		}
+
+		return idx
+	}
+
+	return -1
+}
+
 func giteaParseCatFileLine(data []byte, start int, header string) (string, int, error) {
 	// for simplicity only look at data from start onwards
 	data = data[start:]
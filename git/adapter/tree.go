@@ -92,6 +92,7 @@ func (a Adapter) GetTreeNode(
 
 // ListTreeNodes lists the child nodes of a tree reachable from ref via the specified path
 // and includes the latest commit for all nodes if requested.
+// In case recursive is set, all nodes of all subtrees are returned (tree nodes themselves are omitted).
 // Note: ref can be Branch / Tag / CommitSHA.
 //
 //nolint:gocognit // refactor if needed
@@ -100,6 +101,7 @@ func (a Adapter) ListTreeNodes(
 	repoPath string,
 	ref string,
 	treePath string,
+	recursive bool,
 ) ([]types.TreeNode, error) {
 	if repoPath == "" {
 		return nil, ErrRepositoryPathEmpty
@@ -126,6 +128,10 @@ func (a Adapter) ListTreeNodes(
 		}
 	}
 
+	if recursive {
+		return listTreeNodesRecursive(tree, treePath)
+	}
+
 	treeNodes := make([]types.TreeNode, len(tree.Entries))
 	for i, treeEntry := range tree.Entries {
 		nodeType, mode, err := mapGogitNodeToTreeNodeModeAndType(treeEntry.Mode)
@@ -145,6 +151,41 @@ func (a Adapter) ListTreeNodes(
 	return treeNodes, nil
 }
 
+func listTreeNodesRecursive(tree *gogitobject.Tree, treePath string) ([]types.TreeNode, error) {
+	walker := gogitobject.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	var treeNodes []types.TreeNode
+	for {
+		name, treeEntry, err := walker.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk the tree: %w", err)
+		}
+
+		nodeType, mode, err := mapGogitNodeToTreeNodeModeAndType(treeEntry.Mode)
+		if err != nil {
+			return nil, err
+		}
+
+		if nodeType == types.TreeNodeTypeTree {
+			continue
+		}
+
+		treeNodes = append(treeNodes, types.TreeNode{
+			NodeType: nodeType,
+			Mode:     mode,
+			Sha:      treeEntry.Hash.String(),
+			Name:     treeEntry.Name,
+			Path:     path.Join(treePath, name),
+		})
+	}
+
+	return treeNodes, nil
+}
+
 func (a Adapter) ReadTree(
 	ctx context.Context,
 	repoPath string,
@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/types"
+)
+
+// ArchiveFormat is the file format of a repository archive.
+type ArchiveFormat string
+
+const (
+	ArchiveFormatZip   ArchiveFormat = "zip"
+	ArchiveFormatTarGz ArchiveFormat = "tar.gz"
+)
+
+// ArchiveFormats lists all supported archive formats.
+var ArchiveFormats = []ArchiveFormat{
+	ArchiveFormatZip,
+	ArchiveFormatTarGz,
+}
+
+func (f ArchiveFormat) Validate() error {
+	for _, format := range ArchiveFormats {
+		if f == format {
+			return nil
+		}
+	}
+	return errors.InvalidArgument("archive format '%s' is not supported", f)
+}
+
+// ContentType returns the media type of archives of the format.
+func (f ArchiveFormat) ContentType() string {
+	switch f {
+	case ArchiveFormatZip:
+		return "application/zip"
+	case ArchiveFormatTarGz:
+		return "application/gzip"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+type ArchiveParams struct {
+	ReadParams
+	Format ArchiveFormat
+	// Treeish is the git reference (branch / tag / commit SHA) of the archived tree.
+	Treeish string
+	// Prefix [OPTIONAL] is prepended to the path of every file in the archive (e.g. "gitness-v1.0/").
+	Prefix string
+	// Paths [OPTIONAL] restricts the archive to the provided paths.
+	Paths []string
+}
+
+func (p *ArchiveParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.Format.Validate(); err != nil {
+		return err
+	}
+
+	if p.Treeish == "" {
+		return errors.InvalidArgument("tree-ish can't be empty")
+	}
+
+	if strings.HasPrefix(p.Treeish, "-") {
+		return errors.InvalidArgument("tree-ish can't start with '-'")
+	}
+
+	return nil
+}
+
+// Archive writes an archive of the tree of the provided git reference to w.
+func (s *Service) Archive(ctx context.Context, params *ArchiveParams, w io.Writer) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	return s.adapter.Archive(ctx, repoPath, types.ArchiveOptions{
+		Format:  string(params.Format),
+		Treeish: params.Treeish,
+		Prefix:  params.Prefix,
+		Paths:   params.Paths,
+	}, w)
+}
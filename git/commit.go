@@ -35,6 +35,16 @@ type Commit struct {
 	Message   string    `json:"message,omitempty"`
 	Author    Signature `json:"author"`
 	Committer Signature `json:"committer"`
+	// SignedData is set in case the commit is signed (GPG or SSH).
+	SignedData *SignedData `json:"-"`
+}
+
+// SignedData contains the cryptographic signature of a git object and the content that was signed.
+type SignedData struct {
+	// Signature is the armored signature (e.g. "-----BEGIN PGP SIGNATURE-----...").
+	Signature []byte
+	// SignedContent is the raw git object without the signature.
+	SignedContent []byte
 }
 
 type GetCommitOutput struct {
@@ -177,6 +187,73 @@ func (s *Service) ListCommits(ctx context.Context, params *ListCommitsParams) (*
 	}, nil
 }
 
+type ListNewCommitsParams struct {
+	ReadParams
+	// SHA is the commit from which the new commits are listed.
+	SHA string
+	// ExcludeSHAs are the commits whose history is excluded from the listed commits
+	// (e.g. the old commit of an updated branch). If empty, the whole history of the SHA is listed.
+	ExcludeSHAs []string
+	// AlternateObjectDirs are additional object directories used to read the commits
+	// (e.g. the quarantine directory of a push that is being verified in the pre-receive hook).
+	AlternateObjectDirs []string
+}
+
+func (p *ListNewCommitsParams) Validate() error {
+	if p == nil {
+		return ErrNoParamsProvided
+	}
+
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SHA == "" {
+		return errors.InvalidArgument("commit sha cannot be empty")
+	}
+
+	for _, sha := range p.ExcludeSHAs {
+		if sha == "" {
+			return errors.InvalidArgument("excluded commit sha cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+type ListNewCommitsOutput struct {
+	Commits []Commit
+}
+
+// ListNewCommits lists all commits reachable from the provided sha that aren't reachable from any of
+// the excluded commits - in other words, the commits that are introduced to a branch by an update.
+func (s *Service) ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	gitCommits, err := s.adapter.ListNewCommits(ctx, repoPath, params.SHA, params.ExcludeSHAs,
+		params.AlternateObjectDirs)
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make([]Commit, len(gitCommits))
+	for i := range gitCommits {
+		commit, err := mapCommit(&gitCommits[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to map rpc commit: %w", err)
+		}
+		commits[i] = *commit
+	}
+
+	return &ListNewCommitsOutput{
+		Commits: commits,
+	}, nil
+}
+
 type GetCommitDivergencesParams struct {
 	ReadParams
 	MaxCount int32
@@ -57,6 +57,9 @@ type ServicePackParams struct {
 	GitProtocol string
 	Data        io.Reader
 	Options     []string // (key, value) pair
+	// StatelessRPC indicates whether the pack is served via git's stateless-rpc mode (used by smart http).
+	// Transports with a bidirectional connection (e.g. ssh) have to set it to false.
+	StatelessRPC bool
 }
 
 func (p *ServicePackParams) Validate() error {
@@ -96,7 +99,7 @@ func (s *Service) ServicePack(ctx context.Context, w io.Writer, params *ServiceP
 		env = append(env, "GIT_PROTOCOL="+params.GitProtocol)
 	}
 
-	err := s.adapter.ServicePack(ctx, repoPath, params.Service, params.Data, w, env...)
+	err := s.adapter.ServicePack(ctx, repoPath, params.Service, params.StatelessRPC, params.Data, w, env...)
 	if err != nil {
 		return fmt.Errorf("failed to execute git %s: %w", params.Service, err)
 	}
@@ -41,6 +41,9 @@ type Interface interface {
 	UpdateRef(ctx context.Context, params UpdateRefParams) error
 
 	SyncRepository(ctx context.Context, params *SyncRepositoryParams) (*SyncRepositoryOutput, error)
+	ForkRepository(ctx context.Context, params *ForkRepositoryParams) (*ForkRepositoryOutput, error)
+	DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error
+	FetchObjects(ctx context.Context, params *FetchObjectsParams) error
 
 	MatchFiles(ctx context.Context, params *MatchFilesParams) (*MatchFilesOutput, error)
 
@@ -49,6 +52,7 @@ type Interface interface {
 	 */
 	GetCommit(ctx context.Context, params *GetCommitParams) (*GetCommitOutput, error)
 	ListCommits(ctx context.Context, params *ListCommitsParams) (*ListCommitsOutput, error)
+	ListNewCommits(ctx context.Context, params *ListNewCommitsParams) (*ListNewCommitsOutput, error)
 	ListCommitTags(ctx context.Context, params *ListCommitTagsParams) (*ListCommitTagsOutput, error)
 	GetCommitDivergences(ctx context.Context, params *GetCommitDivergencesParams) (*GetCommitDivergencesOutput, error)
 	CommitFiles(ctx context.Context, params *CommitFilesParams) (CommitFilesResponse, error)
@@ -84,5 +88,10 @@ type Interface interface {
 	Blame(ctx context.Context, params *BlameParams) (<-chan *BlamePart, <-chan error)
 	PushRemote(ctx context.Context, params *PushRemoteParams) error
 
+	/*
+	 * Archive services
+	 */
+	Archive(ctx context.Context, params *ArchiveParams, w io.Writer) error
+
 	GeneratePipeline(ctx context.Context, params *GeneratePipelineParams) (GeneratePipelinesOutput, error)
 }
@@ -57,14 +57,26 @@ func mapCommit(c *types.Commit) (*Commit, error) {
 	}
 
 	return &Commit{
-		SHA:       c.SHA,
-		Title:     c.Title,
-		Message:   c.Message,
-		Author:    *author,
-		Committer: *comitter,
+		SHA:        c.SHA,
+		Title:      c.Title,
+		Message:    c.Message,
+		Author:     *author,
+		Committer:  *comitter,
+		SignedData: mapSignedData(c.SignedData),
 	}, nil
 }
 
+func mapSignedData(d *types.SignedData) *SignedData {
+	if d == nil {
+		return nil
+	}
+
+	return &SignedData{
+		Signature:     d.Signature,
+		SignedContent: d.SignedContent,
+	}
+}
+
 func mapSignature(s *types.Signature) (*Signature, error) {
 	if s == nil {
 		return nil, fmt.Errorf("rpc signature is nil")
@@ -116,6 +128,7 @@ func mapAnnotatedTag(tag *types.Tag) *CommitTag {
 		Tagger:      tagger,
 		IsAnnotated: true,
 		Commit:      nil,
+		SignedData:  mapSignedData(tag.SignedData),
 	}
 }
 
@@ -36,7 +36,7 @@ type MergeParams struct {
 	WriteParams
 	BaseBranch string
 	// HeadRepoUID specifies the UID of the repo that contains the head branch (required for forking).
-	// WARNING: This field is currently not supported yet!
+	// If not provided, the head branch is expected to be in the base repository.
 	HeadRepoUID string
 	HeadBranch  string
 	Title       string
@@ -129,8 +129,14 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 	baseBranch := "base"
 	trackingBranch := "tracking"
 
+	headRepoPath := repoPath
+	if params.HeadRepoUID != "" {
+		headRepoPath = getFullPathForRepo(s.reposRoot, params.HeadRepoUID)
+	}
+
 	pr := &types.PullRequest{
 		BaseRepoPath: repoPath,
+		HeadRepoPath: headRepoPath,
 		BaseBranch:   params.BaseBranch,
 		HeadBranch:   params.HeadBranch,
 	}
@@ -157,6 +163,13 @@ func (s *Service) Merge(ctx context.Context, params *MergeParams) (MergeOutput,
 			params.HeadBranch, params.BaseBranch)
 	}
 
+	if params.Method == enum.MergeMethodFastForward && mergeBaseCommitSHA != tmpRepo.BaseSHA {
+		return MergeOutput{}, errors.PreconditionFailed(
+			"fast-forward merge isn't possible: base branch '%s' has diverged from head branch '%s'",
+			params.BaseBranch,
+			params.HeadBranch)
+	}
+
 	if params.HeadExpectedSHA != "" && params.HeadExpectedSHA != tmpRepo.HeadSHA {
 		return MergeOutput{}, errors.PreconditionFailed(
 			"head branch '%s' is on SHA '%s' which doesn't match expected SHA '%s'.",
@@ -0,0 +1,169 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/adapter"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+
+	gitea "code.gitea.io/gitea/modules/git"
+)
+
+var testSignature = &gitea.Signature{
+	Name:  "test",
+	Email: "test@test.com",
+}
+
+func setupMergeService(t *testing.T, repoUID string) (*Service, *gitea.Repository) {
+	t.Helper()
+	ctx := context.Background()
+
+	config := &types.Config{}
+	gogitProvider := adapter.ProvideGoGitRepoProvider()
+	gitAdapter, err := adapter.New(
+		gogitProvider,
+		adapter.ProvideLastCommitCache(
+			config,
+			nil,
+			gogitProvider,
+		),
+	)
+	if err != nil {
+		t.Fatalf("error initializing adapter: %v", err)
+	}
+
+	s, err := New(t.TempDir(), t.TempDir(), gitAdapter, nil, "")
+	if err != nil {
+		t.Fatalf("error initializing service: %v", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, repoUID)
+	if err = gitAdapter.InitRepository(ctx, repoPath, true); err != nil {
+		t.Fatalf("error initializing repository: %v", err)
+	}
+
+	repo, err := gitAdapter.OpenRepository(ctx, repoPath)
+	if err != nil {
+		t.Fatalf("error opening repository: %v", err)
+	}
+	t.Cleanup(func() { repo.Close() })
+
+	return s, repo
+}
+
+// commitFile commits the file on top of the parents and points the branch to the commit.
+func commitFile(
+	t *testing.T,
+	repo *gitea.Repository,
+	branch string,
+	path string,
+	content string,
+	parents ...string,
+) string {
+	t.Helper()
+
+	sha, err := repo.HashObject(strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("failed to hash object: %v", err)
+	}
+
+	if err = repo.AddObjectToIndex("100644", sha, path); err != nil {
+		t.Fatalf("failed to add object to index: %v", err)
+	}
+
+	tree, err := repo.WriteTree()
+	if err != nil {
+		t.Fatalf("failed to write tree: %v", err)
+	}
+
+	sha, err = repo.CommitTree(testSignature, testSignature, tree, gitea.CommitTreeOpts{
+		Message: "write " + path,
+		Parents: parents,
+	})
+	if err != nil {
+		t.Fatalf("failed to commit tree: %v", err)
+	}
+
+	if err = repo.SetReference(gitea.BranchPrefix+branch, sha.String()); err != nil {
+		t.Fatalf("failed to update branch '%s': %v", branch, err)
+	}
+
+	return sha.String()
+}
+
+func TestService_Merge_FastForward(t *testing.T) {
+	const repoUID = "testmergefastforward"
+
+	s, repo := setupMergeService(t, repoUID)
+
+	baseSHA := commitFile(t, repo, "main", "file.txt", "base")
+	headSHA := commitFile(t, repo, "feature", "file.txt", "feature", baseSHA)
+	divergedSHA := commitFile(t, repo, "diverged", "other.txt", "diverged", baseSHA)
+
+	merge := func(baseBranch string) (MergeOutput, error) {
+		return s.Merge(context.Background(), &MergeParams{
+			WriteParams: WriteParams{
+				RepoUID: repoUID,
+				Actor:   Identity{Name: testSignature.Name, Email: testSignature.Email},
+			},
+			BaseBranch:      baseBranch,
+			HeadBranch:      "feature",
+			HeadExpectedSHA: headSHA,
+			RefType:         enum.RefTypeBranch,
+			RefName:         baseBranch,
+			Method:          enum.MergeMethodFastForward,
+		})
+	}
+
+	t.Run("diverged", func(t *testing.T) {
+		_, err := merge("diverged")
+		if !errors.IsPreconditionFailed(err) {
+			t.Fatalf("expected precondition failed error, got: %v", err)
+		}
+
+		sha, err := repo.GetRefCommitID(gitea.BranchPrefix + "diverged")
+		if err != nil {
+			t.Fatalf("failed to get branch: %v", err)
+		}
+		if sha != divergedSHA {
+			t.Errorf("diverged branch must not change: want=%s got=%s", divergedSHA, sha)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		out, err := merge("main")
+		if err != nil {
+			t.Fatalf("failed to merge: %v", err)
+		}
+
+		if out.MergeSHA != headSHA || out.BaseSHA != baseSHA || out.MergeBaseSHA != baseSHA {
+			t.Errorf("unexpected output: %+v", out)
+		}
+
+		sha, err := repo.GetRefCommitID(gitea.BranchPrefix + "main")
+		if err != nil {
+			t.Fatalf("failed to get branch: %v", err)
+		}
+		if sha != headSHA {
+			t.Errorf("base branch must point to the head commit: want=%s got=%s", headSHA, sha)
+		}
+	})
+}
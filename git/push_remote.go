@@ -25,6 +25,11 @@ import (
 type PushRemoteParams struct {
 	ReadParams
 	RemoteURL string
+
+	// RefSpecs [OPTIONAL] restricts the push to the provided refspecs.
+	// Remote references matching the refspecs that don't exist locally anymore are deleted.
+	// By default all references are pushed in mirror mode.
+	RefSpecs []string
 }
 
 func (p *PushRemoteParams) Validate() error {
@@ -57,10 +62,12 @@ func (s *Service) PushRemote(ctx context.Context, params *PushRemoteParams) erro
 	}
 
 	err = s.adapter.Push(ctx, repoPath, types.PushOptions{
-		Remote: params.RemoteURL,
-		Force:  false,
-		Env:    nil,
-		Mirror: true,
+		Remote:   params.RemoteURL,
+		Force:    false,
+		Env:      nil,
+		Mirror:   len(params.RefSpecs) == 0,
+		Prune:    len(params.RefSpecs) > 0,
+		RefSpecs: params.RefSpecs,
 	})
 	if err != nil {
 		return fmt.Errorf("PushRemote: failed to push to remote repository: %w", err)
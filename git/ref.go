@@ -62,6 +62,10 @@ func (s *Service) GetRef(ctx context.Context, params GetRefParams) (GetRefRespon
 	}
 
 	sha, err := s.adapter.GetRef(ctx, repoPath, reference)
+	var notFoundErr *types.NotFoundError
+	if errors.As(err, &notFoundErr) {
+		return GetRefResponse{}, errors.NotFound("%s", notFoundErr.Msg)
+	}
 	if err != nil {
 		return GetRefResponse{}, err
 	}
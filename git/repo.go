@@ -109,6 +109,62 @@ type SyncRepositoryOutput struct {
 	DefaultBranch string
 }
 
+type ForkRepositoryParams struct {
+	// Fork operation is similar to the create operation, as the UID of the fork doesn't exist yet.
+	// Only take actor and envars as input and create WriteParams manually
+	Actor   Identity
+	EnvVars map[string]string
+
+	// SourceRepoUID is the UID of the repository that is being forked.
+	SourceRepoUID string
+	DefaultBranch string
+}
+
+func (p *ForkRepositoryParams) Validate() error {
+	if p.SourceRepoUID == "" {
+		return errors.InvalidArgument("source repository is mandatory")
+	}
+
+	if p.DefaultBranch == "" {
+		return errors.InvalidArgument("default branch is mandatory")
+	}
+
+	return p.Actor.Validate()
+}
+
+type ForkRepositoryOutput struct {
+	UID string
+}
+
+type DissociateRepositoryParams struct {
+	WriteParams
+}
+
+type FetchObjectsParams struct {
+	WriteParams
+	// Source is the UID of the repository from which the objects are fetched.
+	Source     string
+	ObjectSHAs []string
+}
+
+func (p *FetchObjectsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Source == "" {
+		return errors.InvalidArgument("source repository is mandatory")
+	}
+
+	for _, sha := range p.ObjectSHAs {
+		if !isValidGitSHA(sha) {
+			return errors.InvalidArgument("invalid object sha %q", sha)
+		}
+	}
+
+	return nil
+}
+
 type HashRepositoryParams struct {
 	ReadParams
 	HashType        hash.Type
@@ -286,6 +342,118 @@ func (s *Service) SyncRepository(
 	}, nil
 }
 
+// ForkRepository creates a new repository that contains all branches and tags of the source repository.
+// The fork doesn't copy the objects of the source repository but uses them via git alternates.
+func (s *Service) ForkRepository(
+	ctx context.Context,
+	params *ForkRepositoryParams,
+) (*ForkRepositoryOutput, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	sourceRepoPath := getFullPathForRepo(s.reposRoot, params.SourceRepoUID)
+	if _, err := os.Stat(sourceRepoPath); err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.NotFound("source repository path not found")
+		}
+		return nil, fmt.Errorf("failed to check the status of the source repository: %w", err)
+	}
+
+	uid, err := NewRepositoryUID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new uid: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Msgf("Fork git repository with uid '%s' into new repository with uid '%s'", params.SourceRepoUID, uid)
+
+	writeParams := WriteParams{
+		RepoUID: uid,
+		Actor:   params.Actor,
+		EnvVars: params.EnvVars,
+	}
+
+	err = s.createRepositoryInternal(
+		ctx,
+		&writeParams,
+		params.DefaultBranch,
+		nil,
+		nil,
+		time.Time{},
+		nil,
+		time.Time{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// delete repo dir on error
+	defer func() {
+		if err != nil {
+			cleanuperr := s.DeleteRepositoryBestEffort(ctx, uid)
+			if cleanuperr != nil {
+				log.Ctx(ctx).Warn().Err(cleanuperr).Msg("failed to cleanup forked repo dir")
+			}
+		}
+	}()
+
+	repoPath := getFullPathForRepo(s.reposRoot, uid)
+
+	err = s.adapter.AddAlternateObjects(repoPath, sourceRepoPath)
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to share objects with source repo: %w", err)
+	}
+
+	// all objects are available via alternates, so this only copies the references of the source repo.
+	err = s.adapter.Sync(ctx, repoPath, sourceRepoPath, []string{
+		"+" + gitReferenceNamePrefixBranch + "*:" + gitReferenceNamePrefixBranch + "*",
+		"+" + gitReferenceNamePrefixTag + "*:" + gitReferenceNamePrefixTag + "*",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ForkRepository: failed to sync references from source repo: %w", err)
+	}
+
+	return &ForkRepositoryOutput{
+		UID: uid,
+	}, nil
+}
+
+// DissociateRepository makes the repository independent of the repositories it shares objects with (e.g. for forks).
+// It has to be called before a repository is deleted that other repositories borrow objects from.
+func (s *Service) DissociateRepository(ctx context.Context, params *DissociateRepositoryParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	err := s.adapter.Dissociate(ctx, repoPath)
+	if err != nil {
+		return fmt.Errorf("DissociateRepository: failed to dissociate git repo: %w", err)
+	}
+
+	return nil
+}
+
+// FetchObjects copies the provided objects (and all their dependencies) from the source repository.
+// This is used to make commits of a fork available in the upstream repository (e.g. for pull requests).
+func (s *Service) FetchObjects(ctx context.Context, params *FetchObjectsParams) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+	sourcePath := getFullPathForRepo(s.reposRoot, params.Source)
+
+	err := s.adapter.FetchObjects(ctx, repoPath, sourcePath, params.ObjectSHAs)
+	if err != nil {
+		return fmt.Errorf("FetchObjects: failed to fetch objects from source repo: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Service) HashRepository(ctx context.Context, params *HashRepositoryParams) (*HashRepositoryOutput, error) {
 	if err := params.Validate(); err != nil {
 		return nil, err
@@ -69,6 +69,8 @@ type CommitTag struct {
 	Message     string
 	Tagger      *Signature
 	Commit      *Commit
+	// SignedData is set in case the annotated tag is signed (GPG or SSH).
+	SignedData *SignedData
 }
 
 type CreateCommitTagParams struct {
@@ -194,6 +196,7 @@ func (s *Service) ListCommitTags(
 				return nil, fmt.Errorf("signature mapping error: %w", err)
 			}
 			tags[wi].Tagger = tagger
+			tags[wi].SignedData = mapSignedData(aTags[ai].SignedData)
 
 			ai++
 			wi++
@@ -144,6 +144,16 @@ type Commit struct {
 	Message   string    `json:"message,omitempty"`
 	Author    Signature `json:"author"`
 	Committer Signature `json:"committer"`
+	// SignedData is set in case the commit is signed (GPG or SSH).
+	SignedData *SignedData `json:"-"`
+}
+
+// SignedData contains the cryptographic signature of a git object and the content that was signed.
+type SignedData struct {
+	// Signature is the armored signature (e.g. "-----BEGIN PGP SIGNATURE-----...").
+	Signature []byte
+	// SignedContent is the raw git object without the signature.
+	SignedContent []byte
 }
 
 type Branch struct {
@@ -169,6 +179,7 @@ type Tag struct {
 	Title      string
 	Message    string
 	Tagger     Signature
+	SignedData *SignedData
 }
 
 type CreateTagOptions struct {
@@ -222,6 +233,16 @@ type PushOptions struct {
 	Env            []string
 	Timeout        time.Duration
 	Mirror         bool
+	Prune          bool
+	// RefSpecs are pushed in addition to the branch (if provided).
+	RefSpecs []string
+}
+
+type ArchiveOptions struct {
+	Format  string
+	Treeish string
+	Prefix  string
+	Paths   []string
 }
 
 type TreeNodeWithCommit struct {
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -48,7 +49,8 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 	}
 
 	in := &PreReceiveInput{
-		RefUpdates: refUpdates,
+		RefUpdates:  refUpdates,
+		Environment: getEnvironment(),
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -114,6 +116,24 @@ func handleServerHookOutput(out *Output, err error) error {
 	return nil
 }
 
+// getEnvironment returns the git environment of the hook.
+// During the pre-receive hook, git stores the objects of the push in a quarantine directory
+// which is provided as object directory to the hook.
+func getEnvironment() Environment {
+	var dirs []string
+	if dir := os.Getenv("GIT_OBJECT_DIRECTORY"); dir != "" {
+		// the path could be relative to the repository (the working directory of the hook).
+		if absDir, err := filepath.Abs(dir); err == nil {
+			dir = absDir
+		}
+		dirs = append(dirs, dir)
+	}
+
+	return Environment{
+		AlternateObjectDirs: dirs,
+	}
+}
+
 // getUpdatedReferencesFromStdIn reads the updated references provided by git from stdin.
 // The expected format is "<old-value> SP <new-value> SP <ref-name> LF"
 // For more details see https://git-scm.com/docs/githooks#pre-receive
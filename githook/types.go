@@ -39,10 +39,20 @@ type PostReceiveInput struct {
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
 }
 
+// Environment contains the git environment of the hook that is relevant for the server.
+type Environment struct {
+	// AlternateObjectDirs contains the directories with the objects of the push
+	// that aren't moved into the repository yet (quarantine).
+	AlternateObjectDirs []string `json:"alternate_object_dirs,omitempty"`
+}
+
 // PreReceiveInput represents the input of the pre-receive git hook.
 type PreReceiveInput struct {
 	// RefUpdates contains all references that are being updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+
+	// Environment contains the git environment of the hook.
+	Environment Environment `json:"environment"`
 }
 
 // UpdateInput represents the input of the update git hook.
@@ -0,0 +1,38 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import "time"
+
+type Provider string
+
+const (
+	ProviderMemory Provider = "inmemory"
+	ProviderRedis  Provider = "redis"
+)
+
+type Config struct {
+	// App is the prefix of all redis keys to avoid conflicts with other applications.
+	App string
+
+	Provider Provider
+
+	// MaxLength is the (approximate) number of lines kept per log stream for late subscribers.
+	MaxLength int64
+
+	// Expiry is the time after which a log stream without any writes is removed.
+	// It ensures streams of steps that never got deleted (e.g. crashed runners) don't live forever.
+	Expiry time.Duration
+}
@@ -0,0 +1,263 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package livelog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	redisFieldLine = "line"
+	redisFieldEOF  = "eof"
+	redisFieldInit = "init"
+
+	// redisTailBlockTimeout is the maximum time a tail waits for new lines
+	// before checking whether the stream still exists.
+	redisTailBlockTimeout = 5 * time.Second
+
+	// redisTailBatchSize is the maximum number of lines read from redis at once.
+	redisTailBatchSize = 100
+
+	// redisDeleteGracePeriod is the time a deleted stream is kept to ensure
+	// subscribers on other instances get notified about the end of the stream.
+	redisDeleteGracePeriod = time.Minute
+)
+
+// redisStreamer is a LogStream backed by redis streams.
+// It allows to tail the logs of a step independent of the instance the step is executed on.
+type redisStreamer struct {
+	client    redis.UniversalClient
+	app       string
+	maxLength int64
+	expiry    time.Duration
+
+	// subscribers counts the local subscribers per stream (used for Info only).
+	subscribersMx sync.Mutex
+	subscribers   map[int64]int
+}
+
+// NewRedis returns a LogStream that stores the log lines in redis streams.
+func NewRedis(client redis.UniversalClient, app string, maxLength int64, expiry time.Duration) LogStream {
+	if maxLength <= 0 {
+		maxLength = bufferSize
+	}
+
+	return &redisStreamer{
+		client:      client,
+		app:         app,
+		maxLength:   maxLength,
+		expiry:      expiry,
+		subscribers: map[int64]int{},
+	}
+}
+
+func (s *redisStreamer) Create(ctx context.Context, id int64) error {
+	key := s.key(id)
+
+	// the stream is (re)created with an init entry as redis streams can't be empty.
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: key,
+			Values: []string{redisFieldInit, "1"},
+		})
+		pipe.Expire(ctx, key, s.expiry)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create log stream in redis: %w", err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Delete(ctx context.Context, id int64) error {
+	key := s.key(id)
+
+	// don't remove the stream right away to ensure all subscribers receive the eof.
+	var xAddCmd *redis.StringCmd
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		xAddCmd = pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream:     key,
+			NoMkStream: true,
+			Values:     []string{redisFieldEOF, "1"},
+		})
+		pipe.Expire(ctx, key, redisDeleteGracePeriod)
+		return nil
+	})
+	if errors.Is(xAddCmd.Err(), redis.Nil) {
+		return ErrStreamNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete log stream in redis: %w", err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Write(ctx context.Context, id int64, line *Line) error {
+	key := s.key(id)
+
+	data, err := json.Marshal(line)
+	if err != nil {
+		return fmt.Errorf("failed to marshal line: %w", err)
+	}
+
+	var xAddCmd *redis.StringCmd
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		xAddCmd = pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream:     key,
+			NoMkStream: true,
+			MaxLen:     s.maxLength,
+			Approx:     true,
+			Values:     []string{redisFieldLine, string(data)},
+		})
+		pipe.Expire(ctx, key, s.expiry)
+		return nil
+	})
+	if errors.Is(xAddCmd.Err(), redis.Nil) {
+		return ErrStreamNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write line to redis: %w", err)
+	}
+
+	return nil
+}
+
+func (s *redisStreamer) Tail(ctx context.Context, id int64) (<-chan *Line, <-chan error) {
+	key := s.key(id)
+
+	n, err := s.client.Exists(ctx, key).Result()
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("step_id", id).Msg("failed to check existence of log stream")
+		return nil, nil
+	}
+	if n == 0 {
+		return nil, nil
+	}
+
+	linec := make(chan *Line, bufferSize)
+	errc := make(chan error, 1)
+
+	s.addSubscriber(id, 1)
+	go func() {
+		defer s.addSubscriber(id, -1)
+		defer close(errc)
+
+		if err := s.tail(ctx, key, linec); err != nil {
+			errc <- err
+		}
+	}()
+
+	return linec, errc
+}
+
+// tail reads the full stream (to replay the history) and then blocks for new lines until the stream ends.
+func (s *redisStreamer) tail(ctx context.Context, key string, linec chan<- *Line) error {
+	lastID := "0"
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		streams, err := s.client.XRead(ctx, &redis.XReadArgs{
+			Streams: []string{key, lastID},
+			Count:   redisTailBatchSize,
+			Block:   redisTailBlockTimeout,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			// no new lines - stop in case the stream expired in the meantime.
+			n, err := s.client.Exists(ctx, key).Result()
+			if err != nil {
+				return fmt.Errorf("failed to check existence of log stream: %w", err)
+			}
+			if n == 0 {
+				return nil
+			}
+			continue
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read log stream from redis: %w", err)
+		}
+
+		for _, stream := range streams {
+			for _, msg := range stream.Messages {
+				lastID = msg.ID
+
+				if _, ok := msg.Values[redisFieldEOF]; ok {
+					return nil
+				}
+
+				raw, ok := msg.Values[redisFieldLine].(string)
+				if !ok {
+					continue
+				}
+
+				line := &Line{}
+				if err := json.Unmarshal([]byte(raw), line); err != nil {
+					return fmt.Errorf("failed to unmarshal line: %w", err)
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case linec <- line:
+				}
+			}
+		}
+	}
+}
+
+// Info returns the local subscribers per stream of this instance.
+func (s *redisStreamer) Info(_ context.Context) *LogStreamInfo {
+	s.subscribersMx.Lock()
+	defer s.subscribersMx.Unlock()
+
+	info := &LogStreamInfo{
+		Streams: make(map[int64]int, len(s.subscribers)),
+	}
+	for id, n := range s.subscribers {
+		info.Streams[id] = n
+	}
+
+	return info
+}
+
+func (s *redisStreamer) addSubscriber(id int64, delta int) {
+	s.subscribersMx.Lock()
+	defer s.subscribersMx.Unlock()
+
+	s.subscribers[id] += delta
+	if s.subscribers[id] <= 0 {
+		delete(s.subscribers, id)
+	}
+}
+
+func (s *redisStreamer) key(id int64) string {
+	return s.app + ":livelog:" + strconv.FormatInt(id, 10)
+}
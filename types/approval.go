@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// StageTypeApproval is the type of pipeline stages that aren't executed by a runner,
+// but pause the execution until a user approves or rejects them.
+const StageTypeApproval = "approval"
+
+// StageLabelApprovalRole is the stage label that overwrites the membership role
+// required to approve or reject an approval stage.
+const StageLabelApprovalRole = "approval_role"
+
+// Approval represents the decision of a user on an approval stage of an execution.
+type Approval struct {
+	ID          int64                 `json:"id"`
+	ExecutionID int64                 `json:"execution_id"`
+	StageID     int64                 `json:"stage_id"`
+	Decision    enum.ApprovalDecision `json:"decision"`
+	Comment     string                `json:"comment"`
+	CreatedBy   int64                 `json:"created_by"`
+	Created     int64                 `json:"created"`
+
+	Author PrincipalInfo `json:"author"`
+}
+
+// IsApprovalGate returns true if the stage is an approval stage.
+func (s *Stage) IsApprovalGate() bool {
+	return s.Type == StageTypeApproval
+}
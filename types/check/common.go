@@ -37,7 +37,7 @@ const (
 var (
 	// illegalRootSpaceUIDs is the list of space UIDs we are blocking for root spaces
 	// as they might cause issues with routing.
-	illegalRootSpaceUIDs = []string{"api", "git"}
+	illegalRootSpaceUIDs = []string{"api", "git", "rpc"}
 )
 
 var (
@@ -15,7 +15,12 @@
 package check
 
 import (
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 const (
@@ -30,6 +35,9 @@ var (
 	ErrTokenLifeTimeRequired = &ValidationError{
 		"The life time of a token is required.",
 	}
+	ErrTokenPathEmpty = &ValidationError{
+		"The paths a token is restricted to can't be empty.",
+	}
 )
 
 // TokenLifetime returns true if the lifetime is valid for a token.
@@ -48,3 +56,38 @@ func TokenLifetime(lifetime *time.Duration, optional bool) error {
 
 	return nil
 }
+
+// TokenRestrictions validates and sanitizes the permissions and paths a token is restricted to.
+func TokenRestrictions(permissions []enum.Permission, paths []string) ([]enum.Permission, []string, error) {
+	var sanitizedPermissions []enum.Permission
+	seenPermissions := make(map[enum.Permission]struct{}, len(permissions))
+	for _, permission := range permissions {
+		sanitized, ok := permission.Sanitize()
+		if !ok || sanitized == "" {
+			return nil, nil, &ValidationError{fmt.Sprintf("Unknown permission '%s'.", permission)}
+		}
+
+		if _, ok = seenPermissions[sanitized]; ok {
+			continue
+		}
+		seenPermissions[sanitized] = struct{}{}
+		sanitizedPermissions = append(sanitizedPermissions, sanitized)
+	}
+
+	var sanitizedPaths []string
+	seenPaths := make(map[string]struct{}, len(paths))
+	for _, path := range paths {
+		path = strings.Trim(strings.TrimSpace(path), types.PathSeparator)
+		if path == "" {
+			return nil, nil, ErrTokenPathEmpty
+		}
+
+		if _, ok := seenPaths[strings.ToLower(path)]; ok {
+			continue
+		}
+		seenPaths[strings.ToLower(path)] = struct{}{}
+		sanitizedPaths = append(sanitizedPaths, path)
+	}
+
+	return sanitizedPermissions, sanitizedPaths, nil
+}
@@ -19,8 +19,10 @@ import (
 
 	"github.com/harness/gitness/blob"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/livelog"
 	"github.com/harness/gitness/lock"
 	"github.com/harness/gitness/pubsub"
+	"github.com/harness/gitness/types/enum"
 )
 
 // LastCommitCache holds configuration options for the last commit cache.
@@ -133,13 +135,58 @@ type Config struct {
 		}
 	}
 
+	// SSH defines the configuration of the built-in ssh server for git operations.
+	SSH struct {
+		Enabled bool `envconfig:"GITNESS_SSH_ENABLED" default:"false"`
+		Port    int  `envconfig:"GITNESS_SSH_PORT" default:"3022"`
+		// HostKeyPath is the path to the host key of the server (generated if it doesn't exist).
+		// If not provided, the host key is stored in the git root directory.
+		HostKeyPath string `envconfig:"GITNESS_SSH_HOST_KEY_PATH"`
+	}
+
 	// CI defines configuration related to build executions.
 	CI struct {
 		ParallelWorkers int `envconfig:"GITNESS_CI_PARALLEL_WORKERS" default:"2"`
+		// EmbeddedRunnerEnabled specifies whether stages are executed by the runner embedded in the server.
+		// Disable it in case all stages should be executed by external runners connecting via the rpc endpoint.
+		EmbeddedRunnerEnabled bool `envconfig:"GITNESS_CI_EMBEDDED_RUNNER_ENABLED" default:"true"`
 		// PluginsZipURL is a pointer to a zip containing all the plugins schemas.
 		// This could be a local path or an external location.
 		//nolint:lll
 		PluginsZipURL string `envconfig:"GITNESS_CI_PLUGINS_ZIP_URL" default:"https://github.com/bradrydzewski/plugins/archive/refs/heads/master.zip"`
+
+		// ExecutionRetention defines the default retention policy of pipeline executions and their logs.
+		// Pipelines can override the policy. The last successful execution of each ref is always kept,
+		// and in case both limits are set, executions are only purged once they exceed both.
+		ExecutionRetention struct {
+			// Count is the number of most recent executions kept per pipeline (0 keeps all).
+			Count int64 `envconfig:"GITNESS_CI_EXECUTION_RETENTION_COUNT" default:"0"`
+			// Days is the number of days executions are kept (0 keeps them forever).
+			Days int64 `envconfig:"GITNESS_CI_EXECUTION_RETENTION_DAYS" default:"0"`
+		}
+
+		// Artifacts defines the storage of files uploaded by pipeline steps (stored in the blob store).
+		Artifacts struct {
+			// Retention is the duration artifacts are kept after their upload.
+			Retention time.Duration `envconfig:"GITNESS_CI_ARTIFACTS_RETENTION" default:"720h"` // 30 days
+			// MaxSize is the maximum size of a single artifact in bytes.
+			MaxSize int64 `envconfig:"GITNESS_CI_ARTIFACTS_MAX_SIZE" default:"1073741824"` // 1 GiB
+		}
+
+		// Cache defines the caches saved and restored by the built-in cache step (stored in the blob store).
+		Cache struct {
+			// Retention is the duration a cache entry is kept after it was last used.
+			Retention time.Duration `envconfig:"GITNESS_CI_CACHE_RETENTION" default:"168h"` // 7 days
+			// MaxSize is the maximum size of a single cache entry in bytes.
+			MaxSize int64 `envconfig:"GITNESS_CI_CACHE_MAX_SIZE" default:"2147483648"` // 2 GiB
+			// Image is the image used to execute the built-in cache step (requires sh, tar, gzip and wget).
+			Image string `envconfig:"GITNESS_CI_CACHE_IMAGE" default:"alpine:3"`
+		}
+
+		// ApprovalRole is the minimum membership role on the space of the repository (or any of its ancestors)
+		// required to approve or reject approval stages. Roles with a higher rank can decide on them as well.
+		// Stages can overwrite the role with the "approval_role" node label.
+		ApprovalRole enum.MembershipRole `envconfig:"GITNESS_CI_APPROVAL_ROLE" default:"space_owner"`
 	}
 
 	// Database defines the database configuration parameters.
@@ -150,10 +197,10 @@ type Config struct {
 
 	// BlobStore defines the blob storage configuration parameters.
 	BlobStore struct {
-		// Provider is a name of blob storage service like filesystem or gcs
+		// Provider is a name of blob storage service like filesystem, gcs or s3
 		Provider blob.Provider `envconfig:"GITNESS_BLOBSTORE_PROVIDER" default:"filesystem"`
 		// Bucket is a path to the directory where the files will be stored when using filesystem blob storage,
-		// in case of gcs provider this will be the actual bucket where the images are stored.
+		// in case of gcs or s3 provider this will be the actual bucket where the images are stored.
 		Bucket string `envconfig:"GITNESS_BLOBSTORE_BUCKET"`
 
 		// In case of GCS provider, this is expected to be the path to the service account key file.
@@ -163,6 +210,15 @@ type Config struct {
 		TargetPrincipal string `envconfig:"GITNESS_BLOBSTORE_TARGET_PRINCIPAL" default:""`
 
 		ImpersonationLifetime time.Duration `envconfig:"GITNESS_BLOBSTORE_IMPERSONATION_LIFETIME" default:"12h"`
+
+		// S3 configures the s3 provider, which supports any S3 compatible service (e.g. MinIO).
+		S3 struct {
+			Endpoint  string `envconfig:"GITNESS_BLOBSTORE_S3_ENDPOINT"`
+			Region    string `envconfig:"GITNESS_BLOBSTORE_S3_REGION"`
+			AccessKey string `envconfig:"GITNESS_BLOBSTORE_S3_ACCESS_KEY"`
+			SecretKey string `envconfig:"GITNESS_BLOBSTORE_S3_SECRET_KEY"`
+			PathStyle bool   `envconfig:"GITNESS_BLOBSTORE_S3_PATH_STYLE"`
+		}
 	}
 
 	// Token defines token configuration parameters.
@@ -171,6 +227,35 @@ type Config struct {
 		Expire     time.Duration `envconfig:"GITNESS_TOKEN_EXPIRE" default:"720h"`
 	}
 
+	// OIDC defines the configuration of the OpenID Connect login flow.
+	OIDC struct {
+		Enabled bool `envconfig:"GITNESS_OIDC_ENABLED"`
+		// Issuer is the URL of the identity provider, used for discovery and to validate the id token.
+		Issuer       string `envconfig:"GITNESS_OIDC_ISSUER"`
+		ClientID     string `envconfig:"GITNESS_OIDC_CLIENT_ID"`
+		ClientSecret string `envconfig:"GITNESS_OIDC_CLIENT_SECRET"`
+		// RedirectURL is the callback URL registered with the identity provider.
+		// Value is derived from URL.API unless explicitly specified (e.g. http://localhost:3000/api/v1/oidc/callback).
+		RedirectURL string   `envconfig:"GITNESS_OIDC_REDIRECT_URL"`
+		Scopes      []string `envconfig:"GITNESS_OIDC_SCOPES" default:"openid,profile,email"`
+
+		// AutoProvision specifies whether a user is created on first login.
+		AutoProvision bool `envconfig:"GITNESS_OIDC_AUTO_PROVISION" default:"true"`
+
+		// Claims define which claims of the id token are mapped to provisioned users.
+		// Users are identified by the iss and sub claims, existing accounts have to be linked explicitly.
+		Claims struct {
+			UID         string `envconfig:"GITNESS_OIDC_CLAIM_UID"          default:"preferred_username"`
+			Email       string `envconfig:"GITNESS_OIDC_CLAIM_EMAIL"        default:"email"`
+			DisplayName string `envconfig:"GITNESS_OIDC_CLAIM_DISPLAY_NAME" default:"name"`
+			Groups      string `envconfig:"GITNESS_OIDC_CLAIM_GROUPS"       default:"groups"`
+		}
+
+		// GroupMemberships maps groups of the groups claim to space memberships.
+		// Each entry has the format `<group>:<space_path>:<role>` (e.g. developers:acme/backend:contributor).
+		GroupMemberships []string `envconfig:"GITNESS_OIDC_GROUP_MEMBERSHIPS"`
+	}
+
 	Logs struct {
 		// S3 provides optional storage option for logs.
 		S3 struct {
@@ -276,6 +361,17 @@ type Config struct {
 		ChannelSize      int           `envconfig:"GITNESS_PUBSUB_CHANNEL_SIZE"      default:"100"`
 	}
 
+	LogStream struct {
+		// Provider is a name of the live log streaming service like redis or memory.
+		Provider livelog.Provider `envconfig:"GITNESS_LOGSTREAM_PROVIDER"      default:"inmemory"`
+		// AppNamespace is just service app prefix to avoid conflicts on key definition
+		AppNamespace string `envconfig:"GITNESS_LOGSTREAM_APP_NAMESPACE" default:"gitness"`
+		// MaxLength is the approximate number of lines kept per step for late subscribers.
+		MaxLength int64 `envconfig:"GITNESS_LOGSTREAM_MAX_LENGTH" default:"5000"`
+		// Expiry is the time after which the stream of a step without any new lines is removed.
+		Expiry time.Duration `envconfig:"GITNESS_LOGSTREAM_EXPIRY" default:"1h"`
+	}
+
 	BackgroundJobs struct {
 		// MaxRunning is maximum number of jobs that can be running at once.
 		MaxRunning int `envconfig:"GITNESS_JOBS_MAX_RUNNING" default:"10"`
@@ -305,6 +401,20 @@ type Config struct {
 		MaxRetries  int `envconfig:"GITNESS_TRIGGER_MAX_RETRIES" default:"3"`
 	}
 
+	// Mirror defines the configuration of repositories that are kept in sync with an upstream remote.
+	Mirror struct {
+		// MinInterval is the minimum time between two syncs of a pull mirror.
+		MinInterval time.Duration `envconfig:"GITNESS_MIRROR_MIN_INTERVAL" default:"5m"`
+		// DefaultInterval is the time between two syncs of a pull mirror if none is configured.
+		DefaultInterval time.Duration `envconfig:"GITNESS_MIRROR_DEFAULT_INTERVAL" default:"1h"`
+		// SyncTimeout is the maximum duration of a single sync.
+		SyncTimeout time.Duration `envconfig:"GITNESS_MIRROR_SYNC_TIMEOUT" default:"30m"`
+		// PushDebounce is the time changes of a repository are collected before they are pushed to push mirrors.
+		PushDebounce time.Duration `envconfig:"GITNESS_MIRROR_PUSH_DEBOUNCE" default:"30s"`
+		Concurrency  int           `envconfig:"GITNESS_MIRROR_CONCURRENCY" default:"4"`
+		MaxRetries   int           `envconfig:"GITNESS_MIRROR_MAX_RETRIES" default:"3"`
+	}
+
 	Metric struct {
 		Enabled  bool   `envconfig:"GITNESS_METRIC_ENABLED" default:"true"`
 		Endpoint string `envconfig:"GITNESS_METRIC_ENDPOINT" default:"https://stats.drone.ci/api/v1/gitness"`
@@ -324,8 +434,21 @@ type Config struct {
 		Insecure bool   `envconfig:"GITNESS_SMTP_INSECURE"`
 	}
 
+	// Notification defines the configuration of email notifications (requires SMTP to be configured).
+	Notification struct {
+		Enabled     bool `envconfig:"GITNESS_NOTIFICATION_ENABLED" default:"true"`
+		Concurrency int  `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+		MaxRetries  int  `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
+	}
+
 	KeywordSearch struct {
 		Concurrency int `envconfig:"GITNESS_KEYWORD_SEARCH_CONCURRENCY" default:"4"`
 		MaxRetries  int `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_RETRIES" default:"3"`
+
+		// IndexPath is the directory in which the local search index is stored.
+		// Value is derived from Git.Root unless explicitly specified.
+		IndexPath string `envconfig:"GITNESS_KEYWORD_SEARCH_INDEX_PATH"`
+		// MaxFileSize is the maximum size of a file to be indexed, larger files are not searchable.
+		MaxFileSize int64 `envconfig:"GITNESS_KEYWORD_SEARCH_MAX_FILE_SIZE" default:"1048576"` // 1MB
 	}
 }
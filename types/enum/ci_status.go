@@ -28,16 +28,19 @@ const (
 	CIStatusBlocked       CIStatus = "blocked"
 	CIStatusDeclined      CIStatus = "declined"
 	CIStatusWaitingOnDeps CIStatus = "waiting_on_dependencies"
-	CIStatusPending       CIStatus = "pending"
-	CIStatusRunning       CIStatus = "running"
-	CIStatusSuccess       CIStatus = "success"
-	CIStatusFailure       CIStatus = "failure"
-	CIStatusKilled        CIStatus = "killed"
-	CIStatusError         CIStatus = "error"
+	// CIStatusWaitingOnApproval is the status of an approval stage (and its execution)
+	// that waits for a user to approve or reject it.
+	CIStatusWaitingOnApproval CIStatus = "waiting_on_approval"
+	CIStatusPending           CIStatus = "pending"
+	CIStatusRunning           CIStatus = "running"
+	CIStatusSuccess           CIStatus = "success"
+	CIStatusFailure           CIStatus = "failure"
+	CIStatusKilled            CIStatus = "killed"
+	CIStatusError             CIStatus = "error"
 )
 
 func (status CIStatus) ConvertToCheckStatus() CheckStatus {
-	if status == CIStatusPending || status == CIStatusWaitingOnDeps {
+	if status == CIStatusPending || status == CIStatusWaitingOnDeps || status == CIStatusWaitingOnApproval {
 		return CheckStatusPending
 	}
 	if status == CIStatusSuccess || status == CIStatusSkipped {
@@ -57,7 +60,7 @@ func (status CIStatus) ConvertToCheckStatus() CheckStatus {
 // instead of explicitly returning not found error.
 func ParseCIStatus(status string) CIStatus {
 	switch strings.ToLower(status) {
-	case "skipped", "blocked", "declined", "waiting_on_dependencies",
+	case "skipped", "blocked", "declined", "waiting_on_dependencies", "waiting_on_approval",
 		"pending", "running", "success", "failure", "killed", "error":
 		return CIStatus(strings.ToLower(status))
 	case "": // just in case status is not passed through
@@ -72,6 +75,7 @@ func (status CIStatus) IsDone() bool {
 	//nolint:exhaustive
 	switch status {
 	case CIStatusWaitingOnDeps,
+		CIStatusWaitingOnApproval,
 		CIStatusPending,
 		CIStatusRunning,
 		CIStatusBlocked:
@@ -87,3 +91,23 @@ func (status CIStatus) IsFailed() bool {
 		status == CIStatusKilled ||
 		status == CIStatusError
 }
+
+// ApprovalDecision defines the decision of a user on an approval stage.
+type ApprovalDecision string
+
+func (ApprovalDecision) Enum() []interface{} { return toInterfaceSlice(approvalDecisions) }
+func (d ApprovalDecision) Sanitize() (ApprovalDecision, bool) {
+	return Sanitize(d, GetAllApprovalDecisions)
+}
+func GetAllApprovalDecisions() ([]ApprovalDecision, ApprovalDecision) { return approvalDecisions, "" }
+
+// ApprovalDecision enumeration.
+const (
+	ApprovalDecisionApproved ApprovalDecision = "approved"
+	ApprovalDecisionRejected ApprovalDecision = "rejected"
+)
+
+var approvalDecisions = sortEnum([]ApprovalDecision{
+	ApprovalDecisionApproved,
+	ApprovalDecisionRejected,
+})
@@ -23,6 +23,8 @@ const (
 	CodeOwnerViolationCodePatternInvalid CodeOwnerViolationCode = "pattern_invalid"
 	// CodeOwnerViolationCodePatternEmpty occurs when a pattern in codeowners file is empty.
 	CodeOwnerViolationCodePatternEmpty CodeOwnerViolationCode = "pattern_empty"
+	// CodeOwnerViolationCodeUserGroupNotFound occurs when user group in codeowners file is not present.
+	CodeOwnerViolationCodeUserGroupNotFound CodeOwnerViolationCode = "usergroup_not_found"
 )
 
 func (CodeOwnerViolationCode) Enum() []interface{} { return toInterfaceSlice(codeOwnerViolationCodes) }
@@ -31,4 +33,5 @@ var codeOwnerViolationCodes = sortEnum([]CodeOwnerViolationCode{
 	CodeOwnerViolationCodeUserNotFound,
 	CodeOwnerViolationCodePatternInvalid,
 	CodeOwnerViolationCodePatternEmpty,
+	CodeOwnerViolationCodeUserGroupNotFound,
 })
@@ -110,6 +110,23 @@ func (m MembershipRole) Permissions() []Permission {
 	}
 }
 
+// Rank returns the rank of the role. A role includes the privileges of all roles with a lower rank,
+// unknown roles have the rank 0.
+func (m MembershipRole) Rank() int {
+	switch m {
+	case MembershipRoleReader:
+		return 1
+	case MembershipRoleExecutor:
+		return 2
+	case MembershipRoleContributor:
+		return 3
+	case MembershipRoleSpaceOwner:
+		return 4
+	default:
+		return 0
+	}
+}
+
 const (
 	MembershipRoleReader      MembershipRole = "reader"
 	MembershipRoleExecutor    MembershipRole = "executor"
@@ -27,11 +27,61 @@ const (
 	ResourceTypeSecret         ResourceType = "SECRET"
 	ResourceTypeConnector      ResourceType = "CONNECTOR"
 	ResourceTypeTemplate       ResourceType = "TEMPLATE"
+	ResourceTypeRunner         ResourceType = "RUNNER"
 )
 
 // Permission represents the different types of permissions a principal can have.
 type Permission string
 
+func (Permission) Enum() []interface{}              { return toInterfaceSlice(permissions) }
+func (p Permission) Sanitize() (Permission, bool)   { return Sanitize(p, GetAllPermissions) }
+func GetAllPermissions() ([]Permission, Permission) { return permissions, "" }
+
+var permissions = sortEnum([]Permission{
+	PermissionSpaceCreate,
+	PermissionSpaceView,
+	PermissionSpaceEdit,
+	PermissionSpaceDelete,
+	PermissionRepoView,
+	PermissionRepoEdit,
+	PermissionRepoDelete,
+	PermissionRepoPush,
+	PermissionRepoReportCommitCheck,
+	PermissionUserCreate,
+	PermissionUserView,
+	PermissionUserEdit,
+	PermissionUserDelete,
+	PermissionUserEditAdmin,
+	PermissionServiceAccountCreate,
+	PermissionServiceAccountView,
+	PermissionServiceAccountEdit,
+	PermissionServiceAccountDelete,
+	PermissionServiceCreate,
+	PermissionServiceView,
+	PermissionServiceEdit,
+	PermissionServiceDelete,
+	PermissionServiceEditAdmin,
+	PermissionPipelineView,
+	PermissionPipelineEdit,
+	PermissionPipelineDelete,
+	PermissionPipelineExecute,
+	PermissionSecretView,
+	PermissionSecretEdit,
+	PermissionSecretDelete,
+	PermissionSecretAccess,
+	PermissionConnectorView,
+	PermissionConnectorEdit,
+	PermissionConnectorDelete,
+	PermissionConnectorAccess,
+	PermissionTemplateView,
+	PermissionTemplateEdit,
+	PermissionTemplateDelete,
+	PermissionTemplateAccess,
+	PermissionRunnerCreate,
+	PermissionRunnerView,
+	PermissionRunnerDelete,
+})
+
 const (
 	/*
 	   ----- SPACE -----
@@ -124,3 +174,12 @@ const (
 	PermissionTemplateDelete Permission = "template_delete"
 	PermissionTemplateAccess Permission = "template_access"
 )
+
+const (
+	/*
+		----- RUNNER -----
+	*/
+	PermissionRunnerCreate Permission = "runner_create"
+	PermissionRunnerView   Permission = "runner_view"
+	PermissionRunnerDelete Permission = "runner_delete"
+)
@@ -0,0 +1,50 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+import (
+	"strings"
+)
+
+// PublicKeySort contains public key sorting options.
+type PublicKeySort string
+
+const (
+	PublicKeySortUID     PublicKeySort = uid
+	PublicKeySortCreated PublicKeySort = createdAt
+)
+
+var publicKeySorts = sortEnum([]PublicKeySort{
+	PublicKeySortUID,
+	PublicKeySortCreated,
+})
+
+func (PublicKeySort) Enum() []interface{} { return toInterfaceSlice(publicKeySorts) }
+func (s PublicKeySort) Sanitize() (PublicKeySort, bool) {
+	return Sanitize(s, GetAllPublicKeySorts)
+}
+func GetAllPublicKeySorts() ([]PublicKeySort, PublicKeySort) {
+	return publicKeySorts, PublicKeySortCreated
+}
+
+// ParsePublicKeySortAttr parses the public key sorting option.
+func ParsePublicKeySortAttr(s string) PublicKeySort {
+	switch strings.ToLower(s) {
+	case uid:
+		return PublicKeySortUID
+	}
+
+	return PublicKeySortCreated
+}
@@ -0,0 +1,72 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// SigningKeyType represents the type of a key used to sign commits and tags.
+type SigningKeyType string
+
+// SigningKeyType enumeration.
+const (
+	SigningKeyTypeGPG SigningKeyType = "gpg"
+	SigningKeyTypeSSH SigningKeyType = "ssh"
+)
+
+var signingKeyTypes = sortEnum([]SigningKeyType{
+	SigningKeyTypeGPG,
+	SigningKeyTypeSSH,
+})
+
+func (SigningKeyType) Enum() []interface{} { return toInterfaceSlice(signingKeyTypes) }
+func (t SigningKeyType) Sanitize() (SigningKeyType, bool) {
+	return Sanitize(t, GetAllSigningKeyTypes)
+}
+func GetAllSigningKeyTypes() ([]SigningKeyType, SigningKeyType) {
+	return signingKeyTypes, ""
+}
+
+// SignatureVerificationStatus represents the result of the verification of a commit or tag signature.
+type SignatureVerificationStatus string
+
+// SignatureVerificationStatus enumeration.
+const (
+	// SignatureVerificationStatusUnsigned indicates that the object isn't signed.
+	SignatureVerificationStatusUnsigned SignatureVerificationStatus = "unsigned"
+	// SignatureVerificationStatusVerified indicates that the signature is valid and the signing key
+	// belongs to the user with the verified email of the committer (or tagger).
+	SignatureVerificationStatusVerified SignatureVerificationStatus = "verified"
+	// SignatureVerificationStatusUnverified indicates that the signature is invalid or couldn't be verified.
+	SignatureVerificationStatusUnverified SignatureVerificationStatus = "unverified"
+	// SignatureVerificationStatusUnknownKey indicates that the signing key isn't registered by any user.
+	SignatureVerificationStatusUnknownKey SignatureVerificationStatus = "unknown_key"
+	// SignatureVerificationStatusMismatchedEmail indicates that the signature is valid,
+	// but the signing key belongs to a user with a different email than the committer (or tagger).
+	SignatureVerificationStatusMismatchedEmail SignatureVerificationStatus = "mismatched_email"
+	// SignatureVerificationStatusUnverifiedEmail indicates that the signature is valid and the email
+	// of the object matches the email of the owner of the signing key, but the owner didn't verify the email.
+	SignatureVerificationStatusUnverifiedEmail SignatureVerificationStatus = "unverified_email"
+)
+
+var signatureVerificationStatuses = sortEnum([]SignatureVerificationStatus{
+	SignatureVerificationStatusUnsigned,
+	SignatureVerificationStatusVerified,
+	SignatureVerificationStatusUnverified,
+	SignatureVerificationStatusUnknownKey,
+	SignatureVerificationStatusMismatchedEmail,
+	SignatureVerificationStatusUnverifiedEmail,
+})
+
+func (SignatureVerificationStatus) Enum() []interface{} {
+	return toInterfaceSlice(signatureVerificationStatuses)
+}
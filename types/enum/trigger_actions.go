@@ -38,6 +38,10 @@ const (
 	TriggerActionPullReqBranchUpdated TriggerAction = "pullreq_branch_updated"
 	// TriggerActionPullReqClosed gets triggered when a pull request is closed.
 	TriggerActionPullReqClosed = "pullreq_closed"
+
+	// TriggerActionCron gets triggered when the cron schedule of a trigger is due.
+	// NOTE: It's not part of the selectable actions as it's implied by the type of the trigger.
+	TriggerActionCron TriggerAction = "cron"
 )
 
 func (TriggerAction) Enum() []interface{}               { return toInterfaceSlice(triggerActions) }
@@ -52,6 +56,9 @@ func (t TriggerAction) GetTriggerEvent() TriggerEvent {
 	if t == TriggerActionTagCreated || t == TriggerActionTagUpdated {
 		return TriggerEventTag
 	}
+	if t == TriggerActionCron {
+		return TriggerEventCron
+	}
 	if t == "" {
 		return TriggerEventManual
 	}
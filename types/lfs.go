@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// LFSObject represents a Git LFS object that was uploaded to a repository.
+type LFSObject struct {
+	ID        int64  `json:"id"`
+	OID       string `json:"oid"`
+	Size      int64  `json:"size"`
+	RepoID    int64  `json:"repo_id"`
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+}
+
+// LFSLock represents a Git LFS lock of a file of a repository.
+type LFSLock struct {
+	ID          int64  `json:"id"`
+	RepoID      int64  `json:"repo_id"`
+	Path        string `json:"path"`
+	PrincipalID int64  `json:"principal_id"`
+	Created     int64  `json:"created"`
+}
+
+// LFSLockFilter stores Git LFS lock query parameters.
+type LFSLockFilter struct {
+	Path string
+	ID   int64
+	// Cursor is the id of the last lock of the previous page.
+	Cursor int64
+	Limit  int
+}
+
+// LFSUsage contains the Git LFS storage usage of a repository.
+type LFSUsage struct {
+	ObjectCount int64 `json:"object_count"`
+	Size        int64 `json:"size"`
+}
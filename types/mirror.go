@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullMirror represents the configuration and sync status of a repository
+// that is kept in sync with an upstream remote. Branches and tags of a mirror can't be pushed to.
+type PullMirror struct {
+	ID        int64  `json:"-"`
+	RepoID    int64  `json:"repo_id"`
+	URL       string `json:"url"`
+	Username  string `json:"username"`
+	Password  []byte `json:"-"` // encrypted
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Version   int64  `json:"-"`
+
+	// Interval is the time between two syncs in seconds.
+	Interval int64 `json:"interval"`
+
+	State      enum.MirrorSyncState `json:"state"`
+	LastSync   int64                `json:"last_sync"`
+	LastSynced int64                `json:"last_synced"`
+	LastError  string               `json:"last_error,omitempty"`
+	NextSync   int64                `json:"next_sync"`
+}
+
+// PushMirror represents an external remote that every change of a repository is replicated to.
+type PushMirror struct {
+	ID        int64  `json:"id"`
+	RepoID    int64  `json:"repo_id"`
+	URL       string `json:"url"`
+	Username  string `json:"username"`
+	Password  []byte `json:"-"` // encrypted
+	Enabled   bool   `json:"enabled"`
+	CreatedBy int64  `json:"created_by"`
+	Created   int64  `json:"created"`
+	Updated   int64  `json:"updated"`
+	Version   int64  `json:"-"`
+
+	// BranchFilter restricts the pushed branches to the ones matching any of the patterns
+	// (a pattern can contain a single '*'). All branches are pushed if empty. Tags are always pushed.
+	BranchFilter []string `json:"branch_filter"`
+
+	State      enum.MirrorSyncState `json:"state"`
+	LastPush   int64                `json:"last_push"`
+	LastPushed int64                `json:"last_pushed"`
+	// Errors contains the most recent push errors, the latest first.
+	Errors []PushMirrorError `json:"errors"`
+}
+
+// PushMirrorError is an error that occurred while pushing to a push mirror.
+type PushMirrorError struct {
+	Time  int64  `json:"time"`
+	Error string `json:"error"`
+}
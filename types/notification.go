@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// NotificationSettings holds the email notification preferences of a user.
+type NotificationSettings struct {
+	PrincipalID int64 `json:"-"`
+
+	PullReqCreated bool `json:"pullreq_created"`
+	ReviewerAdded  bool `json:"reviewer_added"`
+	CommentCreated bool `json:"comment_created"`
+	PullReqMerged  bool `json:"pullreq_merged"`
+	PullReqClosed  bool `json:"pullreq_closed"`
+	BranchUpdated  bool `json:"branch_updated"`
+
+	Created int64 `json:"created"`
+	Updated int64 `json:"updated"`
+}
+
+// DefaultNotificationSettings returns the settings of users that never changed them - all notifications are enabled.
+func DefaultNotificationSettings(principalID int64) *NotificationSettings {
+	return &NotificationSettings{
+		PrincipalID:    principalID,
+		PullReqCreated: true,
+		ReviewerAdded:  true,
+		CommentCreated: true,
+		PullReqMerged:  true,
+		PullReqClosed:  true,
+		BranchUpdated:  true,
+	}
+}
@@ -26,6 +26,12 @@ type Pipeline struct {
 	DefaultBranch string `db:"pipeline_default_branch"  json:"default_branch"`
 	ConfigPath    string `db:"pipeline_config_path"     json:"config_path"`
 	Created       int64  `db:"pipeline_created"         json:"created"`
+	// RetentionCount is the number of most recent executions to keep.
+	// Zero falls back to the system default, a negative value keeps all executions.
+	RetentionCount int64 `db:"pipeline_retention_count" json:"retention_count"`
+	// RetentionDays is the number of days executions are kept.
+	// Zero falls back to the system default, a negative value keeps executions forever.
+	RetentionDays int64 `db:"pipeline_retention_days"  json:"retention_days"`
 	// Execution contains information about the latest execution if available
 	Execution *Execution `db:"-"                        json:"execution,omitempty"`
 	Updated   int64      `db:"pipeline_updated"         json:"updated"`
@@ -0,0 +1,37 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// PublicKey represents a public key of a principal used for authentication via SSH.
+type PublicKey struct {
+	ID          int64  `json:"-"`
+	PrincipalID int64  `json:"-"`
+	Created     int64  `json:"created"`
+	UID         string `json:"uid"`
+	Fingerprint string `json:"fingerprint"`
+	Type        string `json:"type"`
+	Content     string `json:"content"`
+}
+
+// PublicKeyFilter stores public key query parameters.
+type PublicKeyFilter struct {
+	ListQueryFilter
+	Sort  enum.PublicKeySort `json:"sort"`
+	Order enum.Order         `json:"order"`
+}
@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// Runner represents an external build agent that executes pipeline stages via the runner rpc endpoint.
+type Runner struct {
+	ID          int64  `json:"-"`
+	UID         string `json:"uid"`
+	Description string `json:"description"`
+	CreatedBy   int64  `json:"-"`
+	Created     int64  `json:"created"`
+	Updated     int64  `json:"updated"`
+
+	// TokenHash is the sha256 hash of the registration token used by the runner to authenticate.
+	TokenHash string `json:"-"`
+
+	// LastSeen is the time of the last heartbeat of the runner (0 if it never connected).
+	LastSeen int64  `json:"last_seen"`
+	Machine  string `json:"machine"`
+	OS       string `json:"os"`
+	Arch     string `json:"arch"`
+}
+
+// RunnerHeartbeat contains the information reported by a runner with every heartbeat.
+type RunnerHeartbeat struct {
+	LastSeen int64
+	Machine  string
+	OS       string
+	Arch     string
+}
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"github.com/harness/gitness/types/enum"
+)
+
+// SigningKey represents a GPG or SSH public key of a principal used to verify signatures of commits and tags.
+type SigningKey struct {
+	ID          int64               `json:"-"`
+	PrincipalID int64               `json:"-"`
+	Created     int64               `json:"created"`
+	UID         string              `json:"uid"`
+	Type        enum.SigningKeyType `json:"type"`
+	Fingerprint string              `json:"fingerprint"`
+	// KeyIDs contains the ids under which signatures reference the key
+	// (the ids of the GPG primary key and its sub keys, or the SSH fingerprint).
+	KeyIDs  []string `json:"key_ids"`
+	Content string   `json:"content"`
+}
+
+// SignatureVerification contains the result of the verification of the signature of a commit or tag.
+type SignatureVerification struct {
+	Status enum.SignatureVerificationStatus `json:"status"`
+	// KeyType is the type of the signature, set for signed objects only.
+	KeyType enum.SigningKeyType `json:"key_type,omitempty"`
+	// KeyID is the id of the key that was used to create the signature.
+	KeyID string `json:"key_id,omitempty"`
+	// Signer is the owner of the signing key, set in case the key is registered.
+	Signer *PrincipalInfo `json:"signer,omitempty"`
+}
@@ -29,6 +29,7 @@ type Stage struct {
 	ErrIgnore   bool              `json:"errignore,omitempty"`
 	ExitCode    int               `json:"exit_code"`
 	Machine     string            `json:"machine,omitempty"`
+	RunnerID    *int64            `json:"-"`
 	OS          string            `json:"os,omitempty"`
 	Arch        string            `json:"arch,omitempty"`
 	Variant     string            `json:"variant,omitempty"`
@@ -30,6 +30,16 @@ type Token struct {
 	// IssuedAt is the unix time at which the token was issued.
 	IssuedAt  int64 `db:"token_issued_at"          json:"issued_at"`
 	CreatedBy int64 `db:"token_created_by"         json:"created_by"`
+
+	// Permissions optionally restricts the token to the listed permissions.
+	Permissions []enum.Permission `db:"-" json:"permissions,omitempty"`
+	// Paths optionally restricts the token to the listed spaces and repositories (including everything below).
+	Paths []string `db:"-" json:"paths,omitempty"`
+}
+
+// IsRestricted returns true in case the token is restricted to a set of permissions or paths.
+func (t *Token) IsRestricted() bool {
+	return len(t.Permissions) > 0 || len(t.Paths) > 0
 }
 
 // TokenResponse is returned as part of token creation for PAT / SAT / User Session.
@@ -26,6 +26,9 @@ type Trigger struct {
 	CreatedBy   int64                `json:"created_by"`
 	Disabled    bool                 `json:"disabled"`
 	Actions     []enum.TriggerAction `json:"actions"`
+	Cron        string               `json:"cron"`
+	Timezone    string               `json:"timezone"`
+	NextRun     int64                `json:"next_run"`
 	UID         string               `json:"uid"`
 	Created     int64                `json:"created"`
 	Updated     int64                `json:"updated"`
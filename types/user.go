@@ -35,6 +35,8 @@ type (
 
 		// User specific fields
 		Password string `db:"principal_user_password"    json:"-"`
+		// EmailVerified is true if the user proved to own the email (or an admin confirmed it).
+		EmailVerified bool `db:"principal_user_email_verified" json:"email_verified"`
 	}
 
 	// UserInput store user account details used to
@@ -15,9 +15,51 @@
 // Package types defines common data structures.
 package types
 
+import "github.com/harness/gitness/types/enum"
+
+// UserGroup represents a group of users defined in a space.
 type UserGroup struct {
-	ID          string `json:"id"`
+	ID          int64  `json:"-"`
+	SpaceID     int64  `json:"-"`
+	UID         string `json:"uid"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
-	Users       []string
+	CreatedBy   int64  `json:"-"`
+	Created     int64  `json:"created"`
+	Updated     int64  `json:"updated"`
+
+	// Users contains the UIDs of the members of the group (only populated by the resolver).
+	Users []string `json:"-"`
+}
+
+// UserGroupMember represents a user's membership of a user group.
+type UserGroupMember struct {
+	UserGroupID int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+	CreatedBy   int64 `json:"-"`
+	Created     int64 `json:"created"`
+
+	Principal PrincipalInfo `json:"principal"`
+	AddedBy   PrincipalInfo `json:"added_by"`
+}
+
+// MembershipUserGroupKey can be used as a key for finding a user group's space membership info.
+type MembershipUserGroupKey struct {
+	SpaceID     int64
+	UserGroupID int64
+}
+
+// MembershipUserGroup represents a user group's membership of a space.
+// All members of the group inherit the role of the membership.
+type MembershipUserGroup struct {
+	MembershipUserGroupKey `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Role enum.MembershipRole `json:"role"`
+
+	UserGroup UserGroup     `json:"usergroup"`
+	AddedBy   PrincipalInfo `json:"added_by"`
 }